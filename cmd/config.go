@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"os"
+
+	"github.com/charmbracelet/log"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/config"
+	"github.com/spf13/cobra"
+)
+
+// defaultSchemaFile is the file config schema --write writes to when no path is given
+const defaultSchemaFile = "config.schema.json"
+
+var configSchemaWriteFile string
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Config file utilities",
+}
+
+var configSchemaCmd = &cobra.Command{
+	Use:   "schema",
+	Short: "Print the JSON Schema of the config file",
+	Long: `Print the JSON Schema of the config file - keys, types, defaults and descriptions generated from the config structs.
+Point your editor's YAML language server at it to validate and autocomplete config files.
+Use --write to write it to a file (default ` + defaultSchemaFile + `) instead of stdout.`,
+	Annotations:   map[string]string{annotationSkipConfigLoad: "true"},
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	Run: func(cmd *cobra.Command, args []string) {
+		if configSchemaWriteFile == "" || readOnly {
+			if readOnly && configSchemaWriteFile != "" {
+				log.Warn("read-only mode - printing schema instead of writing it", "file", configSchemaWriteFile)
+			}
+			if _, err := os.Stdout.Write(config.Schema()); err != nil {
+				log.Fatal("failed to write schema", "error", err)
+			}
+			return
+		}
+
+		if err := os.WriteFile(configSchemaWriteFile, config.Schema(), 0o644); err != nil {
+			log.Fatal("failed to write schema", "error", err)
+		}
+		log.Info("wrote config schema", "file", configSchemaWriteFile)
+	},
+}
+
+func init() {
+	configSchemaCmd.Flags().StringVar(&configSchemaWriteFile, "write", "", "Write the schema to a file instead of stdout (default "+defaultSchemaFile+" when no path is given)")
+	configSchemaCmd.Flags().Lookup("write").NoOptDefVal = defaultSchemaFile
+	configCmd.AddCommand(configSchemaCmd)
+}
@@ -0,0 +1,191 @@
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/log"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/starter"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/state"
+	"github.com/spf13/cobra"
+)
+
+var (
+	initOutputFile      string
+	initForce           bool
+	initNonInteractive  bool
+	initOptions         = starter.DefaultConfigOptions()
+	initSystemd         bool
+	initSystemdDir      string
+	initSystemdUser     string
+	initSystemdCalendar string
+)
+
+var initCmd = &cobra.Command{
+	Use:   "init",
+	Short: "Generate a starter config (and optionally systemd units)",
+	Long: `Generate a commented starter config.yaml from the chosen client, cluster, RPC URL and keypair paths.
+Values not supplied as flags are prompted for when run in a terminal, unless --non-interactive is set.`,
+	Annotations:   map[string]string{annotationSkipConfigLoad: "true"},
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	Run: func(cmd *cobra.Command, args []string) {
+		if !initNonInteractive && stdinIsTerminal() {
+			promptInitOptions(cmd)
+		}
+
+		configContent, err := starter.RenderConfig(initOptions)
+		if err != nil {
+			log.Fatal("failed to generate config", "error", err)
+		}
+
+		// generate every file before writing any, so a failure never leaves a half-done init behind
+		files := []initFile{{path: initOutputFile, content: configContent}}
+
+		serviceFile := filepath.Join(initSystemdDir, starter.UnitName+".service")
+		timerFile := filepath.Join(initSystemdDir, starter.UnitName+".timer")
+		if initSystemd {
+			systemdOptions, err := initSystemdOptions()
+			if err != nil {
+				log.Fatal("failed to generate systemd units", "error", err)
+			}
+
+			serviceContent, err := starter.RenderSystemdService(systemdOptions)
+			if err != nil {
+				log.Fatal("failed to generate systemd service", "error", err)
+			}
+			timerContent, err := starter.RenderSystemdTimer(systemdOptions)
+			if err != nil {
+				log.Fatal("failed to generate systemd timer", "error", err)
+			}
+
+			files = append(files, initFile{path: serviceFile, content: serviceContent}, initFile{path: timerFile, content: timerContent})
+		}
+
+		if readOnly {
+			log.Warn("read-only mode - printing generated files instead of writing them")
+			for _, file := range files {
+				fmt.Printf("# %s\n%s\n", file.path, file.content)
+			}
+			return
+		}
+
+		if err := writeInitFiles(files, initForce); err != nil {
+			log.Fatal("failed to write generated files", "error", err)
+		}
+
+		log.Info("wrote starter config - review the example commands before running", "file", initOutputFile)
+		if initSystemd {
+			log.Info("wrote systemd units - install with: systemctl enable --now "+starter.UnitName+".timer", "service", serviceFile, "timer", timerFile)
+		}
+	},
+}
+
+// initFile is a file generated by init
+type initFile struct {
+	path    string
+	content string
+}
+
+// promptInitOptions prompts for every option not explicitly set with a flag
+func promptInitOptions(cmd *cobra.Command) {
+	reader := bufio.NewReader(os.Stdin)
+	prompt := func(flagName string, label string, value *string) {
+		if cmd.Flags().Changed(flagName) {
+			return
+		}
+		fmt.Fprintf(os.Stderr, "%s [%s]: ", label, *value)
+		answer, _ := reader.ReadString('\n')
+		if answer = strings.TrimSpace(answer); answer != "" {
+			*value = answer
+		}
+	}
+
+	prompt("client", "Validator client (agave|jito-solana|rakurai-validator|firedancer)", &initOptions.Client)
+	prompt("cluster", "Cluster (mainnet-beta|testnet)", &initOptions.Cluster)
+	prompt("rpc-url", "Validator RPC URL", &initOptions.RPCURL)
+	prompt("version-constraint", "Version constraint", &initOptions.VersionConstraint)
+	prompt("active-identity", "Active identity keypair file", &initOptions.ActiveIdentityFile)
+	prompt("passive-identity", "Passive identity keypair file", &initOptions.PassiveIdentityFile)
+	prompt("validator-service", "Validator systemd service to restart", &initOptions.ValidatorService)
+	prompt("state-file", "State file (empty for in memory only)", &initOptions.StateFile)
+
+	sfdp := strconv.FormatBool(initOptions.EnableSFDPCompliance)
+	prompt("enable-sfdp-compliance", "Enable SFDP compliance (true|false)", &sfdp)
+	if parsed, err := strconv.ParseBool(sfdp); err == nil {
+		initOptions.EnableSFDPCompliance = parsed
+	}
+}
+
+// initSystemdOptions builds the systemd unit options, resolving absolute paths for the binary and config
+func initSystemdOptions() (opts starter.SystemdOptions, err error) {
+	binaryPath, err := os.Executable()
+	if err != nil {
+		return opts, fmt.Errorf("failed to resolve binary path: %w", err)
+	}
+
+	configFile, err := filepath.Abs(initOutputFile)
+	if err != nil {
+		return opts, fmt.Errorf("failed to resolve config path: %w", err)
+	}
+
+	return starter.SystemdOptions{
+		BinaryPath: binaryPath,
+		ConfigFile: configFile,
+		User:       initSystemdUser,
+		OnCalendar: initSystemdCalendar,
+	}, nil
+}
+
+// writeInitFiles writes the generated files, refusing to overwrite existing ones unless force is set. Every
+// path is checked before any file is written, and each file is written atomically
+func writeInitFiles(files []initFile, force bool) error {
+	for _, file := range files {
+		info, err := os.Stat(file.path)
+		switch {
+		case err == nil && info.IsDir():
+			return fmt.Errorf("%s is a directory", file.path)
+		case err == nil && !force:
+			return fmt.Errorf("%s already exists - use --force to overwrite", file.path)
+		case err != nil && !errors.Is(err, os.ErrNotExist):
+			return err
+		}
+	}
+
+	for _, file := range files {
+		if err := state.WriteFileAtomicMode(file.path, []byte(file.content), 0o644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// stdinIsTerminal returns true when stdin is an interactive terminal
+func stdinIsTerminal() bool {
+	info, err := os.Stdin.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+func init() {
+	initCmd.Flags().StringVarP(&initOutputFile, "output", "o", "config.yaml", "Path to write the generated config to")
+	initCmd.Flags().BoolVarP(&initForce, "force", "f", false, "Overwrite existing files")
+	initCmd.Flags().BoolVar(&initNonInteractive, "non-interactive", false, "Never prompt - use flag values and defaults only")
+	initCmd.Flags().StringVar(&initOptions.Client, "client", initOptions.Client, "Validator client (agave, jito-solana, rakurai-validator, firedancer)")
+	initCmd.Flags().StringVar(&initOptions.Cluster, "cluster", initOptions.Cluster, "Cluster (mainnet-beta, testnet)")
+	initCmd.Flags().StringVar(&initOptions.RPCURL, "rpc-url", initOptions.RPCURL, "Local validator RPC URL")
+	initCmd.Flags().StringVar(&initOptions.VersionConstraint, "version-constraint", initOptions.VersionConstraint, "Version constraint for sync targets")
+	initCmd.Flags().StringVar(&initOptions.ActiveIdentityFile, "active-identity", initOptions.ActiveIdentityFile, "Path to the active identity keypair")
+	initCmd.Flags().StringVar(&initOptions.PassiveIdentityFile, "passive-identity", initOptions.PassiveIdentityFile, "Path to the passive identity keypair")
+	initCmd.Flags().StringVar(&initOptions.ValidatorService, "validator-service", initOptions.ValidatorService, "Validator systemd service restarted by the example commands")
+	initCmd.Flags().StringVar(&initOptions.StateFile, "state-file", initOptions.StateFile, "State file persisting prepared targets across runs")
+	initCmd.Flags().BoolVar(&initOptions.EnableSFDPCompliance, "enable-sfdp-compliance", initOptions.EnableSFDPCompliance, "Enable SFDP compliance")
+	initCmd.Flags().BoolVar(&initSystemd, "systemd", false, "Also write a systemd service and timer")
+	initCmd.Flags().StringVar(&initSystemdDir, "systemd-dir", ".", "Directory to write the systemd units to (e.g. /etc/systemd/system)")
+	initCmd.Flags().StringVar(&initSystemdUser, "systemd-user", "solana", "User the systemd service runs as")
+	initCmd.Flags().StringVar(&initSystemdCalendar, "systemd-on-calendar", "hourly", "systemd OnCalendar schedule for the timer")
+}
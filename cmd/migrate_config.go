@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"os"
+
+	"github.com/charmbracelet/log"
+	"github.com/knadh/koanf/parsers/yaml"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/config"
+	"github.com/spf13/cobra"
+)
+
+var migrateConfigWrite bool
+
+var migrateConfigCmd = &cobra.Command{
+	Use:   "migrate-config",
+	Short: "Migrate a config file written for an older config format to the current format",
+	Long: `Read the --config file written for an older config format and print the equivalent config in the current format,
+keeping comments and key order. Every change is noted in a "# migrated: ..." comment next to the migrated key - review
+them, as some settings (e.g. sync.interval) are no longer config keys.
+Use --write to overwrite the --config file instead of printing it, keeping the original as <file>.bak.`,
+	Annotations:   map[string]string{annotationSkipConfigLoad: "true"},
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	Run: func(cmd *cobra.Command, args []string) {
+		content, err := os.ReadFile(configFile)
+		if err != nil {
+			log.Fatal("failed to read config", "file", configFile, "error", err)
+		}
+
+		migrated, notes, err := config.Migrate(content)
+		if err != nil {
+			log.Fatal("failed to migrate config", "file", configFile, "error", err)
+		}
+		if len(notes) == 0 {
+			log.Info("config is already in the current format - nothing to migrate", "file", configFile)
+			return
+		}
+		for _, note := range notes {
+			log.Info("migrated", "change", note)
+		}
+
+		// migrations only cover legacy keys - anything else invalid is left to the user
+		raw, err := yaml.Parser().Unmarshal(migrated)
+		if err == nil {
+			err = config.ValidateSchema(raw)
+		}
+		if err != nil {
+			log.Warn("migrated config still needs changes before it loads", "error", err)
+		}
+
+		if !migrateConfigWrite || readOnly {
+			if readOnly && migrateConfigWrite {
+				log.Warn("read-only mode - printing migrated config instead of writing it", "file", configFile)
+			}
+			if _, err := os.Stdout.Write(migrated); err != nil {
+				log.Fatal("failed to write migrated config", "error", err)
+			}
+			return
+		}
+
+		info, err := os.Stat(configFile)
+		if err != nil {
+			log.Fatal("failed to stat config", "file", configFile, "error", err)
+		}
+		backupFile := configFile + ".bak"
+		if err := os.WriteFile(backupFile, content, info.Mode().Perm()); err != nil {
+			log.Fatal("failed to back up config", "file", backupFile, "error", err)
+		}
+		if err := os.WriteFile(configFile, migrated, info.Mode().Perm()); err != nil {
+			log.Fatal("failed to write migrated config", "file", configFile, "error", err)
+		}
+		log.Info("wrote migrated config", "file", configFile, "backup", backupFile)
+	},
+}
+
+func init() {
+	migrateConfigCmd.Flags().BoolVar(&migrateConfigWrite, "write", false, "Overwrite the --config file with the migrated config, keeping the original as <file>.bak")
+}
@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/charmbracelet/log"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/recipes"
+	"github.com/spf13/cobra"
+)
+
+var recipesCmd = &cobra.Command{
+	Use:   "recipes",
+	Short: "List and show the command recipes shipped with the binary",
+	Long: `Recipes are curated sync command sets selected with sync.recipe instead of writing sync.commands,
+parameterized by sync.recipe_options.`,
+	Annotations: map[string]string{annotationSkipConfigLoad: "true"},
+}
+
+var recipesListCmd = &cobra.Command{
+	Use:           "list",
+	Short:         "List the available recipes",
+	Annotations:   map[string]string{annotationSkipConfigLoad: "true"},
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	Run: func(cmd *cobra.Command, args []string) {
+		for _, recipe := range recipes.All() {
+			fmt.Printf("%-22s %-12s %s\n", recipe.Name, recipe.Client, recipe.Description)
+		}
+	},
+}
+
+var recipesShowCmd = &cobra.Command{
+	Use:           "show <recipe>",
+	Short:         "Show the commands a recipe expands to with the default sync.recipe_options",
+	Args:          cobra.ExactArgs(1),
+	Annotations:   map[string]string{annotationSkipConfigLoad: "true"},
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	Run: func(cmd *cobra.Command, args []string) {
+		rendered, err := recipes.Render(args[0], recipes.DefaultOptions())
+		if err != nil {
+			log.Fatal("failed to show recipe", "error", err)
+		}
+		if _, err := os.Stdout.Write(rendered); err != nil {
+			log.Fatal("failed to show recipe", "error", err)
+		}
+	},
+}
+
+func init() {
+	recipesCmd.AddCommand(recipesListCmd)
+	recipesCmd.AddCommand(recipesShowCmd)
+}
@@ -6,6 +6,9 @@ import (
 
 	"github.com/charmbracelet/log"
 	"github.com/sol-strategies/solana-validator-version-sync/internal/config"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/github"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/logging"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/sfdp"
 	"github.com/spf13/cobra"
 )
 
@@ -14,10 +17,18 @@ var versionFile string
 
 var version = strings.TrimSpace(strings.Split(versionFile, "\n")[0])
 
+// annotationSkipConfigLoad marks commands that run without loading the config file
+const annotationSkipConfigLoad = "skip-config-load"
+
 var (
-	configFile   string
-	logLevel     string
-	loadedConfig *config.Config
+	configFile      string
+	logLevel        string
+	logFullPayloads bool
+	readOnly        bool
+	failAtStages    []string
+	githubAPIURL    string
+	sfdpAPIURL      string
+	loadedConfig    *config.Config
 )
 
 var rootCmd = &cobra.Command{
@@ -29,6 +40,22 @@ It monitors the validator's current version and syncs it with the latest availab
 	SilenceUsage:  true,
 	SilenceErrors: true,
 	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		logging.SetFullPayloads(logFullPayloads)
+
+		if githubAPIURL != "" {
+			if err := github.SetAPIBaseURL(githubAPIURL); err != nil {
+				log.Fatal("failed to set GitHub API URL", "error", err)
+			}
+		}
+		if sfdpAPIURL != "" {
+			sfdp.SetBaseURL(sfdpAPIURL)
+		}
+
+		// some commands (e.g. init) don't need a config
+		if cmd.Annotations[annotationSkipConfigLoad] == "true" {
+			return
+		}
+
 		// Load configuration
 		var err error
 		loadedConfig, err = config.NewFromConfigFile(configFile)
@@ -49,7 +76,26 @@ func init() {
 	// Add global flags here
 	rootCmd.PersistentFlags().StringVarP(&configFile, "config", "c", "~/solana-validator-version-sync/config.yaml", "Path to configuration file (default: ~/solana-validator-version-sync/config.yaml)")
 	rootCmd.PersistentFlags().StringVarP(&logLevel, "log-level", "l", "", "Log level (debug, info, warn, error, fatal) - overrides config.yaml log.level if specified")
+	rootCmd.PersistentFlags().BoolVar(&logFullPayloads, "log-full-payloads", false, "Log large debug payloads (e.g. RPC responses, release listings) in full instead of summarized")
+	rootCmd.PersistentFlags().BoolVar(&readOnly, "read-only", false, "Never execute commands or write state regardless of config - for safe ad-hoc inspection")
+
+	// Hidden flags for rehearsing failure handling against a mock validator
+	rootCmd.PersistentFlags().StringSliceVar(&failAtStages, "fail-at", nil, "Deliberately fail syncs at the given stages (refresh, release-lookup, sfdp, prepare, verify, slot-trigger, command:N)")
+	rootCmd.PersistentFlags().MarkHidden("fail-at")
+
+	// Hidden flags for running against fake release and SFDP servers, e.g. in integration tests
+	rootCmd.PersistentFlags().StringVar(&githubAPIURL, "github-api-url", "", "GitHub API URL releases and tags are listed from (default: https://api.github.com)")
+	rootCmd.PersistentFlags().MarkHidden("github-api-url")
+	rootCmd.PersistentFlags().StringVar(&sfdpAPIURL, "sfdp-api-url", "", "SFDP API URL requirements are looked up from (default: https://api.solana.org/api)")
+	rootCmd.PersistentFlags().MarkHidden("sfdp-api-url")
 
 	// Add subcommands here
 	rootCmd.AddCommand(runCmd)
+	rootCmd.AddCommand(initCmd)
+	rootCmd.AddCommand(configCmd)
+	rootCmd.AddCommand(migrateConfigCmd)
+	rootCmd.AddCommand(whatsNewCmd)
+	rootCmd.AddCommand(watchCmd)
+	rootCmd.AddCommand(recipesCmd)
+	rootCmd.AddCommand(serviceCmd)
 }
@@ -1,14 +1,24 @@
 package cmd
 
 import (
+	"context"
+	"errors"
+	"os"
+	"os/signal"
+	"syscall"
 	"time"
 
 	"github.com/charmbracelet/log"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/management"
 	"github.com/sol-strategies/solana-validator-version-sync/internal/manager"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/service"
 	"github.com/spf13/cobra"
 )
 
-var onIntervalDuration time.Duration
+var (
+	onIntervalDuration time.Duration
+	managementOptions  = management.Options{Address: management.DefaultAddress}
+)
 
 var runCmd = &cobra.Command{
 	Use:           "run",
@@ -21,15 +31,44 @@ var runCmd = &cobra.Command{
 
 		log.Info("starting solana-validator-version-sync", "version", version)
 
-		m, err := manager.NewFromConfig(loadedConfig)
+		m, err := manager.NewFromConfig(loadedConfig, manager.Options{
+			FailAt:   failAtStages,
+			ReadOnly: readOnly,
+			Version:  version,
+		})
 		if err != nil {
 			log.Fatal("failed to create sync manager", "error", err)
 		}
 
+		// stop cleanly (e.g. abandon a trigger slot wait) on interrupt or service stop
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+		ctx, cancel := service.NotifyContext(ctx)
+		defer cancel()
+
+		if managementOptions.Enabled() {
+			managementOptions.Status = func() any { return m.Status() }
+			server, err := management.Start(ctx, managementOptions)
+			if err != nil {
+				log.Fatal("failed to start management listener", "error", err)
+			}
+			if managementOptions.EnablePprof {
+				log.Info("serving pprof on management listener", "address", "http://"+server.Addr()+"/debug/pprof/")
+			}
+			if managementOptions.EnableStatus {
+				log.Info("serving status on management listener", "address", "http://"+server.Addr()+"/status")
+			}
+		}
+
 		if onIntervalDuration != 0 {
-			err = m.RunOnInterval(onIntervalDuration)
+			err = m.RunOnInterval(ctx, onIntervalDuration)
 		} else {
-			err = m.RunOnce()
+			err = m.RunOnce(ctx)
+		}
+
+		if errors.Is(err, context.Canceled) {
+			log.Info("stopped")
+			return
 		}
 
 		if err != nil {
@@ -40,4 +79,7 @@ var runCmd = &cobra.Command{
 
 func init() {
 	runCmd.Flags().DurationVarP(&onIntervalDuration, "on-interval", "i", 0, "Run continuously at the specified interval (e.g., 1m, 30s, 1h). If not specified, runs once and exits.")
+	runCmd.Flags().BoolVar(&managementOptions.EnablePprof, "enable-pprof", false, "Serve net/http/pprof on the management listener for investigating memory and goroutine leaks")
+	runCmd.Flags().BoolVar(&managementOptions.EnableStatus, "enable-status", false, "Serve the last run's status with daemon uptime, build, loaded config and run counts as JSON on the management listener under /status")
+	runCmd.Flags().StringVar(&managementOptions.Address, "management-address", managementOptions.Address, "Address the management listener binds to when enabled - keep it on loopback, profiles expose process internals")
 }
@@ -0,0 +1,124 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/service"
+	"github.com/spf13/cobra"
+)
+
+var (
+	serviceInterval time.Duration
+	serviceUser     string
+	serviceForce    bool
+)
+
+var serviceCmd = &cobra.Command{
+	Use:   "service",
+	Short: "Install, start and stop the interval daemon with the platform's service manager",
+	Long: `Install, start and stop the daemon (run --on-interval) with systemd on Linux, launchd on macOS or the
+service control manager on Windows. Usually requires root or administrator privileges.`,
+	Annotations: map[string]string{annotationSkipConfigLoad: "true"},
+}
+
+var serviceInstallCmd = &cobra.Command{
+	Use:   "install",
+	Short: "Install the daemon with the current --config and enable it at boot",
+	Long: `Install the daemon running this binary with the current --config on --on-interval, enabled at boot.
+The config is loaded first so an invalid config is never installed. On Linux the unit is named
+solana-validator-version-sync.service - replacing a unit generated by init --systemd requires --force and
+disabling its timer.`,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	Run: func(cmd *cobra.Command, args []string) {
+		m := serviceManager()
+
+		opts, err := serviceOptions()
+		if err != nil {
+			log.Fatal("failed to install service", "error", err)
+		}
+
+		if err := m.Install(opts); err != nil {
+			log.Fatal("failed to install service", "error", err)
+		}
+		log.Info("installed service - start it with: solana-validator-version-sync service start", "service", m.Description(), "interval", opts.Interval)
+	},
+}
+
+var serviceStartCmd = &cobra.Command{
+	Use:           "start",
+	Short:         "Start the installed daemon",
+	Annotations:   map[string]string{annotationSkipConfigLoad: "true"},
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	Run: func(cmd *cobra.Command, args []string) {
+		m := serviceManager()
+		if err := m.Start(); err != nil {
+			log.Fatal("failed to start service", "error", err)
+		}
+		log.Info("started service", "service", m.Description())
+	},
+}
+
+var serviceStopCmd = &cobra.Command{
+	Use:           "stop",
+	Short:         "Stop the running daemon",
+	Annotations:   map[string]string{annotationSkipConfigLoad: "true"},
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	Run: func(cmd *cobra.Command, args []string) {
+		m := serviceManager()
+		if err := m.Stop(); err != nil {
+			log.Fatal("failed to stop service", "error", err)
+		}
+		log.Info("stopped service", "service", m.Description())
+	},
+}
+
+// serviceManager returns the platform's service manager, refusing to change services in read-only mode
+func serviceManager() service.Manager {
+	if readOnly {
+		log.Fatal("refusing to change services in read-only mode")
+	}
+
+	m, err := service.New()
+	if err != nil {
+		log.Fatal("failed to find service manager", "error", err)
+	}
+	return m
+}
+
+// serviceOptions builds the install options, resolving absolute paths for the binary and config
+func serviceOptions() (opts service.Options, err error) {
+	binaryPath, err := os.Executable()
+	if err != nil {
+		return opts, fmt.Errorf("failed to resolve binary path: %w", err)
+	}
+
+	configPath, err := filepath.Abs(configFile)
+	if err != nil {
+		return opts, fmt.Errorf("failed to resolve config path: %w", err)
+	}
+
+	return service.Options{
+		BinaryPath: binaryPath,
+		ConfigFile: configPath,
+		Interval:   serviceInterval,
+		User:       serviceUser,
+		Force:      serviceForce,
+	}, nil
+}
+
+func init() {
+	serviceInstallCmd.Flags().DurationVarP(&serviceInterval, "on-interval", "i", service.DefaultInterval, "Interval the daemon syncs on")
+	serviceInstallCmd.Flags().StringVar(&serviceUser, "user", "solana", "User the daemon runs as (systemd and launchd - Windows services run as LocalSystem)")
+	serviceInstallCmd.Flags().BoolVarP(&serviceForce, "force", "f", false, "Overwrite an existing service definition")
+
+	serviceCmd.AddCommand(serviceInstallCmd)
+	serviceCmd.AddCommand(serviceStartCmd)
+	serviceCmd.AddCommand(serviceStopCmd)
+}
@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/logging"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/validator"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/watch"
+	"github.com/spf13/cobra"
+)
+
+var watchRefreshRate time.Duration
+
+var watchCmd = &cobra.Command{
+	Use:   "watch",
+	Short: "Continuously display validator state, gossip, releases and SFDP requirements without syncing",
+	Long: `Continuously display the validator's state, whether the active identity is in gossip, the latest release
+syncs would target and the SFDP requirements - refreshed every --refresh-rate. Never executes commands or writes
+state, so it is safe to run alongside the syncing daemon, e.g. during incidents.
+Logs below error level (including log.levels overrides) are hidden so they don't interleave with the display
+unless --log-level is given.`,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	Run: func(cmd *cobra.Command, args []string) {
+		if logLevel == "" {
+			log.SetLevel(log.ErrorLevel)
+			logging.SetComponentLevels(nil)
+		}
+
+		// watch is always read-only and keeps state in memory only
+		v, err := validator.New(validator.Options{
+			Cluster:         loadedConfig.Cluster.Name,
+			HardForks:       loadedConfig.Cluster.HardForks,
+			ValidatorConfig: loadedConfig.Validator,
+			SyncConfig:      loadedConfig.Sync,
+			ReadOnly:        true,
+		})
+		if err != nil {
+			log.Fatal("failed to create validator", "error", err)
+		}
+
+		w, err := watch.New(watch.Options{
+			Snapshotter: v,
+			RefreshRate: watchRefreshRate,
+			Out:         os.Stdout,
+			ClearScreen: isTerminal(os.Stdout),
+		})
+		if err != nil {
+			log.Fatal("failed to create watcher", "error", err)
+		}
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		err = w.Run(ctx)
+		if err != nil && !errors.Is(err, context.Canceled) {
+			log.Fatal("failed to watch validator", "error", err)
+		}
+	},
+}
+
+// isTerminal returns true when file is a terminal rather than e.g. a pipe or file
+func isTerminal(file *os.File) bool {
+	info, err := file.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+func init() {
+	watchCmd.Flags().DurationVarP(&watchRefreshRate, "refresh-rate", "r", 5*time.Second, "How often to refresh the display (e.g., 5s, 1m)")
+}
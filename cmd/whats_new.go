@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/log"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/changelog"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/state"
+	"github.com/spf13/cobra"
+)
+
+var (
+	whatsNewSince string
+	whatsNewJSON  bool
+)
+
+var whatsNewCmd = &cobra.Command{
+	Use:   "whats-new",
+	Short: "List behavior changes between the previously run version and this binary",
+	Long: `List the behavior changes shipped between the version of this tool that previously ran against the state.file
+and this binary, from the changelog embedded in the binary. Use --since to list changes since a given version instead,
+and --json for machine-readable output with stable change ids.`,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	Run: func(cmd *cobra.Command, args []string) {
+		previousVersion := whatsNewSince
+		if previousVersion == "" {
+			stateStore, err := state.NewStore(loadedConfig.State.File)
+			if err != nil {
+				log.Fatal("failed to load state", "error", err)
+			}
+			previousVersion = whatsNewPreviousVersion(stateStore.Get(), version)
+		}
+
+		changes, err := changelog.All()
+		if err != nil {
+			log.Fatal("failed to load changelog", "error", err)
+		}
+		changes = changelog.Between(changes, previousVersion, version)
+
+		if whatsNewJSON {
+			encoder := json.NewEncoder(os.Stdout)
+			encoder.SetIndent("", "  ")
+			err := encoder.Encode(struct {
+				PreviousVersion string             `json:"previous_version"`
+				CurrentVersion  string             `json:"current_version"`
+				Changes         []changelog.Change `json:"changes"`
+			}{previousVersion, version, append([]changelog.Change{}, changes...)})
+			if err != nil {
+				log.Fatal("failed to write changes", "error", err)
+			}
+			return
+		}
+
+		if len(changes) == 0 {
+			log.Info("no behavior changes since the previously run version", "previousVersion", previousVersion, "version", version)
+			return
+		}
+		for _, change := range changes {
+			fmt.Printf("[%s] %s (%s)\n  %s\n", change.Kind, change.ID, change.Version, change.Summary)
+			if len(change.ConfigKeys) > 0 {
+				fmt.Printf("  config keys: %s\n", strings.Join(change.ConfigKeys, ", "))
+			}
+		}
+	},
+}
+
+// whatsNewPreviousVersion returns the version that ran before currentVersion according to the state - once this
+// binary has run the recorded tool version is the current one, so the version before it is the previous one
+func whatsNewPreviousVersion(data state.Data, currentVersion string) string {
+	if data.ToolVersion == currentVersion {
+		return data.PreviousToolVersion
+	}
+	return data.ToolVersion
+}
+
+func init() {
+	whatsNewCmd.Flags().StringVar(&whatsNewSince, "since", "", "List changes since this version instead of the previously run version recorded in the state")
+	whatsNewCmd.Flags().BoolVar(&whatsNewJSON, "json", false, "Print the changes as JSON")
+}
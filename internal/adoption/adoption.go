@@ -0,0 +1,90 @@
+package adoption
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/go-version"
+)
+
+// Distribution is how stake is distributed across the versions validators run
+type Distribution struct {
+	// Entries are the stake running each version - a version may appear more than once
+	Entries []Entry
+	// Source describes where the distribution came from, for logging
+	Source string
+}
+
+// Entry is the stake running a version - stake is in any unit (lamports, SOL, percent) as long as every entry
+// of a distribution uses the same one
+type Entry struct {
+	Version string  `json:"version"`
+	Stake   float64 `json:"stake"`
+}
+
+// Source provides the current stake-weighted version distribution
+type Source interface {
+	Distribution(ctx context.Context) (Distribution, error)
+}
+
+// TotalStake returns the stake of all entries, including those running unparseable versions
+func (d Distribution) TotalStake() (total float64) {
+	for _, entry := range d.Entries {
+		total += entry.Stake
+	}
+	return total
+}
+
+// StakePercentAtOrAbove returns the percentage of stake running the target core version or newer - entries
+// running unparseable versions count towards the total but never towards adoption
+func (d Distribution) StakePercentAtOrAbove(target *version.Version) (percent float64, err error) {
+	total := d.TotalStake()
+	if total <= 0 {
+		return 0, fmt.Errorf("no stake in version distribution from %s", d.Source)
+	}
+
+	targetCore := target.Core()
+	adopted := 0.0
+	for _, entry := range d.Entries {
+		entryVersion, err := version.NewVersion(entry.Version)
+		if err != nil {
+			continue
+		}
+		if entryVersion.Core().GreaterThanOrEqual(targetCore) {
+			adopted += entry.Stake
+		}
+	}
+
+	return adopted / total * 100, nil
+}
+
+// TopVersions returns up to n versions with the most stake, as percentages of the total, for logging
+func (d Distribution) TopVersions(n int) map[string]float64 {
+	total := d.TotalStake()
+	if total <= 0 {
+		return nil
+	}
+
+	byVersion := make(map[string]float64)
+	for _, entry := range d.Entries {
+		byVersion[entry.Version] += entry.Stake
+	}
+
+	versions := make([]string, 0, len(byVersion))
+	for v := range byVersion {
+		versions = append(versions, v)
+	}
+	sort.Slice(versions, func(i, j int) bool {
+		if byVersion[versions[i]] != byVersion[versions[j]] {
+			return byVersion[versions[i]] > byVersion[versions[j]]
+		}
+		return versions[i] < versions[j]
+	})
+
+	top := make(map[string]float64, n)
+	for _, v := range versions[:min(n, len(versions))] {
+		top[v] = byVersion[v] / total * 100
+	}
+	return top
+}
@@ -0,0 +1,64 @@
+package adoption
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/go-version"
+)
+
+func TestDistribution_StakePercentAtOrAbove(t *testing.T) {
+	distribution := Distribution{Source: "test", Entries: []Entry{
+		{Version: "2.1.4", Stake: 20},
+		{Version: "2.1.5", Stake: 30},
+		{Version: "2.2.0-beta.1", Stake: 10},
+		{Version: "2.1.5", Stake: 15},
+		{Version: "unknown", Stake: 25},
+	}}
+
+	tests := []struct {
+		name          string
+		distribution  Distribution
+		targetVersion string
+		want          float64
+		wantErr       bool
+	}{
+		{name: "target with adoption", distribution: distribution, targetVersion: "2.1.5", want: 55},
+		{name: "target tag version compares by core", distribution: distribution, targetVersion: "2.1.5-jito", want: 55},
+		{name: "newer target", distribution: distribution, targetVersion: "2.2.0", want: 10},
+		{name: "target nobody runs", distribution: distribution, targetVersion: "3.0.0", want: 0},
+		{name: "old target everybody parseable runs", distribution: distribution, targetVersion: "1.18.0", want: 75},
+		{name: "no stake", distribution: Distribution{}, targetVersion: "2.1.5", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.distribution.StakePercentAtOrAbove(version.Must(version.NewVersion(tt.targetVersion)))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("StakePercentAtOrAbove() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("StakePercentAtOrAbove() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDistribution_TopVersions(t *testing.T) {
+	distribution := Distribution{Entries: []Entry{
+		{Version: "2.1.4", Stake: 20},
+		{Version: "2.1.5", Stake: 30},
+		{Version: "2.1.5", Stake: 20},
+		{Version: "2.2.0", Stake: 10},
+		{Version: "2.0.0", Stake: 20},
+	}}
+
+	want := map[string]float64{"2.1.5": 50, "2.0.0": 20}
+	if got := distribution.TopVersions(2); !reflect.DeepEqual(got, want) {
+		t.Errorf("TopVersions(2) = %v, want %v", got, want)
+	}
+	if got := (Distribution{}).TopVersions(2); got != nil {
+		t.Errorf("TopVersions(2) of empty distribution = %v, want nil", got)
+	}
+}
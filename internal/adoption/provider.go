@@ -0,0 +1,145 @@
+package adoption
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// maxProviderResponseSize bounds provider responses - per-validator listings of a large cluster are a few MB
+const maxProviderResponseSize = 64 << 20
+
+// ProviderOptions represents the options for creating a provider source
+type ProviderOptions struct {
+	// URL is the endpoint the distribution is fetched from with GET
+	URL string
+	// Headers are extra request headers, e.g. for authentication
+	Headers map[string]string
+	// Timeout is the timeout for each request
+	Timeout time.Duration
+	// CacheTTL is how long a fetched distribution is reused for
+	CacheTTL time.Duration
+}
+
+// ProviderSource fetches the distribution from a trusted provider (e.g. a validators.app-style API or a
+// self-hosted aggregator). Responses are either an aggregated distribution:
+//
+//	{"versions": [{"version": "2.1.5", "stake": 1234.5}, ...]}
+//
+// or a list of validators with validators.app field names:
+//
+//	[{"software_version": "2.1.5", "active_stake": 1234.5}, ...]
+type ProviderSource struct {
+	opts       ProviderOptions
+	httpClient *http.Client
+	now        func() time.Time
+
+	mutex     sync.Mutex
+	cached    *Distribution
+	fetchedAt time.Time
+}
+
+// NewProviderSource creates a source fetching the distribution from a provider
+func NewProviderSource(opts ProviderOptions) *ProviderSource {
+	return &ProviderSource{
+		opts:       opts,
+		httpClient: &http.Client{Timeout: opts.Timeout},
+		now:        time.Now,
+	}
+}
+
+// Distribution returns the cached distribution when fresh, otherwise fetches it - failed fetches are not cached
+func (s *ProviderSource) Distribution(ctx context.Context) (Distribution, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.cached != nil && s.now().Sub(s.fetchedAt) < s.opts.CacheTTL {
+		return *s.cached, nil
+	}
+
+	distribution, err := s.fetch(ctx)
+	if err != nil {
+		return Distribution{}, err
+	}
+
+	s.cached = &distribution
+	s.fetchedAt = s.now()
+	return distribution, nil
+}
+
+// fetch fetches and decodes the distribution from the provider
+func (s *ProviderSource) fetch(ctx context.Context) (Distribution, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.opts.URL, nil)
+	if err != nil {
+		return Distribution{}, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	for name, value := range s.opts.Headers {
+		req.Header.Set(name, value)
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return Distribution{}, fmt.Errorf("failed to fetch version distribution: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return Distribution{}, fmt.Errorf("unexpected status code %d fetching version distribution: %s", resp.StatusCode, string(respBody))
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxProviderResponseSize))
+	if err != nil {
+		return Distribution{}, fmt.Errorf("failed to read version distribution: %w", err)
+	}
+
+	distribution, err := decodeProviderResponse(body)
+	if err != nil {
+		return Distribution{}, fmt.Errorf("invalid version distribution: %w", err)
+	}
+	distribution.Source = s.opts.URL
+	return distribution, nil
+}
+
+// providerValidator is a validator as listed by validators.app-style APIs
+type providerValidator struct {
+	SoftwareVersion string  `json:"software_version"`
+	ActiveStake     float64 `json:"active_stake"`
+}
+
+// decodeProviderResponse decodes either response format
+func decodeProviderResponse(body []byte) (Distribution, error) {
+	trimmed := bytes.TrimSpace(body)
+	if len(trimmed) > 0 && trimmed[0] == '[' {
+		var validators []providerValidator
+		if err := json.Unmarshal(trimmed, &validators); err != nil {
+			return Distribution{}, err
+		}
+		distribution := Distribution{}
+		for _, validator := range validators {
+			nodeVersion := validator.SoftwareVersion
+			if nodeVersion == "" {
+				nodeVersion = unknownVersion
+			}
+			distribution.Entries = append(distribution.Entries, Entry{Version: nodeVersion, Stake: validator.ActiveStake})
+		}
+		return distribution, nil
+	}
+
+	var aggregated struct {
+		Versions []Entry `json:"versions"`
+	}
+	if err := json.Unmarshal(trimmed, &aggregated); err != nil {
+		return Distribution{}, err
+	}
+	if aggregated.Versions == nil {
+		return Distribution{}, fmt.Errorf("expected a versions list or a list of validators")
+	}
+	return Distribution{Entries: aggregated.Versions}, nil
+}
@@ -0,0 +1,127 @@
+package adoption
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestDecodeProviderResponse(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		want    []Entry
+		wantErr bool
+	}{
+		{
+			name: "aggregated versions",
+			body: `{"versions": [{"version": "2.1.5", "stake": 70.5}, {"version": "2.1.4", "stake": 29.5}]}`,
+			want: []Entry{{Version: "2.1.5", Stake: 70.5}, {Version: "2.1.4", Stake: 29.5}},
+		},
+		{
+			name: "validators list",
+			body: ` [{"account": "a", "software_version": "2.1.5", "active_stake": 300}, {"account": "b", "software_version": "", "active_stake": 100}]`,
+			want: []Entry{{Version: "2.1.5", Stake: 300}, {Version: unknownVersion, Stake: 100}},
+		},
+		{
+			name:    "object without versions",
+			body:    `{"data": []}`,
+			wantErr: true,
+		},
+		{
+			name:    "invalid json",
+			body:    `not json`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := decodeProviderResponse([]byte(tt.body))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("decodeProviderResponse() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got.Entries, tt.want) {
+				t.Errorf("decodeProviderResponse() = %+v, want %+v", got.Entries, tt.want)
+			}
+		})
+	}
+}
+
+func TestProviderSource_Distribution(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.Header.Get("Authorization") != "Token secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(`{"versions": [{"version": "2.1.5", "stake": 1}]}`))
+	}))
+	defer server.Close()
+
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	source := NewProviderSource(ProviderOptions{
+		URL:      server.URL,
+		Headers:  map[string]string{"Authorization": "Token secret"},
+		Timeout:  time.Second,
+		CacheTTL: time.Minute,
+	})
+	source.now = func() time.Time { return now }
+
+	for _, advance := range []time.Duration{0, 30 * time.Second, 31 * time.Second} {
+		now = now.Add(advance)
+		distribution, err := source.Distribution(context.Background())
+		if err != nil {
+			t.Fatalf("Distribution() error = %v", err)
+		}
+		if distribution.Source != server.URL || len(distribution.Entries) != 1 {
+			t.Errorf("Distribution() = %+v", distribution)
+		}
+	}
+
+	// fetched, reused within the cache ttl, then refetched once expired
+	if requests != 2 {
+		t.Errorf("provider requests = %d, want 2", requests)
+	}
+}
+
+func TestProviderSource_Distribution_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		headers map[string]string
+	}{
+		{name: "unauthorized", status: http.StatusUnauthorized, body: `{"error": "unauthorized"}`},
+		{name: "server error", status: http.StatusBadGateway, body: `bad gateway`},
+		{name: "invalid body", status: http.StatusOK, body: `{"data": []}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			source := NewProviderSource(ProviderOptions{URL: server.URL, Timeout: time.Second, CacheTTL: time.Minute})
+			for i := 0; i < 2; i++ {
+				if _, err := source.Distribution(context.Background()); err == nil {
+					t.Fatal("Distribution() expected error")
+				}
+			}
+
+			// failed fetches are not cached
+			if requests != 2 {
+				t.Errorf("provider requests = %d, want 2", requests)
+			}
+		})
+	}
+}
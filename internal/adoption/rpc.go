@@ -0,0 +1,53 @@
+package adoption
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sol-strategies/solana-validator-version-sync/internal/rpc"
+)
+
+// unknownVersion is the version recorded for stake whose node isn't advertising a version in gossip
+const unknownVersion = "unknown"
+
+// RPCSource computes the distribution from the versions nodes advertise in gossip (getClusterNodes) weighted by
+// the activated stake of their current vote accounts (getVoteAccounts) - delinquent stake is not counted
+type RPCSource struct {
+	client *rpc.Client
+}
+
+// NewRPCSource creates a source computing the distribution from the given RPC URL
+func NewRPCSource(rpcURL string) *RPCSource {
+	return &RPCSource{client: rpc.NewClient(rpcURL)}
+}
+
+// Distribution computes the current distribution from gossip and vote accounts
+func (s *RPCSource) Distribution(ctx context.Context) (Distribution, error) {
+	versions, err := s.client.GetClusterNodeVersions()
+	if err != nil {
+		return Distribution{}, fmt.Errorf("failed to get cluster node versions: %w", err)
+	}
+
+	voteAccounts, err := s.client.GetVoteAccounts()
+	if err != nil {
+		return Distribution{}, fmt.Errorf("failed to get vote accounts: %w", err)
+	}
+
+	return distributionFromRPC(versions, voteAccounts.Current), nil
+}
+
+// distributionFromRPC weights node versions by the activated stake of their vote accounts
+func distributionFromRPC(versions map[string]string, voteAccounts []rpc.VoteAccount) Distribution {
+	distribution := Distribution{Source: "rpc"}
+	for _, account := range voteAccounts {
+		nodeVersion, ok := versions[account.NodePubkey]
+		if !ok {
+			nodeVersion = unknownVersion
+		}
+		distribution.Entries = append(distribution.Entries, Entry{
+			Version: nodeVersion,
+			Stake:   float64(account.ActivatedStake),
+		})
+	}
+	return distribution
+}
@@ -0,0 +1,68 @@
+package adoption
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/sol-strategies/solana-validator-version-sync/internal/rpc"
+)
+
+func TestRPCSource_Distribution(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req rpc.JSONRPCRequest
+		json.NewDecoder(r.Body).Decode(&req)
+
+		resp := rpc.JSONRPCResponse{JSONRPC: "2.0", ID: req.ID}
+		switch req.Method {
+		case "getClusterNodes":
+			resp.Result = []interface{}{
+				map[string]interface{}{"pubkey": "node-1", "version": "2.1.5"},
+				map[string]interface{}{"pubkey": "node-2", "version": "2.1.4"},
+				map[string]interface{}{"pubkey": "node-4", "version": "2.1.5"},
+			}
+		case "getVoteAccounts":
+			resp.Result = map[string]interface{}{
+				"current": []interface{}{
+					map[string]interface{}{"votePubkey": "vote-1", "nodePubkey": "node-1", "activatedStake": 300},
+					map[string]interface{}{"votePubkey": "vote-2", "nodePubkey": "node-2", "activatedStake": 200},
+					map[string]interface{}{"votePubkey": "vote-3", "nodePubkey": "node-3", "activatedStake": 100},
+				},
+				"delinquent": []interface{}{
+					map[string]interface{}{"votePubkey": "vote-4", "nodePubkey": "node-4", "activatedStake": 1000},
+				},
+			}
+		}
+		json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	distribution, err := NewRPCSource(server.URL).Distribution(context.Background())
+	if err != nil {
+		t.Fatalf("Distribution() error = %v", err)
+	}
+
+	// delinquent stake is not counted, nodes missing from gossip count as unknown
+	want := Distribution{Source: "rpc", Entries: []Entry{
+		{Version: "2.1.5", Stake: 300},
+		{Version: "2.1.4", Stake: 200},
+		{Version: unknownVersion, Stake: 100},
+	}}
+	if !reflect.DeepEqual(distribution, want) {
+		t.Errorf("Distribution() = %+v, want %+v", distribution, want)
+	}
+}
+
+func TestRPCSource_Distribution_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	if _, err := NewRPCSource(server.URL).Distribution(context.Background()); err == nil {
+		t.Error("Distribution() expected error")
+	}
+}
@@ -0,0 +1,101 @@
+package budget
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+)
+
+const (
+	// ProviderGitHub is the budget of GitHub API requests
+	ProviderGitHub = "github"
+	// ProviderSFDP is the budget of SFDP API requests
+	ProviderSFDP = "sfdp"
+)
+
+// ErrExhausted is returned when a request is not made because its provider's budget is exhausted and nothing
+// is cached to degrade to
+var ErrExhausted = errors.New("request budget exhausted")
+
+var (
+	// buckets are the request budgets shared by every component of the daemon, keyed by provider - providers
+	// without a bucket are unlimited
+	buckets      = make(map[string]*Bucket)
+	bucketsMutex sync.Mutex
+)
+
+// SetLimit sets the requests per hour allowed to the provider, shared by every component of the daemon -
+// 0 removes the limit
+func SetLimit(provider string, perHour int) {
+	bucketsMutex.Lock()
+	defer bucketsMutex.Unlock()
+
+	if perHour <= 0 {
+		delete(buckets, provider)
+		return
+	}
+	buckets[provider] = NewBucket(perHour)
+}
+
+// Allow takes a request from the provider's budget, returning false when the budget is exhausted
+func Allow(provider string) bool {
+	bucketsMutex.Lock()
+	bucket := buckets[provider]
+	bucketsMutex.Unlock()
+
+	return bucket.Allow()
+}
+
+// Exhausted returns an error wrapping ErrExhausted for the provider
+func Exhausted(provider string) error {
+	return fmt.Errorf("%s %w", provider, ErrExhausted)
+}
+
+// Bucket is a token bucket allowing a number of requests per hour - it starts full, so a burst of up to the
+// hourly limit is allowed, and refills continuously
+type Bucket struct {
+	mutex      sync.Mutex
+	capacity   float64
+	tokens     float64
+	refillRate float64 // tokens per second
+	refilledAt time.Time
+	now        func() time.Time
+}
+
+// NewBucket creates a full bucket allowing perHour requests per hour
+func NewBucket(perHour int) *Bucket {
+	b := &Bucket{
+		capacity:   float64(perHour),
+		tokens:     float64(perHour),
+		refillRate: float64(perHour) / time.Hour.Seconds(),
+		now:        time.Now,
+	}
+	b.refilledAt = b.now()
+	return b
+}
+
+// Allow takes a token, returning false when none is left - a nil bucket is unlimited
+func (b *Bucket) Allow() bool {
+	if b == nil {
+		return true
+	}
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.refill()
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// refill adds the tokens accrued since the last refill - callers hold the mutex
+func (b *Bucket) refill() {
+	now := b.now()
+	b.tokens = math.Min(b.capacity, b.tokens+now.Sub(b.refilledAt).Seconds()*b.refillRate)
+	b.refilledAt = now
+}
@@ -0,0 +1,84 @@
+package budget
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestBucket_Allow(t *testing.T) {
+	now := time.Date(2026, 1, 2, 3, 0, 0, 0, time.UTC)
+	bucket := NewBucket(60)
+	bucket.now = func() time.Time { return now }
+	bucket.refilledAt = now
+
+	// a burst of up to the hourly limit is allowed
+	for i := 0; i < 60; i++ {
+		if !bucket.Allow() {
+			t.Fatalf("Allow() = false on request %d, want the full bucket to allow 60", i+1)
+		}
+	}
+	if bucket.Allow() {
+		t.Error("Allow() = true with an exhausted bucket, want false")
+	}
+
+	tests := []struct {
+		name    string
+		elapsed time.Duration
+		want    bool
+	}{
+		{name: "not refilled yet", elapsed: 30 * time.Second, want: false},
+		{name: "refilled one token", elapsed: 30 * time.Second, want: true},
+		{name: "token taken", elapsed: 0, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now = now.Add(tt.elapsed)
+			if got := bucket.Allow(); got != tt.want {
+				t.Errorf("Allow() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	// refills never exceed the hourly limit
+	now = now.Add(24 * time.Hour)
+	allowed := 0
+	for bucket.Allow() {
+		allowed++
+	}
+	if allowed != 60 {
+		t.Errorf("Allow() allowed %d requests after a day, want 60", allowed)
+	}
+}
+
+func TestBucket_Allow_Nil(t *testing.T) {
+	var bucket *Bucket
+	if !bucket.Allow() {
+		t.Error("nil bucket Allow() = false, want unlimited")
+	}
+}
+
+func TestSetLimit(t *testing.T) {
+	defer SetLimit(ProviderSFDP, 0)
+
+	if !Allow(ProviderSFDP) {
+		t.Error("Allow() = false without a limit, want true")
+	}
+
+	SetLimit(ProviderSFDP, 1)
+	if !Allow(ProviderSFDP) || Allow(ProviderSFDP) {
+		t.Error("Allow() with a limit of 1 per hour did not allow exactly one request")
+	}
+	if !Allow(ProviderGitHub) {
+		t.Error("Allow() = false for another provider, want budgets kept per provider")
+	}
+
+	SetLimit(ProviderSFDP, 0)
+	if !Allow(ProviderSFDP) {
+		t.Error("Allow() = false once the limit is removed, want true")
+	}
+
+	if err := Exhausted(ProviderSFDP); !errors.Is(err, ErrExhausted) {
+		t.Errorf("Exhausted() = %v, want it to wrap ErrExhausted", err)
+	}
+}
@@ -0,0 +1,55 @@
+package cadence
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// MinReleases is the number of releases needed to compute a cadence - one interval
+const MinReleases = 2
+
+// day is the length of a day cadences are expressed in
+const day = 24 * time.Hour
+
+// Cadence represents a client's release cadence, computed from the publish times of its recent releases
+type Cadence struct {
+	// Releases is the number of releases the cadence was computed from
+	Releases int `json:"releases"`
+	// AverageIntervalDays is the average number of days between consecutive releases
+	AverageIntervalDays float64 `json:"average_interval_days"`
+	// LastReleaseAt is when the most recent release was published
+	LastReleaseAt time.Time `json:"last_release_at"`
+	// DaysSinceLastRelease is the number of days since the most recent release was published
+	DaysSinceLastRelease float64 `json:"days_since_last_release"`
+	// NextReleaseEstimate is the last release plus the average interval - a naive estimate for scheduling upgrade
+	// windows, not a prediction of any actual release
+	NextReleaseEstimate time.Time `json:"next_release_estimate"`
+	// Overdue is true when the next release estimate has already passed
+	Overdue bool `json:"overdue"`
+}
+
+// New computes the cadence of releases published at the given times as of now, in any order
+func New(publishedAt []time.Time, now time.Time) (Cadence, error) {
+	if len(publishedAt) < MinReleases {
+		return Cadence{}, fmt.Errorf("at least %d releases are needed to compute a release cadence - got: %d", MinReleases, len(publishedAt))
+	}
+
+	sorted := make([]time.Time, len(publishedAt))
+	copy(sorted, publishedAt)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Before(sorted[j]) })
+
+	first := sorted[0]
+	last := sorted[len(sorted)-1]
+	averageInterval := last.Sub(first) / time.Duration(len(sorted)-1)
+	nextReleaseEstimate := last.Add(averageInterval)
+
+	return Cadence{
+		Releases:             len(sorted),
+		AverageIntervalDays:  averageInterval.Hours() / day.Hours(),
+		LastReleaseAt:        last.UTC(),
+		DaysSinceLastRelease: now.Sub(last).Hours() / day.Hours(),
+		NextReleaseEstimate:  nextReleaseEstimate.UTC(),
+		Overdue:              nextReleaseEstimate.Before(now),
+	}, nil
+}
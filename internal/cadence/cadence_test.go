@@ -0,0 +1,72 @@
+package cadence
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNew(t *testing.T) {
+	start := time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC)
+	daysAfterStart := func(days int) time.Time { return start.AddDate(0, 0, days) }
+
+	tests := []struct {
+		name        string
+		publishedAt []time.Time
+		now         time.Time
+		want        Cadence
+		wantErr     bool
+	}{
+		{
+			name:    "no releases",
+			now:     start,
+			wantErr: true,
+		},
+		{
+			name:        "single release",
+			publishedAt: []time.Time{start},
+			now:         start,
+			wantErr:     true,
+		},
+		{
+			name:        "unordered releases",
+			publishedAt: []time.Time{daysAfterStart(6), start, daysAfterStart(2)},
+			now:         daysAfterStart(7),
+			want: Cadence{
+				Releases:             3,
+				AverageIntervalDays:  3,
+				LastReleaseAt:        daysAfterStart(6),
+				DaysSinceLastRelease: 1,
+				NextReleaseEstimate:  daysAfterStart(9),
+				Overdue:              false,
+			},
+		},
+		{
+			name:        "overdue",
+			publishedAt: []time.Time{start, daysAfterStart(7)},
+			now:         daysAfterStart(17),
+			want: Cadence{
+				Releases:             2,
+				AverageIntervalDays:  7,
+				LastReleaseAt:        daysAfterStart(7),
+				DaysSinceLastRelease: 10,
+				NextReleaseEstimate:  daysAfterStart(14),
+				Overdue:              true,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := New(tt.publishedAt, tt.now)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("New() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got != tt.want {
+				t.Errorf("New() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,116 @@
+// Package changelog holds the embedded, machine-readable changelog of tool behavior changes
+package changelog
+
+import (
+	"bytes"
+	_ "embed"
+	"fmt"
+	"slices"
+
+	"github.com/hashicorp/go-version"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// VersionUnreleased marks changes not in a release yet
+	VersionUnreleased = "unreleased"
+	// KindBreaking changes stop configs or invocations from working
+	KindBreaking = "breaking"
+	// KindBehavior changes give the same config a different outcome
+	KindBehavior = "behavior"
+)
+
+// Kinds are the valid change kinds
+var Kinds = []string{KindBreaking, KindBehavior}
+
+//go:embed changelog.yaml
+var changelogFile []byte
+
+// Change is a behavior change of the tool
+type Change struct {
+	// ID is the change's stable identifier
+	ID string `yaml:"id" json:"id"`
+	// Version is the release the change shipped in, or unreleased
+	Version string `yaml:"version" json:"version"`
+	// Kind is one of breaking, behavior
+	Kind string `yaml:"kind" json:"kind"`
+	// Summary is what changed and what to do about it
+	Summary string `yaml:"summary" json:"summary"`
+	// ConfigKeys are the config keys affected
+	ConfigKeys []string `yaml:"config_keys,omitempty" json:"config_keys,omitempty"`
+}
+
+// All returns every change, oldest first
+func All() ([]Change, error) {
+	return parse(changelogFile)
+}
+
+// parse parses and validates a changelog
+func parse(content []byte) (changes []Change, err error) {
+	var changelog struct {
+		Changes []Change `yaml:"changes"`
+	}
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&changelog); err != nil {
+		return nil, fmt.Errorf("failed to parse changelog: %w", err)
+	}
+
+	ids := make(map[string]bool, len(changelog.Changes))
+	for i, change := range changelog.Changes {
+		switch {
+		case change.ID == "":
+			return nil, fmt.Errorf("changelog change %d has no id", i)
+		case ids[change.ID]:
+			return nil, fmt.Errorf("changelog change %s is listed more than once", change.ID)
+		case !slices.Contains(Kinds, change.Kind):
+			return nil, fmt.Errorf("changelog change %s kind must be one of %v - got: %s", change.ID, Kinds, change.Kind)
+		case change.Summary == "":
+			return nil, fmt.Errorf("changelog change %s has no summary", change.ID)
+		}
+		if change.Version != VersionUnreleased {
+			if _, err := version.NewVersion(change.Version); err != nil {
+				return nil, fmt.Errorf("changelog change %s version must be a semver version or %s - got: %s", change.ID, VersionUnreleased, change.Version)
+			}
+		}
+		ids[change.ID] = true
+	}
+
+	return changelog.Changes, nil
+}
+
+// Between returns the changes after previousVersion up to and including currentVersion, oldest first.
+// An empty or unparseable previousVersion (e.g. never recorded, or a dev build) returns every change up to
+// currentVersion. Unreleased changes are only in unparseable current versions, e.g. dev builds.
+func Between(changes []Change, previousVersion string, currentVersion string) []Change {
+	previous, previousErr := version.NewVersion(previousVersion)
+	current, currentErr := version.NewVersion(currentVersion)
+
+	// the same build has no new changes
+	if previousVersion != "" && previousVersion == currentVersion {
+		return nil
+	}
+
+	var between []Change
+	for _, change := range changes {
+		if change.Version == VersionUnreleased {
+			if currentErr != nil {
+				between = append(between, change)
+			}
+			continue
+		}
+
+		changeVersion, err := version.NewVersion(change.Version)
+		if err != nil {
+			continue
+		}
+		if previousErr == nil && !changeVersion.GreaterThan(previous) {
+			continue
+		}
+		if currentErr == nil && changeVersion.GreaterThan(current) {
+			continue
+		}
+		between = append(between, change)
+	}
+	return between
+}
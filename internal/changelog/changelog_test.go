@@ -0,0 +1,117 @@
+package changelog
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestAll(t *testing.T) {
+	changes, err := All()
+	if err != nil {
+		t.Fatalf("All() error = %v", err)
+	}
+	if len(changes) == 0 {
+		t.Error("All() returned no changes")
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantIDs []string
+		wantErr bool
+	}{
+		{
+			name: "valid",
+			content: `changes:
+  - {id: a, version: 1.0.0, kind: breaking, summary: a changed}
+  - {id: b, version: unreleased, kind: behavior, summary: b changed, config_keys: [sync.commands]}
+`,
+			wantIDs: []string{"a", "b"},
+		},
+		{
+			name:    "missing id",
+			content: "changes:\n  - {version: 1.0.0, kind: breaking, summary: changed}\n",
+			wantErr: true,
+		},
+		{
+			name:    "duplicate id",
+			content: "changes:\n  - {id: a, version: 1.0.0, kind: breaking, summary: changed}\n  - {id: a, version: 1.1.0, kind: breaking, summary: changed}\n",
+			wantErr: true,
+		},
+		{
+			name:    "unknown kind",
+			content: "changes:\n  - {id: a, version: 1.0.0, kind: feature, summary: changed}\n",
+			wantErr: true,
+		},
+		{
+			name:    "invalid version",
+			content: "changes:\n  - {id: a, version: next, kind: breaking, summary: changed}\n",
+			wantErr: true,
+		},
+		{
+			name:    "missing summary",
+			content: "changes:\n  - {id: a, version: 1.0.0, kind: breaking}\n",
+			wantErr: true,
+		},
+		{
+			name:    "unknown field",
+			content: "changes:\n  - {id: a, version: 1.0.0, kind: breaking, summary: changed, since: 1.0.0}\n",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			changes, err := parse([]byte(tt.content))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parse() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := changeIDs(changes); !slices.Equal(got, tt.wantIDs) {
+				t.Errorf("parse() ids = %v, want %v", got, tt.wantIDs)
+			}
+		})
+	}
+}
+
+func TestBetween(t *testing.T) {
+	changes := []Change{
+		{ID: "a", Version: "1.0.0", Kind: KindBreaking},
+		{ID: "b", Version: "1.1.0", Kind: KindBehavior},
+		{ID: "c", Version: "1.2.0", Kind: KindBehavior},
+		{ID: "d", Version: VersionUnreleased, Kind: KindBehavior},
+	}
+
+	tests := []struct {
+		name            string
+		previousVersion string
+		currentVersion  string
+		wantIDs         []string
+	}{
+		{name: "upgrade", previousVersion: "1.0.0", currentVersion: "1.2.0", wantIDs: []string{"b", "c"}},
+		{name: "upgrade by one release", previousVersion: "1.1.0", currentVersion: "1.2.0", wantIDs: []string{"c"}},
+		{name: "same version", previousVersion: "1.2.0", currentVersion: "1.2.0", wantIDs: nil},
+		{name: "downgrade", previousVersion: "1.2.0", currentVersion: "1.0.0", wantIDs: nil},
+		{name: "no previous version", previousVersion: "", currentVersion: "1.1.0", wantIDs: []string{"a", "b"}},
+		{name: "dev build includes unreleased", previousVersion: "1.1.0", currentVersion: "dev", wantIDs: []string{"c", "d"}},
+		{name: "from a dev build", previousVersion: "dev", currentVersion: "1.2.0", wantIDs: []string{"a", "b", "c"}},
+		{name: "same dev build", previousVersion: "dev", currentVersion: "dev", wantIDs: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := changeIDs(Between(changes, tt.previousVersion, tt.currentVersion)); !slices.Equal(got, tt.wantIDs) {
+				t.Errorf("Between(%q, %q) = %v, want %v", tt.previousVersion, tt.currentVersion, got, tt.wantIDs)
+			}
+		})
+	}
+}
+
+// changeIDs returns the ids of changes
+func changeIDs(changes []Change) (ids []string) {
+	for _, change := range changes {
+		ids = append(ids, change.ID)
+	}
+	return ids
+}
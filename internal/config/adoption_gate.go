@@ -0,0 +1,98 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/sol-strategies/solana-validator-version-sync/internal/secrets"
+)
+
+const (
+	// AdoptionSourceRPC computes adoption from gossip versions weighted by vote account stake
+	AdoptionSourceRPC = "rpc"
+	// AdoptionSourceProvider fetches adoption from a trusted stake-weighted version distribution provider
+	AdoptionSourceProvider = "provider"
+)
+
+// AdoptionSources are the valid adoption gate sources
+var AdoptionSources = []string{AdoptionSourceRPC, AdoptionSourceProvider}
+
+// AdoptionGate represents the configuration for holding activation until enough of the cluster's stake runs the
+// target version or newer. Upgrades to leave a running version below the SFDP minimum are never held back.
+type AdoptionGate struct {
+	// Enabled holds activation until MinStakePercent of stake runs the target version or newer
+	Enabled bool `koanf:"enabled"`
+	// MinStakePercent is the percentage of stake that must run the target version or newer
+	MinStakePercent float64 `koanf:"min_stake_percent"`
+	// Source is where the stake-weighted version distribution comes from - one of rpc, provider
+	Source string `koanf:"source"`
+	// RPCURL is the RPC URL the rpc source queries getClusterNodes and getVoteAccounts from - validator.rpc_url when empty
+	RPCURL string `koanf:"rpc_url"`
+	// Provider is the trusted distribution provider queried by the provider source
+	Provider AdoptionProvider `koanf:"provider"`
+}
+
+// AdoptionProvider represents a trusted stake-weighted version distribution provider
+type AdoptionProvider struct {
+	// URL is the endpoint the distribution is fetched from
+	URL string `koanf:"url"`
+	// Headers are extra request headers, e.g. Authorization - values are redacted in logs
+	Headers map[string]string `koanf:"headers"`
+	// Timeout is the timeout for each request, defaults to 10s
+	Timeout time.Duration `koanf:"timeout"`
+	// CacheTTL is how long a fetched distribution is reused for, defaults to 5m
+	CacheTTL time.Duration `koanf:"cache_ttl"`
+}
+
+// Validate validates the adoption gate configuration
+func (a *AdoptionGate) Validate() error {
+	if !a.Enabled {
+		return nil
+	}
+
+	if a.MinStakePercent <= 0 || a.MinStakePercent > 100 {
+		return fmt.Errorf("sync.adoption_gate.min_stake_percent must be greater than 0 and at most 100 - got: %v", a.MinStakePercent)
+	}
+
+	switch a.Source {
+	case AdoptionSourceRPC:
+		if a.RPCURL != "" && !validHTTPURL(a.RPCURL) {
+			return fmt.Errorf("sync.adoption_gate.rpc_url must be a valid http(s) URL - got: %s", a.RPCURL)
+		}
+	case AdoptionSourceProvider:
+		if !validHTTPURL(a.Provider.URL) {
+			return fmt.Errorf("sync.adoption_gate.provider.url must be a valid http(s) URL - got: %s", a.Provider.URL)
+		}
+		if a.Provider.Timeout <= 0 {
+			return fmt.Errorf("sync.adoption_gate.provider.timeout must be greater than 0 - got: %s", a.Provider.Timeout)
+		}
+		if a.Provider.CacheTTL < 0 {
+			return fmt.Errorf("sync.adoption_gate.provider.cache_ttl must be 0 or greater - got: %s", a.Provider.CacheTTL)
+		}
+	default:
+		return fmt.Errorf("sync.adoption_gate.source must be one of %v - got: %s", AdoptionSources, a.Source)
+	}
+
+	return nil
+}
+
+// Redacted returns a copy of the adoption gate configuration with provider header values redacted so it is safe to log
+func (a AdoptionGate) Redacted() AdoptionGate {
+	if len(a.Provider.Headers) == 0 {
+		return a
+	}
+
+	headers := make(map[string]string, len(a.Provider.Headers))
+	for name := range a.Provider.Headers {
+		headers[name] = secrets.Redacted
+	}
+	a.Provider.Headers = headers
+	return a
+}
+
+// validHTTPURL returns true when rawURL is an absolute http(s) URL
+func validHTTPURL(rawURL string) bool {
+	parsedURL, err := url.Parse(rawURL)
+	return err == nil && (parsedURL.Scheme == "http" || parsedURL.Scheme == "https") && parsedURL.Host != ""
+}
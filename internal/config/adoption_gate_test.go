@@ -0,0 +1,87 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sol-strategies/solana-validator-version-sync/internal/secrets"
+)
+
+func TestAdoptionGate_Validate(t *testing.T) {
+	provider := AdoptionProvider{URL: "https://adoption.example.com/versions", Timeout: 10 * time.Second, CacheTTL: 5 * time.Minute}
+
+	tests := []struct {
+		name         string
+		adoptionGate AdoptionGate
+		wantErr      bool
+	}{
+		{
+			name:         "disabled",
+			adoptionGate: AdoptionGate{Source: "nope"},
+		},
+		{
+			name:         "rpc source",
+			adoptionGate: AdoptionGate{Enabled: true, MinStakePercent: 33, Source: AdoptionSourceRPC},
+		},
+		{
+			name:         "rpc source with rpc url",
+			adoptionGate: AdoptionGate{Enabled: true, MinStakePercent: 33, Source: AdoptionSourceRPC, RPCURL: "https://api.mainnet-beta.solana.com"},
+		},
+		{
+			name:         "rpc source with invalid rpc url",
+			adoptionGate: AdoptionGate{Enabled: true, MinStakePercent: 33, Source: AdoptionSourceRPC, RPCURL: "not a url"},
+			wantErr:      true,
+		},
+		{
+			name:         "provider source",
+			adoptionGate: AdoptionGate{Enabled: true, MinStakePercent: 33, Source: AdoptionSourceProvider, Provider: provider},
+		},
+		{
+			name:         "provider source without url",
+			adoptionGate: AdoptionGate{Enabled: true, MinStakePercent: 33, Source: AdoptionSourceProvider, Provider: AdoptionProvider{Timeout: time.Second}},
+			wantErr:      true,
+		},
+		{
+			name: "provider source without timeout",
+			adoptionGate: AdoptionGate{Enabled: true, MinStakePercent: 33, Source: AdoptionSourceProvider,
+				Provider: AdoptionProvider{URL: provider.URL}},
+			wantErr: true,
+		},
+		{
+			name:         "unknown source",
+			adoptionGate: AdoptionGate{Enabled: true, MinStakePercent: 33, Source: "validators.app"},
+			wantErr:      true,
+		},
+		{
+			name:         "zero min stake percent",
+			adoptionGate: AdoptionGate{Enabled: true, Source: AdoptionSourceRPC},
+			wantErr:      true,
+		},
+		{
+			name:         "min stake percent over 100",
+			adoptionGate: AdoptionGate{Enabled: true, MinStakePercent: 101, Source: AdoptionSourceRPC},
+			wantErr:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.adoptionGate.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("AdoptionGate.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAdoptionGate_Redacted(t *testing.T) {
+	adoptionGate := AdoptionGate{Provider: AdoptionProvider{Headers: map[string]string{"Authorization": "Bearer secret"}}}
+
+	redacted := adoptionGate.Redacted()
+	if got := redacted.Provider.Headers["Authorization"]; got != secrets.Redacted {
+		t.Errorf("redacted Authorization header = %q, want %q", got, secrets.Redacted)
+	}
+	if got := adoptionGate.Provider.Headers["Authorization"]; got != "Bearer secret" {
+		t.Errorf("original Authorization header = %q, want it unchanged", got)
+	}
+}
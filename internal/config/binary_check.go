@@ -0,0 +1,69 @@
+package config
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/sol-strategies/solana-validator-version-sync/internal/sync_commands"
+)
+
+// BinaryCheck represents the configuration for checking the installed target binary before restarting - it runs
+// the binary with args after the activate commands before BeforeCommand, aborting the sync and running the
+// rollback commands when the version it reports isn't the sync target, e.g. after a partial or wrong-arch install
+type BinaryCheck struct {
+	// Enabled enables the binary check
+	Enabled bool `koanf:"enabled"`
+	// Binary is the path of the installed target binary, templated like commands,
+	// e.g. /home/solana/releases/{{ .VersionToTag }}/bin/agave-validator
+	Binary string `koanf:"binary"`
+	// Args are the arguments the binary prints its version with, templated like commands, defaults to --version
+	Args []string `koanf:"args"`
+	// BeforeCommand is the name of the activate command the check runs before, e.g. restart
+	BeforeCommand string `koanf:"before_command"`
+	// Timeout is the timeout for running the binary, defaults to 30s
+	Timeout time.Duration `koanf:"timeout"`
+}
+
+// Validate validates the binary check configuration
+func (b *BinaryCheck) Validate() error {
+	if !b.Enabled {
+		return nil
+	}
+
+	if b.Binary == "" {
+		return fmt.Errorf("sync.binary_check.binary must not be empty")
+	}
+
+	if b.BeforeCommand == "" {
+		return fmt.Errorf("sync.binary_check.before_command must not be empty")
+	}
+
+	if b.Timeout <= 0 {
+		return fmt.Errorf("sync.binary_check.timeout must be greater than 0 - got: %s", b.Timeout)
+	}
+
+	command := b.Command()
+	if err := command.Parse(); err != nil {
+		return fmt.Errorf("sync.binary_check: %w", err)
+	}
+	if err := command.DryRender(); err != nil {
+		return fmt.Errorf("sync.binary_check: %w", err)
+	}
+
+	return nil
+}
+
+// Command returns the command running the binary with its args, unparsed - it inherits the environment filtered
+// by sync.environment_policy like other commands with inherit_environment enabled
+func (b *BinaryCheck) Command() sync_commands.Command {
+	args := b.Args
+	if len(args) == 0 {
+		args = []string{"--version"}
+	}
+	return sync_commands.Command{
+		Name:               "binary_check",
+		Cmd:                b.Binary,
+		Args:               args,
+		InheritEnvironment: true,
+	}
+}
@@ -0,0 +1,84 @@
+package config
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestBinaryCheck_Validate(t *testing.T) {
+	tests := []struct {
+		name        string
+		binaryCheck BinaryCheck
+		wantErr     bool
+	}{
+		{
+			name:        "disabled",
+			binaryCheck: BinaryCheck{},
+			wantErr:     false,
+		},
+		{
+			name:        "valid",
+			binaryCheck: BinaryCheck{Enabled: true, Binary: "/home/solana/releases/{{ .VersionToTag }}/bin/agave-validator", BeforeCommand: "restart", Timeout: 30 * time.Second},
+			wantErr:     false,
+		},
+		{
+			name:        "empty binary",
+			binaryCheck: BinaryCheck{Enabled: true, BeforeCommand: "restart", Timeout: 30 * time.Second},
+			wantErr:     true,
+		},
+		{
+			name:        "empty before command",
+			binaryCheck: BinaryCheck{Enabled: true, Binary: "/usr/local/bin/agave-validator", Timeout: 30 * time.Second},
+			wantErr:     true,
+		},
+		{
+			name:        "zero timeout",
+			binaryCheck: BinaryCheck{Enabled: true, Binary: "/usr/local/bin/agave-validator", BeforeCommand: "restart"},
+			wantErr:     true,
+		},
+		{
+			name:        "invalid binary template",
+			binaryCheck: BinaryCheck{Enabled: true, Binary: "/home/solana/releases/{{ .VersionToTag", BeforeCommand: "restart", Timeout: 30 * time.Second},
+			wantErr:     true,
+		},
+		{
+			name:        "unknown template field in args",
+			binaryCheck: BinaryCheck{Enabled: true, Binary: "/usr/local/bin/agave-validator", Args: []string{"{{ .Nope }}"}, BeforeCommand: "restart", Timeout: 30 * time.Second},
+			wantErr:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.binaryCheck.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("BinaryCheck.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestBinaryCheck_Command(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		wantArgs []string
+	}{
+		{name: "default args", wantArgs: []string{"--version"}},
+		{name: "configured args", args: []string{"-V"}, wantArgs: []string{"-V"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			binaryCheck := BinaryCheck{Binary: "/usr/local/bin/agave-validator", Args: tt.args}
+			command := binaryCheck.Command()
+			if command.Cmd != binaryCheck.Binary {
+				t.Errorf("Command().Cmd = %q, want %q", command.Cmd, binaryCheck.Binary)
+			}
+			if !slices.Equal(command.Args, tt.wantArgs) {
+				t.Errorf("Command().Args = %v, want %v", command.Args, tt.wantArgs)
+			}
+		})
+	}
+}
@@ -1,6 +1,8 @@
 package config
 
 import (
+	"fmt"
+
 	"github.com/sol-strategies/solana-validator-version-sync/internal/constants"
 )
 
@@ -8,9 +10,21 @@ import (
 type Cluster struct {
 	// Name is the Solana cluster this validator is running on. One of mainnet-beta or testnet
 	Name string `koanf:"name"`
+	// HardForks are the cluster's hard fork slots, exposed to commands e.g. for --hard-fork arguments
+	HardForks []uint64 `koanf:"hard_forks"`
 }
 
 // Validate validates the cluster configuration
 func (c *Cluster) Validate() error {
-	return constants.ValidateClusterName(c.Name)
+	if err := constants.ValidateClusterName(c.Name); err != nil {
+		return err
+	}
+
+	for i, slot := range c.HardForks {
+		if slot == 0 {
+			return fmt.Errorf("cluster.hard_forks[%d] must be a slot greater than 0", i)
+		}
+	}
+
+	return nil
 }
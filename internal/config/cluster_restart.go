@@ -0,0 +1,34 @@
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+// ClusterRestart represents the configuration for coordinated cluster restarts - while the restart manifest is
+// published, syncs target its version and restart commands get its shred version and snapshot slot
+type ClusterRestart struct {
+	// Enabled enables fetching the restart manifest on every sync
+	Enabled bool `koanf:"enabled"`
+	// ManifestURL is the URL the restart manifest is published at - 404 or 204 when no restart is in progress
+	ManifestURL string `koanf:"manifest_url"`
+	// Timeout is the timeout for fetching the manifest, defaults to 10s
+	Timeout time.Duration `koanf:"timeout"`
+}
+
+// Validate validates the cluster restart configuration
+func (c *ClusterRestart) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+
+	if !validHTTPURL(c.ManifestURL) {
+		return fmt.Errorf("sync.cluster_restart.manifest_url must be a valid http(s) URL - got: %s", c.ManifestURL)
+	}
+
+	if c.Timeout <= 0 {
+		return fmt.Errorf("sync.cluster_restart.timeout must be greater than 0 - got: %s", c.Timeout)
+	}
+
+	return nil
+}
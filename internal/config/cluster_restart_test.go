@@ -0,0 +1,49 @@
+package config
+
+import (
+	"testing"
+	"time"
+)
+
+func TestClusterRestart_Validate(t *testing.T) {
+	tests := []struct {
+		name           string
+		clusterRestart ClusterRestart
+		wantErr        bool
+	}{
+		{
+			name:           "disabled",
+			clusterRestart: ClusterRestart{},
+			wantErr:        false,
+		},
+		{
+			name:           "valid",
+			clusterRestart: ClusterRestart{Enabled: true, ManifestURL: "https://example.com/restart.json", Timeout: 10 * time.Second},
+			wantErr:        false,
+		},
+		{
+			name:           "missing manifest url",
+			clusterRestart: ClusterRestart{Enabled: true, Timeout: 10 * time.Second},
+			wantErr:        true,
+		},
+		{
+			name:           "invalid manifest url",
+			clusterRestart: ClusterRestart{Enabled: true, ManifestURL: "example.com/restart.json", Timeout: 10 * time.Second},
+			wantErr:        true,
+		},
+		{
+			name:           "zero timeout",
+			clusterRestart: ClusterRestart{Enabled: true, ManifestURL: "https://example.com/restart.json"},
+			wantErr:        true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.clusterRestart.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ClusterRestart.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -26,6 +26,22 @@ func TestCluster_Validate(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "valid hard forks",
+			cluster: Cluster{
+				Name:      constants.ClusterNameMainnetBeta,
+				HardForks: []uint64{332000000},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid hard fork slot",
+			cluster: Cluster{
+				Name:      constants.ClusterNameMainnetBeta,
+				HardForks: []uint64{332000000, 0},
+			},
+			wantErr: true,
+		},
 		{
 			name: "invalid cluster name - empty string",
 			cluster: Cluster{
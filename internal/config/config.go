@@ -1,12 +1,19 @@
 package config
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"os"
+	"time"
 
 	"github.com/charmbracelet/log"
 	"github.com/knadh/koanf"
 	"github.com/knadh/koanf/parsers/yaml"
-	"github.com/knadh/koanf/providers/file"
+	"github.com/knadh/koanf/providers/rawbytes"
+	"github.com/mitchellh/mapstructure"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/logging"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/recipes"
 )
 
 // Config represents the complete configuration
@@ -19,8 +26,18 @@ type Config struct {
 	Cluster Cluster `koanf:"cluster"`
 	// Sync is the version sync configuration
 	Sync Sync `koanf:"sync"`
+	// State is the sync state persistence configuration
+	State State `koanf:"state"`
+	// Report is the sync decision reporting configuration
+	Report Report `koanf:"report"`
+	// Notify is the notification configuration
+	Notify Notify `koanf:"notify"`
 	// File is the file that the config was loaded from
 	File string `koanf:"-"`
+	// Hash is the hex encoded sha256 of the config file content, to tell which config a running daemon loaded
+	Hash string `koanf:"-"`
+	// LoadedAt is when the config file was loaded - config is only loaded at startup
+	LoadedAt time.Time `koanf:"-"`
 
 	logger *log.Logger
 }
@@ -28,7 +45,7 @@ type Config struct {
 // New creates a new Config
 func New() (config *Config, err error) {
 	config = &Config{
-		logger: log.WithPrefix("config"),
+		logger: logging.WithPrefix("config"),
 	}
 	return config, nil
 }
@@ -62,19 +79,58 @@ func (c *Config) LoadFromFile(filePath string) error {
 	// Set defaults in koanf first
 	c.setKoanfDefaults(k)
 
+	content, err := os.ReadFile(c.File)
+	if err != nil {
+		return fmt.Errorf("error loading config file: %w", err)
+	}
+	contentHash := sha256.Sum256(content)
+	c.Hash = hex.EncodeToString(contentHash[:])
+	c.LoadedAt = time.Now().UTC()
+
+	// Validate the file as written against the schema before defaults are merged
+	raw, err := yaml.Parser().Unmarshal(content)
+	if err != nil {
+		return fmt.Errorf("error loading config file: %w", err)
+	}
+	if err := checkLegacyKeys(raw); err != nil {
+		return fmt.Errorf("invalid config file %s: %w", c.File, err)
+	}
+	if err := ValidateSchema(raw); err != nil {
+		return fmt.Errorf("invalid config file %s: %w", c.File, err)
+	}
+
 	// Load YAML config file (this will merge with defaults)
-	if err := k.Load(file.Provider(c.File), yaml.Parser()); err != nil {
+	if err := k.Load(rawbytes.Provider(content), yaml.Parser()); err != nil {
 		return fmt.Errorf("error loading config file: %w", err)
 	}
 
+	// Expand the selected recipe (if any) into sync.commands
+	if err := expandRecipe(k); err != nil {
+		return err
+	}
+
 	// Unmarshal into this config struct
-	if err := k.Unmarshal("", c); err != nil {
+	if err := k.UnmarshalWithConf("", c, koanf.UnmarshalConf{
+		DecoderConfig: &mapstructure.DecoderConfig{
+			DecodeHook:       decodeHook(),
+			Result:           c,
+			WeaklyTypedInput: true,
+		},
+	}); err != nil {
 		return fmt.Errorf("error unmarshaling config: %w", err)
 	}
 
 	return nil
 }
 
+// Redacted returns a copy of the config with sensitive values redacted so it is safe to log
+func (c Config) Redacted() Config {
+	c.Report = c.Report.Redacted()
+	c.Sync.AdoptionGate = c.Sync.AdoptionGate.Redacted()
+	c.Notify = c.Notify.Redacted()
+	return c
+}
+
 // Initialize processes and validates the loaded configuration
 func (c *Config) Initialize() error {
 	// load identity key pair files
@@ -112,21 +168,96 @@ func (c *Config) validate() error {
 		return err
 	}
 
+	err = c.validateRecipe()
+	if err != nil {
+		return err
+	}
+
+	err = c.Report.Validate()
+	if err != nil {
+		return err
+	}
+
+	err = c.Notify.Validate()
+	if err != nil {
+		return err
+	}
+
+	for name := range c.Sync.OnOtherRole {
+		if _, ok := c.Validator.Identities.OtherKeyPairFiles[name]; !ok {
+			return fmt.Errorf("sync.on_other_role.%s is not an identity in validator.identities.others", name)
+		}
+	}
+
+	if c.Sync.HeadsUp.Enabled && c.Notify.Webhook.URL == "" {
+		return fmt.Errorf("sync.heads_up requires notify.webhook.url to be set")
+	}
+
 	return nil
 }
 
+// defaults are the default values set before loading the config file, keyed by koanf path
+var defaults = map[string]interface{}{
+	// log defaults
+	"log.level":  "info",
+	"log.format": "text",
+
+	// validator defaults
+	"validator.rpc_url": "http://127.0.0.1:8899",
+
+	// sync defaults - major defaults to false already
+	"sync.allowed_semver_changes.minor":           true,
+	"sync.allowed_semver_changes.patch":           true,
+	"sync.enable_sfdp_compliance":                 false,
+	"sync.sfdp_required":                          true,
+	"sync.on_unknown_role":                        OnUnknownRoleError,
+	"sync.prefer_mainnet_version":                 true,
+	"sync.slot_trigger.poll_interval":             "2s",
+	"sync.release_feed.max_age":                   "1h",
+	"sync.flap_detection.history_size":            10,
+	"sync.flap_detection.max_role_changes":        2,
+	"sync.flap_detection.max_health_changes":      4,
+	"sync.stake_activation.max_pending_stake_sol": 10000,
+	"sync.adoption_gate.min_stake_percent":        33,
+	"sync.adoption_gate.source":                   AdoptionSourceRPC,
+	"sync.adoption_gate.provider.timeout":         "10s",
+	"sync.adoption_gate.provider.cache_ttl":       "5m",
+	"sync.heads_up.release_notes_max_length":      500,
+	"sync.nomad.address":                          "http://127.0.0.1:4646",
+	"sync.nomad.namespace":                        "default",
+	"sync.nomad.meta_key":                         "validator_version",
+	"sync.nomad.timeout":                          "30s",
+	"sync.cluster_restart.timeout":                "10s",
+	"sync.config_revision.git_ref":                "HEAD",
+	"sync.binary_check.timeout":                   "30s",
+	"sync.recipe_options.install_dir":             recipes.DefaultInstallDir,
+	"sync.recipe_options.active_release_link":     recipes.DefaultActiveReleaseLink,
+	"sync.recipe_options.validator_service":       recipes.DefaultValidatorService,
+
+	// report defaults
+	"report.http.timeout":     "10s",
+	"report.consul.kv_prefix": "solana-validator-version-sync",
+	"report.consul.timeout":   "10s",
+
+	// notify defaults
+	"notify.webhook.timeout":                  "10s",
+	"notify.timezone":                         "UTC",
+	"notify.drift_detected.realert_interval":  "12h",
+	"notify.activation_held.realert_interval": "12h",
+}
+
+// Defaults returns a copy of the default config values, keyed by koanf path
+func Defaults() map[string]interface{} {
+	copied := make(map[string]interface{}, len(defaults))
+	for path, value := range defaults {
+		copied[path] = value
+	}
+	return copied
+}
+
 // setKoanfDefaults sets default values in koanf configuration
 func (c *Config) setKoanfDefaults(k *koanf.Koanf) {
-	// Set log defaults
-	k.Set("log.level", "info")
-	k.Set("log.format", "text")
-
-	// Set validator defaults
-	k.Set("validator.rpc_url", "http://127.0.0.1:8899")
-
-	// Set sync defaults
-	// major defaults to false already
-	k.Set("sync.allowed_semver_changes.minor", true)
-	k.Set("sync.allowed_semver_changes.patch", true)
-	k.Set("sync.enable_sfdp_compliance", false)
+	for path, value := range defaults {
+		k.Set(path, value)
+	}
 }
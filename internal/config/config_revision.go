@@ -0,0 +1,34 @@
+package config
+
+import "fmt"
+
+// ConfigRevision represents the configuration for syncing configuration-only changes, e.g. new validator flags
+// required by SFDP guidance - the target is a configuration revision instead of a client version, applied through
+// the same gates and commands as version syncs whenever the validator already runs the target version
+type ConfigRevision struct {
+	// Enabled enables configuration revision syncs
+	Enabled bool `koanf:"enabled"`
+	// File is a config-version file whose trimmed content is the target configuration revision
+	File string `koanf:"file"`
+	// GitRepo is a local checkout of an ops repo whose GitRef commit SHA is the target configuration revision
+	GitRepo string `koanf:"git_repo"`
+	// GitRef is the ref of GitRepo resolved to the target commit SHA, defaults to HEAD
+	GitRef string `koanf:"git_ref"`
+}
+
+// Validate validates the configuration revision configuration
+func (c *ConfigRevision) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+
+	if (c.File == "") == (c.GitRepo == "") {
+		return fmt.Errorf("sync.config_revision requires exactly one of file or git_repo - got: file=%q git_repo=%q", c.File, c.GitRepo)
+	}
+
+	if c.GitRepo != "" && c.GitRef == "" {
+		return fmt.Errorf("sync.config_revision.git_ref must not be empty")
+	}
+
+	return nil
+}
@@ -0,0 +1,51 @@
+package config
+
+import "testing"
+
+func TestConfigRevision_Validate(t *testing.T) {
+	tests := []struct {
+		name           string
+		configRevision ConfigRevision
+		wantErr        bool
+	}{
+		{
+			name:           "disabled",
+			configRevision: ConfigRevision{},
+			wantErr:        false,
+		},
+		{
+			name:           "config-version file",
+			configRevision: ConfigRevision{Enabled: true, File: "/etc/solana/config-version"},
+			wantErr:        false,
+		},
+		{
+			name:           "git repo",
+			configRevision: ConfigRevision{Enabled: true, GitRepo: "/opt/ops", GitRef: "HEAD"},
+			wantErr:        false,
+		},
+		{
+			name:           "no source",
+			configRevision: ConfigRevision{Enabled: true},
+			wantErr:        true,
+		},
+		{
+			name:           "both sources",
+			configRevision: ConfigRevision{Enabled: true, File: "/etc/solana/config-version", GitRepo: "/opt/ops", GitRef: "HEAD"},
+			wantErr:        true,
+		},
+		{
+			name:           "empty git ref",
+			configRevision: ConfigRevision{Enabled: true, GitRepo: "/opt/ops"},
+			wantErr:        true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.configRevision.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ConfigRevision.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
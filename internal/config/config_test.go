@@ -50,6 +50,7 @@ func TestConfig_LoadFromFile(t *testing.T) {
   format: json
 validator:
   client: agave
+  version_constraint: ">= 2.0.0"
   rpc_url: http://localhost:8899
   identities:
     active: ` + activeKeyFile + `
@@ -104,10 +105,13 @@ sync:
 				t.Errorf("Config.LoadFromFile() error = %v, wantErr %v", err, tt.wantErr)
 			}
 
-			// If loading was successful, verify the file path was set
+			// If loading was successful, verify the file path, hash and load time were set
 			if !tt.wantErr && tt.config.File != tt.filePath {
 				t.Errorf("Config.LoadFromFile() File = %v, want %v", tt.config.File, tt.filePath)
 			}
+			if !tt.wantErr && (len(tt.config.Hash) != 64 || tt.config.LoadedAt.IsZero()) {
+				t.Errorf("Config.LoadFromFile() Hash = %q, LoadedAt = %v, want a sha256 hash and load time", tt.config.Hash, tt.config.LoadedAt)
+			}
 		})
 	}
 }
@@ -254,6 +258,54 @@ func TestConfig_Initialize(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "heads-up without notify webhook",
+			config: &Config{
+				Log: Log{
+					Level:  "info",
+					Format: "text",
+				},
+				Validator: Validator{
+					Client: constants.ClientNameAgave,
+					RPCURL: "http://localhost:8899",
+					Identities: Identities{
+						ActiveKeyPairFile:  activeKeyFile,
+						PassiveKeyPairFile: passiveKeyFile,
+					},
+				},
+				Cluster: Cluster{
+					Name: constants.ClusterNameMainnetBeta,
+				},
+				Sync: Sync{
+					HeadsUp: HeadsUp{Enabled: true},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "on other role for an identity not in others",
+			config: &Config{
+				Log: Log{
+					Level:  "info",
+					Format: "text",
+				},
+				Validator: Validator{
+					Client: constants.ClientNameAgave,
+					RPCURL: "http://localhost:8899",
+					Identities: Identities{
+						ActiveKeyPairFile:  activeKeyFile,
+						PassiveKeyPairFile: passiveKeyFile,
+					},
+				},
+				Cluster: Cluster{
+					Name: constants.ClusterNameMainnetBeta,
+				},
+				Sync: Sync{
+					OnOtherRole: map[string]string{"maintenance": OnUnknownRoleSkip},
+				},
+			},
+			wantErr: true,
+		},
 		{
 			name: "missing keypair files",
 			config: &Config{
@@ -319,6 +371,7 @@ func TestConfig_NewFromConfigFile(t *testing.T) {
   format: text
 validator:
   client: agave
+  version_constraint: ">= 2.0.0"
   rpc_url: http://localhost:8899
   identities:
     active: ` + activeKeyFile + `
@@ -421,6 +474,7 @@ func createInvalidYamlFile(t *testing.T, tempDir string) string {
   format: json
 validator:
   client: agave
+  version_constraint: ">= 2.0.0"
   rpc_url: http://localhost:8899
   identities:
     active: /path/to/active.json
@@ -0,0 +1,70 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/sync_commands"
+)
+
+// decodeHook returns the hook used when unmarshaling the config - koanf's default hooks plus our own
+func decodeHook() mapstructure.DecodeHookFunc {
+	return mapstructure.ComposeDecodeHookFunc(
+		mapstructure.StringToTimeDurationHookFunc(),
+		mapstructure.StringToSliceHookFunc(","),
+		mapstructure.TextUnmarshallerHookFunc(),
+		environmentSecretsHookFunc(),
+	)
+}
+
+// environmentSecretsHookFunc moves secret references declared inline in a command's environment
+// (e.g. API_KEY: {from_env: MY_KEY}) into the command's secrets so they are resolved at execution time,
+// failing when the same name is also declared under secrets
+func environmentSecretsHookFunc() mapstructure.DecodeHookFuncType {
+	return func(from reflect.Type, to reflect.Type, data interface{}) (interface{}, error) {
+		if to != reflect.TypeOf(sync_commands.Command{}) {
+			return data, nil
+		}
+
+		commandMap, ok := data.(map[string]interface{})
+		if !ok {
+			return data, nil
+		}
+
+		environment, ok := commandMap["environment"].(map[string]interface{})
+		if !ok {
+			return data, nil
+		}
+
+		declaredSecrets, _ := commandMap["secrets"].(map[string]interface{})
+		secrets := make(map[string]interface{}, len(declaredSecrets))
+		for secretName, secretRef := range declaredSecrets {
+			secrets[secretName] = secretRef
+		}
+
+		plainEnvironment := make(map[string]interface{}, len(environment))
+		for envName, envValue := range environment {
+			if secretRef, isSecretRef := envValue.(map[string]interface{}); isSecretRef {
+				if _, exists := secrets[envName]; exists {
+					return nil, fmt.Errorf("command %v: environment %s is a secret reference also declared under secrets - declare it once", commandMap["name"], envName)
+				}
+				secrets[envName] = secretRef
+				continue
+			}
+			plainEnvironment[envName] = envValue
+		}
+
+		// copy so the loaded koanf config is left untouched
+		decoded := make(map[string]interface{}, len(commandMap))
+		for k, v := range commandMap {
+			decoded[k] = v
+		}
+		decoded["environment"] = plainEnvironment
+		if len(secrets) > 0 {
+			decoded["secrets"] = secrets
+		}
+
+		return decoded, nil
+	}
+}
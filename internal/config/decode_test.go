@@ -0,0 +1,89 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestEnvironmentSecretsHookFunc(t *testing.T) {
+	configFile := filepath.Join(t.TempDir(), "config.yaml")
+	configContent := minimalConfigContent + `sync:
+  commands:
+    - name: install
+      cmd: /usr/local/bin/install.sh
+      environment:
+        VERSION: "{{ .VersionTo }}"
+        API_KEY:
+          from_env: MY_KEY
+        DB_PASSWORD:
+          from_vault: secret/data/validator#db_password
+      secrets:
+        TOKEN:
+          from_file: /run/secrets/token
+`
+	if err := os.WriteFile(configFile, []byte(configContent), 0644); err != nil {
+		t.Fatalf("Failed to create config file: %v", err)
+	}
+
+	cfg, err := New()
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if err := cfg.LoadFromFile(configFile); err != nil {
+		t.Fatalf("LoadFromFile() error = %v", err)
+	}
+
+	command := cfg.Sync.Commands[0]
+	if len(command.Environment) != 1 || command.Environment["VERSION"] != "{{ .VersionTo }}" {
+		t.Errorf("Environment = %v, want only the plain VERSION value", command.Environment)
+	}
+	if command.Secrets["API_KEY"].FromEnv != "MY_KEY" {
+		t.Errorf("Secrets[API_KEY] = %+v, want from_env MY_KEY", command.Secrets["API_KEY"])
+	}
+	if command.Secrets["DB_PASSWORD"].FromVault != "secret/data/validator#db_password" {
+		t.Errorf("Secrets[DB_PASSWORD] = %+v, want from_vault reference", command.Secrets["DB_PASSWORD"])
+	}
+	if command.Secrets["TOKEN"].FromFile != "/run/secrets/token" {
+		t.Errorf("Secrets[TOKEN] = %+v, want from_file reference", command.Secrets["TOKEN"])
+	}
+}
+
+func TestEnvironmentSecretsHookFunc_Collision(t *testing.T) {
+	configFile := filepath.Join(t.TempDir(), "config.yaml")
+	configContent := minimalConfigContent + `sync:
+  commands:
+    - name: install
+      cmd: /usr/local/bin/install.sh
+      environment:
+        API_KEY:
+          from_env: MY_KEY
+      secrets:
+        API_KEY:
+          from_file: /run/secrets/api_key
+`
+	if err := os.WriteFile(configFile, []byte(configContent), 0644); err != nil {
+		t.Fatalf("Failed to create config file: %v", err)
+	}
+
+	cfg, err := New()
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	err = cfg.LoadFromFile(configFile)
+	if err == nil || !strings.Contains(err.Error(), "API_KEY") {
+		t.Errorf("LoadFromFile() error = %v, want collision error naming API_KEY", err)
+	}
+}
+
+// minimalConfigContent is the config content required by the schema, to be extended by each test
+const minimalConfigContent = `validator:
+  client: agave
+  version_constraint: ">= 2.0.0"
+  identities:
+    active: /home/solana/active-identity.json
+    passive: /home/solana/passive-identity.json
+cluster:
+  name: testnet
+`
@@ -0,0 +1,37 @@
+package config
+
+import "fmt"
+
+// FlapDetection represents the configuration for detecting a flapping validator - health oscillating or role
+// flipping repeatedly across recent syncs - and suppressing syncs while it flaps
+type FlapDetection struct {
+	// Enabled suppresses syncs while the validator is flapping
+	Enabled bool `koanf:"enabled"`
+	// HistorySize is how many recent health and role observations are kept and checked for flapping
+	HistorySize int `koanf:"history_size"`
+	// MaxRoleChanges is the most role changes tolerated within the history before the validator is flapping
+	MaxRoleChanges int `koanf:"max_role_changes"`
+	// MaxHealthChanges is the most health changes tolerated within the history before the validator is flapping
+	MaxHealthChanges int `koanf:"max_health_changes"`
+}
+
+// Validate validates the flap detection configuration
+func (f *FlapDetection) Validate() error {
+	if !f.Enabled {
+		return nil
+	}
+
+	if f.HistorySize < 2 {
+		return fmt.Errorf("sync.flap_detection.history_size must be 2 or greater - got: %d", f.HistorySize)
+	}
+
+	if f.MaxRoleChanges < 1 {
+		return fmt.Errorf("sync.flap_detection.max_role_changes must be 1 or greater - got: %d", f.MaxRoleChanges)
+	}
+
+	if f.MaxHealthChanges < 1 {
+		return fmt.Errorf("sync.flap_detection.max_health_changes must be 1 or greater - got: %d", f.MaxHealthChanges)
+	}
+
+	return nil
+}
@@ -0,0 +1,51 @@
+package config
+
+import "testing"
+
+func TestFlapDetection_Validate(t *testing.T) {
+	tests := []struct {
+		name          string
+		flapDetection FlapDetection
+		wantErr       bool
+	}{
+		{
+			name:          "disabled with defaults",
+			flapDetection: FlapDetection{HistorySize: 10, MaxRoleChanges: 2, MaxHealthChanges: 4},
+			wantErr:       false,
+		},
+		{
+			name:          "disabled ignores limits",
+			flapDetection: FlapDetection{},
+			wantErr:       false,
+		},
+		{
+			name:          "enabled",
+			flapDetection: FlapDetection{Enabled: true, HistorySize: 10, MaxRoleChanges: 2, MaxHealthChanges: 4},
+			wantErr:       false,
+		},
+		{
+			name:          "enabled with history too small",
+			flapDetection: FlapDetection{Enabled: true, HistorySize: 1, MaxRoleChanges: 2, MaxHealthChanges: 4},
+			wantErr:       true,
+		},
+		{
+			name:          "enabled without max role changes",
+			flapDetection: FlapDetection{Enabled: true, HistorySize: 10, MaxHealthChanges: 4},
+			wantErr:       true,
+		},
+		{
+			name:          "enabled without max health changes",
+			flapDetection: FlapDetection{Enabled: true, HistorySize: 10, MaxRoleChanges: 2},
+			wantErr:       true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.flapDetection.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("FlapDetection.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
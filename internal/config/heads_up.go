@@ -0,0 +1,25 @@
+package config
+
+import "fmt"
+
+// HeadsUp represents the configuration for notifying once when a new sync target is first detected, ahead of
+// any gate holding activation, so the actual upgrade later doesn't come as a surprise
+type HeadsUp struct {
+	// Enabled sends a heads-up notification to notify.webhook once per target version
+	Enabled bool `koanf:"enabled"`
+	// ReleaseNotesMaxLength is the longest release notes summary included in the heads-up, 0 includes all notes
+	ReleaseNotesMaxLength int `koanf:"release_notes_max_length"`
+}
+
+// Validate validates the heads-up configuration
+func (h *HeadsUp) Validate() error {
+	if !h.Enabled {
+		return nil
+	}
+
+	if h.ReleaseNotesMaxLength < 0 {
+		return fmt.Errorf("sync.heads_up.release_notes_max_length must be 0 (no limit) or greater - got: %d", h.ReleaseNotesMaxLength)
+	}
+
+	return nil
+}
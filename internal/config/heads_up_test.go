@@ -0,0 +1,41 @@
+package config
+
+import "testing"
+
+func TestHeadsUp_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		headsUp HeadsUp
+		wantErr bool
+	}{
+		{
+			name:    "disabled",
+			headsUp: HeadsUp{ReleaseNotesMaxLength: -1},
+			wantErr: false,
+		},
+		{
+			name:    "valid",
+			headsUp: HeadsUp{Enabled: true, ReleaseNotesMaxLength: 500},
+			wantErr: false,
+		},
+		{
+			name:    "no release notes limit",
+			headsUp: HeadsUp{Enabled: true},
+			wantErr: false,
+		},
+		{
+			name:    "negative release notes limit",
+			headsUp: HeadsUp{Enabled: true, ReleaseNotesMaxLength: -1},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.headsUp.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("HeadsUp.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// legacyKey is a config key from the legacy internal/sync design that the sync engine never reads
+type legacyKey struct {
+	// path is the key's path, with [] matching every list entry, e.g. sync.commands[].dry_run
+	path string
+	// migration is how to express the key in the current config, once removed
+	migration string
+}
+
+// legacyKeys are the documented legacy config keys, rejected with their migration rather than ignored
+var legacyKeys = []legacyKey{
+	{
+		path:      "sync.client_source_repositories",
+		migration: "release repositories are built in for each validator.client",
+	},
+	{
+		path:      "sync.interval",
+		migration: "pass the interval on the command line instead, e.g. run --on-interval 1m or service install --on-interval 1m",
+	},
+	{
+		path:      "sync.commands[].dry_run",
+		migration: "use disabled: true to skip the command, or --read-only to never execute commands",
+	},
+	{
+		path:      "sync.commands[].must_succeed",
+		migration: "commands must succeed by default, use allow_failure: true in place of must_succeed: false",
+	},
+}
+
+// checkLegacyKeys returns an error mapping every legacy key set in raw (parsed but not yet defaulted or decoded)
+// config file content to its replacement, e.g. sync.commands[1].dry_run: legacy key, remove it - use disabled...
+func checkLegacyKeys(raw map[string]interface{}) error {
+	legacyErrs := []error{}
+	for _, key := range legacyKeys {
+		for _, keyPath := range findKeyPaths(raw, strings.Split(key.path, "."), "") {
+			legacyErrs = append(legacyErrs, fmt.Errorf("%s: legacy key, remove it - %s", keyPath, key.migration))
+		}
+	}
+	if len(legacyErrs) == 0 {
+		return nil
+	}
+	return fmt.Errorf("config uses legacy keys - migrate-config migrates them:\n%w", errors.Join(legacyErrs...))
+}
+
+// findKeyPaths returns the key paths of every value at the given path segments in raw config content,
+// a segment ending in [] matching every entry of its list
+func findKeyPaths(raw map[string]interface{}, segments []string, prefix string) (keyPaths []string) {
+	segment := segments[0]
+	key, isList := strings.CutSuffix(segment, "[]")
+
+	value, ok := raw[key]
+	if !ok {
+		return nil
+	}
+
+	keyPath := key
+	if prefix != "" {
+		keyPath = prefix + "." + key
+	}
+
+	if len(segments) == 1 {
+		return []string{keyPath}
+	}
+
+	if !isList {
+		nested, ok := value.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		return findKeyPaths(nested, segments[1:], keyPath)
+	}
+
+	entries, ok := value.([]interface{})
+	if !ok {
+		return nil
+	}
+	for i, entry := range entries {
+		nested, ok := entry.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		keyPaths = append(keyPaths, findKeyPaths(nested, segments[1:], fmt.Sprintf("%s[%d]", keyPath, i))...)
+	}
+	return keyPaths
+}
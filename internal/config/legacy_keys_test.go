@@ -0,0 +1,98 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/knadh/koanf/parsers/yaml"
+)
+
+func TestCheckLegacyKeys(t *testing.T) {
+	tests := []struct {
+		name       string
+		content    string
+		wantErrors []string
+	}{
+		{
+			name:    "no legacy keys",
+			content: minimalConfigContent + "sync:\n  commands:\n    - name: restart\n      cmd: systemctl\n      allow_failure: true\n",
+		},
+		{
+			name:    "legacy allowed_semver_changes is still accepted",
+			content: minimalConfigContent + "sync:\n  allowed_semver_changes:\n    major: false\n",
+		},
+		{
+			name: "legacy sync keys",
+			content: minimalConfigContent + `sync:
+  interval: 1m
+  client_source_repositories:
+    agave:
+      url: https://github.com/anza-xyz/agave
+`,
+			wantErrors: []string{
+				"sync.client_source_repositories: legacy key, remove it - release repositories are built in",
+				"sync.interval: legacy key, remove it - pass the interval on the command line instead",
+			},
+		},
+		{
+			name: "legacy command keys",
+			content: minimalConfigContent + `sync:
+  commands:
+    - name: build
+      cmd: make
+      dry_run: true
+    - name: restart
+      cmd: systemctl
+      must_succeed: false
+`,
+			wantErrors: []string{
+				"sync.commands[0].dry_run: legacy key, remove it - use disabled: true",
+				"sync.commands[1].must_succeed: legacy key, remove it - commands must succeed by default",
+			},
+		},
+		{
+			name:    "commands not a list",
+			content: minimalConfigContent + "sync:\n  commands: restart\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			raw, err := yaml.Parser().Unmarshal([]byte(tt.content))
+			if err != nil {
+				t.Fatalf("failed to parse config: %v", err)
+			}
+
+			err = checkLegacyKeys(raw)
+			if len(tt.wantErrors) == 0 {
+				if err != nil {
+					t.Errorf("checkLegacyKeys() error = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("checkLegacyKeys() error = nil, want %v", tt.wantErrors)
+			}
+			for _, wantError := range tt.wantErrors {
+				if !strings.Contains(err.Error(), wantError) {
+					t.Errorf("checkLegacyKeys() error = %v, want it to contain %q", err, wantError)
+				}
+			}
+		})
+	}
+}
+
+func TestLoadFromFile_LegacyKeys(t *testing.T) {
+	configFile := filepath.Join(t.TempDir(), "config.yaml")
+	content := minimalConfigContent + "sync:\n  interval: 1m\n"
+	if err := os.WriteFile(configFile, []byte(content), 0o600); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	err := (&Config{}).LoadFromFile(configFile)
+	if err == nil || !strings.Contains(err.Error(), "config uses legacy keys") {
+		t.Errorf("LoadFromFile() error = %v, want legacy keys error", err)
+	}
+}
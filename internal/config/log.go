@@ -2,10 +2,14 @@ package config
 
 import (
 	"fmt"
+	"slices"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/charmbracelet/log"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/logging"
 )
 
 var (
@@ -22,8 +26,12 @@ type Log struct {
 	Level string `koanf:"level"`
 	// Format is the log format - one of "text" or "json" or "logfmt", defaults to text
 	Format string `koanf:"format"`
+	// Levels are log levels overriding level for single components, e.g. github: debug - components are command, config, failinject, github, management, manager, notify, report, rpc, sfdp, state, sync and validator
+	Levels map[string]string `koanf:"levels"`
 	// ParsedLevel is the parsed log level
 	ParsedLevel log.Level `koanf:"-"`
+	// ParsedLevels are the parsed component log levels
+	ParsedLevels map[string]log.Level `koanf:"-"`
 	// ParsedFormat is the parsed log format
 	ParsedFormatter log.Formatter `koanf:"-"`
 }
@@ -43,6 +51,25 @@ func (l *Log) Validate() (err error) {
 		return fmt.Errorf("log.format must be one of text, json, logfmt - got: %s", l.Format)
 	}
 
+	// try to parse the component levels, in order so errors are deterministic
+	components := make([]string, 0, len(l.Levels))
+	for component := range l.Levels {
+		components = append(components, component)
+	}
+	sort.Strings(components)
+
+	l.ParsedLevels = make(map[string]log.Level, len(l.Levels))
+	for _, component := range components {
+		if !slices.Contains(logging.Components, component) {
+			return fmt.Errorf("log.levels.%s is not a known component - must be one of %s", component, strings.Join(logging.Components, ", "))
+		}
+		level, err := log.ParseLevel(l.Levels[component])
+		if err != nil {
+			return fmt.Errorf("log.levels.%s must be one of debug, info, warn, error, fatal - got: %s", component, l.Levels[component])
+		}
+		l.ParsedLevels[component] = level
+	}
+
 	return nil
 }
 
@@ -71,8 +98,9 @@ func (l *Log) ConfigureWithLevelString(logLevel string) {
 		}
 	}
 
-	// Set the global log level
+	// Set the global log level and the component levels overriding it
 	log.SetLevel(l.ParsedLevel)
+	logging.SetComponentLevels(l.ParsedLevels)
 
 	// set the time function to ensure all logs are in UTC and in nanos
 	log.SetTimeFunction(func() time.Time {
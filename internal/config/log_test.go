@@ -84,6 +84,33 @@ func TestLog_Validate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "valid component levels",
+			log: Log{
+				Level:  "info",
+				Format: "text",
+				Levels: map[string]string{"github": "debug", "rpc": "warn", "sync": "info"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid component level",
+			log: Log{
+				Level:  "info",
+				Format: "text",
+				Levels: map[string]string{"github": "verbose"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "unknown component",
+			log: Log{
+				Level:  "info",
+				Format: "text",
+				Levels: map[string]string{"gihtub": "debug"},
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -185,3 +212,24 @@ func TestLog_ConfigureWithLevelString(t *testing.T) {
 		})
 	}
 }
+
+func TestLog_Validate_ParsesLevels(t *testing.T) {
+	logConfig := Log{
+		Level:  "info",
+		Format: "text",
+		Levels: map[string]string{"github": "debug", "rpc": "error"},
+	}
+	if err := logConfig.Validate(); err != nil {
+		t.Fatalf("Log.Validate() error = %v", err)
+	}
+
+	want := map[string]log.Level{"github": log.DebugLevel, "rpc": log.ErrorLevel}
+	if len(logConfig.ParsedLevels) != len(want) {
+		t.Fatalf("Log.ParsedLevels = %v, want %v", logConfig.ParsedLevels, want)
+	}
+	for component, level := range want {
+		if logConfig.ParsedLevels[component] != level {
+			t.Errorf("Log.ParsedLevels[%s] = %v, want %v", component, logConfig.ParsedLevels[component], level)
+		}
+	}
+}
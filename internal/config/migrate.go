@@ -0,0 +1,194 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// migrationCommentPrefix prefixes the comments Migrate leaves next to migrated keys
+const migrationCommentPrefix = "migrated: "
+
+// Migrate migrates config file content written for an older config format to the current format, keeping
+// comments and key order - every change is noted in a comment next to the migrated key and returned as a note,
+// e.g. sync.commands[1].must_succeed: false migrated to allow_failure: true
+func Migrate(content []byte) (migrated []byte, notes []string, err error) {
+	var document yaml.Node
+	if err := yaml.Unmarshal(content, &document); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse config: %w", err)
+	}
+	if document.Kind != yaml.DocumentNode || len(document.Content) == 0 {
+		return content, nil, nil
+	}
+	root := document.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return nil, nil, fmt.Errorf("config must be a mapping of keys to values")
+	}
+
+	m := &migration{}
+	m.migrateValidator(root)
+	m.migrateSync(root)
+	if len(m.notes) == 0 {
+		return content, nil, nil
+	}
+
+	out := bytes.Buffer{}
+	encoder := yaml.NewEncoder(&out)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&document); err != nil {
+		return nil, nil, fmt.Errorf("failed to write migrated config: %w", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, nil, fmt.Errorf("failed to write migrated config: %w", err)
+	}
+	return out.Bytes(), m.notes, nil
+}
+
+// migration collects the notes of the changes made migrating a config
+type migration struct {
+	notes []string
+}
+
+// note records a change made migrating a config
+func (m *migration) note(keyPath string, change string) {
+	m.notes = append(m.notes, keyPath+": "+change)
+}
+
+// migrateValidator migrates the validator section
+func (m *migration) migrateValidator(root *yaml.Node) {
+	_, validator := mappingEntry(root, "validator")
+	if validator == nil || validator.Kind != yaml.MappingNode {
+		return
+	}
+
+	_, client := mappingEntry(validator, "client")
+	if client != nil && client.Kind == yaml.ScalarNode && client.Value == "rakurai" {
+		client.Value = "rakurai-validator"
+		addLineComment(client, "client rakurai was renamed rakurai-validator")
+		m.note("validator.client", "rakurai renamed to rakurai-validator")
+	}
+}
+
+// migrateSync migrates the sync section - keys no longer read are removed, with their value and how to express
+// them now in a comment on the sync key
+func (m *migration) migrateSync(root *yaml.Node) {
+	syncKey, sync := mappingEntry(root, "sync")
+	if sync == nil || sync.Kind != yaml.MappingNode {
+		return
+	}
+
+	removedKeys := []struct {
+		key       string
+		migration string
+	}{
+		{key: "allowed_semver_changes", migration: "ignored - constrain sync targets with validator.version_constraint instead"},
+		{key: "client_source_repositories", migration: legacyKeyMigration("sync.client_source_repositories")},
+		{key: "interval", migration: legacyKeyMigration("sync.interval")},
+	}
+	for _, removed := range removedKeys {
+		value := removeMappingEntry(sync, removed.key)
+		if value == nil {
+			continue
+		}
+		description := "sync." + removed.key
+		if value.Kind == yaml.ScalarNode {
+			description += " " + value.Value
+		}
+		addHeadComment(syncKey, fmt.Sprintf("removed %s - %s", description, removed.migration))
+		m.note("sync."+removed.key, "removed - "+removed.migration)
+	}
+
+	_, commands := mappingEntry(sync, "commands")
+	if commands == nil || commands.Kind != yaml.SequenceNode {
+		return
+	}
+	for i, command := range commands.Content {
+		if command.Kind == yaml.MappingNode {
+			m.migrateCommand(command, fmt.Sprintf("sync.commands[%d]", i))
+		}
+	}
+}
+
+// migrateCommand migrates a sync command - dry_run: true becomes disabled: true and must_succeed: false becomes
+// allow_failure: true, their opposites are the defaults and are dropped
+func (m *migration) migrateCommand(command *yaml.Node, keyPath string) {
+	if dryRun := removeMappingEntry(command, "dry_run"); dryRun != nil {
+		if dryRun.Value == "true" {
+			setMappingBool(command, "disabled", true, "dry_run: true - the command is now skipped instead of run in dry-run mode")
+			m.note(keyPath+".dry_run", "true migrated to disabled: true - the command is skipped instead of run in dry-run mode")
+		} else {
+			m.note(keyPath+".dry_run", "removed - commands always run unless disabled")
+		}
+	}
+
+	if mustSucceed := removeMappingEntry(command, "must_succeed"); mustSucceed != nil {
+		if mustSucceed.Value == "false" {
+			setMappingBool(command, "allow_failure", true, "must_succeed: false - failures are logged and subsequent commands still run")
+			m.note(keyPath+".must_succeed", "false migrated to allow_failure: true")
+		} else {
+			m.note(keyPath+".must_succeed", "removed - commands must succeed by default")
+		}
+	}
+}
+
+// legacyKeyMigration returns how to migrate a legacy key, as reported when a config using it is loaded
+func legacyKeyMigration(path string) string {
+	for _, key := range legacyKeys {
+		if key.path == path {
+			return key.migration
+		}
+	}
+	return ""
+}
+
+// mappingEntry returns the key and value nodes of key in a mapping node, nil when not set
+func mappingEntry(mapping *yaml.Node, key string) (keyNode *yaml.Node, valueNode *yaml.Node) {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return mapping.Content[i], mapping.Content[i+1]
+		}
+	}
+	return nil, nil
+}
+
+// removeMappingEntry removes key from a mapping node, returning its value node - nil when not set
+func removeMappingEntry(mapping *yaml.Node, key string) (valueNode *yaml.Node) {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			valueNode = mapping.Content[i+1]
+			mapping.Content = append(mapping.Content[:i], mapping.Content[i+2:]...)
+			return valueNode
+		}
+	}
+	return nil
+}
+
+// setMappingBool sets key to a bool in a mapping node, adding it when not set, with a comment on why
+func setMappingBool(mapping *yaml.Node, key string, value bool, reason string) {
+	_, valueNode := mappingEntry(mapping, key)
+	if valueNode == nil {
+		valueNode = &yaml.Node{}
+		mapping.Content = append(mapping.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, valueNode)
+	}
+	*valueNode = yaml.Node{Kind: yaml.ScalarNode, Tag: "!!bool", Value: fmt.Sprint(value)}
+	addLineComment(valueNode, "from "+reason)
+}
+
+// addHeadComment adds a migration comment line above a node
+func addHeadComment(node *yaml.Node, comment string) {
+	if node.HeadComment != "" {
+		node.HeadComment += "\n"
+	}
+	node.HeadComment += "# " + migrationCommentPrefix + comment
+}
+
+// addLineComment adds a migration comment at the end of a node's line, after any existing comment
+func addLineComment(node *yaml.Node, comment string) {
+	if node.LineComment != "" {
+		node.LineComment += " - "
+	} else {
+		node.LineComment = "# "
+	}
+	node.LineComment += migrationCommentPrefix + comment
+}
@@ -0,0 +1,160 @@
+package config
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/knadh/koanf/parsers/yaml"
+)
+
+func TestMigrate(t *testing.T) {
+	tests := []struct {
+		name        string
+		content     string
+		want        string
+		wantNotes   []string
+		wantErr     bool
+		wantCurrent bool
+	}{
+		{
+			name:        "current config is unchanged",
+			content:     minimalConfigContent + "sync:\n  commands:\n    - name: restart # restart the validator\n      cmd: systemctl\n",
+			wantCurrent: true,
+		},
+		{
+			name:        "empty config is unchanged",
+			content:     "",
+			wantCurrent: true,
+		},
+		{
+			name:    "invalid yaml",
+			content: "validator: [",
+			wantErr: true,
+		},
+		{
+			name:    "not a mapping",
+			content: "- validator\n",
+			wantErr: true,
+		},
+		{
+			name: "legacy sync keys removed with comments",
+			content: minimalConfigContent + `# sync settings
+sync:
+  interval: 1m
+  client_source_repositories:
+    agave:
+      url: https://github.com/anza-xyz/agave
+  allowed_semver_changes:
+    major: false
+`,
+			want: minimalConfigContent + `# sync settings
+# migrated: removed sync.allowed_semver_changes - ignored - constrain sync targets with validator.version_constraint instead
+# migrated: removed sync.client_source_repositories - release repositories are built in for each validator.client
+# migrated: removed sync.interval 1m - pass the interval on the command line instead, e.g. run --on-interval 1m or service install --on-interval 1m
+sync: {}
+`,
+			wantNotes: []string{
+				"sync.allowed_semver_changes: removed - ignored - constrain sync targets with validator.version_constraint instead",
+				"sync.client_source_repositories: removed - release repositories are built in for each validator.client",
+				"sync.interval: removed - pass the interval on the command line instead, e.g. run --on-interval 1m or service install --on-interval 1m",
+			},
+		},
+		{
+			name: "legacy command keys migrated",
+			content: minimalConfigContent + `sync:
+  commands:
+    - name: build
+      cmd: make
+      dry_run: true
+      must_succeed: true
+    - name: restart # restart the validator
+      cmd: systemctl
+      dry_run: false
+      must_succeed: false
+`,
+			want: minimalConfigContent + `sync:
+  commands:
+    - name: build
+      cmd: make
+      disabled: true # migrated: from dry_run: true - the command is now skipped instead of run in dry-run mode
+    - name: restart # restart the validator
+      cmd: systemctl
+      allow_failure: true # migrated: from must_succeed: false - failures are logged and subsequent commands still run
+`,
+			wantNotes: []string{
+				"sync.commands[0].dry_run: true migrated to disabled: true - the command is skipped instead of run in dry-run mode",
+				"sync.commands[0].must_succeed: removed - commands must succeed by default",
+				"sync.commands[1].dry_run: removed - commands always run unless disabled",
+				"sync.commands[1].must_succeed: false migrated to allow_failure: true",
+			},
+		},
+		{
+			name: "dry run overrides existing disabled",
+			content: minimalConfigContent + `sync:
+  commands:
+    - name: build
+      cmd: make
+      disabled: false
+      dry_run: true
+`,
+			want: minimalConfigContent + `sync:
+  commands:
+    - name: build
+      cmd: make
+      disabled: true # migrated: from dry_run: true - the command is now skipped instead of run in dry-run mode
+`,
+			wantNotes: []string{
+				"sync.commands[0].dry_run: true migrated to disabled: true - the command is skipped instead of run in dry-run mode",
+			},
+		},
+		{
+			name: "legacy client alias renamed keeping comment",
+			content: `validator:
+  client: rakurai # our client
+cluster:
+  name: testnet
+`,
+			want: `validator:
+  client: rakurai-validator # our client - migrated: client rakurai was renamed rakurai-validator
+cluster:
+  name: testnet
+`,
+			wantNotes: []string{"validator.client: rakurai renamed to rakurai-validator"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, notes, err := Migrate([]byte(tt.content))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Migrate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			if tt.wantCurrent {
+				if string(got) != tt.content || len(notes) != 0 {
+					t.Errorf("Migrate() = %q, %v, want content unchanged and no notes", got, notes)
+				}
+				return
+			}
+
+			if string(got) != tt.want {
+				t.Errorf("Migrate() =\n%s\nwant\n%s", got, tt.want)
+			}
+			if strings.Join(notes, "\n") != strings.Join(tt.wantNotes, "\n") {
+				t.Errorf("Migrate() notes =\n%s\nwant\n%s", strings.Join(notes, "\n"), strings.Join(tt.wantNotes, "\n"))
+			}
+
+			// migrated configs no longer use legacy keys
+			raw, err := yaml.Parser().Unmarshal(got)
+			if err != nil {
+				t.Fatalf("failed to parse migrated config: %v", err)
+			}
+			if err := checkLegacyKeys(raw); err != nil {
+				t.Errorf("checkLegacyKeys() on migrated config error = %v, want nil", err)
+			}
+		})
+	}
+}
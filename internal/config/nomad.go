@@ -0,0 +1,66 @@
+package config
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/sol-strategies/solana-validator-version-sync/internal/secrets"
+)
+
+// Nomad represents the configuration for activating syncs by bumping a Nomad job's meta to the target version,
+// for validators orchestrated with Nomad - registering the updated job triggers its deployment
+type Nomad struct {
+	// Enabled sets the job's MetaKey to the target version after activate commands succeed
+	Enabled bool `koanf:"enabled"`
+	// Address is the Nomad HTTP API address, defaults to http://127.0.0.1:4646
+	Address string `koanf:"address"`
+	// Namespace is the namespace of the job, defaults to default
+	Namespace string `koanf:"namespace"`
+	// Region is the region of the job - the agent's region when empty
+	Region string `koanf:"region"`
+	// Job is the ID of the job running the validator
+	Job string `koanf:"job"`
+	// MetaKey is the job meta key set to the target version, e.g. interpolated as ${NOMAD_META_validator_version}
+	// in the job's artifact or image, defaults to validator_version
+	MetaKey string `koanf:"meta_key"`
+	// Token is a reference to the ACL token sent as X-Nomad-Token - no token is sent when no source is set
+	Token secrets.Ref `koanf:"token"`
+	// Timeout is the timeout for each Nomad API request, defaults to 30s
+	Timeout time.Duration `koanf:"timeout"`
+}
+
+// Validate validates the Nomad configuration
+func (n *Nomad) Validate() error {
+	if !n.Enabled {
+		return nil
+	}
+
+	if !validHTTPURL(n.Address) {
+		return fmt.Errorf("sync.nomad.address must be a valid http(s) URL - got: %s", n.Address)
+	}
+
+	if n.Job == "" {
+		return fmt.Errorf("sync.nomad.job is required when sync.nomad is enabled")
+	}
+
+	if n.MetaKey == "" {
+		return fmt.Errorf("sync.nomad.meta_key must not be empty")
+	}
+
+	if n.HasToken() {
+		if err := n.Token.Validate(); err != nil {
+			return fmt.Errorf("sync.nomad.token: %w", err)
+		}
+	}
+
+	if n.Timeout <= 0 {
+		return fmt.Errorf("sync.nomad.timeout must be greater than 0 - got: %s", n.Timeout)
+	}
+
+	return nil
+}
+
+// HasToken returns true when a token source is configured
+func (n *Nomad) HasToken() bool {
+	return n.Token != (secrets.Ref{})
+}
@@ -0,0 +1,77 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sol-strategies/solana-validator-version-sync/internal/secrets"
+)
+
+func TestNomad_Validate(t *testing.T) {
+	valid := Nomad{
+		Enabled:   true,
+		Address:   "http://127.0.0.1:4646",
+		Namespace: "default",
+		Job:       "validator",
+		MetaKey:   "validator_version",
+		Timeout:   30 * time.Second,
+	}
+
+	tests := []struct {
+		name    string
+		mutate  func(n *Nomad)
+		wantErr bool
+	}{
+		{
+			name:    "valid",
+			mutate:  func(n *Nomad) {},
+			wantErr: false,
+		},
+		{
+			name:    "disabled ignores invalid settings",
+			mutate:  func(n *Nomad) { n.Enabled = false; n.Address = ""; n.Job = "" },
+			wantErr: false,
+		},
+		{
+			name:    "valid with token",
+			mutate:  func(n *Nomad) { n.Token = secrets.Ref{FromEnv: "NOMAD_TOKEN"} },
+			wantErr: false,
+		},
+		{
+			name:    "token with several sources",
+			mutate:  func(n *Nomad) { n.Token = secrets.Ref{FromEnv: "NOMAD_TOKEN", FromFile: "/run/secrets/nomad"} },
+			wantErr: true,
+		},
+		{
+			name:    "invalid address",
+			mutate:  func(n *Nomad) { n.Address = "127.0.0.1:4646" },
+			wantErr: true,
+		},
+		{
+			name:    "missing job",
+			mutate:  func(n *Nomad) { n.Job = "" },
+			wantErr: true,
+		},
+		{
+			name:    "missing meta key",
+			mutate:  func(n *Nomad) { n.MetaKey = "" },
+			wantErr: true,
+		},
+		{
+			name:    "zero timeout",
+			mutate:  func(n *Nomad) { n.Timeout = 0 },
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nomad := valid
+			tt.mutate(&nomad)
+			err := nomad.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Nomad.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
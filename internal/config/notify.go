@@ -0,0 +1,110 @@
+package config
+
+import (
+	"fmt"
+	"time"
+	// embedded so notify.timezone loads on hosts without a timezone database, e.g. minimal containers
+	_ "time/tzdata"
+
+	"github.com/sol-strategies/solana-validator-version-sync/internal/secrets"
+)
+
+// Notify represents the notification configuration
+type Notify struct {
+	// Webhook posts notifications (e.g. sync heads-ups) to an HTTP endpoint as JSON
+	Webhook NotifyWebhook `koanf:"webhook"`
+	// DriftDetected notifies while the validator runs a version other than the target after a sync
+	DriftDetected NotifyEvent `koanf:"drift_detected"`
+	// ActivationHeld notifies while a gate (e.g. the role, reference validator or adoption gate) holds activation back
+	ActivationHeld NotifyEvent `koanf:"activation_held"`
+	// Timezone is the IANA timezone (e.g. Europe/Berlin) notification and status file timestamps are rendered in,
+	// defaults to UTC
+	Timezone string `koanf:"timezone"`
+}
+
+// NotifyEvent represents the configuration of a pending state notification - repeats for the same pending state
+// are not sent on every sync
+type NotifyEvent struct {
+	// Enabled sends the notification to the webhook
+	Enabled bool `koanf:"enabled"`
+	// RealertInterval is how often the notification is repeated while the state stays pending, 0 never repeats it
+	RealertInterval time.Duration `koanf:"realert_interval"`
+}
+
+// NotifyWebhook represents the notification webhook configuration
+type NotifyWebhook struct {
+	// URL is the endpoint notifications are posted to as JSON, e.g. a Slack-compatible incoming webhook - disabled when empty
+	URL string `koanf:"url"`
+	// Headers are extra headers sent with each post, e.g. for authentication
+	Headers map[string]string `koanf:"headers"`
+	// Timeout is the timeout for each post, defaults to 10s
+	Timeout time.Duration `koanf:"timeout"`
+}
+
+// Validate validates the notification configuration
+func (n *Notify) Validate() error {
+	if _, err := time.LoadLocation(n.Timezone); err != nil {
+		return fmt.Errorf("notify.timezone must be an IANA timezone, e.g. Europe/Berlin - got: %s", n.Timezone)
+	}
+
+	events := []struct {
+		name  string
+		event NotifyEvent
+	}{
+		{name: "drift_detected", event: n.DriftDetected},
+		{name: "activation_held", event: n.ActivationHeld},
+	}
+	for _, e := range events {
+		if !e.event.Enabled {
+			continue
+		}
+		if n.Webhook.URL == "" {
+			return fmt.Errorf("notify.%s requires notify.webhook.url to be set", e.name)
+		}
+		if e.event.RealertInterval < 0 {
+			return fmt.Errorf("notify.%s.realert_interval must be 0 (never) or greater - got: %s", e.name, e.event.RealertInterval)
+		}
+	}
+
+	if n.Webhook.URL == "" {
+		return nil
+	}
+
+	if !validHTTPURL(n.Webhook.URL) {
+		return fmt.Errorf("notify.webhook.url must be a valid http(s) URL - got: %s", n.Webhook.URL)
+	}
+
+	if n.Webhook.Timeout <= 0 {
+		return fmt.Errorf("notify.webhook.timeout must be greater than 0 - got: %s", n.Webhook.Timeout)
+	}
+
+	return nil
+}
+
+// Location returns the location of the configured timezone - UTC when it can't be loaded
+func (n *Notify) Location() *time.Location {
+	location, err := time.LoadLocation(n.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return location
+}
+
+// Redacted returns a copy of the notification configuration with the webhook URL and header values redacted so
+// it is safe to log - webhook URLs usually embed their credentials
+func (n Notify) Redacted() Notify {
+	if n.Webhook.URL != "" {
+		n.Webhook.URL = secrets.Redacted
+	}
+
+	if len(n.Webhook.Headers) == 0 {
+		return n
+	}
+
+	headers := make(map[string]string, len(n.Webhook.Headers))
+	for name := range n.Webhook.Headers {
+		headers[name] = secrets.Redacted
+	}
+	n.Webhook.Headers = headers
+	return n
+}
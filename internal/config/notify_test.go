@@ -0,0 +1,117 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sol-strategies/solana-validator-version-sync/internal/secrets"
+)
+
+func TestNotify_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		notify  Notify
+		wantErr bool
+	}{
+		{
+			name:    "disabled",
+			notify:  Notify{},
+			wantErr: false,
+		},
+		{
+			name:    "valid webhook",
+			notify:  Notify{Webhook: NotifyWebhook{URL: "https://hooks.slack.com/services/T000/B000/XXXX", Timeout: 10 * time.Second}},
+			wantErr: false,
+		},
+		{
+			name:    "invalid webhook url",
+			notify:  Notify{Webhook: NotifyWebhook{URL: "hooks.slack.com", Timeout: 10 * time.Second}},
+			wantErr: true,
+		},
+		{
+			name: "pending state notifications",
+			notify: Notify{
+				Webhook:        NotifyWebhook{URL: "https://hooks.slack.com/services/T000/B000/XXXX", Timeout: 10 * time.Second},
+				DriftDetected:  NotifyEvent{Enabled: true, RealertInterval: 12 * time.Hour},
+				ActivationHeld: NotifyEvent{Enabled: true},
+			},
+			wantErr: false,
+		},
+		{
+			name:    "pending state notification without webhook",
+			notify:  Notify{DriftDetected: NotifyEvent{Enabled: true, RealertInterval: 12 * time.Hour}},
+			wantErr: true,
+		},
+		{
+			name: "negative realert interval",
+			notify: Notify{
+				Webhook:        NotifyWebhook{URL: "https://hooks.slack.com/services/T000/B000/XXXX", Timeout: 10 * time.Second},
+				ActivationHeld: NotifyEvent{Enabled: true, RealertInterval: -time.Hour},
+			},
+			wantErr: true,
+		},
+		{
+			name:    "timezone",
+			notify:  Notify{Timezone: "Europe/Berlin"},
+			wantErr: false,
+		},
+		{
+			name:    "invalid timezone",
+			notify:  Notify{Timezone: "Mars/Olympus_Mons"},
+			wantErr: true,
+		},
+		{
+			name:    "zero timeout",
+			notify:  Notify{Webhook: NotifyWebhook{URL: "https://hooks.slack.com/services/T000/B000/XXXX"}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.notify.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Notify.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNotify_Location(t *testing.T) {
+	tests := []struct {
+		timezone string
+		want     string
+	}{
+		{timezone: "", want: "UTC"},
+		{timezone: "UTC", want: "UTC"},
+		{timezone: "Asia/Tokyo", want: "Asia/Tokyo"},
+		{timezone: "Mars/Olympus_Mons", want: "UTC"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.timezone, func(t *testing.T) {
+			notify := Notify{Timezone: tt.timezone}
+			if got := notify.Location().String(); got != tt.want {
+				t.Errorf("Location() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNotify_Redacted(t *testing.T) {
+	notify := Notify{Webhook: NotifyWebhook{
+		URL:     "https://hooks.slack.com/services/T000/B000/XXXX",
+		Headers: map[string]string{"Authorization": "Bearer token"},
+	}}
+
+	redacted := notify.Redacted()
+	if redacted.Webhook.URL != secrets.Redacted {
+		t.Errorf("Redacted() url = %s, want %s", redacted.Webhook.URL, secrets.Redacted)
+	}
+	if got := redacted.Webhook.Headers["Authorization"]; got != secrets.Redacted {
+		t.Errorf("Redacted() Authorization header = %s, want %s", got, secrets.Redacted)
+	}
+	if notify.Webhook.Headers["Authorization"] != "Bearer token" {
+		t.Error("Redacted() modified the original headers")
+	}
+}
@@ -0,0 +1,82 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/knadh/koanf"
+	"github.com/knadh/koanf/parsers/yaml"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/constants"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/recipes"
+)
+
+// RecipeOptions represents the values a sync.recipe is parameterized with
+type RecipeOptions struct {
+	// InstallDir is the directory versions are installed into, one directory per tag
+	InstallDir string `koanf:"install_dir"`
+	// ActiveReleaseLink is the symlink pointed at the installed version on activation
+	ActiveReleaseLink string `koanf:"active_release_link"`
+	// ValidatorService is the validator systemd service restarted on activation
+	ValidatorService string `koanf:"validator_service"`
+}
+
+// recipeOptions returns the options as passed to recipes
+func (o RecipeOptions) recipeOptions() recipes.Options {
+	return recipes.Options{
+		InstallDir:        o.InstallDir,
+		ActiveReleaseLink: o.ActiveReleaseLink,
+		ValidatorService:  o.ValidatorService,
+	}
+}
+
+// expandRecipe sets sync.commands to the commands of the configured sync.recipe so they are decoded and
+// validated exactly like commands written out in the config file
+func expandRecipe(k *koanf.Koanf) error {
+	recipeName := k.String("sync.recipe")
+	if recipeName == "" {
+		return nil
+	}
+
+	if k.Exists("sync.commands") {
+		return fmt.Errorf("sync.recipe and sync.commands are mutually exclusive - remove one")
+	}
+
+	recipeOptions := RecipeOptions{}
+	if err := k.Unmarshal("sync.recipe_options", &recipeOptions); err != nil {
+		return fmt.Errorf("invalid sync.recipe_options: %w", err)
+	}
+
+	rendered, err := recipes.Render(recipeName, recipeOptions.recipeOptions())
+	if err != nil {
+		return fmt.Errorf("invalid sync.recipe: %w", err)
+	}
+
+	parsed, err := yaml.Parser().Unmarshal(rendered)
+	if err != nil {
+		return fmt.Errorf("failed to parse recipe %s: %w", recipeName, err)
+	}
+
+	return k.Set("sync.commands", parsed["commands"])
+}
+
+// validateRecipe validates the configured sync.recipe installs the configured validator client
+func (c *Config) validateRecipe() error {
+	if c.Sync.Recipe == "" {
+		return nil
+	}
+
+	recipe, err := recipes.Get(c.Sync.Recipe)
+	if err != nil {
+		return fmt.Errorf("invalid sync.recipe: %w", err)
+	}
+
+	// auto-detected clients are checked against the recipe once detected
+	if c.Validator.Client == constants.ClientNameAuto {
+		return nil
+	}
+
+	if recipe.Client != constants.NormalizeClientName(c.Validator.Client) {
+		return fmt.Errorf("sync.recipe %s installs %s but validator.client is %s", recipe.Name, recipe.Client, c.Validator.Client)
+	}
+
+	return nil
+}
@@ -0,0 +1,142 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sol-strategies/solana-validator-version-sync/internal/recipes"
+)
+
+func TestLoadFromFile_Recipe(t *testing.T) {
+	tests := []struct {
+		name          string
+		syncContent   string
+		wantErr       string
+		wantCommands  []string
+		wantInstallTo string
+	}{
+		{
+			name: "recipe with default options",
+			syncContent: `sync:
+  recipe: agave-default
+`,
+			wantCommands:  []string{"download", "install", "restart"},
+			wantInstallTo: recipes.DefaultInstallDir,
+		},
+		{
+			name: "recipe with options",
+			syncContent: `sync:
+  recipe: agave-default
+  recipe_options:
+    install_dir: /opt/agave/releases
+`,
+			wantCommands:  []string{"download", "install", "restart"},
+			wantInstallTo: "/opt/agave/releases",
+		},
+		{
+			name: "recipe and commands",
+			syncContent: `sync:
+  recipe: agave-default
+  commands:
+    - name: install
+      cmd: /usr/local/bin/install.sh
+`,
+			wantErr: "mutually exclusive",
+		},
+		{
+			name: "unknown recipe",
+			syncContent: `sync:
+  recipe: agave-custom
+`,
+			wantErr: "unknown recipe",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configFile := filepath.Join(t.TempDir(), "config.yaml")
+			if err := os.WriteFile(configFile, []byte(minimalConfigContent+tt.syncContent), 0644); err != nil {
+				t.Fatalf("Failed to create config file: %v", err)
+			}
+
+			cfg, err := New()
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+			err = cfg.LoadFromFile(configFile)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("LoadFromFile() error = %v, want error containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("LoadFromFile() error = %v", err)
+			}
+
+			gotCommands := []string{}
+			for _, command := range cfg.Sync.Commands {
+				gotCommands = append(gotCommands, command.Name)
+			}
+			if strings.Join(gotCommands, ",") != strings.Join(tt.wantCommands, ",") {
+				t.Errorf("Sync.Commands = %v, want %v", gotCommands, tt.wantCommands)
+			}
+			if got := cfg.Sync.Commands[0].Environment["INSTALL_DIR"]; got != tt.wantInstallTo {
+				t.Errorf("INSTALL_DIR = %q, want %q", got, tt.wantInstallTo)
+			}
+
+			// recipe commands must pass the same validation as hand-written ones
+			if err := cfg.Sync.Validate(); err != nil {
+				t.Errorf("Sync.Validate() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestConfig_validateRecipe(t *testing.T) {
+	tests := []struct {
+		name    string
+		client  string
+		recipe  string
+		wantErr bool
+	}{
+		{
+			name:    "no recipe",
+			client:  "agave",
+			recipe:  "",
+			wantErr: false,
+		},
+		{
+			name:    "recipe for client",
+			client:  "jito-solana",
+			recipe:  "jito-solana-default",
+			wantErr: false,
+		},
+		{
+			name:    "recipe for another client",
+			client:  "firedancer",
+			recipe:  "agave-default",
+			wantErr: true,
+		},
+		{
+			name:    "recipe for auto-detected client",
+			client:  "auto",
+			recipe:  "agave-default",
+			wantErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{
+				Validator: Validator{Client: tt.client},
+				Sync:      Sync{Recipe: tt.recipe},
+			}
+			if err := cfg.validateRecipe(); (err != nil) != tt.wantErr {
+				t.Errorf("validateRecipe() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
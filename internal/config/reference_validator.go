@@ -0,0 +1,29 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// base58PublicKeyRegex loosely matches a base58 encoded 32 byte public key
+var base58PublicKeyRegex = regexp.MustCompile(`^[1-9A-HJ-NP-Za-km-z]{32,44}$`)
+
+// ReferenceValidator represents a reference validator (e.g. a canary node) that must already run
+// a target version or newer, as seen in gossip, before this validator syncs to it
+type ReferenceValidator struct {
+	// Identity is the identity public key of the reference validator - when empty, no reference validator is required
+	Identity string `koanf:"identity"`
+}
+
+// Validate validates the reference validator configuration
+func (r *ReferenceValidator) Validate() error {
+	if r.Identity == "" {
+		return nil
+	}
+
+	if !base58PublicKeyRegex.MatchString(r.Identity) {
+		return fmt.Errorf("sync.reference_validator.identity must be a base58 encoded public key - got: %s", r.Identity)
+	}
+
+	return nil
+}
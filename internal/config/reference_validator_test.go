@@ -0,0 +1,41 @@
+package config
+
+import "testing"
+
+func TestReferenceValidator_Validate(t *testing.T) {
+	tests := []struct {
+		name               string
+		referenceValidator ReferenceValidator
+		wantErr            bool
+	}{
+		{
+			name:               "no reference validator",
+			referenceValidator: ReferenceValidator{},
+			wantErr:            false,
+		},
+		{
+			name:               "valid identity",
+			referenceValidator: ReferenceValidator{Identity: "9WzDXwBbmkg8ZTbNMqUxvQRAyrZzDsGYdLVL9zYtAWWM"},
+			wantErr:            false,
+		},
+		{
+			name:               "too short",
+			referenceValidator: ReferenceValidator{Identity: "9WzDXwBbmkg8"},
+			wantErr:            true,
+		},
+		{
+			name:               "invalid base58 characters",
+			referenceValidator: ReferenceValidator{Identity: "0OIl0OIl0OIl0OIl0OIl0OIl0OIl0OIl0OIl"},
+			wantErr:            true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.referenceValidator.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ReferenceValidator.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
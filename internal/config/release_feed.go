@@ -0,0 +1,29 @@
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+// ReleaseFeed represents the configuration for revalidating cached release and tag listings against the
+// repo's Atom feeds, which cost no GitHub API rate limit, before calling the API
+type ReleaseFeed struct {
+	// Enabled enables feed revalidation
+	Enabled bool `koanf:"enabled"`
+	// MaxAge is the longest a listing is reused while its feed is unchanged before it is fetched from the API again -
+	// feeds only hold the latest releases, so edits to older releases are picked up by then
+	MaxAge time.Duration `koanf:"max_age"`
+}
+
+// Validate validates the release feed configuration
+func (r *ReleaseFeed) Validate() error {
+	if !r.Enabled {
+		return nil
+	}
+
+	if r.MaxAge <= 0 {
+		return fmt.Errorf("sync.release_feed.max_age must be greater than 0 - got: %s", r.MaxAge)
+	}
+
+	return nil
+}
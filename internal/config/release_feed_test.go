@@ -0,0 +1,44 @@
+package config
+
+import (
+	"testing"
+	"time"
+)
+
+func TestReleaseFeed_Validate(t *testing.T) {
+	tests := []struct {
+		name        string
+		releaseFeed ReleaseFeed
+		wantErr     bool
+	}{
+		{
+			name:        "disabled",
+			releaseFeed: ReleaseFeed{},
+			wantErr:     false,
+		},
+		{
+			name:        "valid max age",
+			releaseFeed: ReleaseFeed{Enabled: true, MaxAge: time.Hour},
+			wantErr:     false,
+		},
+		{
+			name:        "zero max age",
+			releaseFeed: ReleaseFeed{Enabled: true},
+			wantErr:     true,
+		},
+		{
+			name:        "negative max age",
+			releaseFeed: ReleaseFeed{Enabled: true, MaxAge: -time.Minute},
+			wantErr:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.releaseFeed.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ReleaseFeed.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
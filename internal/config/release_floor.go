@@ -0,0 +1,20 @@
+package config
+
+import "fmt"
+
+// ReleaseFloor represents the minimum version floor applied when scanning releases, derived from the running
+// version so repos with many old matching releases can't lead to selecting a stale tag
+type ReleaseFloor struct {
+	// MinorDistance is how many minor versions below the running version releases are still considered,
+	// within the running major version - 0 disables the floor
+	MinorDistance int `koanf:"minor_distance"`
+}
+
+// Validate validates the release floor configuration
+func (r *ReleaseFloor) Validate() error {
+	if r.MinorDistance < 0 {
+		return fmt.Errorf("sync.release_floor.minor_distance must be 0 (disabled) or greater - got: %d", r.MinorDistance)
+	}
+
+	return nil
+}
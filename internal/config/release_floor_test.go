@@ -0,0 +1,36 @@
+package config
+
+import "testing"
+
+func TestReleaseFloor_Validate(t *testing.T) {
+	tests := []struct {
+		name         string
+		releaseFloor ReleaseFloor
+		wantErr      bool
+	}{
+		{
+			name:         "disabled",
+			releaseFloor: ReleaseFloor{},
+			wantErr:      false,
+		},
+		{
+			name:         "valid minor distance",
+			releaseFloor: ReleaseFloor{MinorDistance: 2},
+			wantErr:      false,
+		},
+		{
+			name:         "negative minor distance",
+			releaseFloor: ReleaseFloor{MinorDistance: -1},
+			wantErr:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.releaseFloor.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ReleaseFloor.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
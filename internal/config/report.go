@@ -0,0 +1,121 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/sol-strategies/solana-validator-version-sync/internal/secrets"
+)
+
+// Report represents the sync decision reporting configuration
+type Report struct {
+	// CSV appends each sync decision to a CSV file
+	CSV ReportCSV `koanf:"csv"`
+	// HTTP posts each sync decision to an HTTP endpoint (e.g. a spreadsheet appender)
+	HTTP ReportHTTP `koanf:"http"`
+	// Status atomically replaces a status file with the last run's outcome after every run, for external watchdogs
+	Status ReportStatus `koanf:"status"`
+	// Consul publishes the validator's current and target versions to the Consul KV store after every run
+	Consul ReportConsul `koanf:"consul"`
+}
+
+// ReportConsul represents the Consul KV publishing configuration
+type ReportConsul struct {
+	// Address is the Consul HTTP API address, e.g. http://127.0.0.1:8500 - disabled when empty
+	Address string `koanf:"address"`
+	// KVPrefix is the KV path versions are published under, as <kv_prefix>/<identity public key>
+	KVPrefix string `koanf:"kv_prefix"`
+	// Datacenter is the datacenter of the KV store - the agent's datacenter when empty
+	Datacenter string `koanf:"datacenter"`
+	// Token is a reference to the ACL token sent as X-Consul-Token - no token is sent when no source is set
+	Token secrets.Ref `koanf:"token"`
+	// Timeout is the timeout for each request, defaults to 10s
+	Timeout time.Duration `koanf:"timeout"`
+}
+
+// ReportCSV represents the CSV file report sink configuration
+type ReportCSV struct {
+	// File is the CSV file decisions are appended to - disabled when empty
+	File string `koanf:"file"`
+}
+
+// ReportStatus represents the status file configuration
+type ReportStatus struct {
+	// File is the status file replaced after every run - disabled when empty
+	File string `koanf:"file"`
+}
+
+// ReportHTTP represents the HTTP report sink configuration
+type ReportHTTP struct {
+	// URL is the endpoint decisions are posted to as JSON - disabled when empty
+	URL string `koanf:"url"`
+	// Headers are extra headers sent with each post, e.g. for authentication
+	Headers map[string]string `koanf:"headers"`
+	// Timeout is the timeout for each post, defaults to 10s
+	Timeout time.Duration `koanf:"timeout"`
+}
+
+// Validate validates the report configuration
+func (r *Report) Validate() error {
+	if err := r.Consul.Validate(); err != nil {
+		return err
+	}
+
+	if r.HTTP.URL == "" {
+		return nil
+	}
+
+	parsedURL, err := url.Parse(r.HTTP.URL)
+	if err != nil || (parsedURL.Scheme != "http" && parsedURL.Scheme != "https") || parsedURL.Host == "" {
+		return fmt.Errorf("report.http.url must be a valid http(s) URL - got: %s", r.HTTP.URL)
+	}
+
+	if r.HTTP.Timeout <= 0 {
+		return fmt.Errorf("report.http.timeout must be greater than 0 - got: %s", r.HTTP.Timeout)
+	}
+
+	return nil
+}
+
+// Validate validates the Consul KV publishing configuration
+func (c *ReportConsul) Validate() error {
+	if c.Address == "" {
+		return nil
+	}
+
+	if !validHTTPURL(c.Address) {
+		return fmt.Errorf("report.consul.address must be a valid http(s) URL - got: %s", c.Address)
+	}
+
+	if strings.Trim(c.KVPrefix, "/") == "" {
+		return fmt.Errorf("report.consul.kv_prefix must not be empty")
+	}
+
+	if c.Token != (secrets.Ref{}) {
+		if err := c.Token.Validate(); err != nil {
+			return fmt.Errorf("report.consul.token: %w", err)
+		}
+	}
+
+	if c.Timeout <= 0 {
+		return fmt.Errorf("report.consul.timeout must be greater than 0 - got: %s", c.Timeout)
+	}
+
+	return nil
+}
+
+// Redacted returns a copy of the report configuration with header values redacted so it is safe to log
+func (r Report) Redacted() Report {
+	if len(r.HTTP.Headers) == 0 {
+		return r
+	}
+
+	headers := make(map[string]string, len(r.HTTP.Headers))
+	for name := range r.HTTP.Headers {
+		headers[name] = secrets.Redacted
+	}
+	r.HTTP.Headers = headers
+	return r
+}
@@ -0,0 +1,113 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sol-strategies/solana-validator-version-sync/internal/secrets"
+)
+
+func TestReport_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		report  Report
+		wantErr bool
+	}{
+		{
+			name:    "no sinks configured",
+			report:  Report{},
+			wantErr: false,
+		},
+		{
+			name:    "csv sink only",
+			report:  Report{CSV: ReportCSV{File: "/var/log/version-sync/decisions.csv"}},
+			wantErr: false,
+		},
+		{
+			name: "valid http sink",
+			report: Report{HTTP: ReportHTTP{
+				URL:     "https://script.google.com/macros/s/abc/exec",
+				Headers: map[string]string{"Authorization": "Bearer token"},
+				Timeout: 10 * time.Second,
+			}},
+			wantErr: false,
+		},
+		{
+			name:    "http sink with invalid scheme",
+			report:  Report{HTTP: ReportHTTP{URL: "ftp://example.com/rows", Timeout: time.Second}},
+			wantErr: true,
+		},
+		{
+			name:    "http sink without host",
+			report:  Report{HTTP: ReportHTTP{URL: "https://", Timeout: time.Second}},
+			wantErr: true,
+		},
+		{
+			name:    "http sink without timeout",
+			report:  Report{HTTP: ReportHTTP{URL: "https://example.com/rows"}},
+			wantErr: true,
+		},
+		{
+			name: "valid consul sink",
+			report: Report{Consul: ReportConsul{
+				Address:  "http://127.0.0.1:8500",
+				KVPrefix: "solana-validator-version-sync",
+				Token:    secrets.Ref{FromFile: "/run/secrets/consul-token"},
+				Timeout:  10 * time.Second,
+			}},
+			wantErr: false,
+		},
+		{
+			name:    "consul sink with invalid address",
+			report:  Report{Consul: ReportConsul{Address: "127.0.0.1:8500", KVPrefix: "versions", Timeout: time.Second}},
+			wantErr: true,
+		},
+		{
+			name:    "consul sink without kv prefix",
+			report:  Report{Consul: ReportConsul{Address: "http://127.0.0.1:8500", KVPrefix: "/", Timeout: time.Second}},
+			wantErr: true,
+		},
+		{
+			name: "consul sink with invalid token",
+			report: Report{Consul: ReportConsul{
+				Address:  "http://127.0.0.1:8500",
+				KVPrefix: "versions",
+				Token:    secrets.Ref{FromVault: "secret/data/consul"},
+				Timeout:  time.Second,
+			}},
+			wantErr: true,
+		},
+		{
+			name:    "consul sink without timeout",
+			report:  Report{Consul: ReportConsul{Address: "http://127.0.0.1:8500", KVPrefix: "versions"}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.report.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Report.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestReport_Redacted(t *testing.T) {
+	report := Report{HTTP: ReportHTTP{
+		URL:     "https://example.com/rows",
+		Headers: map[string]string{"Authorization": "Bearer token"},
+	}}
+
+	redacted := report.Redacted()
+	if got := redacted.HTTP.Headers["Authorization"]; got != secrets.Redacted {
+		t.Errorf("Redacted() Authorization header = %q, want %q", got, secrets.Redacted)
+	}
+	if got := report.HTTP.Headers["Authorization"]; got != "Bearer token" {
+		t.Errorf("Redacted() modified the original header to %q", got)
+	}
+	if redacted.HTTP.URL != report.HTTP.URL {
+		t.Errorf("Redacted() URL = %q, want %q", redacted.HTTP.URL, report.HTTP.URL)
+	}
+}
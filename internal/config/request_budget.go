@@ -0,0 +1,27 @@
+package config
+
+import "fmt"
+
+// RequestBudget represents the per-hour request budgets to external providers, shared by every component of the
+// daemon - when a budget is exhausted lookups degrade to the data last fetched with a warning instead of
+// exceeding the provider's rate limits
+type RequestBudget struct {
+	// GitHubPerHour is the number of GitHub API requests allowed per hour, 0 for no limit - unauthenticated
+	// requests are rate limited to 60 per hour per IP by GitHub
+	GitHubPerHour int `koanf:"github_per_hour"`
+	// SFDPPerHour is the number of SFDP API requests allowed per hour, 0 for no limit
+	SFDPPerHour int `koanf:"sfdp_per_hour"`
+}
+
+// Validate validates the request budget configuration
+func (r *RequestBudget) Validate() error {
+	if r.GitHubPerHour < 0 {
+		return fmt.Errorf("sync.request_budget.github_per_hour must be 0 (no limit) or greater - got: %d", r.GitHubPerHour)
+	}
+
+	if r.SFDPPerHour < 0 {
+		return fmt.Errorf("sync.request_budget.sfdp_per_hour must be 0 (no limit) or greater - got: %d", r.SFDPPerHour)
+	}
+
+	return nil
+}
@@ -0,0 +1,41 @@
+package config
+
+import "testing"
+
+func TestRequestBudget_Validate(t *testing.T) {
+	tests := []struct {
+		name          string
+		requestBudget RequestBudget
+		wantErr       bool
+	}{
+		{
+			name:          "no limits",
+			requestBudget: RequestBudget{},
+			wantErr:       false,
+		},
+		{
+			name:          "limits",
+			requestBudget: RequestBudget{GitHubPerHour: 50, SFDPPerHour: 120},
+			wantErr:       false,
+		},
+		{
+			name:          "negative github limit",
+			requestBudget: RequestBudget{GitHubPerHour: -1},
+			wantErr:       true,
+		},
+		{
+			name:          "negative sfdp limit",
+			requestBudget: RequestBudget{SFDPPerHour: -1},
+			wantErr:       true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.requestBudget.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("RequestBudget.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package config
+
+//go:generate go run schemagen.go
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/santhosh-tekuri/jsonschema/v5"
+)
+
+// durationUnits are the units accepted by time.ParseDuration, as matched by DurationPattern
+const durationUnits = "(ns|us|µs|ms|s|m|h)"
+
+// DurationPattern matches the duration strings accepted by time.ParseDuration
+const DurationPattern = `^-?([0-9]+(\.[0-9]+)?` + durationUnits + `)+$|^0$`
+
+// schemaURL is the URL the embedded schema is compiled as - it is never fetched
+const schemaURL = "config.schema.json"
+
+// schemaJSON is the JSON Schema of the config file, generated from the config structs by go generate
+//
+//go:embed schema.json
+var schemaJSON []byte
+
+// compiledSchema is the embedded schema compiled for validating config files
+var compiledSchema = jsonschema.MustCompileString(schemaURL, string(schemaJSON))
+
+// Schema returns the embedded JSON Schema of the config file
+func Schema() []byte {
+	return schemaJSON
+}
+
+// ValidateSchema validates raw (parsed but not yet defaulted or decoded) config file content against the
+// embedded schema, returning one error per offending key path, e.g. sync.commands[2].timeout: invalid duration
+func ValidateSchema(raw map[string]interface{}) error {
+	// round trip through JSON so YAML scalar types (e.g. timestamps) are validated as they would be decoded
+	content, err := json.Marshal(raw)
+	if err != nil {
+		return fmt.Errorf("failed to convert config for schema validation: %w", err)
+	}
+	var instance interface{}
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	decoder.UseNumber()
+	if err := decoder.Decode(&instance); err != nil {
+		return fmt.Errorf("failed to convert config for schema validation: %w", err)
+	}
+
+	err = compiledSchema.Validate(instance)
+	var validationErr *jsonschema.ValidationError
+	if !errors.As(err, &validationErr) {
+		return err
+	}
+
+	messages := make(map[string]struct{})
+	collectSchemaErrors(validationErr, messages)
+
+	sortedMessages := make([]string, 0, len(messages))
+	for message := range messages {
+		sortedMessages = append(sortedMessages, message)
+	}
+	sort.Strings(sortedMessages)
+
+	schemaErrs := make([]error, 0, len(sortedMessages))
+	for _, message := range sortedMessages {
+		schemaErrs = append(schemaErrs, errors.New(message))
+	}
+	return fmt.Errorf("config does not match schema:\n%w", errors.Join(schemaErrs...))
+}
+
+// collectSchemaErrors collects path-scoped messages for the leaf causes of a validation error - a failed oneOf
+// is reported once rather than once per alternative
+func collectSchemaErrors(validationErr *jsonschema.ValidationError, messages map[string]struct{}) {
+	isOneOf := strings.HasSuffix(validationErr.KeywordLocation, "/oneOf")
+	if len(validationErr.Causes) > 0 && !isOneOf {
+		for _, cause := range validationErr.Causes {
+			collectSchemaErrors(cause, messages)
+		}
+		return
+	}
+
+	message := validationErr.Message
+	switch {
+	case isOneOf:
+		message = "does not match any of the allowed forms"
+	case strings.HasSuffix(validationErr.KeywordLocation, "/pattern") && strings.Contains(message, durationUnits):
+		message = "invalid duration, e.g. 30s, 5m or 1h30m"
+	}
+
+	messages[schemaKeyPath(validationErr.InstanceLocation)+": "+message] = struct{}{}
+}
+
+// schemaKeyPath converts a JSON pointer instance location to a config key path, e.g. /sync/commands/2 to sync.commands[2]
+func schemaKeyPath(instanceLocation string) string {
+	keyPath := strings.Builder{}
+	for _, segment := range strings.Split(strings.TrimPrefix(instanceLocation, "/"), "/") {
+		if segment == "" {
+			continue
+		}
+		segment = strings.NewReplacer("~1", "/", "~0", "~").Replace(segment)
+		if _, err := strconv.Atoi(segment); err == nil {
+			keyPath.WriteString("[" + segment + "]")
+			continue
+		}
+		if keyPath.Len() > 0 {
+			keyPath.WriteString(".")
+		}
+		keyPath.WriteString(segment)
+	}
+
+	if keyPath.Len() == 0 {
+		return "(root)"
+	}
+	return keyPath.String()
+}
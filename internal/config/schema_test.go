@@ -0,0 +1,143 @@
+package config
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/knadh/koanf/parsers/yaml"
+)
+
+func TestSchema(t *testing.T) {
+	var schema map[string]interface{}
+	if err := json.Unmarshal(Schema(), &schema); err != nil {
+		t.Fatalf("embedded schema is not valid JSON: %v", err)
+	}
+
+	properties, ok := schema["properties"].(map[string]interface{})
+	if !ok {
+		t.Fatal("embedded schema has no properties")
+	}
+	for _, key := range []string{"log", "validator", "cluster", "sync", "state", "report"} {
+		if _, ok := properties[key]; !ok {
+			t.Errorf("embedded schema missing %s property", key)
+		}
+	}
+}
+
+func TestDefaults(t *testing.T) {
+	got := Defaults()
+	if got["log.level"] != "info" {
+		t.Errorf("Defaults()[log.level] = %v, want info", got["log.level"])
+	}
+
+	// callers get a copy
+	got["log.level"] = "debug"
+	if Defaults()["log.level"] != "info" {
+		t.Error("Defaults() returned the shared defaults map")
+	}
+}
+
+func TestValidateSchema(t *testing.T) {
+	tests := []struct {
+		name       string
+		content    string
+		wantErrors []string
+	}{
+		{
+			name:    "valid config",
+			content: minimalConfigContent + "sync:\n  commands:\n    - name: restart\n      cmd: systemctl\nreport:\n  http:\n    timeout: 5s\n",
+		},
+		{
+			name:    "legacy allowed_semver_changes is accepted",
+			content: minimalConfigContent + "sync:\n  allowed_semver_changes:\n    major: false\n",
+		},
+		{
+			name:    "secret reference environment value",
+			content: minimalConfigContent + "sync:\n  commands:\n    - name: restart\n      cmd: systemctl\n      environment:\n        API_KEY:\n          from_env: MY_KEY\n",
+		},
+		{
+			name: "invalid duration",
+			content: minimalConfigContent + `report:
+  http:
+    url: https://example.com/rows
+    timeout: 5 minutes
+`,
+			wantErrors: []string{"report.http.timeout: invalid duration"},
+		},
+		{
+			name: "invalid command in list",
+			content: minimalConfigContent + `sync:
+  commands:
+    - name: build
+      cmd: make
+    - name: install
+      cmd: make
+    - name: restart
+      cmd: systemctl
+      phase: later
+`,
+			wantErrors: []string{"sync.commands[2].phase: value must be one of"},
+		},
+		{
+			name:       "unknown key",
+			content:    minimalConfigContent + "sync:\n  enabled_when_activ: true\n",
+			wantErrors: []string{"sync: additionalProperties 'enabled_when_activ' not allowed"},
+		},
+		{
+			name:       "invalid enum and missing required key",
+			content:    "validator:\n  client: solana\n",
+			wantErrors: []string{"(root): missing properties: 'cluster'", "validator.client: value must be one of"},
+		},
+		{
+			name:       "invalid environment value",
+			content:    minimalConfigContent + "sync:\n  commands:\n    - name: restart\n      cmd: systemctl\n      environment:\n        API_KEY: [a, b]\n",
+			wantErrors: []string{"sync.commands[0].environment.API_KEY: does not match any of the allowed forms"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			raw, err := yaml.Parser().Unmarshal([]byte(tt.content))
+			if err != nil {
+				t.Fatalf("failed to parse config: %v", err)
+			}
+
+			err = ValidateSchema(raw)
+			if len(tt.wantErrors) == 0 {
+				if err != nil {
+					t.Errorf("ValidateSchema() error = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("ValidateSchema() error = nil, want %v", tt.wantErrors)
+			}
+			for _, wantError := range tt.wantErrors {
+				if !strings.Contains(err.Error(), wantError) {
+					t.Errorf("ValidateSchema() error = %v, want it to contain %q", err, wantError)
+				}
+			}
+		})
+	}
+}
+
+func TestSchemaKeyPath(t *testing.T) {
+	tests := []struct {
+		instanceLocation string
+		want             string
+	}{
+		{instanceLocation: "", want: "(root)"},
+		{instanceLocation: "/sync", want: "sync"},
+		{instanceLocation: "/sync/commands/2/timeout", want: "sync.commands[2].timeout"},
+		{instanceLocation: "/report/http/headers/X~1Token", want: "report.http.headers.X/Token"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := schemaKeyPath(tt.instanceLocation); got != tt.want {
+				t.Errorf("schemaKeyPath(%q) = %q, want %q", tt.instanceLocation, got, tt.want)
+			}
+		})
+	}
+}
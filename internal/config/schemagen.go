@@ -0,0 +1,27 @@
+//go:build ignore
+
+// schemagen generates schema.json from the config structs - run with go generate ./internal/config
+package main
+
+import (
+	"log"
+	"os"
+
+	"github.com/sol-strategies/solana-validator-version-sync/internal/schemagen"
+)
+
+func main() {
+	docs, err := schemagen.ParseFieldDocs(".", "../sync_commands", "../secrets")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	schema, err := schemagen.Generate(docs)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := os.WriteFile("schema.json", schema, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,40 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SFDPParticipant represents the configuration for looking up the validator's SFDP participant stage on every
+// sync, exposed to commands and notifications, and tracking SFDP compliant releases without holds in some stages
+type SFDPParticipant struct {
+	// Enabled looks up the SFDP participant stage of the validator's active identity on every sync
+	Enabled bool `koanf:"enabled"`
+	// StrictStages are participant stages (e.g. Approved) in which activation is never held by sync.reference_validator, sync.adoption_gate or sync.stake_activation - requires sync.enable_sfdp_compliance
+	StrictStages []string `koanf:"strict_stages"`
+}
+
+// Validate validates the SFDP participant configuration
+func (s *SFDPParticipant) Validate() error {
+	if !s.Enabled {
+		return nil
+	}
+
+	for i, stage := range s.StrictStages {
+		if strings.TrimSpace(stage) == "" {
+			return fmt.Errorf("sync.sfdp_participant.strict_stages[%d] must not be empty", i)
+		}
+	}
+
+	return nil
+}
+
+// IsStrictStage returns true when stage is one of the strict stages, compared case-insensitively
+func (s *SFDPParticipant) IsStrictStage(stage string) bool {
+	for _, strictStage := range s.StrictStages {
+		if strings.EqualFold(strictStage, stage) {
+			return true
+		}
+	}
+	return false
+}
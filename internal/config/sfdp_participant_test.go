@@ -0,0 +1,65 @@
+package config
+
+import "testing"
+
+func TestSFDPParticipant_Validate(t *testing.T) {
+	tests := []struct {
+		name            string
+		sfdpParticipant SFDPParticipant
+		wantErr         bool
+	}{
+		{
+			name:            "disabled",
+			sfdpParticipant: SFDPParticipant{StrictStages: []string{""}},
+			wantErr:         false,
+		},
+		{
+			name:            "enabled without strict stages",
+			sfdpParticipant: SFDPParticipant{Enabled: true},
+			wantErr:         false,
+		},
+		{
+			name:            "enabled with strict stages",
+			sfdpParticipant: SFDPParticipant{Enabled: true, StrictStages: []string{"Approved"}},
+			wantErr:         false,
+		},
+		{
+			name:            "empty strict stage",
+			sfdpParticipant: SFDPParticipant{Enabled: true, StrictStages: []string{"Approved", " "}},
+			wantErr:         true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.sfdpParticipant.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("SFDPParticipant.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSFDPParticipant_IsStrictStage(t *testing.T) {
+	tests := []struct {
+		name         string
+		strictStages []string
+		stage        string
+		want         bool
+	}{
+		{name: "no strict stages", stage: "Approved", want: false},
+		{name: "strict stage", strictStages: []string{"Approved"}, stage: "Approved", want: true},
+		{name: "strict stage in other case", strictStages: []string{"approved"}, stage: "Approved", want: true},
+		{name: "other stage", strictStages: []string{"Approved"}, stage: "Pending", want: false},
+		{name: "unknown stage", strictStages: []string{"Approved"}, stage: "", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := SFDPParticipant{Enabled: true, StrictStages: tt.strictStages}
+			if got := s.IsStrictStage(tt.stage); got != tt.want {
+				t.Errorf("SFDPParticipant.IsStrictStage(%q) = %v, want %v", tt.stage, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+// SlotTrigger represents the slot-height based execution trigger configuration
+type SlotTrigger struct {
+	// Enabled waits for the trigger slot to be reached before executing sync commands
+	Enabled bool `koanf:"enabled"`
+	// Slot is an absolute slot to execute at - when 0, the epoch boundary plus EpochBoundaryOffset is used
+	Slot uint64 `koanf:"slot"`
+	// EpochBoundaryOffset is the number of slots after an epoch boundary to execute at
+	EpochBoundaryOffset uint64 `koanf:"epoch_boundary_offset"`
+	// PollInterval is how often the validator's slot is polled while waiting, defaults to 2s
+	PollInterval time.Duration `koanf:"poll_interval"`
+	// MaxWait is the longest to wait for the trigger slot before giving up, 0 waits indefinitely
+	MaxWait time.Duration `koanf:"max_wait"`
+}
+
+// Validate validates the slot trigger configuration
+func (s *SlotTrigger) Validate() error {
+	if !s.Enabled {
+		return nil
+	}
+
+	if s.PollInterval <= 0 {
+		return fmt.Errorf("sync.slot_trigger.poll_interval must be greater than 0 - got: %s", s.PollInterval)
+	}
+
+	if s.MaxWait < 0 {
+		return fmt.Errorf("sync.slot_trigger.max_wait must not be negative - got: %s", s.MaxWait)
+	}
+
+	return nil
+}
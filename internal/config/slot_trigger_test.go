@@ -0,0 +1,64 @@
+package config
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSlotTrigger_Validate(t *testing.T) {
+	tests := []struct {
+		name        string
+		slotTrigger SlotTrigger
+		wantErr     bool
+	}{
+		{
+			name:        "disabled trigger is not validated",
+			slotTrigger: SlotTrigger{},
+			wantErr:     false,
+		},
+		{
+			name: "valid epoch boundary trigger",
+			slotTrigger: SlotTrigger{
+				Enabled:             true,
+				EpochBoundaryOffset: 100,
+				PollInterval:        2 * time.Second,
+			},
+			wantErr: false,
+		},
+		{
+			name: "valid absolute slot trigger with max wait",
+			slotTrigger: SlotTrigger{
+				Enabled:      true,
+				Slot:         345678901,
+				PollInterval: time.Second,
+				MaxWait:      time.Hour,
+			},
+			wantErr: false,
+		},
+		{
+			name: "enabled trigger without poll interval",
+			slotTrigger: SlotTrigger{
+				Enabled: true,
+			},
+			wantErr: true,
+		},
+		{
+			name: "negative max wait",
+			slotTrigger: SlotTrigger{
+				Enabled:      true,
+				PollInterval: time.Second,
+				MaxWait:      -time.Second,
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.slotTrigger.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("SlotTrigger.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
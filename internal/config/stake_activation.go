@@ -0,0 +1,44 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// StakeActivation represents the configuration for deferring upgrades while large stake activations or
+// deactivations for the validator's vote account are pending in the current epoch - restarts during such
+// transitions are higher risk. Upgrades to leave a running version below the SFDP minimum are never deferred.
+type StakeActivation struct {
+	// Enabled defers upgrades while pending stake changes exceed MaxPendingStakeSOL
+	Enabled bool `koanf:"enabled"`
+	// VoteAccount is the validator's vote account public key - looked up from the active identity when empty
+	VoteAccount string `koanf:"vote_account"`
+	// RPCURL is the RPC URL stake accounts are queried from (getProgramAccounts must be enabled) - validator.rpc_url when empty
+	RPCURL string `koanf:"rpc_url"`
+	// MaxPendingStakeSOL is the most activating plus deactivating stake, in SOL, tolerated before upgrades are deferred
+	MaxPendingStakeSOL float64 `koanf:"max_pending_stake_sol"`
+}
+
+// Validate validates the stake activation configuration
+func (s *StakeActivation) Validate() error {
+	if !s.Enabled {
+		return nil
+	}
+
+	if s.VoteAccount != "" && !base58PublicKeyRegex.MatchString(s.VoteAccount) {
+		return fmt.Errorf("sync.stake_activation.vote_account must be a base58 encoded public key - got: %s", s.VoteAccount)
+	}
+
+	if s.RPCURL != "" {
+		parsedURL, err := url.Parse(s.RPCURL)
+		if err != nil || (parsedURL.Scheme != "http" && parsedURL.Scheme != "https") || parsedURL.Host == "" {
+			return fmt.Errorf("sync.stake_activation.rpc_url must be a valid http(s) URL - got: %s", s.RPCURL)
+		}
+	}
+
+	if s.MaxPendingStakeSOL < 0 {
+		return fmt.Errorf("sync.stake_activation.max_pending_stake_sol must be 0 or greater - got: %v", s.MaxPendingStakeSOL)
+	}
+
+	return nil
+}
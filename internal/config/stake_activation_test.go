@@ -0,0 +1,56 @@
+package config
+
+import "testing"
+
+func TestStakeActivation_Validate(t *testing.T) {
+	tests := []struct {
+		name            string
+		stakeActivation StakeActivation
+		wantErr         bool
+	}{
+		{
+			name:            "disabled",
+			stakeActivation: StakeActivation{VoteAccount: "not-a-key"},
+			wantErr:         false,
+		},
+		{
+			name:            "enabled with defaults",
+			stakeActivation: StakeActivation{Enabled: true, MaxPendingStakeSOL: 10000},
+			wantErr:         false,
+		},
+		{
+			name: "enabled with vote account and rpc url",
+			stakeActivation: StakeActivation{
+				Enabled:            true,
+				VoteAccount:        "9WzDXwBbmkg8ZTbNMqUxvQRAyrZzDsGYdLVL9zYtAWWM",
+				RPCURL:             "https://api.testnet.solana.com",
+				MaxPendingStakeSOL: 10000,
+			},
+			wantErr: false,
+		},
+		{
+			name:            "invalid vote account",
+			stakeActivation: StakeActivation{Enabled: true, VoteAccount: "not-a-key", MaxPendingStakeSOL: 10000},
+			wantErr:         true,
+		},
+		{
+			name:            "invalid rpc url",
+			stakeActivation: StakeActivation{Enabled: true, RPCURL: "not a url", MaxPendingStakeSOL: 10000},
+			wantErr:         true,
+		},
+		{
+			name:            "negative max pending stake",
+			stakeActivation: StakeActivation{Enabled: true, MaxPendingStakeSOL: -1},
+			wantErr:         true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.stakeActivation.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("StakeActivation.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,7 @@
+package config
+
+// State represents the sync state persistence configuration
+type State struct {
+	// File is the path sync state is persisted to across runs - when empty, state is only kept in memory
+	File string `koanf:"file"`
+}
@@ -0,0 +1,13 @@
+package config
+
+import "testing"
+
+func TestState_StructFields(t *testing.T) {
+	state := State{
+		File: "/var/lib/solana-validator-version-sync/state.json",
+	}
+
+	if state.File != "/var/lib/solana-validator-version-sync/state.json" {
+		t.Errorf("Expected File to be /var/lib/solana-validator-version-sync/state.json, got %s", state.File)
+	}
+}
@@ -2,12 +2,26 @@ package config
 
 import (
 	"fmt"
+	"slices"
 
-	"github.com/charmbracelet/log"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/logging"
 	"github.com/sol-strategies/solana-validator-version-sync/internal/sync_commands"
 )
 
-var syncValidationLogger = log.WithPrefix("config")
+var syncValidationLogger = logging.WithPrefix("config")
+
+const (
+	// OnUnknownRoleError fails syncs of a validator running neither the active nor the passive identity
+	OnUnknownRoleError = "error"
+	// OnUnknownRoleSkip skips syncs of a validator running neither the active nor the passive identity
+	OnUnknownRoleSkip = "skip"
+	// OnUnknownRoleTreatAsPassive syncs a validator running neither the active nor the passive identity as if it
+	// were passive
+	OnUnknownRoleTreatAsPassive = "treat_as_passive"
+)
+
+// OnUnknownRoleValues are the valid sync.on_unknown_role values
+var OnUnknownRoleValues = []string{OnUnknownRoleError, OnUnknownRoleSkip, OnUnknownRoleTreatAsPassive}
 
 // Sync represents the version sync configuration
 type Sync struct {
@@ -17,6 +31,52 @@ type Sync struct {
 	EnabledWhenNoActiveLeaderInGossip bool `koanf:"enabled_when_no_active_leader_in_gossip"`
 	// EnableSFDPCompliance enables SFDP compliance checking
 	EnableSFDPCompliance bool `koanf:"enable_sfdp_compliance"`
+	// SFDPRequired fails syncs when SFDP requirements can't be looked up, otherwise they proceed without the SFDP
+	// clamp and the decision marks sfdp unavailable - defaults to true
+	SFDPRequired bool `koanf:"sfdp_required"`
+	// OnUnknownRole decides what syncs do when the validator runs neither the active, the passive nor any of the
+	// validator.identities.others identities - one of error, skip, treat_as_passive, defaults to error
+	OnUnknownRole string `koanf:"on_unknown_role"`
+	// OnOtherRole decides what syncs do when the validator runs one of validator.identities.others, keyed by its
+	// name - one of error, skip, treat_as_passive, defaults to on_unknown_role
+	OnOtherRole map[string]string `koanf:"on_other_role"`
+	// PreferMainnetVersion makes testnet validators target a newer mainnet version over the latest testnet version, defaults to true
+	PreferMainnetVersion bool `koanf:"prefer_mainnet_version"`
+	// SlotTrigger delays command execution until a given slot is reached
+	SlotTrigger SlotTrigger `koanf:"slot_trigger"`
+	// ReferenceValidator is a validator that must already run a target version or newer before syncing to it
+	ReferenceValidator ReferenceValidator `koanf:"reference_validator"`
+	// ReleaseFloor skips releases too far below the running version when looking up the target version
+	ReleaseFloor ReleaseFloor `koanf:"release_floor"`
+	// ReleaseFeed revalidates cached release listings against the repo's Atom feeds before calling the GitHub API
+	ReleaseFeed ReleaseFeed `koanf:"release_feed"`
+	// RequestBudget limits GitHub and SFDP API requests per hour, degrading to the data last fetched when exhausted
+	RequestBudget RequestBudget `koanf:"request_budget"`
+	// FlapDetection suppresses syncs while the validator's health or role keeps changing
+	FlapDetection FlapDetection `koanf:"flap_detection"`
+	// StakeActivation defers upgrades while large stake changes are pending for the validator's vote account
+	StakeActivation StakeActivation `koanf:"stake_activation"`
+	// AdoptionGate holds activation until enough of the cluster's stake runs the target version
+	AdoptionGate AdoptionGate `koanf:"adoption_gate"`
+	// SFDPParticipant looks up the validator's SFDP participant stage, tracking SFDP compliant releases without holds in strict stages
+	SFDPParticipant SFDPParticipant `koanf:"sfdp_participant"`
+	// HeadsUp notifies once when a new sync target is first detected, ahead of any gate holding activation
+	HeadsUp HeadsUp `koanf:"heads_up"`
+	// Nomad activates syncs by bumping a Nomad job's meta to the target version, triggering its deployment
+	Nomad Nomad `koanf:"nomad"`
+	// ClusterRestart targets the version of a published cluster restart manifest and exposes its fields to commands
+	ClusterRestart ClusterRestart `koanf:"cluster_restart"`
+	// ConfigRevision syncs configuration-only changes tracked by a config-version file or an ops repo's git SHA
+	ConfigRevision ConfigRevision `koanf:"config_revision"`
+	// BinaryCheck aborts syncs before restarting when the installed target binary doesn't report the target version
+	BinaryCheck BinaryCheck `koanf:"binary_check"`
+	// Recipe selects a curated command set shipped with the binary instead of writing commands, e.g. agave-default
+	Recipe string `koanf:"recipe"`
+	// RecipeOptions are the values the selected recipe is parameterized with
+	RecipeOptions RecipeOptions `koanf:"recipe_options"`
+	// EnvironmentPolicy filters the inherited environment passed to commands with inherit_environment enabled -
+	// commands with their own environment_policy use it instead
+	EnvironmentPolicy sync_commands.EnvironmentPolicy `koanf:"environment_policy"`
 	// Commands are the commands to run when there is a version change
 	Commands []sync_commands.Command `koanf:"commands"`
 }
@@ -28,8 +88,102 @@ func (s *Sync) SetDefaults() {
 
 // Validate validates the sync configuration
 func (s *Sync) Validate() error {
+	if s.OnUnknownRole != "" && !slices.Contains(OnUnknownRoleValues, s.OnUnknownRole) {
+		return fmt.Errorf("sync.on_unknown_role must be one of %v - got: %s", OnUnknownRoleValues, s.OnUnknownRole)
+	}
+	for name, onOtherRole := range s.OnOtherRole {
+		if !slices.Contains(OnUnknownRoleValues, onOtherRole) {
+			return fmt.Errorf("sync.on_other_role.%s must be one of %v - got: %s", name, OnUnknownRoleValues, onOtherRole)
+		}
+	}
+
+	if err := s.SlotTrigger.Validate(); err != nil {
+		return err
+	}
+
+	if err := s.ReferenceValidator.Validate(); err != nil {
+		return err
+	}
+
+	if err := s.ReleaseFloor.Validate(); err != nil {
+		return err
+	}
+
+	if err := s.ReleaseFeed.Validate(); err != nil {
+		return err
+	}
+
+	if err := s.RequestBudget.Validate(); err != nil {
+		return err
+	}
+
+	if err := s.FlapDetection.Validate(); err != nil {
+		return err
+	}
+
+	if err := s.StakeActivation.Validate(); err != nil {
+		return err
+	}
+
+	if err := s.AdoptionGate.Validate(); err != nil {
+		return err
+	}
+
+	if err := s.SFDPParticipant.Validate(); err != nil {
+		return err
+	}
+	if s.SFDPParticipant.Enabled && len(s.SFDPParticipant.StrictStages) > 0 && !s.EnableSFDPCompliance {
+		return fmt.Errorf("sync.sfdp_participant.strict_stages requires sync.enable_sfdp_compliance")
+	}
+
+	if err := s.HeadsUp.Validate(); err != nil {
+		return err
+	}
+
+	if err := s.Nomad.Validate(); err != nil {
+		return err
+	}
+
+	if err := s.ClusterRestart.Validate(); err != nil {
+		return err
+	}
+
+	if err := s.ConfigRevision.Validate(); err != nil {
+		return err
+	}
+
+	if err := s.BinaryCheck.Validate(); err != nil {
+		return err
+	}
+	if s.BinaryCheck.Enabled {
+		index := slices.IndexFunc(s.Commands, func(command sync_commands.Command) bool {
+			return command.Name == s.BinaryCheck.BeforeCommand
+		})
+		if index < 0 {
+			return fmt.Errorf("sync.binary_check.before_command must be the name of a sync command - got: %s", s.BinaryCheck.BeforeCommand)
+		}
+		if phase := s.Commands[index].Phase; phase != "" && phase != sync_commands.PhaseActivate {
+			return fmt.Errorf("sync.binary_check.before_command must be an %s command - got: %s command %s", sync_commands.PhaseActivate, phase, s.BinaryCheck.BeforeCommand)
+		}
+	}
+
+	if err := s.EnvironmentPolicy.Validate(); err != nil {
+		return fmt.Errorf("sync.environment_policy.%w", err)
+	}
+
+	// parse and dry-render every command so template mistakes fail at startup rather than mid-sync
+	for i := range s.Commands {
+		command := s.Commands[i]
+		if err := command.Parse(); err != nil {
+			return fmt.Errorf("sync.commands[%d] (%s): %w", i, command.Name, err)
+		}
+		if err := command.DryRender(); err != nil {
+			return fmt.Errorf("sync.commands[%d] (%s): %w", i, command.Name, err)
+		}
+	}
+
 	for i, command := range s.Commands {
-		if len(command.Environment) == 0 || command.InheritEnvironment {
+		if len(command.Environment)+len(command.Secrets) == 0 || command.InheritEnvironment {
 			continue
 		}
 
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/charmbracelet/log"
 	"github.com/sol-strategies/solana-validator-version-sync/internal/sync_commands"
@@ -62,6 +63,59 @@ func TestSync_Validate(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "sync with valid environment policy",
+			sync: Sync{
+				EnvironmentPolicy: sync_commands.EnvironmentPolicy{Allow: []string{"PATH", "LC_*"}, Deny: []string{"AWS_*"}},
+			},
+			wantErr: false,
+		},
+		{
+			name: "sfdp participant strict stages with SFDP compliance",
+			sync: Sync{
+				EnableSFDPCompliance: true,
+				SFDPParticipant:      SFDPParticipant{Enabled: true, StrictStages: []string{"Approved"}},
+			},
+			wantErr: false,
+		},
+		{
+			name: "sfdp participant strict stages without SFDP compliance",
+			sync: Sync{
+				SFDPParticipant: SFDPParticipant{Enabled: true, StrictStages: []string{"Approved"}},
+			},
+			wantErr: true,
+		},
+		{
+			name:    "on unknown role treat as passive",
+			sync:    Sync{OnUnknownRole: OnUnknownRoleTreatAsPassive},
+			wantErr: false,
+		},
+		{
+			name:    "invalid on other role",
+			sync:    Sync{OnOtherRole: map[string]string{"maintenance": "ignore"}},
+			wantErr: true,
+		},
+		{
+			name:    "invalid on unknown role",
+			sync:    Sync{OnUnknownRole: "ignore"},
+			wantErr: true,
+		},
+		{
+			name: "sync with invalid environment policy",
+			sync: Sync{
+				EnvironmentPolicy: sync_commands.EnvironmentPolicy{Deny: []string{"AWS_["}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "command with invalid environment policy",
+			sync: Sync{
+				Commands: []sync_commands.Command{
+					{Name: "build", Cmd: "build.sh", EnvironmentPolicy: &sync_commands.EnvironmentPolicy{Allow: []string{"LC_["}}},
+				},
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -87,6 +141,7 @@ func TestSync_Validate_WarnsWhenEnvironmentConfiguredWithoutInheritance(t *testi
 		Commands: []sync_commands.Command{
 			{
 				Name: "build",
+				Cmd:  "/home/solana/scripts/build-solana.sh",
 				Environment: map[string]string{
 					"TO_VERSION": "1.2.3",
 				},
@@ -121,6 +176,7 @@ func TestSync_Validate_DoesNotWarnWhenEnvironmentInheritanceEnabled(t *testing.T
 		Commands: []sync_commands.Command{
 			{
 				Name: "build",
+				Cmd:  "/home/solana/scripts/build-solana.sh",
 				Environment: map[string]string{
 					"TO_VERSION": "1.2.3",
 				},
@@ -177,3 +233,94 @@ func TestSync_StructFields(t *testing.T) {
 		t.Errorf("Expected Commands to be empty, got %v", len(sync.Commands))
 	}
 }
+
+func TestSync_Validate_DryRendersCommandTemplates(t *testing.T) {
+	tests := []struct {
+		name        string
+		command     sync_commands.Command
+		wantErrPart string
+	}{
+		{
+			name: "valid templates",
+			command: sync_commands.Command{
+				Name: "install",
+				Cmd:  "/home/solana/scripts/install.sh",
+				Args: []string{"{{ .VersionToTag }}", "{{ if .ValidatorRoleIsPassive }}--passive{{ end }}"},
+			},
+		},
+		{
+			name: "typo in arg template field",
+			command: sync_commands.Command{
+				Name: "install",
+				Cmd:  "/home/solana/scripts/install.sh",
+				Args: []string{"{{ .VersionTo }}", "{{ .VersonTo }}"},
+			},
+			wantErrPart: "sync.commands[0] (install): failed to render arg[1]",
+		},
+		{
+			name: "typo in environment template field",
+			command: sync_commands.Command{
+				Name:               "install",
+				Cmd:                "/home/solana/scripts/install.sh",
+				Environment:        map[string]string{"CLUSTER": "{{ .Cluster }}"},
+				InheritEnvironment: true,
+			},
+			wantErrPart: "failed to render env[CLUSTER]",
+		},
+		{
+			name: "invalid command",
+			command: sync_commands.Command{
+				Name: "install",
+			},
+			wantErrPart: "sync.commands[0] (install): command cmd is required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sync := Sync{Commands: []sync_commands.Command{tt.command}}
+			err := sync.Validate()
+			if tt.wantErrPart == "" {
+				if err != nil {
+					t.Fatalf("Sync.Validate() error = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErrPart) {
+				t.Fatalf("Sync.Validate() error = %v, want error containing %q", err, tt.wantErrPart)
+			}
+		})
+	}
+}
+
+func TestSync_Validate_BinaryCheckBeforeCommand(t *testing.T) {
+	commands := []sync_commands.Command{
+		{Name: "download", Cmd: "/home/solana/scripts/download.sh", Phase: sync_commands.PhasePrepare},
+		{Name: "install", Cmd: "/home/solana/scripts/install.sh"},
+		{Name: "restart", Cmd: "systemctl", Args: []string{"restart", "solana-validator"}, Phase: sync_commands.PhaseActivate},
+	}
+
+	tests := []struct {
+		name          string
+		beforeCommand string
+		wantErr       bool
+	}{
+		{name: "activate command", beforeCommand: "restart"},
+		{name: "command with default phase", beforeCommand: "install"},
+		{name: "prepare command", beforeCommand: "download", wantErr: true},
+		{name: "unknown command", beforeCommand: "reboot", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sync := Sync{
+				BinaryCheck: BinaryCheck{Enabled: true, Binary: "/usr/local/bin/agave-validator", BeforeCommand: tt.beforeCommand, Timeout: 30 * time.Second},
+				Commands:    commands,
+			}
+			err := sync.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Sync.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -3,8 +3,11 @@ package config
 import (
 	"fmt"
 	"net/url"
+	"regexp"
+	"slices"
 
 	"github.com/gagliardetto/solana-go"
+	"github.com/hashicorp/go-version"
 	"github.com/sol-strategies/solana-validator-version-sync/internal/constants"
 )
 
@@ -12,15 +15,28 @@ import (
 type Validator struct {
 	// Client is the solana validator client - one of: agave, jito-solana, rakurai-validator, firedancer
 	// The legacy alias "rakurai" is also accepted and normalized to "rakurai-validator".
+	// auto detects the client of the running validator on startup.
 	Client string `koanf:"client"`
 	// RPCURL is the URL of the validator's RPC endpoint
 	RPCURL string `koanf:"rpc_url"`
+	// AdminRPCPath is the path to the validator's admin RPC socket (<ledger>/admin.rpc) - optional, only used
+	// to detect the client when client is auto
+	AdminRPCPath string `koanf:"admin_rpc_path"`
 	// VersionConstraint is the constraint for the client version
 	VersionConstraint string `koanf:"version_constraint"`
+	// DowngradeFloor is the lowest version automated downgrades may target, e.g. after the SFDP max version was
+	// lowered - downgrades below it are refused even when version_constraint and SFDP would allow them
+	DowngradeFloor string `koanf:"downgrade_floor"`
 	// Identities are the paths to the active and passive identity keyfiles
 	Identities Identities `koanf:"identities"`
 }
 
+// reservedRoleNames are the role names other identities can't be named
+var reservedRoleNames = []string{"active", "passive", "unknown"}
+
+// otherIdentityNamePattern is the pattern other identity names must match, as they are reported as the role
+var otherIdentityNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_-]*$`)
+
 // Identities represents the validator identity configuration
 type Identities struct {
 	// Active is the path to the active identity keyfile
@@ -31,6 +47,11 @@ type Identities struct {
 	ActiveKeyPair solana.PrivateKey `koanf:"-"`
 	// PassiveKeyPair is the loaded passive keypair
 	PassiveKeyPair solana.PrivateKey `koanf:"-"`
+	// Others are the paths to the keyfiles of additional identities the validator may run, keyed by
+	// name, e.g. maintenance - the validator is reported with the name as its role instead of unknown
+	OtherKeyPairFiles map[string]string `koanf:"others"`
+	// OtherKeyPairs are the loaded other keypairs, keyed by name
+	OtherKeyPairs map[string]solana.PrivateKey `koanf:"-"`
 }
 
 // Load loads the identity keypairs from files
@@ -48,24 +69,78 @@ func (i *Identities) Load() (err error) {
 		return fmt.Errorf("failed to load passive keypair from %s: %w", i.PassiveKeyPairFile, err)
 	}
 
+	// Load other identities - each must be distinct from active, passive and the other identities so the
+	// running identity maps to one role
+	i.OtherKeyPairs = make(map[string]solana.PrivateKey, len(i.OtherKeyPairFiles))
+	publicKeyNames := map[solana.PublicKey]string{
+		i.ActiveKeyPair.PublicKey():  "active",
+		i.PassiveKeyPair.PublicKey(): "passive",
+	}
+	names := make([]string, 0, len(i.OtherKeyPairFiles))
+	for name := range i.OtherKeyPairFiles {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		keyPair, err := solana.PrivateKeyFromSolanaKeygenFile(i.OtherKeyPairFiles[name])
+		if err != nil {
+			return fmt.Errorf("failed to load %s keypair from %s: %w", name, i.OtherKeyPairFiles[name], err)
+		}
+		if existing, ok := publicKeyNames[keyPair.PublicKey()]; ok {
+			return fmt.Errorf("validator.identities.others.%s is the same identity as %s - got: %s", name, existing, keyPair.PublicKey())
+		}
+		publicKeyNames[keyPair.PublicKey()] = name
+		i.OtherKeyPairs[name] = keyPair
+	}
+
+	return nil
+}
+
+// Validate validates the identity configuration
+func (i *Identities) Validate() error {
+	for name := range i.OtherKeyPairFiles {
+		if slices.Contains(reservedRoleNames, name) {
+			return fmt.Errorf("validator.identities.others.%s: name must not be one of %v", name, reservedRoleNames)
+		}
+		if !otherIdentityNamePattern.MatchString(name) {
+			return fmt.Errorf("validator.identities.others.%s: name must be lowercase letters, digits, - and _, starting with a letter", name)
+		}
+	}
+
 	return nil
 }
 
 // Validate validates the validator configuration
 func (v *Validator) Validate() error {
-	// Validate client
-	normalizedClient := constants.NormalizeClientName(v.Client)
-	err := constants.ValidateClientName(normalizedClient)
-	if err != nil {
-		return err
+	// Validate client - auto is resolved when the validator is created
+	if v.Client != constants.ClientNameAuto {
+		normalizedClient := constants.NormalizeClientName(v.Client)
+		err := constants.ValidateClientName(normalizedClient)
+		if err != nil {
+			return err
+		}
+		v.Client = normalizedClient
 	}
-	v.Client = normalizedClient
 
 	// Validate RPC URL
-	_, err = url.Parse(v.RPCURL)
+	_, err := url.Parse(v.RPCURL)
 	if err != nil {
 		return fmt.Errorf("validator.rpc_url %s is not a valid URL: %w", v.RPCURL, err)
 	}
 
+	// Validate identities
+	err = v.Identities.Validate()
+	if err != nil {
+		return err
+	}
+
+	// Validate downgrade floor
+	if v.DowngradeFloor != "" {
+		_, err = version.NewVersion(v.DowngradeFloor)
+		if err != nil {
+			return fmt.Errorf("validator.downgrade_floor must be a version, e.g. 2.3.6 - got: %s", v.DowngradeFloor)
+		}
+	}
+
 	return nil
 }
@@ -61,6 +61,60 @@ func TestValidator_Validate(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "auto client",
+			validator: Validator{
+				Client:       constants.ClientNameAuto,
+				RPCURL:       "http://127.0.0.1:8899",
+				AdminRPCPath: "/mnt/ledger/admin.rpc",
+			},
+			wantErr: false,
+		},
+		{
+			name: "valid downgrade floor",
+			validator: Validator{
+				Client:         constants.ClientNameAgave,
+				RPCURL:         "http://localhost:8899",
+				DowngradeFloor: "2.3.6",
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid downgrade floor",
+			validator: Validator{
+				Client:         constants.ClientNameAgave,
+				RPCURL:         "http://localhost:8899",
+				DowngradeFloor: ">= 2.3.6",
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid other identity",
+			validator: Validator{
+				Client:     constants.ClientNameAgave,
+				RPCURL:     "http://localhost:8899",
+				Identities: Identities{OtherKeyPairFiles: map[string]string{"maintenance": "/path/to/maintenance.json"}},
+			},
+			wantErr: false,
+		},
+		{
+			name: "other identity with a reserved name",
+			validator: Validator{
+				Client:     constants.ClientNameAgave,
+				RPCURL:     "http://localhost:8899",
+				Identities: Identities{OtherKeyPairFiles: map[string]string{"passive": "/path/to/passive.json"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "other identity with an invalid name",
+			validator: Validator{
+				Client:     constants.ClientNameAgave,
+				RPCURL:     "http://localhost:8899",
+				Identities: Identities{OtherKeyPairFiles: map[string]string{"Spare Key": "/path/to/spare.json"}},
+			},
+			wantErr: true,
+		},
 		{
 			name: "invalid client name",
 			validator: Validator{
@@ -123,6 +177,12 @@ func TestIdentities_Load(t *testing.T) {
 		t.Fatalf("Failed to create passive keypair file: %v", err)
 	}
 
+	maintenanceKeyFile := filepath.Join(tempDir, "maintenance-keypair.json")
+	err = writeKeypairFile(maintenanceKeyFile, solana.NewWallet().PrivateKey)
+	if err != nil {
+		t.Fatalf("Failed to create maintenance keypair file: %v", err)
+	}
+
 	tests := []struct {
 		name       string
 		identities Identities
@@ -136,6 +196,33 @@ func TestIdentities_Load(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "valid other keypair files",
+			identities: Identities{
+				ActiveKeyPairFile:  activeKeyFile,
+				PassiveKeyPairFile: passiveKeyFile,
+				OtherKeyPairFiles:  map[string]string{"maintenance": maintenanceKeyFile},
+			},
+			wantErr: false,
+		},
+		{
+			name: "other keypair file is the passive identity",
+			identities: Identities{
+				ActiveKeyPairFile:  activeKeyFile,
+				PassiveKeyPairFile: passiveKeyFile,
+				OtherKeyPairFiles:  map[string]string{"maintenance": maintenanceKeyFile, "spare": passiveKeyFile},
+			},
+			wantErr: true,
+		},
+		{
+			name: "non-existent other keypair file",
+			identities: Identities{
+				ActiveKeyPairFile:  activeKeyFile,
+				PassiveKeyPairFile: passiveKeyFile,
+				OtherKeyPairFiles:  map[string]string{"maintenance": "/non/existent/maintenance.json"},
+			},
+			wantErr: true,
+		},
 		{
 			name: "non-existent active keypair file",
 			identities: Identities{
@@ -177,6 +264,11 @@ func TestIdentities_Load(t *testing.T) {
 				if tt.identities.PassiveKeyPair == nil {
 					t.Error("PassiveKeyPair should be loaded")
 				}
+				for name := range tt.identities.OtherKeyPairFiles {
+					if tt.identities.OtherKeyPairs[name] == nil {
+						t.Errorf("OtherKeyPairs[%s] should be loaded", name)
+					}
+				}
 			}
 		})
 	}
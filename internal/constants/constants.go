@@ -15,6 +15,8 @@ const (
 	ClientNameRakurai = "rakurai-validator"
 	// ClientNameFiredancer is the name of the Firedancer client
 	ClientNameFiredancer = "firedancer"
+	// ClientNameAuto detects the client of the running validator instead of naming it
+	ClientNameAuto = "auto"
 	// ClusterNameMainnetBeta is the name of the Mainnet Beta cluster
 	ClusterNameMainnetBeta = "mainnet-beta"
 	// ClusterNameTestnet is the name of the Testnet cluster
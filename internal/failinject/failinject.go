@@ -0,0 +1,107 @@
+package failinject
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/log"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/logging"
+)
+
+const (
+	// StageRefresh fails refreshing the validator's state from its RPC
+	StageRefresh = "refresh"
+	// StageReleaseLookup fails looking up the latest client release
+	StageReleaseLookup = "release-lookup"
+	// StageSFDP fails fetching the SFDP requirements
+	StageSFDP = "sfdp"
+	// StagePrepare fails before running the prepare phase commands
+	StagePrepare = "prepare"
+	// StageVerify fails after the prepare phase commands ran, before the prepared target is recorded
+	StageVerify = "verify"
+	// StageSlotTrigger fails waiting for the trigger slot
+	StageSlotTrigger = "slot-trigger"
+	// stageCommandPrefix fails the Nth (1-based) configured command, e.g. command:2
+	stageCommandPrefix = "command:"
+)
+
+// ValidStages is a list of valid stages failures can be injected at, besides command:N
+var ValidStages = []string{StageRefresh, StageReleaseLookup, StageSFDP, StagePrepare, StageVerify, StageSlotTrigger}
+
+// ErrInjected is returned (wrapped) for every injected failure
+var ErrInjected = errors.New("injected failure")
+
+// Injector deterministically injects failures at configured stages so failure handling
+// can be rehearsed - a nil Injector never injects anything
+type Injector struct {
+	stages map[string]struct{}
+	logger *log.Logger
+}
+
+// New creates a new Injector from a list of stages, e.g. []string{"sfdp", "command:2"}
+func New(stages []string) (i *Injector, err error) {
+	i = &Injector{
+		stages: make(map[string]struct{}),
+		logger: logging.WithPrefix("failinject"),
+	}
+
+	for _, stage := range stages {
+		stage = strings.TrimSpace(stage)
+		if stage == "" {
+			continue
+		}
+		if err := validateStage(stage); err != nil {
+			return nil, err
+		}
+		i.stages[stage] = struct{}{}
+	}
+
+	if len(i.stages) > 0 {
+		i.logger.Warn("failure injection enabled - syncs will fail deliberately", "stages", stages)
+	}
+
+	return i, nil
+}
+
+// Check returns an injected failure error when a failure is configured for the given stage
+func (i *Injector) Check(stage string) error {
+	if i == nil {
+		return nil
+	}
+	if _, ok := i.stages[stage]; !ok {
+		return nil
+	}
+	i.logger.Warn("injecting failure", "stage", stage)
+	return fmt.Errorf("%w at stage %s", ErrInjected, stage)
+}
+
+// CheckCommand returns an injected failure error when a failure is configured for the command at the given zero-based index
+func (i *Injector) CheckCommand(commandIndex int) error {
+	return i.Check(CommandStage(commandIndex))
+}
+
+// CommandStage returns the stage name for the command at the given zero-based index
+func CommandStage(commandIndex int) string {
+	return stageCommandPrefix + strconv.Itoa(commandIndex+1)
+}
+
+// validateStage validates a stage name
+func validateStage(stage string) error {
+	for _, validStage := range ValidStages {
+		if stage == validStage {
+			return nil
+		}
+	}
+
+	if commandNumber, ok := strings.CutPrefix(stage, stageCommandPrefix); ok {
+		n, err := strconv.Atoi(commandNumber)
+		if err != nil || n < 1 {
+			return fmt.Errorf("invalid failure injection stage %s - command number must be a positive integer", stage)
+		}
+		return nil
+	}
+
+	return fmt.Errorf("invalid failure injection stage: %s - must be one of %s, %sN", stage, strings.Join(ValidStages, ", "), stageCommandPrefix)
+}
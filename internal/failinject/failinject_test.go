@@ -0,0 +1,95 @@
+package failinject
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name    string
+		stages  []string
+		wantErr bool
+	}{
+		{
+			name:    "no stages",
+			stages:  nil,
+			wantErr: false,
+		},
+		{
+			name:    "valid stages",
+			stages:  []string{StageRefresh, StageReleaseLookup, StageSFDP, StagePrepare, StageVerify, StageSlotTrigger, "command:2"},
+			wantErr: false,
+		},
+		{
+			name:    "blank stages are ignored",
+			stages:  []string{" ", ""},
+			wantErr: false,
+		},
+		{
+			name:    "unknown stage",
+			stages:  []string{"upload"},
+			wantErr: true,
+		},
+		{
+			name:    "command without number",
+			stages:  []string{"command:"},
+			wantErr: true,
+		},
+		{
+			name:    "command zero",
+			stages:  []string{"command:0"},
+			wantErr: true,
+		},
+		{
+			name:    "command not a number",
+			stages:  []string{"command:two"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New(tt.stages)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("New() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestInjector_Check(t *testing.T) {
+	injector, err := New([]string{StageSFDP, "command:2"})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	if err := injector.Check(StageSFDP); !errors.Is(err, ErrInjected) {
+		t.Errorf("Check(%s) error = %v, want ErrInjected", StageSFDP, err)
+	}
+	if err := injector.Check(StageRefresh); err != nil {
+		t.Errorf("Check(%s) error = %v, want nil", StageRefresh, err)
+	}
+	if err := injector.CheckCommand(1); !errors.Is(err, ErrInjected) {
+		t.Errorf("CheckCommand(1) error = %v, want ErrInjected", err)
+	}
+	if err := injector.CheckCommand(0); err != nil {
+		t.Errorf("CheckCommand(0) error = %v, want nil", err)
+	}
+}
+
+func TestInjector_NilNeverInjects(t *testing.T) {
+	var injector *Injector
+	if err := injector.Check(StageSFDP); err != nil {
+		t.Errorf("nil Check() error = %v, want nil", err)
+	}
+	if err := injector.CheckCommand(0); err != nil {
+		t.Errorf("nil CheckCommand() error = %v, want nil", err)
+	}
+}
+
+func TestCommandStage(t *testing.T) {
+	if got := CommandStage(0); got != "command:1" {
+		t.Errorf("CommandStage(0) = %s, want command:1", got)
+	}
+}
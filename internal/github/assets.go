@@ -0,0 +1,55 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/sol-strategies/solana-validator-version-sync/internal/constants"
+)
+
+// ReleaseAsset represents a file attached to a client repo release
+type ReleaseAsset struct {
+	// Name is the asset's file name
+	Name string
+	// URL is the asset's browser download URL
+	URL string
+	// Size is the asset's size in bytes
+	Size int64
+	// SHA256 is the hex sha256 digest GitHub publishes for the asset - empty when GitHub reports none, e.g. for
+	// assets uploaded before it started computing digests
+	SHA256 string
+}
+
+// GetReleaseAssets gets the assets of the client repo's release of a tag from its recent releases - none for
+// clients published as tags only (e.g. rakurai) or a release not among the recent releases
+func (c *Client) GetReleaseAssets(tagName string) (assets []ReleaseAsset, err error) {
+	if c.clientName == constants.ClientNameRakurai {
+		return nil, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	releases, err := c.listReleases(ctx, c.repoOwner, c.repoName, c.releasesPerPage())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get releases: %w", err)
+	}
+
+	for _, release := range releases {
+		if release.GetTagName() != tagName {
+			continue
+		}
+		for _, asset := range release.Assets {
+			assets = append(assets, ReleaseAsset{
+				Name:   asset.GetName(),
+				URL:    asset.GetBrowserDownloadURL(),
+				Size:   int64(asset.GetSize()),
+				SHA256: strings.TrimPrefix(asset.GetDigest(), "sha256:"),
+			})
+		}
+		return assets, nil
+	}
+	return nil, nil
+}
@@ -0,0 +1,87 @@
+package github
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"testing"
+
+	"github.com/google/go-github/v74/github"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/constants"
+)
+
+func TestClient_GetReleaseAssets(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[
+			{"tag_name": "v3.0.11", "assets": []},
+			{"tag_name": "v3.0.10", "assets": [
+				{"name": "solana-release-x86_64-unknown-linux-gnu.tar.bz2", "size": 1024, "digest": "sha256:4f2a9c",
+				 "browser_download_url": "https://github.com/anza-xyz/agave/releases/download/v3.0.10/solana-release-x86_64-unknown-linux-gnu.tar.bz2"},
+				{"name": "solana-release-x86_64-unknown-linux-gnu.yml", "size": 64,
+				 "browser_download_url": "https://github.com/anza-xyz/agave/releases/download/v3.0.10/solana-release-x86_64-unknown-linux-gnu.yml"}
+			]}
+		]`))
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name       string
+		client     string
+		tagName    string
+		wantAssets []ReleaseAsset
+	}{
+		{
+			name:    "release with assets",
+			client:  constants.ClientNameAgave,
+			tagName: "v3.0.10",
+			wantAssets: []ReleaseAsset{
+				{
+					Name:   "solana-release-x86_64-unknown-linux-gnu.tar.bz2",
+					URL:    "https://github.com/anza-xyz/agave/releases/download/v3.0.10/solana-release-x86_64-unknown-linux-gnu.tar.bz2",
+					Size:   1024,
+					SHA256: "4f2a9c",
+				},
+				{
+					Name: "solana-release-x86_64-unknown-linux-gnu.yml",
+					URL:  "https://github.com/anza-xyz/agave/releases/download/v3.0.10/solana-release-x86_64-unknown-linux-gnu.yml",
+					Size: 64,
+				},
+			},
+		},
+		{
+			name:    "release without assets",
+			client:  constants.ClientNameAgave,
+			tagName: "v3.0.11",
+		},
+		{
+			name:    "release not in recent releases",
+			client:  constants.ClientNameAgave,
+			tagName: "v2.3.13",
+		},
+		{
+			name:    "tags only client",
+			client:  constants.ClientNameRakurai,
+			tagName: "v3.0.10",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := NewClient(Options{Cluster: constants.ClusterNameMainnetBeta, Client: tt.client})
+			if err != nil {
+				t.Fatalf("NewClient() error = %v", err)
+			}
+			c.client = github.NewClient(nil)
+			c.client.BaseURL, _ = url.Parse(server.URL + "/")
+
+			assets, err := c.GetReleaseAssets(tt.tagName)
+			if err != nil {
+				t.Fatalf("GetReleaseAssets() error = %v", err)
+			}
+			if !slices.Equal(assets, tt.wantAssets) {
+				t.Errorf("GetReleaseAssets() = %+v, want %+v", assets, tt.wantAssets)
+			}
+		})
+	}
+}
@@ -0,0 +1,185 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/go-github/v74/github"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/budget"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/logging"
+)
+
+// listingCacheTTL is how long release and tag listings are shared between lookups - short enough that every
+// sync interval still sees fresh releases, long enough that lookups made during the same sync share one call
+const listingCacheTTL = 30 * time.Second
+
+var (
+	// sharedAPIClient is the GitHub API client shared by all clients for the daemon lifetime
+	sharedAPIClient = github.NewClient(nil) // No auth token for public repos
+
+	// compiledRegexes memoizes compiled release and tag regexes by pattern
+	compiledRegexes      = make(map[string]*regexp.Regexp)
+	compiledRegexesMutex sync.Mutex
+
+	// sharedClients are the clients returned by SharedClient, keyed by their options
+	sharedClients      = make(map[Options]*Client)
+	sharedClientsMutex sync.Mutex
+
+	// releaseListings and tagListings are the shared release and tag listing caches
+	releaseListings = newListingCache()
+	tagListings     = newListingCache()
+)
+
+// SetAPIBaseURL points the shared GitHub API client at another API, e.g. a fake release server in integration
+// tests - call it before syncing
+func SetAPIBaseURL(baseURL string) error {
+	parsed, err := url.Parse(strings.TrimSuffix(baseURL, "/") + "/")
+	if err != nil || parsed.Scheme == "" || parsed.Host == "" {
+		return fmt.Errorf("invalid GitHub API URL: %s", baseURL)
+	}
+	sharedAPIClient.BaseURL = parsed
+	return nil
+}
+
+// SharedClient returns the client for the given options, creating it on first use so every validator syncing
+// the same client on the same cluster shares one client and its tag caches for the daemon lifetime.
+// Clients are not safe for concurrent use - callers sync validators one at a time.
+func SharedClient(opts Options) (c *Client, err error) {
+	sharedClientsMutex.Lock()
+	defer sharedClientsMutex.Unlock()
+
+	if c, ok := sharedClients[opts]; ok {
+		return c, nil
+	}
+
+	c, err = NewClient(opts)
+	if err != nil {
+		return nil, err
+	}
+	sharedClients[opts] = c
+	return c, nil
+}
+
+// compileRegex compiles a regex pattern once, returning the memoized regex on subsequent calls
+func compileRegex(pattern string) (*regexp.Regexp, error) {
+	compiledRegexesMutex.Lock()
+	defer compiledRegexesMutex.Unlock()
+
+	if compiled, ok := compiledRegexes[pattern]; ok {
+		return compiled, nil
+	}
+
+	compiled, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	compiledRegexes[pattern] = compiled
+	return compiled, nil
+}
+
+// listingCache caches API listings by key for listingCacheTTL
+type listingCache struct {
+	mutex   sync.Mutex
+	entries map[listingCacheKey]listingCacheEntry
+	now     func() time.Time
+}
+
+// listingCacheKey identifies a listing - keyed by API client too, as all clients share sharedAPIClient
+// except when pointed at another API (e.g. in tests)
+type listingCacheKey struct {
+	apiClient *github.Client
+	listing   string
+}
+
+// listingCacheEntry is a cached API listing, when it was fetched and when it was last confirmed current
+type listingCacheEntry struct {
+	listing   interface{}
+	fetchedAt time.Time
+	checkedAt time.Time
+	// feedFingerprint is the fingerprint of the repo's feed when the listing was fetched - empty without a feed
+	feedFingerprint string
+}
+
+// newListingCache creates a new empty listing cache
+func newListingCache() *listingCache {
+	return &listingCache{
+		entries: make(map[listingCacheKey]listingCacheEntry),
+		now:     time.Now,
+	}
+}
+
+// get returns the cached listing for key when fresh, otherwise fetches and caches it - failed fetches are not cached.
+// With a feed, a stale listing is reused without an API call while the feed is unchanged, for up to the feed's
+// max age since it was fetched - feed failures fall back to the API. When the GitHub request budget is exhausted
+// a stale listing is reused with a warning rather than fetched.
+func (lc *listingCache) get(key listingCacheKey, fetch func() (interface{}, error), listingFeed *feed) (interface{}, error) {
+	lc.mutex.Lock()
+	defer lc.mutex.Unlock()
+
+	now := lc.now()
+	entry, cached := lc.entries[key]
+	if cached && now.Sub(entry.checkedAt) < listingCacheTTL {
+		return entry.listing, nil
+	}
+
+	feedFingerprint := ""
+	if listingFeed != nil {
+		var err error
+		feedFingerprint, err = listingFeed.fingerprint()
+		switch {
+		case err != nil:
+			logging.WithPrefix("github").Debug("failed to check feed - falling back to the API", "error", err)
+		case cached && entry.feedFingerprint == feedFingerprint && now.Sub(entry.fetchedAt) < listingFeed.maxAge:
+			logging.WithPrefix("github").Debug("feed unchanged - reusing cached listing", "feed", listingFeed.url, "age", now.Sub(entry.fetchedAt).Round(time.Second).String())
+			entry.checkedAt = now
+			lc.entries[key] = entry
+			return entry.listing, nil
+		}
+	}
+
+	if !budget.Allow(budget.ProviderGitHub) {
+		if !cached {
+			return nil, budget.Exhausted(budget.ProviderGitHub)
+		}
+		logging.WithPrefix("github").Warn("GitHub request budget exhausted - using cached listing", "listing", key.listing, "age", now.Sub(entry.fetchedAt).Round(time.Second).String())
+		return entry.listing, nil
+	}
+
+	listing, err := fetch()
+	if err != nil {
+		return nil, err
+	}
+	lc.entries[key] = listingCacheEntry{listing: listing, fetchedAt: now, checkedAt: now, feedFingerprint: feedFingerprint}
+	return listing, nil
+}
+
+// listReleases lists the most recent releases of a repo through the shared release listing cache
+func (c *Client) listReleases(ctx context.Context, owner string, repo string, perPage int) ([]*github.RepositoryRelease, error) {
+	key := listingCacheKey{apiClient: c.client, listing: fmt.Sprintf("%s/%s?per_page=%d", owner, repo, perPage)}
+	listing, err := releaseListings.get(key, func() (interface{}, error) {
+		releases, _, err := c.client.Repositories.ListReleases(ctx, owner, repo, &github.ListOptions{PerPage: perPage})
+		return releases, err
+	}, c.newFeed(owner, repo, "releases"))
+	if err != nil {
+		return nil, err
+	}
+	return listing.([]*github.RepositoryRelease), nil
+}
+
+// listTags lists the most recent tags of a repo through the shared tag listing cache
+func (c *Client) listTags(ctx context.Context, owner string, repo string, perPage int) ([]*github.RepositoryTag, error) {
+	key := listingCacheKey{apiClient: c.client, listing: fmt.Sprintf("%s/%s?per_page=%d", owner, repo, perPage)}
+	listing, err := tagListings.get(key, func() (interface{}, error) {
+		tags, _, err := c.client.Repositories.ListTags(ctx, owner, repo, &github.ListOptions{PerPage: perPage})
+		return tags, err
+	}, c.newFeed(owner, repo, "tags"))
+	if err != nil {
+		return nil, err
+	}
+	return listing.([]*github.RepositoryTag), nil
+}
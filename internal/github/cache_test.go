@@ -0,0 +1,183 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v74/github"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/budget"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/constants"
+)
+
+func TestSharedClient(t *testing.T) {
+	testnetOpts := Options{Cluster: constants.ClusterNameTestnet, Client: constants.ClientNameAgave}
+
+	first, err := SharedClient(testnetOpts)
+	if err != nil {
+		t.Fatalf("SharedClient() error = %v", err)
+	}
+	second, err := SharedClient(testnetOpts)
+	if err != nil {
+		t.Fatalf("SharedClient() error = %v", err)
+	}
+	if first != second {
+		t.Error("SharedClient() returned different clients for the same options")
+	}
+
+	mainnet, err := SharedClient(Options{Cluster: constants.ClusterNameMainnetBeta, Client: constants.ClientNameAgave})
+	if err != nil {
+		t.Fatalf("SharedClient() error = %v", err)
+	}
+	if mainnet == first {
+		t.Error("SharedClient() returned the same client for different clusters")
+	}
+
+	if _, err := SharedClient(Options{Cluster: constants.ClusterNameTestnet, Client: "unknown"}); err == nil {
+		t.Error("SharedClient() error = nil, want error for unknown client")
+	}
+}
+
+func TestSetAPIBaseURL(t *testing.T) {
+	defaultBaseURL := sharedAPIClient.BaseURL
+	defer func() { sharedAPIClient.BaseURL = defaultBaseURL }()
+
+	tests := []struct {
+		name    string
+		baseURL string
+		want    string
+		wantErr bool
+	}{
+		{name: "without trailing slash", baseURL: "http://127.0.0.1:9000", want: "http://127.0.0.1:9000/"},
+		{name: "with path", baseURL: "http://127.0.0.1:9000/api/v3/", want: "http://127.0.0.1:9000/api/v3/"},
+		{name: "missing scheme", baseURL: "127.0.0.1:9000", wantErr: true},
+		{name: "invalid", baseURL: "://invalid", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := SetAPIBaseURL(tt.baseURL)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("SetAPIBaseURL() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && sharedAPIClient.BaseURL.String() != tt.want {
+				t.Errorf("SetAPIBaseURL() base URL = %v, want %v", sharedAPIClient.BaseURL, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompileRegex(t *testing.T) {
+	first, err := compileRegex(`^v\d+$`)
+	if err != nil {
+		t.Fatalf("compileRegex() error = %v", err)
+	}
+	second, err := compileRegex(`^v\d+$`)
+	if err != nil {
+		t.Fatalf("compileRegex() error = %v", err)
+	}
+	if first != second {
+		t.Error("compileRegex() compiled the same pattern twice")
+	}
+
+	if _, err := compileRegex(`(`); err == nil {
+		t.Error("compileRegex() error = nil, want error for invalid pattern")
+	}
+}
+
+func TestClient_listReleases_Cached(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Write([]byte(`[{"tag_name": "v3.0.10"}]`))
+	}))
+	defer server.Close()
+
+	apiClient := github.NewClient(nil)
+	apiClient.BaseURL, _ = url.Parse(server.URL + "/")
+
+	now := time.Now()
+	releaseListings.now = func() time.Time { return now }
+	defer func() { releaseListings.now = time.Now }()
+
+	// two clients (e.g. two validators) share one listing
+	clients := []*Client{{client: apiClient}, {client: apiClient}}
+	for _, c := range clients {
+		releases, err := c.listReleases(context.Background(), "anza-xyz", "agave", 20)
+		if err != nil {
+			t.Fatalf("listReleases() error = %v", err)
+		}
+		if len(releases) != 1 || releases[0].GetTagName() != "v3.0.10" {
+			t.Errorf("listReleases() = %v, want v3.0.10", releases)
+		}
+	}
+	if got := requests.Load(); got != 1 {
+		t.Errorf("API requests = %d, want 1 while the listing is fresh", got)
+	}
+
+	// stale listings are fetched again
+	now = now.Add(listingCacheTTL)
+	if _, err := clients[0].listReleases(context.Background(), "anza-xyz", "agave", 20); err != nil {
+		t.Fatalf("listReleases() error = %v", err)
+	}
+	if got := requests.Load(); got != 2 {
+		t.Errorf("API requests = %d, want 2 once the listing is stale", got)
+	}
+}
+
+func TestListingCache_FailedFetchNotCached(t *testing.T) {
+	cache := newListingCache()
+	fetches := 0
+	fetch := func() (interface{}, error) {
+		fetches++
+		if fetches == 1 {
+			return nil, context.DeadlineExceeded
+		}
+		return "listing", nil
+	}
+
+	if _, err := cache.get(listingCacheKey{listing: "key"}, fetch, nil); err == nil {
+		t.Fatal("get() error = nil, want fetch error")
+	}
+	listing, err := cache.get(listingCacheKey{listing: "key"}, fetch, nil)
+	if err != nil || listing != "listing" {
+		t.Errorf("get() = %v, %v, want listing after a failed fetch", listing, err)
+	}
+}
+
+func TestListingCache_get_BudgetExhausted(t *testing.T) {
+	budget.SetLimit(budget.ProviderGitHub, 1)
+	defer budget.SetLimit(budget.ProviderGitHub, 0)
+
+	cache := newListingCache()
+	now := time.Now()
+	cache.now = func() time.Time { return now }
+	fetches := 0
+	fetch := func() (interface{}, error) {
+		fetches++
+		return fetches, nil
+	}
+
+	if listing, err := cache.get(listingCacheKey{listing: "cached"}, fetch, nil); err != nil || listing != 1 {
+		t.Fatalf("get() = %v, %v, want the fetched listing", listing, err)
+	}
+
+	// stale listings are reused once the budget is exhausted
+	now = now.Add(listingCacheTTL)
+	if listing, err := cache.get(listingCacheKey{listing: "cached"}, fetch, nil); err != nil || listing != 1 {
+		t.Errorf("get() = %v, %v, want the cached listing", listing, err)
+	}
+
+	// without a cached listing there is nothing to degrade to
+	if _, err := cache.get(listingCacheKey{listing: "uncached"}, fetch, nil); !errors.Is(err, budget.ErrExhausted) {
+		t.Errorf("get() error = %v, want ErrExhausted", err)
+	}
+	if fetches != 1 {
+		t.Errorf("fetches = %d, want 1 once the budget is exhausted", fetches)
+	}
+}
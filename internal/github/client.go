@@ -14,6 +14,7 @@ import (
 	"github.com/google/go-github/v74/github"
 	"github.com/hashicorp/go-version"
 	"github.com/sol-strategies/solana-validator-version-sync/internal/constants"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/logging"
 )
 
 var (
@@ -47,13 +48,23 @@ type Client struct {
 	releaseTitleRegexes map[string]*regexp.Regexp
 	// map of cluster to git tag regex
 	tagRegexes map[string]*regexp.Regexp
-	repoURL    string
-	repoOwner  string
-	repoName   string
-	clientName string
-	client     *github.Client
-	cluster    string
-	logger     *log.Logger
+	// disableMainnetPreference stops testnet preferring a newer mainnet version over the latest testnet version
+	disableMainnetPreference bool
+	// versionFloor is the minimum version considered when looking up the latest version - nil when disabled
+	versionFloor *version.Version
+	// releaseFeeds revalidates stale release and tag listings against the repo's Atom feeds before calling the API
+	releaseFeeds bool
+	// releaseFeedMaxAge is the longest a listing is reused on an unchanged feed
+	releaseFeedMaxAge time.Duration
+	// feedBaseURL is where repo feeds are fetched from
+	feedBaseURL string
+	repoURL     string
+	repoOwner   string
+	repoName    string
+	clientName  string
+	client      *github.Client
+	cluster     string
+	logger      *log.Logger
 	// cachedTagVersions holds all parsed tag versions from the last GetLatestClientVersion call
 	cachedTagVersions []*version.Version
 	cachedTagInfos    []tagVersionInfo
@@ -69,6 +80,13 @@ type tagVersionInfo struct {
 type Options struct {
 	Cluster string
 	Client  string
+	// DisableMainnetPreference stops testnet preferring a newer mainnet version over the latest testnet version
+	DisableMainnetPreference bool
+	// ReleaseFeeds revalidates stale release and tag listings against the repo's Atom feeds, which cost no API
+	// rate limit, only calling the API when a feed changed or ReleaseFeedMaxAge passed
+	ReleaseFeeds bool
+	// ReleaseFeedMaxAge is the longest a listing is reused on an unchanged feed
+	ReleaseFeedMaxAge time.Duration
 }
 
 // NewClient creates a new GitHub client
@@ -82,11 +100,15 @@ func NewClient(opts Options) (c *Client, err error) {
 	}
 
 	c = &Client{
-		cluster:    opts.Cluster,
-		clientName: normalizedClient,
-		repoURL:    repoConfig.URL,
-		client:     github.NewClient(nil), // No auth token for public repos
-		logger:     log.WithPrefix("github"),
+		cluster:                  opts.Cluster,
+		clientName:               normalizedClient,
+		disableMainnetPreference: opts.DisableMainnetPreference,
+		releaseFeeds:             opts.ReleaseFeeds,
+		releaseFeedMaxAge:        opts.ReleaseFeedMaxAge,
+		feedBaseURL:              feedBaseURL,
+		repoURL:                  repoConfig.URL,
+		client:                   sharedAPIClient,
+		logger:                   logging.WithPrefix("github"),
 	}
 
 	// extract owner and repo from URL
@@ -103,17 +125,17 @@ func NewClient(opts Options) (c *Client, err error) {
 	// compile release notes and title regexes for each cluster
 	for _, cluster := range constants.ValidClusterNames {
 		// compile release notes regexes
-		c.releaseNotesRegexes[cluster], err = regexp.Compile(repoConfig.ReleaseNotesRegexes[cluster])
+		c.releaseNotesRegexes[cluster], err = compileRegex(repoConfig.ReleaseNotesRegexes[cluster])
 		if err != nil {
 			return nil, fmt.Errorf("failed to compile release notes regex: %w", err)
 		}
 		// compile release title regexes
-		c.releaseTitleRegexes[cluster], err = regexp.Compile(repoConfig.ReleaseTitleRegexes[cluster])
+		c.releaseTitleRegexes[cluster], err = compileRegex(repoConfig.ReleaseTitleRegexes[cluster])
 		if err != nil {
 			return nil, fmt.Errorf("failed to compile release title regex: %w", err)
 		}
 		// compile tag regexes
-		c.tagRegexes[cluster], err = regexp.Compile(repoConfig.TagRegexes[cluster])
+		c.tagRegexes[cluster], err = compileRegex(repoConfig.TagRegexes[cluster])
 		if err != nil {
 			return nil, fmt.Errorf("failed to compile tag regex: %w", err)
 		}
@@ -129,9 +151,7 @@ func (c *Client) GetLatestClientVersion() (latestVersion *version.Version, err e
 	switch c.clientName {
 	case constants.ClientNameAgave:
 		// Get releases from GitHub API using go-github
-		releases, _, err := c.client.Repositories.ListReleases(ctx, c.repoOwner, c.repoName, &github.ListOptions{
-			PerPage: 20, // We just need the last few releases
-		})
+		releases, err := c.listReleases(ctx, c.repoOwner, c.repoName, 20)
 		if err != nil {
 			return nil, fmt.Errorf("failed to get releases: %w", err)
 		}
@@ -139,9 +159,7 @@ func (c *Client) GetLatestClientVersion() (latestVersion *version.Version, err e
 	case constants.ClientNameJitoSolana:
 		return c.getLatestJitoSolanaVersion(ctx)
 	case constants.ClientNameFiredancer:
-		releases, _, err := c.client.Repositories.ListReleases(ctx, c.repoOwner, c.repoName, &github.ListOptions{
-			PerPage: 20, // We just need the last few releases
-		})
+		releases, err := c.listReleases(ctx, c.repoOwner, c.repoName, 20)
 		if err != nil {
 			return nil, fmt.Errorf("failed to get releases: %w", err)
 		}
@@ -153,6 +171,60 @@ func (c *Client) GetLatestClientVersion() (latestVersion *version.Version, err e
 	}
 }
 
+// GetReleasePublishTimes gets the publish times of the client repo's recent published releases (including
+// pre-releases, excluding drafts), newest first - shares the listing used to look up the latest version
+func (c *Client) GetReleasePublishTimes() (publishedAt []time.Time, err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	releases, err := c.listReleases(ctx, c.repoOwner, c.repoName, c.releasesPerPage())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get releases: %w", err)
+	}
+
+	for _, release := range releases {
+		if release.GetDraft() || release.PublishedAt == nil {
+			continue
+		}
+		publishedAt = append(publishedAt, release.GetPublishedAt().Time)
+	}
+	return publishedAt, nil
+}
+
+// GetReleaseNotes gets the notes and URL of the client repo's release of a version from its recent releases -
+// clients published as tags only (e.g. rakurai) have no notes, so only the tag URL is returned
+func (c *Client) GetReleaseNotes(v *version.Version) (notes string, releaseURL string, err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	tagName := c.TagNameForVersion(v)
+	releaseURL = fmt.Sprintf("%s/releases/tag/%s", c.repoURL, tagName)
+	if c.clientName == constants.ClientNameRakurai {
+		return "", releaseURL, nil
+	}
+
+	releases, err := c.listReleases(ctx, c.repoOwner, c.repoName, c.releasesPerPage())
+	if err != nil {
+		return "", "", fmt.Errorf("failed to get releases: %w", err)
+	}
+
+	for _, release := range releases {
+		if release.GetTagName() == tagName {
+			return release.GetBody(), release.GetHTMLURL(), nil
+		}
+	}
+	return "", releaseURL, nil
+}
+
+// releasesPerPage is the number of recent releases listed for the client - the same listing the latest version
+// is looked up from, so other lookups share its cached listing
+func (c *Client) releasesPerPage() int {
+	if c.clientName == constants.ClientNameJitoSolana {
+		return 100
+	}
+	return 20
+}
+
 func (c *Client) firedancerVersionStringsByCluster(releases []*github.RepositoryRelease) map[string][]string {
 	versionStrings := make(map[string][]string)
 	// Firedancer usually flags release cluster in the release title prefix.
@@ -207,9 +279,7 @@ func (c *Client) firedancerVersionStringsByCluster(releases []*github.Repository
 }
 
 func (c *Client) getLatestJitoSolanaVersion(ctx context.Context) (latestVersion *version.Version, err error) {
-	jitoReleases, _, err := c.client.Repositories.ListReleases(ctx, c.repoOwner, c.repoName, &github.ListOptions{
-		PerPage: 100,
-	})
+	jitoReleases, err := c.listReleases(ctx, c.repoOwner, c.repoName, 100)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get jito-solana releases: %w", err)
 	}
@@ -224,9 +294,7 @@ func (c *Client) getLatestJitoSolanaVersion(ctx context.Context) (latestVersion
 		return nil, fmt.Errorf("failed to extract agave owner/repo from URL: %w", err)
 	}
 
-	agaveReleases, _, err := c.client.Repositories.ListReleases(ctx, agaveOwner, agaveRepo, &github.ListOptions{
-		PerPage: 100,
-	})
+	agaveReleases, err := c.listReleases(ctx, agaveOwner, agaveRepo, 100)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get agave releases for jito-solana classification: %w", err)
 	}
@@ -235,7 +303,7 @@ func (c *Client) getLatestJitoSolanaVersion(ctx context.Context) (latestVersion
 	// promoted across clusters by upstream Agave notes.
 	agaveReleaseNotesRegexes := make(map[string]*regexp.Regexp)
 	for _, cluster := range constants.ValidClusterNames {
-		agaveReleaseNotesRegex, err := regexp.Compile(clientRepoConfigs[constants.ClientNameAgave].ReleaseNotesRegexes[cluster])
+		agaveReleaseNotesRegex, err := compileRegex(clientRepoConfigs[constants.ClientNameAgave].ReleaseNotesRegexes[cluster])
 		if err != nil {
 			return nil, fmt.Errorf("failed to compile agave release notes regex for jito-solana classification: %w", err)
 		}
@@ -258,15 +326,13 @@ func (c *Client) getLatestJitoSolanaVersion(ctx context.Context) (latestVersion
 }
 
 func (c *Client) getLatestRakuraiVersion(ctx context.Context) (latestVersion *version.Version, err error) {
-	rakuraiTags, _, err := c.client.Repositories.ListTags(ctx, c.repoOwner, c.repoName, &github.ListOptions{
-		PerPage: 100,
-	})
+	rakuraiTags, err := c.listTags(ctx, c.repoOwner, c.repoName, 100)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get rakurai tags: %w", err)
 	}
 
-	mainnetTagInfos := tagVersionInfosFromTagRegex(rakuraiTags, c.tagRegexes[constants.ClusterNameMainnetBeta], false)
-	testnetTagInfos := tagVersionInfosFromTagRegex(rakuraiTags, c.tagRegexes[constants.ClusterNameTestnet], true)
+	mainnetTagInfos := c.tagVersionInfosAtOrAboveVersionFloor(tagVersionInfosFromTagRegex(rakuraiTags, c.tagRegexes[constants.ClusterNameMainnetBeta], false))
+	testnetTagInfos := c.tagVersionInfosAtOrAboveVersionFloor(tagVersionInfosFromTagRegex(rakuraiTags, c.tagRegexes[constants.ClusterNameTestnet], true))
 
 	c.setCachedTagInfos(append(mainnetTagInfos, testnetTagInfos...))
 
@@ -285,12 +351,12 @@ func (c *Client) getLatestRakuraiVersion(ctx context.Context) (latestVersion *ve
 	return selectedTag.Version, nil
 }
 
+// latestVersionFromClusterVersionStrings returns the latest version for the configured cluster - only the configured
+// cluster must have versions, other clusters (e.g. mainnet for a client with only testnet releases so far) may have none
 func (c *Client) latestVersionFromClusterVersionStrings(versionStrings map[string][]string) (latestVersion *version.Version, err error) {
 	// fail if no releases/tags found for client configured cluster
-	for cluster, versionStrings := range versionStrings {
-		if len(versionStrings) == 0 {
-			return nil, fmt.Errorf("no %s versions found for client %s", cluster, c.clientName)
-		}
+	if len(versionStrings[c.cluster]) == 0 {
+		return nil, fmt.Errorf("%w: no %s versions found for client %s", ErrNoMatchingTaggedVersion, c.cluster, c.clientName)
 	}
 
 	// For each cluster, create a versions slice and sort, and get the latest version
@@ -299,9 +365,16 @@ func (c *Client) latestVersionFromClusterVersionStrings(versionStrings map[strin
 	c.cachedTagInfos = nil
 	for cluster, versionStrings := range versionStrings {
 		sortedTagInfos := c.sortedTagVersionInfosFromVersionStrings(versionStrings)
-		if len(sortedTagInfos) == 0 {
+		if len(sortedTagInfos) == 0 && cluster == c.cluster && c.versionFloor != nil {
+			return nil, fmt.Errorf("%w: no %s versions at or above floor %s found for client %s", ErrNoMatchingTaggedVersion, cluster, c.versionFloor.String(), c.clientName)
+		}
+		if len(sortedTagInfos) == 0 && cluster == c.cluster {
 			return nil, fmt.Errorf("no parsable %s versions found for client %s", cluster, c.clientName)
 		}
+		if len(sortedTagInfos) == 0 {
+			c.logger.Debug("no versions found for cluster - not considered", "client", c.clientName, "cluster", cluster)
+			continue
+		}
 		for i := range sortedTagInfos {
 			sortedTagInfos[i].TestnetOnly = cluster == constants.ClusterNameTestnet
 		}
@@ -313,9 +386,10 @@ func (c *Client) latestVersionFromClusterVersionStrings(versionStrings map[strin
 		c.logger.Debug("latest version "+latestClusterVersion[cluster].Original(), "client", c.clientName, "cluster", cluster, "repoURL", c.versionSourceURL())
 	}
 
-	// If cluster is testnet and mainnet version is higher, use mainnet version and warn
+	// If cluster is testnet and mainnet version is higher, use mainnet version and warn (unless disabled)
 	latestVersion = latestClusterVersion[c.cluster]
-	if c.cluster == constants.ClusterNameTestnet && latestClusterVersion[constants.ClusterNameMainnetBeta].GreaterThan(latestVersion) {
+	latestMainnetVersion, hasMainnetVersion := latestClusterVersion[constants.ClusterNameMainnetBeta]
+	if c.cluster == constants.ClusterNameTestnet && !c.disableMainnetPreference && hasMainnetVersion && latestMainnetVersion.GreaterThan(latestVersion) {
 		latestVersion = latestClusterVersion[constants.ClusterNameMainnetBeta]
 		c.logger.Warn(fmt.Sprintf("mainnet v%s > v%s testnet - preferring mainnet version",
 			latestClusterVersion[constants.ClusterNameMainnetBeta].Original(),
@@ -362,15 +436,14 @@ func (c *Client) selectRakuraiTagVersionInfo(mainnetTagInfos []tagVersionInfo, t
 	return tagVersionInfo{}, fmt.Errorf("unsupported cluster: %s", c.cluster)
 }
 
-// HasTaggedVersion checks if a tagged version exists in the client repo
+// HasTaggedVersion checks if a tagged version exists in the client repo, caching the matching tag so it
+// can be resolved by NormalizeToTagVersion and TagNameForVersion
 func (c *Client) HasTaggedVersion(testVersion *version.Version) (hasTaggedVersion bool, err error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
 	// get tags from the client repo and return true if a tag with the version exists
-	tags, _, err := c.client.Repositories.ListTags(ctx, c.repoOwner, c.repoName, &github.ListOptions{
-		PerPage: 20,
-	})
+	tags, err := c.listTags(ctx, c.repoOwner, c.repoName, 20)
 	if err != nil {
 		return false, fmt.Errorf("failed to get tags: %w", err)
 	}
@@ -383,6 +456,7 @@ func (c *Client) HasTaggedVersion(testVersion *version.Version) (hasTaggedVersio
 		for _, tagInfo := range tagInfos {
 			c.logger.Debug("comparing rakurai tag version to test version", "tag", tagInfo.TagName, "tagVersion", tagInfo.Version.Core().String(), "testVersion", testVersion.Core().String())
 			if tagInfo.Version.Core().Compare(testVersion.Core()) == 0 {
+				c.cacheTagInfo(tagInfo)
 				return true, nil
 			}
 		}
@@ -422,17 +496,56 @@ func (c *Client) HasTaggedVersion(testVersion *version.Version) (hasTaggedVersio
 		c.logger.Debug("comparing tag version to test version", "tagVersion", tagVersion.Original(), "testVersion", testVersion.Original())
 		if testVersion.Prerelease() != "" {
 			if tagVersion.Equal(testVersion) {
+				c.cacheTagInfo(tagVersionInfo{TagName: tag.GetName(), Version: tagVersion})
 				return true, nil
 			}
 			continue
 		}
 		if tagVersion.Core().Compare(testVersion.Core()) == 0 {
+			c.cacheTagInfo(tagVersionInfo{TagName: tag.GetName(), Version: tagVersion})
 			return true, nil
 		}
 	}
 	return false, nil
 }
 
+// SetVersionFloor sets the minimum version considered by subsequent GetLatestClientVersion calls, nil disables it
+func (c *Client) SetVersionFloor(floor *version.Version) {
+	c.versionFloor = floor
+}
+
+// belowVersionFloor returns true when a version's core is below the version floor, if one is set
+func (c *Client) belowVersionFloor(v *version.Version) bool {
+	return c.versionFloor != nil && v.Core().LessThan(c.versionFloor)
+}
+
+// tagVersionInfosAtOrAboveVersionFloor returns the tag infos not below the version floor
+func (c *Client) tagVersionInfosAtOrAboveVersionFloor(tagInfos []tagVersionInfo) (filtered []tagVersionInfo) {
+	for _, tagInfo := range tagInfos {
+		if c.belowVersionFloor(tagInfo.Version) {
+			c.logger.Debug("skipping tag below floor", "tag", tagInfo.TagName, "floor", c.versionFloor.String())
+			continue
+		}
+		filtered = append(filtered, tagInfo)
+	}
+	return filtered
+}
+
+// GetPinnedClientVersion verifies a tag exists for an exactly pinned version without listing releases,
+// returning the tagged version or ErrNoMatchingTaggedVersion when the pinned version is not tagged (yet)
+func (c *Client) GetPinnedClientVersion(pinnedVersion *version.Version) (taggedVersion *version.Version, err error) {
+	hasTaggedVersion, err := c.HasTaggedVersion(pinnedVersion)
+	if err != nil {
+		return nil, err
+	}
+	if !hasTaggedVersion {
+		return nil, fmt.Errorf("%w: pinned version %s is not tagged in %s", ErrNoMatchingTaggedVersion, pinnedVersion.Original(), c.repoURL)
+	}
+
+	// matched tags are cached by HasTaggedVersion, so this resolves the tag-format version
+	return c.NormalizeToTagVersion(pinnedVersion), nil
+}
+
 func (c *Client) GetRepoURL() string {
 	return c.repoURL
 }
@@ -994,7 +1107,7 @@ func agaveVersionStringsByCluster(releases []*github.RepositoryRelease, releaseN
 func jitoVersionStringsByCluster(releases []*github.RepositoryRelease, logger *log.Logger) (map[string][]string, error) {
 	versionStrings := make(map[string][]string)
 	for _, cluster := range constants.ValidClusterNames {
-		titleRegex, err := regexp.Compile(clientRepoConfigs[constants.ClientNameJitoSolana].ReleaseTitleRegexes[cluster])
+		titleRegex, err := compileRegex(clientRepoConfigs[constants.ClientNameJitoSolana].ReleaseTitleRegexes[cluster])
 		if err != nil {
 			return nil, fmt.Errorf("failed to compile jito-solana release title regex for %s: %w", cluster, err)
 		}
@@ -1119,7 +1232,7 @@ func tagVersionInfosFromTagRegex(tags []*github.RepositoryTag, regex *regexp.Reg
 }
 
 func (c *Client) sortedTagVersionInfosFromVersionStrings(versionStrings []string) (sortedTagInfos []tagVersionInfo) {
-	c.logger.Debug("sorting versions", "versionStrings", versionStrings)
+	c.logger.Debug("sorting versions", "versionStrings", logging.Payload(versionStrings))
 	sortedTagInfos = make([]tagVersionInfo, 0, len(versionStrings))
 	for _, raw := range versionStrings {
 		tagInfo, err := c.tagVersionInfoFromVersionString(raw)
@@ -1127,6 +1240,10 @@ func (c *Client) sortedTagVersionInfosFromVersionStrings(versionStrings []string
 			c.logger.Debug("skipping unparsable version", "version", raw, "error", err)
 			continue
 		}
+		if c.belowVersionFloor(tagInfo.Version) {
+			c.logger.Debug("skipping version below floor", "version", raw, "floor", c.versionFloor.String())
+			continue
+		}
 		sortedTagInfos = append(sortedTagInfos, tagInfo)
 	}
 	sort.Slice(sortedTagInfos, func(i, j int) bool {
@@ -1135,7 +1252,7 @@ func (c *Client) sortedTagVersionInfosFromVersionStrings(versionStrings []string
 		}
 		return versionTagLess(sortedTagInfos[i].TagName, sortedTagInfos[j].TagName)
 	})
-	c.logger.Debug("sorted versions", "sortedVersions", sortedTagInfos)
+	c.logger.Debug("sorted versions", "sortedVersions", logging.Payload(sortedTagInfos))
 	return sortedTagInfos
 }
 
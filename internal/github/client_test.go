@@ -1,13 +1,21 @@
 package github
 
 import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"regexp"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/charmbracelet/log"
 	"github.com/google/go-github/v74/github"
 	"github.com/hashicorp/go-version"
 	"github.com/sol-strategies/solana-validator-version-sync/internal/constants"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/testsupport/githubfake"
 )
 
 func TestNewClient(t *testing.T) {
@@ -1279,3 +1287,371 @@ func TestClient_GetLatestClientVersion_EdgeCases(t *testing.T) {
 		})
 	}
 }
+
+func TestClient_GetLatestClientVersion_GitHubFake(t *testing.T) {
+	tests := []struct {
+		name         string
+		cluster      string
+		releases     []githubfake.Release
+		rateLimited  bool
+		want         string
+		wantRequests []string
+		wantErr      bool
+	}{
+		{
+			name:    "testnet considers prereleases",
+			cluster: constants.ClusterNameTestnet,
+			releases: []githubfake.Release{
+				{TagName: "v3.1.0-beta.1", Prerelease: true, Body: "This is a testnet release"},
+				{TagName: "v3.0.11", Body: "This is a testnet release"},
+			},
+			want:         "3.1.0-beta.1",
+			wantRequests: []string{"/repos/anza-xyz/agave/releases?per_page=20"},
+		},
+		{
+			name:    "mainnet ignores testnet releases",
+			cluster: constants.ClusterNameMainnetBeta,
+			releases: []githubfake.Release{
+				{TagName: "v3.0.12", Body: "This is a testnet release"},
+				{TagName: "v3.0.10", Body: "This is a stable release"},
+			},
+			want:         "3.0.10",
+			wantRequests: []string{"/repos/anza-xyz/agave/releases?per_page=20"},
+		},
+		{
+			name:        "rate limited",
+			cluster:     constants.ClusterNameTestnet,
+			releases:    []githubfake.Release{{TagName: "v3.0.11", Body: "This is a testnet release"}},
+			rateLimited: true,
+			wantErr:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := githubfake.New()
+			defer fake.Close()
+			fake.SetReleases("anza-xyz", "agave", tt.releases...)
+			if tt.rateLimited {
+				fake.SetRateLimit(0, time.Now().Add(time.Hour))
+			}
+
+			c, err := NewClient(Options{Cluster: tt.cluster, Client: constants.ClientNameAgave, DisableMainnetPreference: true})
+			if err != nil {
+				t.Fatalf("NewClient() error = %v", err)
+			}
+			c.client = github.NewClient(nil)
+			c.client.BaseURL, _ = url.Parse(fake.URL())
+
+			got, err := c.GetLatestClientVersion()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetLatestClientVersion() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				var rateLimitErr *github.RateLimitError
+				if tt.rateLimited && !errors.As(err, &rateLimitErr) {
+					t.Errorf("GetLatestClientVersion() error = %v, want a rate limit error", err)
+				}
+				return
+			}
+
+			if got.String() != tt.want {
+				t.Errorf("GetLatestClientVersion() = %s, want %s", got, tt.want)
+			}
+			if requests := fake.Requests(); strings.Join(requests, ",") != strings.Join(tt.wantRequests, ",") {
+				t.Errorf("API requests = %v, want %v", requests, tt.wantRequests)
+			}
+		})
+	}
+}
+
+func TestClient_GetPinnedClientVersion(t *testing.T) {
+	tests := []struct {
+		name        string
+		client      string
+		tags        []string
+		pinned      string
+		wantTag     string
+		wantNoMatch bool
+	}{
+		{
+			name:    "agave pinned version is tagged",
+			client:  constants.ClientNameAgave,
+			tags:    []string{"v3.0.11", "v3.0.10", "v2.3.13"},
+			pinned:  "3.0.10",
+			wantTag: "v3.0.10",
+		},
+		{
+			name:    "jito-solana pinned version resolves to the suffixed tag",
+			client:  constants.ClientNameJitoSolana,
+			tags:    []string{"v3.0.11-jito", "v3.0.10-jito.1"},
+			pinned:  "3.0.10",
+			wantTag: "v3.0.10-jito.1",
+		},
+		{
+			name:        "pinned version not tagged yet",
+			client:      constants.ClientNameAgave,
+			tags:        []string{"v3.0.11", "v3.0.10"},
+			pinned:      "3.0.12",
+			wantNoMatch: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if !strings.HasSuffix(r.URL.Path, "/tags") {
+					t.Errorf("unexpected request to %s - pinned lookups must only list tags", r.URL.Path)
+					http.NotFound(w, r)
+					return
+				}
+				names := make([]string, 0, len(tt.tags))
+				for _, tag := range tt.tags {
+					names = append(names, fmt.Sprintf(`{"name": %q}`, tag))
+				}
+				fmt.Fprintf(w, "[%s]", strings.Join(names, ","))
+			}))
+			defer server.Close()
+
+			c, err := NewClient(Options{Cluster: constants.ClusterNameMainnetBeta, Client: tt.client})
+			if err != nil {
+				t.Fatalf("NewClient() error = %v", err)
+			}
+			c.client = github.NewClient(nil)
+			c.client.BaseURL, _ = url.Parse(server.URL + "/")
+
+			taggedVersion, err := c.GetPinnedClientVersion(version.Must(version.NewVersion(tt.pinned)))
+			if tt.wantNoMatch {
+				if !errors.Is(err, ErrNoMatchingTaggedVersion) {
+					t.Errorf("GetPinnedClientVersion() error = %v, want ErrNoMatchingTaggedVersion", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetPinnedClientVersion() error = %v", err)
+			}
+			if tag := c.TagNameForVersion(taggedVersion); tag != tt.wantTag {
+				t.Errorf("TagNameForVersion(GetPinnedClientVersion()) = %s, want %s", tag, tt.wantTag)
+			}
+		})
+	}
+}
+
+func TestClient_GetReleasePublishTimes(t *testing.T) {
+	tests := []struct {
+		name        string
+		client      string
+		wantPerPage string
+	}{
+		{
+			name:        "agave",
+			client:      constants.ClientNameAgave,
+			wantPerPage: "20",
+		},
+		{
+			name:        "jito-solana shares the larger latest version listing",
+			client:      constants.ClientNameJitoSolana,
+			wantPerPage: "100",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if got := r.URL.Query().Get("per_page"); got != tt.wantPerPage {
+					t.Errorf("per_page = %s, want %s", got, tt.wantPerPage)
+				}
+				w.Write([]byte(`[
+					{"tag_name": "v3.0.11", "draft": true},
+					{"tag_name": "v3.0.10", "published_at": "2025-10-08T00:00:00Z"},
+					{"tag_name": "v3.0.9", "published_at": "2025-10-01T00:00:00Z", "prerelease": true},
+					{"tag_name": "v3.0.8"}
+				]`))
+			}))
+			defer server.Close()
+
+			c, err := NewClient(Options{Cluster: constants.ClusterNameMainnetBeta, Client: tt.client})
+			if err != nil {
+				t.Fatalf("NewClient() error = %v", err)
+			}
+			c.client = github.NewClient(nil)
+			c.client.BaseURL, _ = url.Parse(server.URL + "/")
+
+			got, err := c.GetReleasePublishTimes()
+			if err != nil {
+				t.Fatalf("GetReleasePublishTimes() error = %v", err)
+			}
+			want := []time.Time{
+				time.Date(2025, 10, 8, 0, 0, 0, 0, time.UTC),
+				time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC),
+			}
+			if len(got) != len(want) {
+				t.Fatalf("GetReleasePublishTimes() = %v, want %v", got, want)
+			}
+			for i := range want {
+				if !got[i].Equal(want[i]) {
+					t.Errorf("GetReleasePublishTimes()[%d] = %s, want %s", i, got[i], want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestClient_GetReleaseNotes(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[
+			{"tag_name": "v3.0.11", "body": "newer notes", "html_url": "https://github.com/anza-xyz/agave/releases/tag/v3.0.11"},
+			{"tag_name": "v3.0.10", "body": "This is a stable release", "html_url": "https://github.com/anza-xyz/agave/releases/tag/v3.0.10"}
+		]`))
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name      string
+		client    string
+		version   string
+		wantNotes string
+		wantURL   string
+	}{
+		{
+			name:      "release found",
+			client:    constants.ClientNameAgave,
+			version:   "v3.0.10",
+			wantNotes: "This is a stable release",
+			wantURL:   "https://github.com/anza-xyz/agave/releases/tag/v3.0.10",
+		},
+		{
+			name:    "release not in recent releases",
+			client:  constants.ClientNameAgave,
+			version: "v2.3.13",
+			wantURL: "https://github.com/anza-xyz/agave/releases/tag/v2.3.13",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := NewClient(Options{Cluster: constants.ClusterNameMainnetBeta, Client: tt.client})
+			if err != nil {
+				t.Fatalf("NewClient() error = %v", err)
+			}
+			c.client = github.NewClient(nil)
+			c.client.BaseURL, _ = url.Parse(server.URL + "/")
+
+			notes, releaseURL, err := c.GetReleaseNotes(version.Must(version.NewVersion(tt.version)))
+			if err != nil {
+				t.Fatalf("GetReleaseNotes() error = %v", err)
+			}
+			if notes != tt.wantNotes {
+				t.Errorf("GetReleaseNotes() notes = %q, want %q", notes, tt.wantNotes)
+			}
+			if releaseURL != tt.wantURL {
+				t.Errorf("GetReleaseNotes() url = %s, want %s", releaseURL, tt.wantURL)
+			}
+		})
+	}
+}
+
+func TestClientLatestVersionFromClusterVersionStrings_ClusterMatches(t *testing.T) {
+	tests := []struct {
+		name                     string
+		cluster                  string
+		disableMainnetPreference bool
+		versionStrings           map[string][]string
+		want                     string
+		wantNoMatch              bool
+	}{
+		{
+			name:    "testnet only releases sync on testnet",
+			cluster: constants.ClusterNameTestnet,
+			versionStrings: map[string][]string{
+				constants.ClusterNameMainnetBeta: {},
+				constants.ClusterNameTestnet:     {"v0.1.0", "v0.2.0"},
+			},
+			want: "v0.2.0",
+		},
+		{
+			name:    "testnet only releases do not sync on mainnet",
+			cluster: constants.ClusterNameMainnetBeta,
+			versionStrings: map[string][]string{
+				constants.ClusterNameMainnetBeta: {},
+				constants.ClusterNameTestnet:     {"v0.1.0", "v0.2.0"},
+			},
+			wantNoMatch: true,
+		},
+		{
+			name:    "testnet prefers newer mainnet version by default",
+			cluster: constants.ClusterNameTestnet,
+			versionStrings: map[string][]string{
+				constants.ClusterNameMainnetBeta: {"v3.1.0"},
+				constants.ClusterNameTestnet:     {"v3.0.10"},
+			},
+			want: "v3.1.0",
+		},
+		{
+			name:                     "testnet keeps testnet version with mainnet preference disabled",
+			cluster:                  constants.ClusterNameTestnet,
+			disableMainnetPreference: true,
+			versionStrings: map[string][]string{
+				constants.ClusterNameMainnetBeta: {"v3.1.0"},
+				constants.ClusterNameTestnet:     {"v3.0.10"},
+			},
+			want: "v3.0.10",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := NewClient(Options{
+				Cluster:                  tt.cluster,
+				Client:                   constants.ClientNameAgave,
+				DisableMainnetPreference: tt.disableMainnetPreference,
+			})
+			if err != nil {
+				t.Fatalf("NewClient() error = %v", err)
+			}
+
+			got, err := client.latestVersionFromClusterVersionStrings(tt.versionStrings)
+			if tt.wantNoMatch {
+				if !errors.Is(err, ErrNoMatchingTaggedVersion) {
+					t.Errorf("latestVersionFromClusterVersionStrings() error = %v, want ErrNoMatchingTaggedVersion", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("latestVersionFromClusterVersionStrings() error = %v", err)
+			}
+			if got.Original() != tt.want {
+				t.Errorf("latestVersionFromClusterVersionStrings() = %q, want %q", got.Original(), tt.want)
+			}
+		})
+	}
+}
+
+func TestClientLatestVersionFromClusterVersionStrings_VersionFloor(t *testing.T) {
+	client, err := NewClient(Options{Cluster: constants.ClusterNameMainnetBeta, Client: constants.ClientNameAgave})
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	client.SetVersionFloor(version.Must(version.NewVersion("3.0.0")))
+
+	// stale releases below the floor are never selected
+	_, err = client.latestVersionFromClusterVersionStrings(map[string][]string{
+		constants.ClusterNameMainnetBeta: {"v1.18.26", "v2.3.13"},
+	})
+	if !errors.Is(err, ErrNoMatchingTaggedVersion) {
+		t.Errorf("latestVersionFromClusterVersionStrings() error = %v, want ErrNoMatchingTaggedVersion", err)
+	}
+
+	got, err := client.latestVersionFromClusterVersionStrings(map[string][]string{
+		constants.ClusterNameMainnetBeta: {"v2.3.13", "v3.0.0-rc.1", "v3.0.1"},
+	})
+	if err != nil {
+		t.Fatalf("latestVersionFromClusterVersionStrings() error = %v", err)
+	}
+	if got.Original() != "v3.0.1" {
+		t.Errorf("latestVersionFromClusterVersionStrings() = %q, want v3.0.1", got.Original())
+	}
+	if tag := client.TagNameForVersion(version.Must(version.NewVersion("2.3.13"))); tag != "2.3.13" {
+		t.Errorf("TagNameForVersion(2.3.13) = %q, want below-floor versions left uncached", tag)
+	}
+}
@@ -0,0 +1,85 @@
+package github
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+const (
+	// feedBaseURL is where repo Atom feeds are served from - outside the API, so fetching them costs no API rate limit
+	feedBaseURL = "https://github.com"
+	// feedTimeout is the timeout for fetching a feed - feeds are only an optimization, so they fail fast
+	feedTimeout = 10 * time.Second
+	// maxFeedSize bounds feed responses - feeds hold the latest 10 entries
+	maxFeedSize = 8 << 20
+)
+
+// feedHTTPClient is the client feeds are fetched with
+var feedHTTPClient = &http.Client{Timeout: feedTimeout}
+
+// atomFeed is the subset of an Atom feed that identifies its entries' content
+type atomFeed struct {
+	Entries []atomEntry `xml:"entry"`
+}
+
+// atomEntry is an Atom feed entry - a release (title and notes) or a tag
+type atomEntry struct {
+	ID      string `xml:"id"`
+	Updated string `xml:"updated"`
+	Title   string `xml:"title"`
+	Content string `xml:"content"`
+}
+
+// feed is a repo Atom feed (releases.atom or tags.atom) used to detect whether a cached API listing changed
+type feed struct {
+	url string
+	// maxAge is the longest a listing is reused on an unchanged feed before it is fetched from the API again -
+	// feeds only hold the latest entries, so older releases edited in place are picked up by then
+	maxAge time.Duration
+}
+
+// newFeed returns the feed of the given repo and kind (releases or tags) when feeds are enabled, otherwise nil
+func (c *Client) newFeed(owner string, repo string, kind string) *feed {
+	if !c.releaseFeeds {
+		return nil
+	}
+	return &feed{
+		url:    fmt.Sprintf("%s/%s/%s/%s.atom", c.feedBaseURL, owner, repo, kind),
+		maxAge: c.releaseFeedMaxAge,
+	}
+}
+
+// fingerprint fetches the feed and returns a digest of its entries, which changes whenever a release or tag
+// is added, removed or edited (e.g. release notes reclassifying a release for mainnet)
+func (f *feed) fingerprint() (string, error) {
+	resp, err := feedHTTPClient.Get(f.url)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch feed %s: %w", f.url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("unexpected status code %d fetching feed %s", resp.StatusCode, f.url)
+	}
+
+	var parsed atomFeed
+	if err := xml.NewDecoder(io.LimitReader(resp.Body, maxFeedSize)).Decode(&parsed); err != nil {
+		return "", fmt.Errorf("failed to parse feed %s: %w", f.url, err)
+	}
+	if len(parsed.Entries) == 0 {
+		return "", fmt.Errorf("feed %s has no entries", f.url)
+	}
+
+	digest := sha256.New()
+	for _, entry := range parsed.Entries {
+		for _, field := range []string{entry.ID, entry.Updated, entry.Title, entry.Content} {
+			fmt.Fprintf(digest, "%d:%s", len(field), field)
+		}
+	}
+	return hex.EncodeToString(digest.Sum(nil)), nil
+}
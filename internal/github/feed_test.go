@@ -0,0 +1,173 @@
+package github
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+const testFeed = `<?xml version="1.0" encoding="UTF-8"?>
+<feed xmlns="http://www.w3.org/2005/Atom">
+  <entry>
+    <id>tag:github.com,2008:Repository/1/v3.0.10</id>
+    <updated>2025-10-01T00:00:00Z</updated>
+    <title>v3.0.10</title>
+    <content type="html">This is a Testnet release</content>
+  </entry>
+</feed>`
+
+// feedServer serves the given feed body and status, counting requests
+func feedServer(t *testing.T, status *int, body *string) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(*status)
+		w.Write([]byte(*body))
+	}))
+	t.Cleanup(server.Close)
+	return server, &requests
+}
+
+func TestFeed_fingerprint(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		wantErr bool
+	}{
+		{
+			name:   "valid feed",
+			status: http.StatusOK,
+			body:   testFeed,
+		},
+		{
+			name:    "unexpected status",
+			status:  http.StatusTooManyRequests,
+			body:    testFeed,
+			wantErr: true,
+		},
+		{
+			name:    "invalid xml",
+			status:  http.StatusOK,
+			body:    "<feed>",
+			wantErr: true,
+		},
+		{
+			name:    "no entries",
+			status:  http.StatusOK,
+			body:    `<feed xmlns="http://www.w3.org/2005/Atom"></feed>`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, _ := feedServer(t, &tt.status, &tt.body)
+			got, err := (&feed{url: server.URL}).fingerprint()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("fingerprint() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && got == "" {
+				t.Error("fingerprint() = empty, want a fingerprint")
+			}
+		})
+	}
+}
+
+func TestFeed_fingerprint_ChangesOnEdit(t *testing.T) {
+	status := http.StatusOK
+	body := testFeed
+	server, _ := feedServer(t, &status, &body)
+	f := &feed{url: server.URL}
+
+	before, err := f.fingerprint()
+	if err != nil {
+		t.Fatalf("fingerprint() error = %v", err)
+	}
+	body = `<feed xmlns="http://www.w3.org/2005/Atom"><entry><id>tag:github.com,2008:Repository/1/v3.0.10</id>` +
+		`<updated>2025-10-01T00:00:00Z</updated><title>v3.0.10</title><content>This is a Mainnet release</content></entry></feed>`
+	after, err := f.fingerprint()
+	if err != nil {
+		t.Fatalf("fingerprint() error = %v", err)
+	}
+	if before == after {
+		t.Error("fingerprint() unchanged after release notes were edited")
+	}
+}
+
+func TestClient_newFeed(t *testing.T) {
+	c := &Client{feedBaseURL: feedBaseURL, releaseFeedMaxAge: time.Hour}
+	if got := c.newFeed("anza-xyz", "agave", "releases"); got != nil {
+		t.Errorf("newFeed() = %v, want nil when feeds are disabled", got)
+	}
+
+	c.releaseFeeds = true
+	got := c.newFeed("anza-xyz", "agave", "tags")
+	if got == nil {
+		t.Fatal("newFeed() = nil, want a feed when feeds are enabled")
+	}
+	if want := "https://github.com/anza-xyz/agave/tags.atom"; got.url != want {
+		t.Errorf("newFeed().url = %s, want %s", got.url, want)
+	}
+	if got.maxAge != time.Hour {
+		t.Errorf("newFeed().maxAge = %s, want 1h", got.maxAge)
+	}
+}
+
+func TestListingCache_get_Feed(t *testing.T) {
+	status := http.StatusOK
+	body := testFeed
+	server, feedRequests := feedServer(t, &status, &body)
+	listingFeed := &feed{url: server.URL, maxAge: time.Hour}
+
+	var fetches atomic.Int32
+	fetch := func() (interface{}, error) {
+		fetches.Add(1)
+		return "listing", nil
+	}
+
+	start := time.Now()
+	now := start
+	cache := newListingCache()
+	cache.now = func() time.Time { return now }
+	key := listingCacheKey{listing: "key"}
+
+	get := func(wantFetches int32, reason string) {
+		t.Helper()
+		listing, err := cache.get(key, fetch, listingFeed)
+		if err != nil {
+			t.Fatalf("get() error = %v", err)
+		}
+		if listing != "listing" {
+			t.Errorf("get() = %v, want listing", listing)
+		}
+		if got := fetches.Load(); got != wantFetches {
+			t.Errorf("API fetches = %d, want %d %s", got, wantFetches, reason)
+		}
+	}
+
+	get(1, "on first use")
+
+	now = now.Add(listingCacheTTL / 2)
+	get(1, "while the listing is fresh")
+	if got := feedRequests.Load(); got != 1 {
+		t.Errorf("feed requests = %d, want 1 - fresh listings skip the feed", got)
+	}
+
+	now = now.Add(listingCacheTTL)
+	get(1, "while the feed is unchanged")
+
+	body = `<feed xmlns="http://www.w3.org/2005/Atom"><entry><id>v3.0.11</id></entry></feed>`
+	now = now.Add(listingCacheTTL)
+	get(2, "once the feed changed")
+
+	now = start.Add(2 * time.Hour)
+	get(3, "once the listing is older than the feed max age")
+
+	status = http.StatusInternalServerError
+	now = now.Add(listingCacheTTL)
+	get(4, "when the feed fails")
+}
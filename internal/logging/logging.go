@@ -0,0 +1,68 @@
+package logging
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/charmbracelet/log"
+)
+
+// Components are the components whose log level can be set separately from the global log level
+var Components = []string{
+	"command",
+	"config",
+	"failinject",
+	"github",
+	"management",
+	"manager",
+	"nomad",
+	"notify",
+	"report",
+	"rpc",
+	"sfdp",
+	"state",
+	"sync",
+	"validator",
+}
+
+var (
+	// componentLevels are the log levels overriding the global log level, keyed by component
+	componentLevels      = make(map[string]log.Level)
+	componentLevelsMutex sync.RWMutex
+)
+
+// Component returns the component of a logger prefix - the prefix up to the first ':' or '[',
+// e.g. sync for sync:commands[1/2 build]
+func Component(prefix string) string {
+	if i := strings.IndexAny(prefix, ":["); i >= 0 {
+		return prefix[:i]
+	}
+	return prefix
+}
+
+// SetComponentLevels sets the log levels overriding the global log level, keyed by component - only loggers
+// created after the call use them, a nil map clears all overrides
+func SetComponentLevels(levels map[string]log.Level) {
+	componentLevelsMutex.Lock()
+	defer componentLevelsMutex.Unlock()
+
+	componentLevels = make(map[string]log.Level, len(levels))
+	for component, level := range levels {
+		componentLevels[component] = level
+	}
+}
+
+// WithPrefix returns a logger with the given prefix derived from the default logger, at its component's
+// log level when one is set
+func WithPrefix(prefix string) *log.Logger {
+	logger := log.WithPrefix(prefix)
+
+	componentLevelsMutex.RLock()
+	level, ok := componentLevels[Component(prefix)]
+	componentLevelsMutex.RUnlock()
+
+	if ok {
+		logger.SetLevel(level)
+	}
+	return logger
+}
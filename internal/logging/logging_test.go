@@ -0,0 +1,89 @@
+package logging
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/log"
+)
+
+func TestComponent(t *testing.T) {
+	tests := []struct {
+		name   string
+		prefix string
+		want   string
+	}{
+		{name: "plain prefix", prefix: "github", want: "github"},
+		{name: "sync command prefix", prefix: "sync:commands[1/2 build]", want: "sync"},
+		{name: "command prefix", prefix: "command[build]", want: "command"},
+		{name: "empty prefix", prefix: "", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Component(tt.prefix); got != tt.want {
+				t.Errorf("Component(%q) = %q, want %q", tt.prefix, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithPrefix(t *testing.T) {
+	globalLevel := log.GetLevel()
+	log.SetLevel(log.InfoLevel)
+	defer func() {
+		log.SetLevel(globalLevel)
+		SetComponentLevels(nil)
+	}()
+
+	tests := []struct {
+		name   string
+		levels map[string]log.Level
+		prefix string
+		want   log.Level
+	}{
+		{
+			name:   "no overrides uses global level",
+			prefix: "github",
+			want:   log.InfoLevel,
+		},
+		{
+			name:   "override for component",
+			levels: map[string]log.Level{"github": log.DebugLevel},
+			prefix: "github",
+			want:   log.DebugLevel,
+		},
+		{
+			name:   "override for other component uses global level",
+			levels: map[string]log.Level{"github": log.DebugLevel},
+			prefix: "rpc",
+			want:   log.InfoLevel,
+		},
+		{
+			name:   "override applies to sub prefixes",
+			levels: map[string]log.Level{"sync": log.WarnLevel},
+			prefix: "sync:commands[1/2 build]",
+			want:   log.WarnLevel,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetComponentLevels(tt.levels)
+			if got := WithPrefix(tt.prefix).GetLevel(); got != tt.want {
+				t.Errorf("WithPrefix(%q).GetLevel() = %v, want %v", tt.prefix, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetComponentLevels_CopiesLevels(t *testing.T) {
+	defer SetComponentLevels(nil)
+
+	levels := map[string]log.Level{"rpc": log.ErrorLevel}
+	SetComponentLevels(levels)
+	levels["rpc"] = log.DebugLevel
+
+	if got := WithPrefix("rpc").GetLevel(); got != log.ErrorLevel {
+		t.Errorf("WithPrefix(rpc).GetLevel() = %v, want %v", got, log.ErrorLevel)
+	}
+}
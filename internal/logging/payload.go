@@ -0,0 +1,79 @@
+package logging
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"unicode/utf8"
+)
+
+const (
+	// payloadMaxEntries is how many entries of a slice, array or map payload are logged
+	payloadMaxEntries = 5
+	// payloadMaxLength is how many bytes of a payload's string form are logged
+	payloadMaxLength = 512
+)
+
+// fullPayloads disables payload summaries, set by the --log-full-payloads flag
+var fullPayloads atomic.Bool
+
+// SetFullPayloads sets whether Payload logs payloads in full rather than summarized
+func SetFullPayloads(full bool) {
+	fullPayloads.Store(full)
+}
+
+// Payload returns a payload for logging - slices, arrays and maps with more than payloadMaxEntries entries are
+// summarized as their entry count and first entries, and anything longer than payloadMaxLength bytes once
+// formatted is truncated. Payloads are returned as-is when small or when full payloads are enabled.
+func Payload(payload interface{}) interface{} {
+	if fullPayloads.Load() || payload == nil {
+		return payload
+	}
+
+	value := reflect.ValueOf(payload)
+	switch value.Kind() {
+	case reflect.Pointer:
+		if value.IsNil() {
+			return payload
+		}
+		return Payload(value.Elem().Interface())
+	case reflect.Slice, reflect.Array:
+		if value.Len() > payloadMaxEntries {
+			entries := make([]string, 0, payloadMaxEntries)
+			for i := 0; i < payloadMaxEntries; i++ {
+				entries = append(entries, fmt.Sprintf("%+v", value.Index(i).Interface()))
+			}
+			return truncate(fmt.Sprintf("%d entries, first %d: [%s]", value.Len(), payloadMaxEntries, strings.Join(entries, " ")))
+		}
+	case reflect.Map:
+		if value.Len() > payloadMaxEntries {
+			// sort entries so summaries of the same map are stable
+			entries := make([]string, 0, value.Len())
+			iter := value.MapRange()
+			for iter.Next() {
+				entries = append(entries, fmt.Sprintf("%+v:%+v", iter.Key().Interface(), iter.Value().Interface()))
+			}
+			sort.Strings(entries)
+			return truncate(fmt.Sprintf("%d entries, first %d: map[%s]", value.Len(), payloadMaxEntries, strings.Join(entries[:payloadMaxEntries], " ")))
+		}
+	}
+
+	if formatted := fmt.Sprintf("%+v", payload); len(formatted) > payloadMaxLength {
+		return truncate(formatted)
+	}
+	return payload
+}
+
+// truncate truncates s to payloadMaxLength bytes, on a rune boundary, noting how many bytes were dropped
+func truncate(s string) string {
+	if len(s) <= payloadMaxLength {
+		return s
+	}
+	end := payloadMaxLength
+	for end > 0 && !utf8.RuneStart(s[end]) {
+		end--
+	}
+	return fmt.Sprintf("%s... (%d more bytes, use --log-full-payloads to log in full)", s[:end], len(s)-end)
+}
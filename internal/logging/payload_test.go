@@ -0,0 +1,105 @@
+package logging
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestPayload(t *testing.T) {
+	type node struct {
+		Pubkey  string
+		Version string
+	}
+	manyNodes := make([]node, 0, 100)
+	for i := 0; i < 100; i++ {
+		manyNodes = append(manyNodes, node{Pubkey: "pubkey", Version: "2.3.6"})
+	}
+
+	tests := []struct {
+		name         string
+		payload      interface{}
+		fullPayloads bool
+		want         interface{}
+		wantPrefix   string
+		wantContains string
+	}{
+		{
+			name:    "nil payload",
+			payload: nil,
+			want:    nil,
+		},
+		{
+			name:    "small string returned as-is",
+			payload: "identity",
+			want:    "identity",
+		},
+		{
+			name:    "small slice returned as-is",
+			payload: []string{"a", "b"},
+			want:    []string{"a", "b"},
+		},
+		{
+			name:         "large slice summarized",
+			payload:      manyNodes,
+			wantPrefix:   "100 entries, first 5: [{Pubkey:pubkey Version:2.3.6} ",
+			wantContains: "{Pubkey:pubkey Version:2.3.6}]",
+		},
+		{
+			name:       "pointer to large slice summarized",
+			payload:    &manyNodes,
+			wantPrefix: "100 entries, first 5: ",
+		},
+		{
+			name:    "large map summarized in key order",
+			payload: map[string]int{"g": 7, "f": 6, "e": 5, "d": 4, "c": 3, "b": 2, "a": 1},
+			want:    "7 entries, first 5: map[a:1 b:2 c:3 d:4 e:5]",
+		},
+		{
+			name:         "long string truncated",
+			payload:      strings.Repeat("x", payloadMaxLength+10),
+			wantPrefix:   strings.Repeat("x", payloadMaxLength) + "...",
+			wantContains: "(10 more bytes, use --log-full-payloads to log in full)",
+		},
+		{
+			name:         "full payloads returned as-is",
+			payload:      manyNodes,
+			fullPayloads: true,
+			want:         manyNodes,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetFullPayloads(tt.fullPayloads)
+			defer SetFullPayloads(false)
+
+			got := Payload(tt.payload)
+			if tt.wantPrefix == "" {
+				if !reflect.DeepEqual(got, tt.want) {
+					t.Errorf("Payload() = %v, want %v", got, tt.want)
+				}
+				return
+			}
+
+			gotString, ok := got.(string)
+			if !ok {
+				t.Fatalf("Payload() = %T, want string", got)
+			}
+			if !strings.HasPrefix(gotString, tt.wantPrefix) {
+				t.Errorf("Payload() = %q, want prefix %q", gotString, tt.wantPrefix)
+			}
+			if !strings.Contains(gotString, tt.wantContains) {
+				t.Errorf("Payload() = %q, want it to contain %q", gotString, tt.wantContains)
+			}
+		})
+	}
+}
+
+func TestTruncate_RuneBoundary(t *testing.T) {
+	s := strings.Repeat("x", payloadMaxLength-1) + "µµ"
+	got := truncate(s)
+	if !strings.HasPrefix(got, strings.Repeat("x", payloadMaxLength-1)+"...") {
+		t.Errorf("truncate() = %q, want it cut before the split rune", got)
+	}
+}
@@ -0,0 +1,114 @@
+package management
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/pprof"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/logging"
+)
+
+// DefaultAddress is the address the management listener binds to by default - loopback only, as profiles expose
+// process internals
+const DefaultAddress = "127.0.0.1:6060"
+
+// shutdownTimeout is how long in-flight requests (e.g. a 30s CPU profile) are given to finish on shutdown
+const shutdownTimeout = 5 * time.Second
+
+// Options represents the management listener options
+type Options struct {
+	// Address is the address to listen on
+	Address string
+	// EnablePprof serves net/http/pprof under /debug/pprof/
+	EnablePprof bool
+	// EnableStatus serves the value returned by Status as JSON under /status
+	EnableStatus bool
+	// Status returns the daemon's current status, e.g. the last run's outcome and daemon metadata
+	Status func() any
+}
+
+// Enabled returns true when anything is served, so the listener is only opened when needed
+func (o Options) Enabled() bool {
+	return o.EnablePprof || o.EnableStatus
+}
+
+// Server is the management listener
+type Server struct {
+	listener net.Listener
+	server   *http.Server
+	logger   *log.Logger
+}
+
+// Start binds the management listener and serves it in the background until ctx is done - binding errors
+// (e.g. address in use) are returned so the daemon fails fast
+func Start(ctx context.Context, opts Options) (*Server, error) {
+	if !opts.Enabled() {
+		return nil, fmt.Errorf("nothing to serve on the management listener")
+	}
+
+	listener, err := net.Listen("tcp", opts.Address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on %s: %w", opts.Address, err)
+	}
+
+	s := &Server{
+		listener: listener,
+		server:   &http.Server{Handler: Handler(opts), ReadHeaderTimeout: 10 * time.Second},
+		logger:   logging.WithPrefix("management"),
+	}
+
+	go s.serve(ctx)
+
+	return s, nil
+}
+
+// Addr returns the address the listener is bound to
+func (s *Server) Addr() string {
+	return s.listener.Addr().String()
+}
+
+// serve serves requests until ctx is done, then shuts the listener down
+func (s *Server) serve(ctx context.Context) {
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		if err := s.server.Shutdown(shutdownCtx); err != nil {
+			s.logger.Warn("failed to shut down management listener cleanly", "error", err)
+		}
+	}()
+
+	if err := s.server.Serve(s.listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		s.logger.Error("management listener stopped", "error", err)
+	}
+}
+
+// Handler returns the management routes enabled by opts - served on their own mux so nothing registered on
+// http.DefaultServeMux is exposed
+func Handler(opts Options) http.Handler {
+	mux := http.NewServeMux()
+	if opts.EnablePprof {
+		mux.HandleFunc("/debug/pprof/", pprof.Index)
+		mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+		mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+		mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+		mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	}
+	if opts.EnableStatus && opts.Status != nil {
+		mux.HandleFunc("/status", func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			encoder := json.NewEncoder(w)
+			encoder.SetIndent("", "  ")
+			if err := encoder.Encode(opts.Status()); err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+			}
+		})
+	}
+	return mux
+}
@@ -0,0 +1,133 @@
+package management
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHandler(t *testing.T) {
+	tests := []struct {
+		name        string
+		opts        Options
+		path        string
+		wantStatus  int
+		wantContent string
+	}{
+		{
+			name:        "pprof index",
+			opts:        Options{EnablePprof: true},
+			path:        "/debug/pprof/",
+			wantStatus:  http.StatusOK,
+			wantContent: "goroutine",
+		},
+		{
+			name:        "goroutine profile",
+			opts:        Options{EnablePprof: true},
+			path:        "/debug/pprof/goroutine?debug=1",
+			wantStatus:  http.StatusOK,
+			wantContent: "goroutine profile:",
+		},
+		{
+			name:        "heap profile",
+			opts:        Options{EnablePprof: true},
+			path:        "/debug/pprof/heap?debug=1",
+			wantStatus:  http.StatusOK,
+			wantContent: "heap profile:",
+		},
+		{
+			name:        "status",
+			opts:        Options{EnableStatus: true, Status: func() any { return map[string]int{"runs": 3} }},
+			path:        "/status",
+			wantStatus:  http.StatusOK,
+			wantContent: `"runs": 3`,
+		},
+		{
+			name:       "status disabled",
+			opts:       Options{EnablePprof: true, Status: func() any { return map[string]int{"runs": 3} }},
+			path:       "/status",
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "pprof disabled",
+			opts:       Options{},
+			path:       "/debug/pprof/",
+			wantStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(Handler(tt.opts))
+			defer server.Close()
+
+			resp, err := http.Get(server.URL + tt.path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer resp.Body.Close()
+			body, _ := io.ReadAll(resp.Body)
+
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("status = %d, want %d", resp.StatusCode, tt.wantStatus)
+			}
+			if !strings.Contains(string(body), tt.wantContent) {
+				t.Errorf("body missing %q", tt.wantContent)
+			}
+		})
+	}
+}
+
+func TestStart(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	s, err := Start(ctx, Options{Address: "127.0.0.1:0", EnablePprof: true})
+	if err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+
+	resp, err := http.Get("http://" + s.Addr() + "/debug/pprof/")
+	if err != nil {
+		t.Fatalf("failed to reach management listener: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("status = %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+
+	cancel()
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		_, err := http.Get("http://" + s.Addr() + "/debug/pprof/")
+		if err != nil {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("management listener still serving after ctx was cancelled")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestStartErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		opts Options
+	}{
+		{name: "nothing enabled", opts: Options{Address: "127.0.0.1:0"}},
+		{name: "invalid address", opts: Options{Address: "not-an-address", EnablePprof: true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Start(context.Background(), tt.opts); err == nil {
+				t.Error("Start() expected error")
+			}
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package manager
+
+import (
+	"time"
+
+	"github.com/sol-strategies/solana-validator-version-sync/internal/report"
+)
+
+// recordRun counts a finished run and keeps its status for Status, returning the status with the daemon
+// metadata as of now
+func (m *Manager) recordRun(status report.Status, failed bool, now time.Time) report.Status {
+	m.daemonMu.Lock()
+	defer m.daemonMu.Unlock()
+
+	m.daemon.Runs++
+	if failed {
+		m.daemon.Failures++
+	}
+	status.Daemon = m.daemonAt(now)
+	m.lastStatus = status
+	return status
+}
+
+// Status returns the last run's status with the daemon metadata as of now, in the notify.timezone - only the
+// daemon metadata is set before the first run finishes
+func (m *Manager) Status() report.Status {
+	m.daemonMu.Lock()
+	defer m.daemonMu.Unlock()
+
+	status := m.lastStatus
+	status.Daemon = m.daemonAt(time.Now().UTC())
+	return status.In(m.cfg.Notify.Location())
+}
+
+// daemonAt returns a copy of the daemon metadata with the uptime as of now - callers hold daemonMu
+func (m *Manager) daemonAt(now time.Time) *report.Daemon {
+	daemon := m.daemon
+	daemon.UptimeSeconds = now.Sub(daemon.StartedAt).Seconds()
+	return &daemon
+}
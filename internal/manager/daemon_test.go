@@ -0,0 +1,65 @@
+package manager
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sol-strategies/solana-validator-version-sync/internal/config"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/report"
+)
+
+func TestManager_recordRun(t *testing.T) {
+	startedAt := time.Date(2026, 1, 2, 3, 0, 0, 0, time.UTC)
+	m := &Manager{
+		cfg:    &config.Config{},
+		daemon: report.Daemon{StartedAt: startedAt, ConfigHash: "abc"},
+	}
+
+	runs := []struct {
+		outcome      string
+		failed       bool
+		wantRuns     int
+		wantFailures int
+	}{
+		{outcome: report.OutcomeUpToDate, failed: false, wantRuns: 1, wantFailures: 0},
+		{outcome: report.OutcomeFailed, failed: true, wantRuns: 2, wantFailures: 1},
+		{outcome: report.OutcomeSynced, failed: false, wantRuns: 3, wantFailures: 1},
+	}
+
+	for i, run := range runs {
+		now := startedAt.Add(time.Duration(i+1) * time.Minute)
+		status := m.recordRun(report.Status{Decision: report.Decision{Outcome: run.outcome}}, run.failed, now)
+
+		if status.Daemon == nil {
+			t.Fatalf("run %d: recordRun() daemon = nil", i)
+		}
+		if status.Daemon.Runs != run.wantRuns || status.Daemon.Failures != run.wantFailures {
+			t.Errorf("run %d: runs, failures = %d, %d, want %d, %d", i, status.Daemon.Runs, status.Daemon.Failures, run.wantRuns, run.wantFailures)
+		}
+		if want := float64((i + 1) * 60); status.Daemon.UptimeSeconds != want {
+			t.Errorf("run %d: uptime = %v, want %v", i, status.Daemon.UptimeSeconds, want)
+		}
+		if status.Daemon.ConfigHash != "abc" {
+			t.Errorf("run %d: config hash = %q, want abc", i, status.Daemon.ConfigHash)
+		}
+	}
+
+	if status := m.Status(); status.Outcome != report.OutcomeSynced || status.Daemon.Runs != 3 {
+		t.Errorf("Status() outcome = %s, runs = %d, want the last run's %s and 3 runs", status.Outcome, status.Daemon.Runs, report.OutcomeSynced)
+	}
+}
+
+func TestManager_Status_BeforeFirstRun(t *testing.T) {
+	m := &Manager{
+		cfg:    &config.Config{},
+		daemon: report.Daemon{StartedAt: time.Now().UTC().Add(-time.Hour)},
+	}
+
+	status := m.Status()
+	if status.Daemon == nil || status.Daemon.Runs != 0 || status.Daemon.UptimeSeconds < 3600 {
+		t.Errorf("Status() daemon = %+v, want no runs and at least an hour of uptime", status.Daemon)
+	}
+	if status.Outcome != "" {
+		t.Errorf("Status() outcome = %q, want none before the first run", status.Outcome)
+	}
+}
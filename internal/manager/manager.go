@@ -1,11 +1,22 @@
 package manager
 
 import (
+	"context"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/charmbracelet/log"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/budget"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/cadence"
 	"github.com/sol-strategies/solana-validator-version-sync/internal/config"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/failinject"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/logging"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/notify"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/report"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/secrets"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/state"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/timeutil"
 	"github.com/sol-strategies/solana-validator-version-sync/internal/validator"
 )
 
@@ -14,39 +25,165 @@ type Manager struct {
 	cfg       *config.Config
 	logger    *log.Logger
 	validator *validator.Validator
+	reporter  *report.Reporter
+
+	// daemonMu guards the daemon metadata and last status, read concurrently by the management listener
+	daemonMu   sync.Mutex
+	daemon     report.Daemon
+	lastStatus report.Status
+}
+
+// Options represents the runtime options for creating a new Manager that are not part of the config file
+type Options struct {
+	// FailAt is a list of stages to deliberately fail syncs at (see failinject.ValidStages)
+	FailAt []string
+	// ReadOnly disables executing commands and persisting state regardless of config
+	ReadOnly bool
+	// Version is the running tool version, recorded in the state so whats-new can list changes since the previous one
+	Version string
 }
 
 // NewFromConfig creates a new Manager from an already loaded config
-func NewFromConfig(cfg *config.Config) (m *Manager, err error) {
+func NewFromConfig(cfg *config.Config, opts Options) (m *Manager, err error) {
 	m = &Manager{
 		cfg:    cfg,
-		logger: log.WithPrefix("manager"),
+		logger: logging.WithPrefix("manager"),
+		daemon: report.Daemon{
+			StartedAt:      time.Now().UTC(),
+			Build:          report.NewBuild(opts.Version),
+			ConfigFile:     cfg.File,
+			ConfigHash:     cfg.Hash,
+			ConfigLoadedAt: cfg.LoadedAt,
+		},
+	}
+
+	// Create failure injector
+	failureInjector, err := failinject.New(opts.FailAt)
+	if err != nil {
+		return nil, err
+	}
+
+	// Limit requests to external providers across every component
+	budget.SetLimit(budget.ProviderGitHub, cfg.Sync.RequestBudget.GitHubPerHour)
+	budget.SetLimit(budget.ProviderSFDP, cfg.Sync.RequestBudget.SFDPPerHour)
+
+	// Create state store
+	stateStore, err := state.NewStore(cfg.State.File)
+	if err != nil {
+		return nil, err
+	}
+	stateStore.SetReadOnly(opts.ReadOnly)
+
+	if opts.ReadOnly {
+		m.logger.Warn("read-only mode - commands will not be executed and state will not be persisted")
+	}
+
+	// Record the running tool version and point out behavior changes since the previous one
+	previousVersion, err := recordToolVersion(stateStore, opts.Version)
+	if err != nil {
+		return nil, err
 	}
+	m.warnToolVersionChanges(previousVersion, opts.Version)
 
 	// Create validator
 	m.validator, err = validator.New(validator.Options{
 		Cluster:         cfg.Cluster.Name,
+		HardForks:       cfg.Cluster.HardForks,
 		ValidatorConfig: cfg.Validator,
 		SyncConfig:      cfg.Sync,
+		StateStore:      stateStore,
+		FailureInjector: failureInjector,
+		ReadOnly:        opts.ReadOnly,
+		Notifier: notify.New(notify.Options{
+			URL:      cfg.Notify.Webhook.URL,
+			Headers:  cfg.Notify.Webhook.Headers,
+			Timeout:  cfg.Notify.Webhook.Timeout,
+			ReadOnly: opts.ReadOnly,
+			Location: cfg.Notify.Location(),
+		}),
+		NotifyConfig: cfg.Notify,
 	})
 
 	if err != nil {
 		return nil, err
 	}
 
+	// Create decision reporter
+	var consulToken func() (string, error)
+	if cfg.Report.Consul.Token != (secrets.Ref{}) {
+		consulToken = cfg.Report.Consul.Token.Resolve
+	}
+	m.reporter = report.New(report.Options{
+		CSVFile:          cfg.Report.CSV.File,
+		HTTPURL:          cfg.Report.HTTP.URL,
+		HTTPHeaders:      cfg.Report.HTTP.Headers,
+		HTTPTimeout:      cfg.Report.HTTP.Timeout,
+		StatusFile:       cfg.Report.Status.File,
+		ConsulAddress:    cfg.Report.Consul.Address,
+		ConsulKVPrefix:   cfg.Report.Consul.KVPrefix,
+		ConsulDatacenter: cfg.Report.Consul.Datacenter,
+		ConsulToken:      consulToken,
+		ConsulTimeout:    cfg.Report.Consul.Timeout,
+		Location:         cfg.Notify.Location(),
+		ReadOnly:         opts.ReadOnly,
+	})
+
 	// manager created
-	m.logger.Debug("created manager from config", "config", cfg)
+	m.logger.Debug("created manager from config", "config", cfg.Redacted())
 	return m, nil
 }
 
 // RunOnce runs a single sync check and exits
-func (m *Manager) RunOnce() error {
+func (m *Manager) RunOnce(ctx context.Context) error {
 	m.logger.Info("🚀 starting solana-validator-version-sync (single run mode)")
-	return m.validator.SyncVersion()
+	return m.syncVersion(ctx, 0)
 }
 
-// RunOnInterval runs the sync manager continuously at the specified interval, errors are logged but not returned after parsing the interval duration string
-func (m *Manager) RunOnInterval(intervalDuration time.Duration) (err error) {
+// syncVersion runs a single sync and reports the decision it reached - intervalDuration is the interval the next
+// sync is scheduled on, 0 in single run mode
+func (m *Manager) syncVersion(ctx context.Context, intervalDuration time.Duration) error {
+	err := m.validator.SyncVersion(ctx)
+	decision := m.validator.LastDecision()
+	m.logger.Info("sync decision", "outcome", decision.Outcome, "reasonCode", decision.ReasonCode, "reason", decision.Reason)
+	if len(decision.UnavailableSources) > 0 {
+		m.logger.Warn("sync decision reached without some data sources", "unavailableSources", decision.UnavailableSources, "outcome", decision.Outcome)
+	}
+	m.reporter.Report(decision)
+
+	var nextSyncTime *time.Time
+	if intervalDuration > 0 {
+		next := m.calculateNextBoundary(time.Now().UTC(), intervalDuration)
+		nextSyncTime = &next
+	}
+	status := report.NewStatus(decision, nextSyncTime)
+	status.ReleaseCadence = m.releaseCadence()
+	status.CommandRuns = m.validator.LastCommandRuns()
+	status = m.recordRun(status, err != nil, time.Now().UTC())
+	m.reporter.WriteStatus(status)
+
+	return err
+}
+
+// releaseCadence returns the client's release cadence, logging it for upgrade window planning - nil when it could
+// not be computed, as cadence is informational and never fails a sync
+func (m *Manager) releaseCadence() *cadence.Cadence {
+	releaseCadence, err := m.validator.ReleaseCadence()
+	if err != nil {
+		m.logger.Debug("failed to compute release cadence", "error", err)
+		return nil
+	}
+
+	m.logger.Info("release cadence",
+		"daysSinceLastRelease", fmt.Sprintf("%.1f", releaseCadence.DaysSinceLastRelease),
+		"averageIntervalDays", fmt.Sprintf("%.1f", releaseCadence.AverageIntervalDays),
+		"nextReleaseEstimate", releaseCadence.NextReleaseEstimate.Format("2006-01-02"),
+		"overdue", releaseCadence.Overdue,
+	)
+	return &releaseCadence
+}
+
+// RunOnInterval runs the sync manager continuously at the specified interval until ctx is cancelled, sync errors are logged but not returned
+func (m *Manager) RunOnInterval(ctx context.Context, intervalDuration time.Duration) (err error) {
 	m.logger.Info("🚀 starting solana-validator-version-sync (continuous mode)", "interval", intervalDuration.String())
 
 	// Calculate the next boundary time based on the interval
@@ -57,12 +194,14 @@ func (m *Manager) RunOnInterval(intervalDuration time.Duration) (err error) {
 	if nextSyncTime.After(now) {
 		waitDuration := nextSyncTime.Sub(now)
 		m.logger.Info("waiting until next interval boundary", "wait", waitDuration.String(), "next_sync", nextSyncTime.Format("2006-01-02T15:04:05Z"))
-		time.Sleep(waitDuration)
+		if err := timeutil.Sleep(ctx, waitDuration); err != nil {
+			return err
+		}
 	}
 
 	// Run sync on a loop, aligning to interval boundaries
 	for {
-		m.runSyncVersionInterval(intervalDuration)
+		m.runSyncVersionInterval(ctx, intervalDuration)
 
 		// Calculate next boundary time
 		now = time.Now().UTC()
@@ -70,7 +209,9 @@ func (m *Manager) RunOnInterval(intervalDuration time.Duration) (err error) {
 		waitDuration := nextSyncTime.Sub(now)
 
 		if waitDuration > 0 {
-			time.Sleep(waitDuration)
+			if err := timeutil.Sleep(ctx, waitDuration); err != nil {
+				return err
+			}
 		}
 	}
 }
@@ -98,9 +239,9 @@ func (m *Manager) calculateNextBoundary(now time.Time, intervalDuration time.Dur
 }
 
 // runSyncVersionInterval runs the sync version and logs the result without returning an error - used with on interval mode
-func (m *Manager) runSyncVersionInterval(intervalDuration time.Duration) {
+func (m *Manager) runSyncVersionInterval(ctx context.Context, intervalDuration time.Duration) {
 	m.logger.Info("running sync")
-	err := m.validator.SyncVersion()
+	err := m.syncVersion(ctx, intervalDuration)
 	now := time.Now().UTC()
 	nextSyncTime := m.calculateNextBoundary(now, intervalDuration)
 
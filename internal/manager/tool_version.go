@@ -0,0 +1,58 @@
+package manager
+
+import (
+	"fmt"
+
+	"github.com/sol-strategies/solana-validator-version-sync/internal/changelog"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/state"
+)
+
+// recordToolVersion records the running tool version in the state, keeping the version that ran before it so
+// whats-new can list the behavior changes between them. Returns the previously run version when it changed,
+// empty otherwise.
+func recordToolVersion(stateStore *state.Store, toolVersion string) (previousVersion string, err error) {
+	recordedVersion := stateStore.Get().ToolVersion
+	if toolVersion == "" || recordedVersion == toolVersion {
+		return "", nil
+	}
+
+	err = stateStore.Update(func(data *state.Data) {
+		data.PreviousToolVersion = data.ToolVersion
+		data.ToolVersion = toolVersion
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to record tool version: %w", err)
+	}
+	return recordedVersion, nil
+}
+
+// warnToolVersionChanges warns when the tool version changed since the last run and behavior changes shipped in between
+func (m *Manager) warnToolVersionChanges(previousVersion, toolVersion string) {
+	if previousVersion == "" {
+		return
+	}
+
+	changes, err := changelog.All()
+	if err != nil {
+		m.logger.Warn("failed to load changelog", "error", err)
+		return
+	}
+
+	changes = changelog.Between(changes, previousVersion, toolVersion)
+	if len(changes) == 0 {
+		return
+	}
+
+	breaking := 0
+	for _, change := range changes {
+		if change.Kind == changelog.KindBreaking {
+			breaking++
+		}
+	}
+	m.logger.Warn("behavior changed since the previously run version - run whats-new for details",
+		"previousVersion", previousVersion,
+		"version", toolVersion,
+		"changes", len(changes),
+		"breaking", breaking,
+	)
+}
@@ -0,0 +1,66 @@
+package manager
+
+import (
+	"testing"
+
+	"github.com/sol-strategies/solana-validator-version-sync/internal/state"
+)
+
+func TestRecordToolVersion(t *testing.T) {
+	tests := []struct {
+		name         string
+		recorded     state.Data
+		toolVersion  string
+		wantPrevious string
+		wantState    state.Data
+	}{
+		{
+			name:        "first run",
+			toolVersion: "1.2.0",
+			wantState:   state.Data{ToolVersion: "1.2.0"},
+		},
+		{
+			name:         "upgrade",
+			recorded:     state.Data{ToolVersion: "1.1.0", PreviousToolVersion: "1.0.0"},
+			toolVersion:  "1.2.0",
+			wantPrevious: "1.1.0",
+			wantState:    state.Data{ToolVersion: "1.2.0", PreviousToolVersion: "1.1.0"},
+		},
+		{
+			name:        "same version keeps previous version",
+			recorded:    state.Data{ToolVersion: "1.2.0", PreviousToolVersion: "1.1.0"},
+			toolVersion: "1.2.0",
+			wantState:   state.Data{ToolVersion: "1.2.0", PreviousToolVersion: "1.1.0"},
+		},
+		{
+			name:        "no version",
+			recorded:    state.Data{ToolVersion: "1.2.0"},
+			toolVersion: "",
+			wantState:   state.Data{ToolVersion: "1.2.0"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stateStore, err := state.NewStore("")
+			if err != nil {
+				t.Fatalf("NewStore() error = %v", err)
+			}
+			_ = stateStore.Update(func(data *state.Data) { *data = tt.recorded })
+
+			previousVersion, err := recordToolVersion(stateStore, tt.toolVersion)
+			if err != nil {
+				t.Fatalf("recordToolVersion() error = %v", err)
+			}
+			if previousVersion != tt.wantPrevious {
+				t.Errorf("recordToolVersion() = %q, want %q", previousVersion, tt.wantPrevious)
+			}
+
+			got := stateStore.Get()
+			if got.ToolVersion != tt.wantState.ToolVersion || got.PreviousToolVersion != tt.wantState.PreviousToolVersion {
+				t.Errorf("state = %q (previous %q), want %q (previous %q)",
+					got.ToolVersion, got.PreviousToolVersion, tt.wantState.ToolVersion, tt.wantState.PreviousToolVersion)
+			}
+		})
+	}
+}
@@ -0,0 +1,147 @@
+package nomad
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/logging"
+)
+
+// Options represents the options for creating a new Client
+type Options struct {
+	// Address is the Nomad HTTP API address, e.g. http://127.0.0.1:4646
+	Address string
+	// Namespace is the namespace jobs are looked up in
+	Namespace string
+	// Region is the region jobs are looked up in - the agent's region when empty
+	Region string
+	// Token is the ACL token sent as X-Nomad-Token - not sent when empty
+	Token string
+	// Timeout is the timeout for each request
+	Timeout time.Duration
+}
+
+// Client updates Nomad jobs through the Nomad HTTP API
+type Client struct {
+	opts       Options
+	httpClient *http.Client
+	logger     *log.Logger
+}
+
+// NewClient creates a new Client
+func NewClient(opts Options) *Client {
+	return &Client{
+		opts:       opts,
+		httpClient: &http.Client{Timeout: opts.Timeout},
+		logger:     logging.WithPrefix("nomad"),
+	}
+}
+
+// SetJobMeta sets the job's meta key to value and registers the updated job, which triggers its deployment.
+// Jobs whose meta already has the value are left as they are so repeated syncs don't trigger new deployments.
+// Returns the ID of the evaluation registering the job created, empty when the job was left as it is.
+func (c *Client) SetJobMeta(ctx context.Context, jobID string, key string, value string) (evalID string, err error) {
+	job, err := c.getJob(ctx, jobID)
+	if err != nil {
+		return "", err
+	}
+
+	meta, _ := job["Meta"].(map[string]any)
+	if meta == nil {
+		meta = map[string]any{}
+	}
+	if current, ok := meta[key].(string); ok && current == value {
+		c.logger.Debug("job meta already set", "job", jobID, "key", key, "value", value)
+		return "", nil
+	}
+	meta[key] = value
+	job["Meta"] = meta
+
+	// only register over the job as read, so concurrent updates by operators or other tooling are not clobbered
+	request := map[string]any{
+		"Job":            job,
+		"EnforceIndex":   true,
+		"JobModifyIndex": job["JobModifyIndex"],
+	}
+	var response struct {
+		EvalID string `json:"EvalID"`
+	}
+	err = c.do(ctx, http.MethodPost, "/v1/job/"+url.PathEscape(jobID), request, &response)
+	if err != nil {
+		return "", fmt.Errorf("failed to register job %s: %w", jobID, err)
+	}
+
+	c.logger.Debug("registered job", "job", jobID, "key", key, "value", value, "evalID", response.EvalID)
+	return response.EvalID, nil
+}
+
+// getJob gets the job with the given ID - kept as generic JSON so registering it back preserves every field
+func (c *Client) getJob(ctx context.Context, jobID string) (job map[string]any, err error) {
+	err = c.do(ctx, http.MethodGet, "/v1/job/"+url.PathEscape(jobID), nil, &job)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get job %s: %w", jobID, err)
+	}
+	return job, nil
+}
+
+// do sends a request to the Nomad API, encoding body as JSON when set and decoding the response into result
+func (c *Client) do(ctx context.Context, method string, path string, body any, result any) error {
+	query := url.Values{}
+	if c.opts.Namespace != "" {
+		query.Set("namespace", c.opts.Namespace)
+	}
+	if c.opts.Region != "" {
+		query.Set("region", c.opts.Region)
+	}
+	requestURL := strings.TrimRight(c.opts.Address, "/") + path
+	if len(query) > 0 {
+		requestURL += "?" + query.Encode()
+	}
+
+	var requestBody io.Reader
+	if body != nil {
+		content, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to marshal request: %w", err)
+		}
+		requestBody = bytes.NewReader(content)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, requestURL, requestBody)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.opts.Token != "" {
+		req.Header.Set("X-Nomad-Token", c.opts.Token)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("nomad API returned status %d: %s", resp.StatusCode, strings.TrimSpace(string(message)))
+	}
+
+	// keep numbers (e.g. modify indexes) as they are when registering jobs back
+	decoder := json.NewDecoder(resp.Body)
+	decoder.UseNumber()
+	if err := decoder.Decode(result); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,122 @@
+package nomad
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestClient_SetJobMeta(t *testing.T) {
+	tests := []struct {
+		name           string
+		job            string
+		registerStatus int
+		wantEvalID     string
+		wantRegistered bool
+		wantMeta       map[string]any
+		wantErr        bool
+	}{
+		{
+			name:           "meta updated",
+			job:            `{"ID":"validator","JobModifyIndex":123456789012,"Meta":{"validator_version":"2.3.5","owner":"ops"},"TaskGroups":[{"Name":"validator"}]}`,
+			registerStatus: http.StatusOK,
+			wantEvalID:     "eval-1",
+			wantRegistered: true,
+			wantMeta:       map[string]any{"validator_version": "2.3.6", "owner": "ops"},
+		},
+		{
+			name:           "job without meta",
+			job:            `{"ID":"validator","JobModifyIndex":7,"Meta":null}`,
+			registerStatus: http.StatusOK,
+			wantEvalID:     "eval-1",
+			wantRegistered: true,
+			wantMeta:       map[string]any{"validator_version": "2.3.6"},
+		},
+		{
+			name:           "meta already set",
+			job:            `{"ID":"validator","JobModifyIndex":7,"Meta":{"validator_version":"2.3.6"}}`,
+			wantRegistered: false,
+		},
+		{
+			name:           "register rejected",
+			job:            `{"ID":"validator","JobModifyIndex":7,"Meta":{}}`,
+			registerStatus: http.StatusInternalServerError,
+			wantRegistered: true,
+			wantErr:        true,
+		},
+		{
+			name:    "job not found",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var registered map[string]any
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/v1/job/validator" {
+					t.Errorf("requested %s, want /v1/job/validator", r.URL.Path)
+				}
+				if got := r.URL.Query().Get("namespace"); got != "validators" {
+					t.Errorf("namespace = %q, want validators", got)
+				}
+				if got := r.Header.Get("X-Nomad-Token"); got != "secret-token" {
+					t.Errorf("X-Nomad-Token = %q, want secret-token", got)
+				}
+
+				switch r.Method {
+				case http.MethodGet:
+					if tt.job == "" {
+						http.Error(w, "job not found", http.StatusNotFound)
+						return
+					}
+					_, _ = w.Write([]byte(tt.job))
+				case http.MethodPost:
+					decoder := json.NewDecoder(r.Body)
+					decoder.UseNumber()
+					if err := decoder.Decode(&registered); err != nil {
+						t.Errorf("failed to decode register request: %v", err)
+					}
+					w.WriteHeader(tt.registerStatus)
+					_, _ = w.Write([]byte(`{"EvalID":"eval-1"}`))
+				}
+			}))
+			defer server.Close()
+
+			client := NewClient(Options{Address: server.URL, Namespace: "validators", Token: "secret-token", Timeout: 5 * time.Second})
+			evalID, err := client.SetJobMeta(context.Background(), "validator", "validator_version", "2.3.6")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("SetJobMeta() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if evalID != tt.wantEvalID {
+				t.Errorf("SetJobMeta() evalID = %q, want %q", evalID, tt.wantEvalID)
+			}
+			if (registered != nil) != tt.wantRegistered {
+				t.Fatalf("SetJobMeta() registered = %v, want %v", registered != nil, tt.wantRegistered)
+			}
+			if registered == nil || tt.wantErr {
+				return
+			}
+
+			if registered["EnforceIndex"] != true {
+				t.Errorf("register EnforceIndex = %v, want true", registered["EnforceIndex"])
+			}
+			job := registered["Job"].(map[string]any)
+			if registered["JobModifyIndex"] != job["JobModifyIndex"] {
+				t.Errorf("register JobModifyIndex = %v, want the job's %v", registered["JobModifyIndex"], job["JobModifyIndex"])
+			}
+			meta := job["Meta"].(map[string]any)
+			if len(meta) != len(tt.wantMeta) {
+				t.Errorf("registered meta = %v, want %v", meta, tt.wantMeta)
+			}
+			for key, value := range tt.wantMeta {
+				if meta[key] != value {
+					t.Errorf("registered meta[%s] = %v, want %v", key, meta[key], value)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,160 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/log"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/logging"
+)
+
+const (
+	// KindHeadsUp is the kind of the one-time notification sent when a new sync target is first detected
+	KindHeadsUp = "heads_up"
+	// KindDriftDetected is the kind of the notification sent while the validator runs a version other than the target
+	KindDriftDetected = "drift_detected"
+	// KindActivationHeld is the kind of the notification sent while a gate holds activation of the target back
+	KindActivationHeld = "activation_held"
+)
+
+// Event represents a notification posted to the webhook as JSON
+type Event struct {
+	Kind              string     `json:"kind"`
+	Time              time.Time  `json:"time"`
+	Cluster           string     `json:"cluster"`
+	Client            string     `json:"client"`
+	IdentityPublicKey string     `json:"identity_public_key"`
+	VersionFrom       string     `json:"version_from"`
+	VersionTo         string     `json:"version_to"`
+	VersionToTag      string     `json:"version_to_tag"`
+	ReleaseURL        string     `json:"release_url,omitempty"`
+	ReleaseNotes      string     `json:"release_notes,omitempty"`
+	ProjectedApplyAt  *time.Time `json:"projected_apply_at,omitempty"`
+	Outcome           string     `json:"outcome,omitempty"`
+	ReasonCode        string     `json:"reason_code,omitempty"`
+	Reason            string     `json:"reason,omitempty"`
+	SFDPStage         string     `json:"sfdp_stage,omitempty"`
+	// Text is a human-readable summary of the event - also what Slack-compatible incoming webhooks display
+	Text string `json:"text"`
+}
+
+// Options represents the options for creating a new Notifier
+type Options struct {
+	// URL is the webhook endpoint events are posted to as JSON - disabled when empty
+	URL string
+	// Headers are extra headers sent with each post, e.g. for authentication
+	Headers map[string]string
+	// Timeout is the timeout for each post
+	Timeout time.Duration
+	// ReadOnly disables sending notifications regardless of the configured webhook
+	ReadOnly bool
+	// Location is the timezone event timestamps and human-readable times are rendered in, UTC when nil
+	Location *time.Location
+}
+
+// Notifier posts notification events to a webhook
+type Notifier struct {
+	opts       Options
+	httpClient *http.Client
+	logger     *log.Logger
+}
+
+// New creates a new Notifier
+func New(opts Options) *Notifier {
+	return &Notifier{
+		opts:       opts,
+		httpClient: &http.Client{Timeout: opts.Timeout},
+		logger:     logging.WithPrefix("notify"),
+	}
+}
+
+// Enabled returns true when a webhook is configured - a nil Notifier is disabled
+func (n *Notifier) Enabled() bool {
+	return n != nil && n.opts.URL != ""
+}
+
+// FormatTime renders t for humans relative to now in the notifier's timezone, e.g. "in 3h at 02:00 UTC+1"
+func (n *Notifier) FormatTime(t time.Time, now time.Time) string {
+	return FormatTime(t, now, n.location())
+}
+
+// location returns the timezone timestamps are rendered in - UTC unless configured
+func (n *Notifier) location() *time.Location {
+	if n == nil || n.opts.Location == nil {
+		return time.UTC
+	}
+	return n.opts.Location
+}
+
+// Notify posts the event to the webhook - callers decide whether a failed notification fails anything
+func (n *Notifier) Notify(event Event) error {
+	if !n.Enabled() {
+		return nil
+	}
+
+	if n.opts.ReadOnly {
+		n.logger.Warn("read-only mode - not sending notification", "kind", event.Kind, "text", event.Text)
+		return nil
+	}
+
+	// render timestamps in the configured timezone - they still denote the same instant
+	event.Time = event.Time.In(n.location())
+	if event.ProjectedApplyAt != nil {
+		projectedApplyAt := event.ProjectedApplyAt.In(n.location())
+		event.ProjectedApplyAt = &projectedApplyAt
+	}
+
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s notification: %w", event.Kind, err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), n.opts.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.opts.URL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for name, value := range n.opts.Headers {
+		req.Header.Set(name, value)
+	}
+
+	resp, err := n.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send %s notification: %w", event.Kind, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("unexpected status code %d sending %s notification: %s", resp.StatusCode, event.Kind, string(respBody))
+	}
+
+	n.logger.Info("sent notification", "kind", event.Kind, "versionTo", event.VersionTo)
+	return nil
+}
+
+// Summarize trims text to at most maxLength characters, cutting at the last word boundary and marking the cut
+// with an ellipsis - text is returned trimmed but otherwise unchanged when it fits or maxLength is 0
+func Summarize(text string, maxLength int) string {
+	text = strings.TrimSpace(text)
+	if maxLength <= 0 || utf8.RuneCountInString(text) <= maxLength {
+		return text
+	}
+
+	runes := []rune(text)
+	cut := string(runes[:maxLength])
+	if i := strings.LastIndexAny(cut, " \t\n"); i > 0 {
+		cut = cut[:i]
+	}
+	return strings.TrimSpace(cut) + "…"
+}
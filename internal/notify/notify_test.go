@@ -0,0 +1,141 @@
+package notify
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestNotifier_Notify(t *testing.T) {
+	tests := []struct {
+		name         string
+		status       int
+		readOnly     bool
+		wantErr      bool
+		wantRequests int
+	}{
+		{
+			name:         "posted",
+			status:       http.StatusOK,
+			wantRequests: 1,
+		},
+		{
+			name:         "unexpected status",
+			status:       http.StatusInternalServerError,
+			wantErr:      true,
+			wantRequests: 1,
+		},
+		{
+			name:         "read-only",
+			status:       http.StatusOK,
+			readOnly:     true,
+			wantRequests: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests := 0
+			var received Event
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				if got := r.Header.Get("Authorization"); got != "Bearer token" {
+					t.Errorf("Authorization header = %q, want Bearer token", got)
+				}
+				if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+					t.Errorf("failed to decode event: %v", err)
+				}
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+
+			notifier := New(Options{
+				URL:      server.URL,
+				Headers:  map[string]string{"Authorization": "Bearer token"},
+				Timeout:  time.Second,
+				ReadOnly: tt.readOnly,
+			})
+
+			err := notifier.Notify(Event{Kind: KindHeadsUp, VersionTo: "3.0.10", Text: "heads up"})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Notify() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if requests != tt.wantRequests {
+				t.Fatalf("requests = %d, want %d", requests, tt.wantRequests)
+			}
+			if tt.wantRequests > 0 && (received.Kind != KindHeadsUp || received.VersionTo != "3.0.10" || received.Text != "heads up") {
+				t.Errorf("received event = %+v, want heads_up to 3.0.10", received)
+			}
+		})
+	}
+}
+
+func TestNotifier_Notify_Location(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatalf("failed to load location: %v", err)
+	}
+
+	var received map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+			t.Errorf("failed to decode event: %v", err)
+		}
+	}))
+	defer server.Close()
+
+	now := time.Date(2026, 1, 14, 22, 0, 0, 0, time.UTC)
+	projectedApplyAt := now.Add(3 * time.Hour)
+	notifier := New(Options{URL: server.URL, Timeout: time.Second, Location: tokyo})
+	if err := notifier.Notify(Event{Kind: KindHeadsUp, Time: now, ProjectedApplyAt: &projectedApplyAt}); err != nil {
+		t.Fatalf("Notify() error = %v", err)
+	}
+
+	if received["time"] != "2026-01-15T07:00:00+09:00" || received["projected_apply_at"] != "2026-01-15T10:00:00+09:00" {
+		t.Errorf("received time = %v, projected_apply_at = %v, want them in Asia/Tokyo", received["time"], received["projected_apply_at"])
+	}
+	if got := notifier.FormatTime(projectedApplyAt, now); got != "in 3h at 10:00 UTC+9" {
+		t.Errorf("FormatTime() = %q, want %q", got, "in 3h at 10:00 UTC+9")
+	}
+}
+
+func TestNotifier_Enabled(t *testing.T) {
+	var nilNotifier *Notifier
+	if nilNotifier.Enabled() {
+		t.Error("nil Notifier Enabled() = true, want false")
+	}
+	if nilNotifier.Notify(Event{}) != nil {
+		t.Error("nil Notifier Notify() returned an error, want nil")
+	}
+	if New(Options{}).Enabled() {
+		t.Error("Notifier without URL Enabled() = true, want false")
+	}
+	if !New(Options{URL: "https://hooks.example.com"}).Enabled() {
+		t.Error("Notifier with URL Enabled() = false, want true")
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	tests := []struct {
+		name      string
+		text      string
+		maxLength int
+		want      string
+	}{
+		{name: "fits", text: "  Bug fixes  \n", maxLength: 20, want: "Bug fixes"},
+		{name: "no limit", text: "Bug fixes and performance improvements", maxLength: 0, want: "Bug fixes and performance improvements"},
+		{name: "cut at word boundary", text: "Bug fixes and performance improvements", maxLength: 20, want: "Bug fixes and…"},
+		{name: "cut without word boundary", text: "Bugfixesandperformance", maxLength: 8, want: "Bugfixes…"},
+		{name: "multibyte", text: "ünïcödé notes", maxLength: 7, want: "ünïcödé…"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Summarize(tt.text, tt.maxLength); got != tt.want {
+				t.Errorf("Summarize() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package notify
+
+import (
+	"fmt"
+	"time"
+)
+
+// FormatTime renders t for humans relative to now in location, e.g. "in 3h at 02:00 UTC+1" or "2h ago at 14:00 UTC".
+// The date is included when t falls on another day than now in location, e.g. "in 2d at Wed 3 Jan 02:00 UTC+1".
+func FormatTime(t time.Time, now time.Time, location *time.Location) string {
+	if location == nil {
+		location = time.UTC
+	}
+	t = t.In(location)
+	now = now.In(location)
+
+	layout := "15:04"
+	if t.Year() != now.Year() || t.YearDay() != now.YearDay() {
+		layout = "Mon 2 Jan 15:04"
+	}
+	at := t.Format(layout) + " " + zoneLabel(t)
+
+	d := t.Sub(now).Round(time.Minute)
+	switch {
+	case d == 0:
+		return "now at " + at
+	case d > 0:
+		return "in " + formatDuration(d) + " at " + at
+	default:
+		return formatDuration(-d) + " ago at " + at
+	}
+}
+
+// formatDuration renders a positive duration coarsely - minutes below an hour, hours and minutes below two days,
+// days otherwise
+func formatDuration(d time.Duration) string {
+	switch {
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	case d < 48*time.Hour:
+		hours := int(d.Hours())
+		if minutes := int(d.Minutes()) % 60; minutes != 0 {
+			return fmt.Sprintf("%dh%dm", hours, minutes)
+		}
+		return fmt.Sprintf("%dh", hours)
+	default:
+		return fmt.Sprintf("%dd", int(d.Hours())/24)
+	}
+}
+
+// zoneLabel renders the UTC offset of t, e.g. UTC, UTC+1 or UTC-3:30 - unambiguous unlike zone abbreviations
+func zoneLabel(t time.Time) string {
+	_, offset := t.Zone()
+	if offset == 0 {
+		return "UTC"
+	}
+
+	sign := "+"
+	if offset < 0 {
+		sign = "-"
+		offset = -offset
+	}
+	if minutes := offset % 3600 / 60; minutes != 0 {
+		return fmt.Sprintf("UTC%s%d:%02d", sign, offset/3600, minutes)
+	}
+	return fmt.Sprintf("UTC%s%d", sign, offset/3600)
+}
@@ -0,0 +1,46 @@
+package notify
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFormatTime(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatalf("failed to load location: %v", err)
+	}
+	kolkata, err := time.LoadLocation("Asia/Kolkata")
+	if err != nil {
+		t.Fatalf("failed to load location: %v", err)
+	}
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatalf("failed to load location: %v", err)
+	}
+
+	now := time.Date(2026, 1, 14, 22, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		t        time.Time
+		location *time.Location
+		want     string
+	}{
+		{name: "now", t: now.Add(20 * time.Second), location: nil, want: "now at 22:00 UTC"},
+		{name: "minutes", t: now.Add(25 * time.Minute), location: time.UTC, want: "in 25m at 22:25 UTC"},
+		{name: "hours in another timezone and day", t: now.Add(3 * time.Hour), location: berlin, want: "in 3h at Thu 15 Jan 02:00 UTC+1"},
+		{name: "hours and minutes", t: now.Add(90 * time.Minute), location: time.UTC, want: "in 1h30m at 23:30 UTC"},
+		{name: "days", t: now.Add(50 * time.Hour), location: time.UTC, want: "in 2d at Sat 17 Jan 00:00 UTC"},
+		{name: "past", t: now.Add(-2 * time.Hour), location: kolkata, want: "2h ago at 01:30 UTC+5:30"},
+		{name: "negative offset", t: now.Add(time.Hour), location: newYork, want: "in 1h at 18:00 UTC-5"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FormatTime(tt.t, now, tt.location); got != tt.want {
+				t.Errorf("FormatTime() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,130 @@
+package recipes
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/sol-strategies/solana-validator-version-sync/internal/constants"
+)
+
+const (
+	// DefaultInstallDir is the directory versions are installed into by default, one directory per tag
+	DefaultInstallDir = "/home/solana/releases"
+	// DefaultActiveReleaseLink is the symlink pointed at the installed version by default
+	DefaultActiveReleaseLink = "/home/solana/.local/share/solana/install/active_release"
+	// DefaultValidatorService is the validator systemd service restarted by default
+	DefaultValidatorService = "solana-validator.service"
+)
+
+//go:embed recipes/*.yaml.tmpl
+var recipesFS embed.FS
+
+// recipes use [[ ]] delimiters so the {{ }} command templates are left as-is,
+// option values are rendered with quote so they are always valid YAML scalars
+var recipeTemplates = template.Must(
+	template.New("recipes").
+		Delims("[[", "]]").
+		Option("missingkey=error").
+		Funcs(template.FuncMap{"quote": strconv.Quote}).
+		ParseFS(recipesFS, "recipes/*.yaml.tmpl"),
+)
+
+// Recipe represents a curated set of sync commands for a client
+type Recipe struct {
+	// Name is the name the recipe is selected by with sync.recipe
+	Name string
+	// Client is the validator client the recipe installs
+	Client string
+	// Description is a short summary of how the recipe installs the client
+	Description string
+}
+
+// all are the recipes shipped with the binary
+var all = []Recipe{
+	{
+		Name:        "agave-default",
+		Client:      constants.ClientNameAgave,
+		Description: "download the release tarball from GitHub, symlink it as the active release and restart the validator service",
+	},
+	{
+		Name:        "jito-solana-default",
+		Client:      constants.ClientNameJitoSolana,
+		Description: "build the release git tag, symlink it as the active release and restart the validator service",
+	},
+	{
+		Name:        "firedancer-default",
+		Client:      constants.ClientNameFiredancer,
+		Description: "build fdctl from the release git tag, symlink it as the active release and restart the validator service",
+	},
+}
+
+// Options represents the values recipes are parameterized with
+type Options struct {
+	// InstallDir is the directory versions are installed into, one directory per tag
+	InstallDir string
+	// ActiveReleaseLink is the symlink pointed at the installed version on activation
+	ActiveReleaseLink string
+	// ValidatorService is the validator systemd service restarted on activation
+	ValidatorService string
+}
+
+// DefaultOptions returns the default recipe options
+func DefaultOptions() Options {
+	return Options{
+		InstallDir:        DefaultInstallDir,
+		ActiveReleaseLink: DefaultActiveReleaseLink,
+		ValidatorService:  DefaultValidatorService,
+	}
+}
+
+// Validate validates the recipe options
+func (o Options) Validate() error {
+	if o.InstallDir == "" || o.ActiveReleaseLink == "" || o.ValidatorService == "" {
+		return fmt.Errorf("install dir, active release link and validator service are required")
+	}
+	return nil
+}
+
+// All returns the recipes shipped with the binary
+func All() []Recipe {
+	return append([]Recipe{}, all...)
+}
+
+// Names returns the names of the recipes shipped with the binary
+func Names() (names []string) {
+	for _, recipe := range all {
+		names = append(names, recipe.Name)
+	}
+	return names
+}
+
+// Get returns the named recipe
+func Get(name string) (recipe Recipe, err error) {
+	for _, recipe := range all {
+		if recipe.Name == name {
+			return recipe, nil
+		}
+	}
+	return Recipe{}, fmt.Errorf("unknown recipe: %s - must be one of %s", name, strings.Join(Names(), ", "))
+}
+
+// Render renders the named recipe with the supplied options as YAML with a top-level commands list
+func Render(name string, opts Options) ([]byte, error) {
+	if _, err := Get(name); err != nil {
+		return nil, err
+	}
+
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+
+	buf := bytes.Buffer{}
+	if err := recipeTemplates.ExecuteTemplate(&buf, name+".yaml.tmpl", opts); err != nil {
+		return nil, fmt.Errorf("failed to render recipe %s: %w", name, err)
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,87 @@
+package recipes
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/knadh/koanf/parsers/yaml"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/constants"
+)
+
+func TestRender(t *testing.T) {
+	for _, recipe := range All() {
+		t.Run(recipe.Name, func(t *testing.T) {
+			if err := constants.ValidateClientName(recipe.Client); err != nil {
+				t.Errorf("recipe client: %v", err)
+			}
+
+			opts := Options{
+				InstallDir:        "/opt/releases",
+				ActiveReleaseLink: "/opt/active_release",
+				ValidatorService:  "validator \"test\".service",
+			}
+			rendered, err := Render(recipe.Name, opts)
+			if err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+
+			parsed, err := yaml.Parser().Unmarshal(rendered)
+			if err != nil {
+				t.Fatalf("rendered recipe is not valid YAML: %v\n%s", err, rendered)
+			}
+
+			commands, ok := parsed["commands"].([]interface{})
+			if !ok || len(commands) == 0 {
+				t.Fatalf("rendered recipe has no commands: %v", parsed)
+			}
+
+			content := string(rendered)
+			for _, want := range []string{`"/opt/releases"`, `"/opt/active_release"`, `"validator \"test\".service"`, "{{ .VersionToTag }}"} {
+				if !strings.Contains(content, want) {
+					t.Errorf("rendered recipe missing %s", want)
+				}
+			}
+		})
+	}
+}
+
+func TestRender_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		recipeName string
+		opts       Options
+	}{
+		{
+			name:       "unknown recipe",
+			recipeName: "agave-custom",
+			opts:       DefaultOptions(),
+		},
+		{
+			name:       "missing options",
+			recipeName: "agave-default",
+			opts:       Options{InstallDir: DefaultInstallDir},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Render(tt.recipeName, tt.opts); err == nil {
+				t.Error("Render() error = nil, want error")
+			}
+		})
+	}
+}
+
+func TestGet(t *testing.T) {
+	recipe, err := Get("jito-solana-default")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if recipe.Client != constants.ClientNameJitoSolana {
+		t.Errorf("Get().Client = %s, want %s", recipe.Client, constants.ClientNameJitoSolana)
+	}
+
+	if _, err := Get("unknown"); err == nil || !strings.Contains(err.Error(), "agave-default") {
+		t.Errorf("Get() error = %v, want error listing recipe names", err)
+	}
+}
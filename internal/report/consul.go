@@ -0,0 +1,108 @@
+package report
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// ConsulEntry is the value published to the Consul KV store after every run, so fleet discovery tools can see
+// version drift without scraping metrics
+type ConsulEntry struct {
+	Time              time.Time `json:"time"`
+	Cluster           string    `json:"cluster"`
+	Client            string    `json:"client"`
+	IdentityPublicKey string    `json:"identity_public_key"`
+	Role              string    `json:"role"`
+	// CurrentVersion is the version the validator ran when the run started
+	CurrentVersion string `json:"current_version"`
+	// TargetVersion is the version the run synced or would sync to - empty when no target was found
+	TargetVersion    string `json:"target_version"`
+	TargetVersionTag string `json:"target_version_tag"`
+	// Drift is true when the validator ran a version other than the target
+	Drift      bool   `json:"drift"`
+	Outcome    string `json:"outcome"`
+	ReasonCode string `json:"reason_code"`
+}
+
+// NewConsulEntry creates the Consul KV entry published for a decision
+func NewConsulEntry(decision Decision) ConsulEntry {
+	return ConsulEntry{
+		Time:              decision.Time.UTC(),
+		Cluster:           decision.Cluster,
+		Client:            decision.Client,
+		IdentityPublicKey: decision.IdentityPublicKey,
+		Role:              decision.Role,
+		CurrentVersion:    decision.VersionFrom,
+		TargetVersion:     decision.VersionTo,
+		TargetVersionTag:  decision.VersionToTag,
+		Drift:             decision.VersionFrom != "" && decision.VersionTo != "" && decision.VersionFrom != decision.VersionTo,
+		Outcome:           decision.Outcome,
+		ReasonCode:        decision.ReasonCode,
+	}
+}
+
+// consulKey returns the KV key the decision is published under - empty when the identity is unknown, e.g. when
+// the validator could not be reached
+func (r *Reporter) consulKey(decision Decision) string {
+	if decision.IdentityPublicKey == "" {
+		return ""
+	}
+	return strings.Trim(r.opts.ConsulKVPrefix, "/") + "/" + decision.IdentityPublicKey
+}
+
+// putConsul publishes the decision's versions to the Consul KV store
+func (r *Reporter) putConsul(key string, decision Decision) error {
+	body, err := json.Marshal(NewConsulEntry(decision))
+	if err != nil {
+		return fmt.Errorf("failed to marshal consul entry: %w", err)
+	}
+
+	// resolve the token at publish time so rotated tokens are picked up without a restart
+	token := ""
+	if r.opts.ConsulToken != nil {
+		token, err = r.opts.ConsulToken()
+		if err != nil {
+			return fmt.Errorf("failed to resolve consul token: %w", err)
+		}
+	}
+
+	kvURL := strings.TrimRight(r.opts.ConsulAddress, "/") + "/v1/kv/" + (&url.URL{Path: key}).EscapedPath()
+	if r.opts.ConsulDatacenter != "" {
+		kvURL += "?" + url.Values{"dc": []string{r.opts.ConsulDatacenter}}.Encode()
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), r.opts.ConsulTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, kvURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("X-Consul-Token", token)
+	}
+
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, string(respBody))
+	}
+	if strings.TrimSpace(string(respBody)) != "true" {
+		return fmt.Errorf("consul did not store the entry: %s", string(respBody))
+	}
+
+	return nil
+}
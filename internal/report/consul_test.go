@@ -0,0 +1,141 @@
+package report
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestNewConsulEntry(t *testing.T) {
+	tests := []struct {
+		name        string
+		versionFrom string
+		versionTo   string
+		wantDrift   bool
+	}{
+		{name: "up to date", versionFrom: "2.3.6", versionTo: "2.3.6", wantDrift: false},
+		{name: "behind target", versionFrom: "2.3.5", versionTo: "2.3.6", wantDrift: true},
+		{name: "no target", versionFrom: "2.3.5", versionTo: "", wantDrift: false},
+		{name: "version unknown", versionFrom: "", versionTo: "", wantDrift: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			decision := testDecision(OutcomeSkipped)
+			decision.VersionFrom = tt.versionFrom
+			decision.VersionTo = tt.versionTo
+
+			entry := NewConsulEntry(decision)
+			if entry.Drift != tt.wantDrift {
+				t.Errorf("NewConsulEntry() drift = %v, want %v", entry.Drift, tt.wantDrift)
+			}
+			if entry.CurrentVersion != tt.versionFrom || entry.TargetVersion != tt.versionTo {
+				t.Errorf("NewConsulEntry() versions = %s -> %s, want %s -> %s", entry.CurrentVersion, entry.TargetVersion, tt.versionFrom, tt.versionTo)
+			}
+		})
+	}
+}
+
+func TestReporter_Report_Consul(t *testing.T) {
+	tests := []struct {
+		name         string
+		identity     string
+		token        func() (string, error)
+		datacenter   string
+		wantRequests int
+		wantPath     string
+		wantToken    string
+		wantDC       string
+	}{
+		{
+			name:         "published under identity",
+			identity:     "identity-key",
+			wantRequests: 1,
+			wantPath:     "/v1/kv/fleet/versions/identity-key",
+		},
+		{
+			name:         "with token and datacenter",
+			identity:     "identity-key",
+			token:        func() (string, error) { return "secret-token", nil },
+			datacenter:   "dc2",
+			wantRequests: 1,
+			wantPath:     "/v1/kv/fleet/versions/identity-key",
+			wantToken:    "secret-token",
+			wantDC:       "dc2",
+		},
+		{
+			name:         "unresolvable token",
+			identity:     "identity-key",
+			token:        func() (string, error) { return "", errors.New("not set") },
+			wantRequests: 0,
+		},
+		{
+			name:         "identity unknown",
+			identity:     "",
+			wantRequests: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests := 0
+			var gotPath, gotToken, gotDC string
+			var gotEntry ConsulEntry
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				if r.Method != http.MethodPut {
+					t.Errorf("method = %s, want PUT", r.Method)
+				}
+				gotPath = r.URL.Path
+				gotToken = r.Header.Get("X-Consul-Token")
+				gotDC = r.URL.Query().Get("dc")
+				if err := json.NewDecoder(r.Body).Decode(&gotEntry); err != nil {
+					t.Errorf("failed to decode entry: %v", err)
+				}
+				_, _ = w.Write([]byte("true"))
+			}))
+			defer server.Close()
+
+			reporter := New(Options{
+				ConsulAddress:    server.URL,
+				ConsulKVPrefix:   "/fleet/versions/",
+				ConsulDatacenter: tt.datacenter,
+				ConsulToken:      tt.token,
+				ConsulTimeout:    5 * time.Second,
+			})
+			decision := testDecision(OutcomeSynced)
+			decision.IdentityPublicKey = tt.identity
+			decision.VersionFrom = "2.3.5"
+			decision.VersionTo = "2.3.6"
+			reporter.Report(decision)
+
+			if requests != tt.wantRequests {
+				t.Fatalf("consul requests = %d, want %d", requests, tt.wantRequests)
+			}
+			if requests == 0 {
+				return
+			}
+			if gotPath != tt.wantPath || gotToken != tt.wantToken || gotDC != tt.wantDC {
+				t.Errorf("request path, token, dc = %q, %q, %q, want %q, %q, %q", gotPath, gotToken, gotDC, tt.wantPath, tt.wantToken, tt.wantDC)
+			}
+			if gotEntry.CurrentVersion != "2.3.5" || gotEntry.TargetVersion != "2.3.6" || !gotEntry.Drift {
+				t.Errorf("published entry = %+v, want 2.3.5 -> 2.3.6 with drift", gotEntry)
+			}
+		})
+	}
+}
+
+func TestReporter_putConsul_NotStored(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("false"))
+	}))
+	defer server.Close()
+
+	reporter := New(Options{ConsulAddress: server.URL, ConsulKVPrefix: "versions", ConsulTimeout: 5 * time.Second})
+	if err := reporter.putConsul("versions/identity-key", testDecision(OutcomeSynced)); err == nil {
+		t.Error("putConsul() error = nil, want error when consul does not store the entry")
+	}
+}
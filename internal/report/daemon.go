@@ -0,0 +1,63 @@
+package report
+
+import (
+	"runtime"
+	"runtime/debug"
+	"time"
+)
+
+// Daemon is daemon-level metadata included in the status, for monitoring the monitor
+type Daemon struct {
+	// StartedAt is when the daemon started
+	StartedAt time.Time `json:"started_at"`
+	// UptimeSeconds is how long the daemon has been running
+	UptimeSeconds float64 `json:"uptime_seconds"`
+	// Build is the build of the running binary
+	Build Build `json:"build"`
+	// ConfigFile is the config file the daemon loaded
+	ConfigFile string `json:"config_file"`
+	// ConfigHash is the hex encoded sha256 of the loaded config file content
+	ConfigHash string `json:"config_hash"`
+	// ConfigLoadedAt is when the config file was loaded - config is only loaded at startup
+	ConfigLoadedAt time.Time `json:"config_loaded_at"`
+	// Runs is the number of runs since the daemon started
+	Runs int `json:"runs"`
+	// Failures is the number of failed runs since the daemon started
+	Failures int `json:"failures"`
+}
+
+// Build represents the build of the running binary
+type Build struct {
+	Version   string `json:"version"`
+	GoVersion string `json:"go_version"`
+	// Revision is the VCS revision the binary was built from - empty when built without VCS info
+	Revision string `json:"revision,omitempty"`
+	// RevisionTime is the commit time of Revision
+	RevisionTime string `json:"revision_time,omitempty"`
+	// Modified is true when the binary was built from a working tree with uncommitted changes
+	Modified bool `json:"modified,omitempty"`
+}
+
+// NewBuild returns the build of the running binary with the given version
+func NewBuild(version string) Build {
+	build := Build{
+		Version:   version,
+		GoVersion: runtime.Version(),
+	}
+
+	buildInfo, ok := debug.ReadBuildInfo()
+	if !ok {
+		return build
+	}
+	for _, setting := range buildInfo.Settings {
+		switch setting.Key {
+		case "vcs.revision":
+			build.Revision = setting.Value
+		case "vcs.time":
+			build.RevisionTime = setting.Value
+		case "vcs.modified":
+			build.Modified = setting.Value == "true"
+		}
+	}
+	return build
+}
@@ -0,0 +1,16 @@
+package report
+
+import (
+	"runtime"
+	"testing"
+)
+
+func TestNewBuild(t *testing.T) {
+	build := NewBuild("1.2.3")
+	if build.Version != "1.2.3" {
+		t.Errorf("NewBuild() version = %s, want 1.2.3", build.Version)
+	}
+	if build.GoVersion != runtime.Version() {
+		t.Errorf("NewBuild() go version = %s, want %s", build.GoVersion, runtime.Version())
+	}
+}
@@ -0,0 +1,92 @@
+package report
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// OutcomeSynced is the outcome of a sync that executed its commands successfully
+	OutcomeSynced = "synced"
+	// OutcomeUpToDate is the outcome of a sync where the validator already runs the target version
+	OutcomeUpToDate = "up-to-date"
+	// OutcomeSkipped is the outcome of a sync that decided not to execute commands
+	OutcomeSkipped = "skipped"
+	// OutcomeFailed is the outcome of a sync that errored
+	OutcomeFailed = "failed"
+	// OutcomeFlapping is the outcome of a sync suppressed because the validator's health or role keeps changing
+	OutcomeFlapping = "flapping"
+)
+
+const (
+	// SourceGitHub is the GitHub releases and tags data source
+	SourceGitHub = "github"
+	// SourceSFDP is the SFDP requirements and participants data source
+	SourceSFDP = "sfdp"
+)
+
+// Columns are the column names of a reported decision row, in order
+var Columns = []string{
+	"time",
+	"cluster",
+	"client",
+	"identity_public_key",
+	"role",
+	"version_from",
+	"version_to",
+	"version_to_tag",
+	"outcome",
+	"reason",
+	"duration_seconds",
+	"reason_code",
+	"unavailable_sources",
+}
+
+// Decision represents the outcome of a single version sync run
+type Decision struct {
+	Time              time.Time `json:"time"`
+	Cluster           string    `json:"cluster"`
+	Client            string    `json:"client"`
+	IdentityPublicKey string    `json:"identity_public_key"`
+	Role              string    `json:"role"`
+	VersionFrom       string    `json:"version_from"`
+	VersionTo         string    `json:"version_to"`
+	VersionToTag      string    `json:"version_to_tag"`
+	Outcome           string    `json:"outcome"`
+	Reason            string    `json:"reason"`
+	ReasonCode        string    `json:"reason_code"`
+	// UnavailableSources are the data sources (github, sfdp) that could not be queried - the decision is partial
+	// when the sync proceeded without them
+	UnavailableSources []string      `json:"unavailable_sources"`
+	Duration           time.Duration `json:"-"`
+}
+
+// Row returns the decision as a row of values ordered as Columns
+func (d Decision) Row() []string {
+	return []string{
+		d.Time.UTC().Format(time.RFC3339),
+		d.Cluster,
+		d.Client,
+		d.IdentityPublicKey,
+		d.Role,
+		d.VersionFrom,
+		d.VersionTo,
+		d.VersionToTag,
+		d.Outcome,
+		d.Reason,
+		strconv.FormatFloat(d.Duration.Seconds(), 'f', 3, 64),
+		d.ReasonCode,
+		strings.Join(d.UnavailableSources, ","),
+	}
+}
+
+// Record returns the decision as a column name to value map, as posted to HTTP sinks
+func (d Decision) Record() map[string]string {
+	row := d.Row()
+	record := make(map[string]string, len(Columns))
+	for i, column := range Columns {
+		record[column] = row[i]
+	}
+	return record
+}
@@ -0,0 +1,55 @@
+package report
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDecision_Row(t *testing.T) {
+	decision := Decision{
+		Time:               time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+		Cluster:            "testnet",
+		Client:             "agave",
+		IdentityPublicKey:  "9WzDXwBbmkg8ZTbNMqUxvQRAyrZzDsGYdLVL9zYtAWWM",
+		Role:               "passive",
+		VersionFrom:        "2.3.5",
+		VersionTo:          "2.3.6",
+		VersionToTag:       "v2.3.6",
+		Outcome:            OutcomeSynced,
+		Reason:             "upgrade v2.3.5 -> v2.3.6",
+		ReasonCode:         ReasonCodeSynced,
+		UnavailableSources: []string{SourceSFDP},
+		Duration:           1500 * time.Millisecond,
+	}
+
+	want := []string{
+		"2026-01-02T03:04:05Z",
+		"testnet",
+		"agave",
+		"9WzDXwBbmkg8ZTbNMqUxvQRAyrZzDsGYdLVL9zYtAWWM",
+		"passive",
+		"2.3.5",
+		"2.3.6",
+		"v2.3.6",
+		OutcomeSynced,
+		"upgrade v2.3.5 -> v2.3.6",
+		"1.500",
+		ReasonCodeSynced,
+		SourceSFDP,
+	}
+
+	row := decision.Row()
+	if len(row) != len(Columns) {
+		t.Fatalf("Row() has %d values, want %d (one per column)", len(row), len(Columns))
+	}
+	for i := range want {
+		if row[i] != want[i] {
+			t.Errorf("Row()[%d] (%s) = %q, want %q", i, Columns[i], row[i], want[i])
+		}
+	}
+
+	record := decision.Record()
+	if record["outcome"] != OutcomeSynced || record["version_to_tag"] != "v2.3.6" {
+		t.Errorf("Record() = %v, want outcome and version_to_tag keyed by column", record)
+	}
+}
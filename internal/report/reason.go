@@ -0,0 +1,114 @@
+package report
+
+import "errors"
+
+// Reason codes are stable, machine-readable reasons for a decision's outcome, so decisions can be aggregated
+// across a fleet - unlike reasons, which are human-readable and may change. Never rename a reason code.
+const (
+	// ReasonCodeSynced is the reason code of a sync that executed its commands
+	ReasonCodeSynced = "synced"
+	// ReasonCodeUpToDate is the reason code of a validator already running the target version
+	ReasonCodeUpToDate = "up_to_date"
+	// ReasonCodeUnhealthy is the reason code of a validator whose health check failed
+	ReasonCodeUnhealthy = "unhealthy"
+	// ReasonCodeFlapping is the reason code of a validator whose health or role keeps changing
+	ReasonCodeFlapping = "flapping"
+	// ReasonCodeRoleActive is the reason code of an active validator with sync.enabled_when_active=false
+	ReasonCodeRoleActive = "role_active"
+	// ReasonCodeRoleUnknown is the reason code of a validator running neither the active nor the passive identity
+	ReasonCodeRoleUnknown = "role_unknown"
+	// ReasonCodeRoleOther is the reason code of a validator running one of validator.identities.others
+	ReasonCodeRoleOther = "role_other"
+	// ReasonCodeNoActiveLeaderInGossip is the reason code of a passive validator whose active leader is not in gossip
+	ReasonCodeNoActiveLeaderInGossip = "no_active_leader_in_gossip"
+	// ReasonCodeNoMatchingRelease is the reason code of a lookup that found no matching tagged release
+	ReasonCodeNoMatchingRelease = "no_matching_release"
+	// ReasonCodeOutsideVersionConstraint is the reason code of a target version outside validator.version_constraint
+	ReasonCodeOutsideVersionConstraint = "outside_version_constraint"
+	// ReasonCodeBelowDowngradeFloor is the reason code of a downgrade target below validator.downgrade_floor
+	ReasonCodeBelowDowngradeFloor = "below_downgrade_floor"
+	// ReasonCodeSFDPVersionUnavailable is the reason code of an SFDP compliant version with no tagged release
+	ReasonCodeSFDPVersionUnavailable = "sfdp_version_unavailable"
+	// ReasonCodeReferenceValidatorBehind is the reason code of a reference validator not running the target version yet
+	ReasonCodeReferenceValidatorBehind = "reference_validator_behind"
+	// ReasonCodeAdoptionBelowThreshold is the reason code of too little of the cluster's stake running the target version
+	ReasonCodeAdoptionBelowThreshold = "adoption_below_threshold"
+	// ReasonCodeStakeActivationPending is the reason code of large stake changes pending for the vote account
+	ReasonCodeStakeActivationPending = "stake_activation_pending"
+	// ReasonCodeNoCommands is the reason code of a sync with no configured commands
+	ReasonCodeNoCommands = "no_commands"
+	// ReasonCodeReadOnly is the reason code of a sync stopped short of executing commands by read-only mode
+	ReasonCodeReadOnly = "read_only"
+	// ReasonCodeSlotTriggerNotReached is the reason code of a trigger slot not reached within sync.slot_trigger.max_wait
+	ReasonCodeSlotTriggerNotReached = "slot_trigger_not_reached"
+	// ReasonCodeRoleChangedDuringWait is the reason code of a role or gossip change while waiting for the trigger slot
+	ReasonCodeRoleChangedDuringWait = "role_changed_during_wait"
+	// ReasonCodeAssetDigestMismatch is the reason code of a downloaded file not matching its release asset's digest
+	ReasonCodeAssetDigestMismatch = "asset_digest_mismatch"
+	// ReasonCodeBinaryCheckFailed is the reason code of an installed target binary not reporting the target version
+	ReasonCodeBinaryCheckFailed = "binary_check_failed"
+	// ReasonCodeInjectedFailure is the reason code of a failure deliberately injected with --fail-at
+	ReasonCodeInjectedFailure = "injected_failure"
+	// ReasonCodeError is the reason code of any other failure
+	ReasonCodeError = "error"
+)
+
+// ReasonCodes are all reason codes
+var ReasonCodes = []string{
+	ReasonCodeSynced,
+	ReasonCodeUpToDate,
+	ReasonCodeUnhealthy,
+	ReasonCodeFlapping,
+	ReasonCodeRoleActive,
+	ReasonCodeRoleUnknown,
+	ReasonCodeRoleOther,
+	ReasonCodeNoActiveLeaderInGossip,
+	ReasonCodeNoMatchingRelease,
+	ReasonCodeOutsideVersionConstraint,
+	ReasonCodeBelowDowngradeFloor,
+	ReasonCodeSFDPVersionUnavailable,
+	ReasonCodeReferenceValidatorBehind,
+	ReasonCodeAdoptionBelowThreshold,
+	ReasonCodeStakeActivationPending,
+	ReasonCodeNoCommands,
+	ReasonCodeReadOnly,
+	ReasonCodeSlotTriggerNotReached,
+	ReasonCodeRoleChangedDuringWait,
+	ReasonCodeAssetDigestMismatch,
+	ReasonCodeBinaryCheckFailed,
+	ReasonCodeInjectedFailure,
+	ReasonCodeError,
+}
+
+// ReasonError is an error carrying the reason code of the decision it fails
+type ReasonError struct {
+	Code string
+	Err  error
+}
+
+// Error returns the wrapped error's message
+func (e *ReasonError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the wrapped error
+func (e *ReasonError) Unwrap() error {
+	return e.Err
+}
+
+// WithReasonCode wraps err with a reason code, returning nil when err is nil
+func WithReasonCode(code string, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &ReasonError{Code: code, Err: err}
+}
+
+// ReasonCodeOf returns the reason code of the outermost ReasonError in err's chain, otherwise ReasonCodeError
+func ReasonCodeOf(err error) string {
+	var reasonErr *ReasonError
+	if errors.As(err, &reasonErr) {
+		return reasonErr.Code
+	}
+	return ReasonCodeError
+}
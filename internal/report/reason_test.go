@@ -0,0 +1,68 @@
+package report
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestReasonCodeOf(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{
+			name: "plain error",
+			err:  errors.New("boom"),
+			want: ReasonCodeError,
+		},
+		{
+			name: "reason error",
+			err:  WithReasonCode(ReasonCodeRoleUnknown, errors.New("unknown identity")),
+			want: ReasonCodeRoleUnknown,
+		},
+		{
+			name: "wrapped reason error",
+			err:  fmt.Errorf("sync failed: %w", WithReasonCode(ReasonCodeNoActiveLeaderInGossip, errors.New("not in gossip"))),
+			want: ReasonCodeNoActiveLeaderInGossip,
+		},
+		{
+			name: "outermost reason code wins",
+			err: WithReasonCode(ReasonCodeRoleChangedDuringWait,
+				fmt.Errorf("no longer allowed: %w", WithReasonCode(ReasonCodeNoActiveLeaderInGossip, errors.New("not in gossip"))),
+			),
+			want: ReasonCodeRoleChangedDuringWait,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ReasonCodeOf(tt.err); got != tt.want {
+				t.Errorf("ReasonCodeOf() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithReasonCode(t *testing.T) {
+	if err := WithReasonCode(ReasonCodeError, nil); err != nil {
+		t.Errorf("WithReasonCode(nil) = %v, want nil", err)
+	}
+
+	cause := errors.New("boom")
+	err := WithReasonCode(ReasonCodeError, cause)
+	if !errors.Is(err, cause) || err.Error() != "boom" {
+		t.Errorf("WithReasonCode() = %v, want wrapped boom", err)
+	}
+}
+
+func TestReasonCodes_Unique(t *testing.T) {
+	seen := make(map[string]bool, len(ReasonCodes))
+	for _, code := range ReasonCodes {
+		if seen[code] {
+			t.Errorf("duplicate reason code %s", code)
+		}
+		seen[code] = true
+	}
+}
@@ -0,0 +1,230 @@
+package report
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/logging"
+)
+
+// Options represents the options for creating a new Reporter
+type Options struct {
+	// CSVFile is a file decisions are appended to as CSV rows - disabled when empty
+	CSVFile string
+	// HTTPURL is an endpoint (e.g. a spreadsheet appender) each decision is posted to as JSON - disabled when empty
+	HTTPURL string
+	// HTTPHeaders are extra headers sent with each post, e.g. for authentication
+	HTTPHeaders map[string]string
+	// HTTPTimeout is the timeout for each post
+	HTTPTimeout time.Duration
+	// StatusFile is a file atomically replaced with the last run's status after every run - disabled when empty
+	StatusFile string
+	// ConsulAddress is the Consul HTTP API address versions are published to after every run - disabled when empty
+	ConsulAddress string
+	// ConsulKVPrefix is the KV path versions are published under, as <prefix>/<identity public key>
+	ConsulKVPrefix string
+	// ConsulDatacenter is the datacenter of the KV store - the agent's datacenter when empty
+	ConsulDatacenter string
+	// ConsulToken resolves the ACL token sent with each request - no token is sent when nil
+	ConsulToken func() (string, error)
+	// ConsulTimeout is the timeout for each Consul request
+	ConsulTimeout time.Duration
+	// Location is the timezone status file timestamps are rendered in, UTC when nil
+	Location *time.Location
+	// ReadOnly disables reporting regardless of configured sinks
+	ReadOnly bool
+}
+
+// Reporter reports sync decisions to the configured sinks - failing to report never fails a sync
+type Reporter struct {
+	opts       Options
+	mu         sync.Mutex
+	httpClient *http.Client
+	logger     *log.Logger
+}
+
+// New creates a new Reporter
+func New(opts Options) *Reporter {
+	return &Reporter{
+		opts:       opts,
+		httpClient: &http.Client{},
+		logger:     logging.WithPrefix("report"),
+	}
+}
+
+// Enabled returns true when at least one sink is configured
+func (r *Reporter) Enabled() bool {
+	return r.opts.CSVFile != "" || r.opts.HTTPURL != "" || r.opts.ConsulAddress != ""
+}
+
+// Report reports the decision to all configured sinks, logging (but not returning) any errors
+func (r *Reporter) Report(decision Decision) {
+	if !r.Enabled() {
+		return
+	}
+
+	if r.opts.ReadOnly {
+		r.logger.Warn("read-only mode - not reporting decision", "outcome", decision.Outcome)
+		return
+	}
+
+	if r.opts.CSVFile != "" {
+		if err := r.appendCSV(decision); err != nil {
+			r.logger.Error("failed to append decision to csv file", "file", r.opts.CSVFile, "error", err)
+		} else {
+			r.logger.Debug("appended decision to csv file", "file", r.opts.CSVFile, "outcome", decision.Outcome)
+		}
+	}
+
+	if r.opts.HTTPURL != "" {
+		if err := r.postHTTP(decision); err != nil {
+			r.logger.Error("failed to post decision", "url", r.opts.HTTPURL, "error", err)
+		} else {
+			r.logger.Debug("posted decision", "url", r.opts.HTTPURL, "outcome", decision.Outcome)
+		}
+	}
+
+	if r.opts.ConsulAddress != "" {
+		key := r.consulKey(decision)
+		if key == "" {
+			r.logger.Debug("validator identity unknown - not publishing versions to consul", "outcome", decision.Outcome)
+		} else if err := r.putConsul(key, decision); err != nil {
+			r.logger.Error("failed to publish versions to consul", "address", r.opts.ConsulAddress, "key", key, "error", err)
+		} else {
+			r.logger.Debug("published versions to consul", "key", key, "outcome", decision.Outcome)
+		}
+	}
+}
+
+// appendCSV appends the decision to the CSV file, writing the header row first when the file is new or empty - a
+// file written with other columns (by an older version) is rotated to <file>.N first, so rows never mix schemas
+func (r *Reporter) appendCSV(decision Decision) (err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err = os.MkdirAll(filepath.Dir(r.opts.CSVFile), 0o755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	writeHeader := false
+	info, err := os.Stat(r.opts.CSVFile)
+	if errors.Is(err, os.ErrNotExist) || (err == nil && info.Size() == 0) {
+		writeHeader = true
+	} else if err != nil {
+		return err
+	}
+
+	if !writeHeader {
+		writeHeader, err = r.rotateStaleCSV()
+		if err != nil {
+			return err
+		}
+	}
+
+	f, err := os.OpenFile(r.opts.CSVFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+	}()
+
+	w := csv.NewWriter(f)
+	if writeHeader {
+		if err = w.Write(Columns); err != nil {
+			return err
+		}
+	}
+	if err = w.Write(decision.Row()); err != nil {
+		return err
+	}
+	w.Flush()
+
+	return w.Error()
+}
+
+// rotateStaleCSV renames the CSV file to the first free <file>.N when its header isn't Columns, returning true
+// when it did and a new file with a header must be started
+func (r *Reporter) rotateStaleCSV() (rotated bool, err error) {
+	f, err := os.Open(r.opts.CSVFile)
+	if err != nil {
+		return false, err
+	}
+	reader := csv.NewReader(f)
+	reader.FieldsPerRecord = -1
+	header, err := reader.Read()
+	f.Close()
+	if err != nil && !errors.Is(err, io.EOF) {
+		return false, fmt.Errorf("failed to read csv header: %w", err)
+	}
+	if slices.Equal(header, Columns) {
+		return false, nil
+	}
+
+	for n := 1; ; n++ {
+		rotatedFile := fmt.Sprintf("%s.%d", r.opts.CSVFile, n)
+		if _, err = os.Stat(rotatedFile); err == nil {
+			continue
+		} else if !errors.Is(err, os.ErrNotExist) {
+			return false, err
+		}
+
+		if err = os.Rename(r.opts.CSVFile, rotatedFile); err != nil {
+			return false, fmt.Errorf("failed to rotate csv file with stale header: %w", err)
+		}
+		r.logger.Warn("csv file header doesn't match the decision columns - rotated it and started a new file",
+			"file", r.opts.CSVFile,
+			"rotatedFile", rotatedFile,
+			"header", header,
+			"columns", Columns,
+		)
+		return true, nil
+	}
+}
+
+// postHTTP posts the decision as a JSON object keyed by column name
+func (r *Reporter) postHTTP(decision Decision) error {
+	body, err := json.Marshal(decision.Record())
+	if err != nil {
+		return fmt.Errorf("failed to marshal decision: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), r.opts.HTTPTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, r.opts.HTTPURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for name, value := range r.opts.HTTPHeaders {
+		req.Header.Set(name, value)
+	}
+
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, string(respBody))
+	}
+
+	return nil
+}
@@ -0,0 +1,185 @@
+package report
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testDecision(outcome string) Decision {
+	return Decision{
+		Time:    time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+		Cluster: "testnet",
+		Client:  "agave",
+		Outcome: outcome,
+		Reason:  "test",
+	}
+}
+
+func TestReporter_Enabled(t *testing.T) {
+	if New(Options{}).Enabled() {
+		t.Error("Enabled() = true with no sinks, want false")
+	}
+	if !New(Options{CSVFile: "decisions.csv"}).Enabled() {
+		t.Error("Enabled() = false with csv sink, want true")
+	}
+	if !New(Options{HTTPURL: "https://example.com"}).Enabled() {
+		t.Error("Enabled() = false with http sink, want true")
+	}
+	if !New(Options{ConsulAddress: "http://127.0.0.1:8500"}).Enabled() {
+		t.Error("Enabled() = false with consul sink, want true")
+	}
+}
+
+func TestReporter_Report_CSV(t *testing.T) {
+	csvFile := filepath.Join(t.TempDir(), "reports", "decisions.csv")
+	reporter := New(Options{CSVFile: csvFile})
+
+	reporter.Report(testDecision(OutcomeSkipped))
+	reporter.Report(testDecision(OutcomeSynced))
+
+	f, err := os.Open(csvFile)
+	if err != nil {
+		t.Fatalf("failed to open csv file: %v", err)
+	}
+	defer f.Close()
+
+	rows, err := csv.NewReader(f).ReadAll()
+	if err != nil {
+		t.Fatalf("failed to read csv file: %v", err)
+	}
+
+	// header once, then one row per decision
+	if len(rows) != 3 {
+		t.Fatalf("csv has %d rows, want 3", len(rows))
+	}
+	if rows[0][0] != Columns[0] {
+		t.Errorf("csv header = %v, want %v", rows[0], Columns)
+	}
+	if rows[1][8] != OutcomeSkipped || rows[2][8] != OutcomeSynced {
+		t.Errorf("csv outcomes = %s, %s, want %s, %s", rows[1][8], rows[2][8], OutcomeSkipped, OutcomeSynced)
+	}
+}
+
+func TestReporter_Report_CSV_StaleHeader(t *testing.T) {
+	tests := []struct {
+		name   string
+		header []string
+	}{
+		{
+			name:   "header without reason_code",
+			header: Columns[:slices.Index(Columns, "reason_code")],
+		},
+		{
+			name:   "header without unavailable_sources",
+			header: Columns[:slices.Index(Columns, "unavailable_sources")],
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			csvFile := filepath.Join(dir, "decisions.csv")
+			staleContent := strings.Join(tt.header, ",") + "\n" + strings.Repeat("x,", len(tt.header)-1) + "x\n"
+			if err := os.WriteFile(csvFile, []byte(staleContent), 0o644); err != nil {
+				t.Fatalf("failed to write csv file: %v", err)
+			}
+			// a previous rotation is kept
+			if err := os.WriteFile(csvFile+".1", []byte("older\n"), 0o644); err != nil {
+				t.Fatalf("failed to write rotated csv file: %v", err)
+			}
+
+			New(Options{CSVFile: csvFile}).Report(testDecision(OutcomeSynced))
+
+			rotated, err := os.ReadFile(csvFile + ".2")
+			if err != nil {
+				t.Fatalf("failed to read rotated csv file: %v", err)
+			}
+			if string(rotated) != staleContent {
+				t.Errorf("rotated csv file = %q, want %q", string(rotated), staleContent)
+			}
+			if older, _ := os.ReadFile(csvFile + ".1"); string(older) != "older\n" {
+				t.Errorf("previously rotated csv file = %q, want it untouched", string(older))
+			}
+
+			f, err := os.Open(csvFile)
+			if err != nil {
+				t.Fatalf("failed to open csv file: %v", err)
+			}
+			defer f.Close()
+			rows, err := csv.NewReader(f).ReadAll()
+			if err != nil {
+				t.Fatalf("failed to read csv file: %v", err)
+			}
+			if len(rows) != 2 || !slices.Equal(rows[0], Columns) || len(rows[1]) != len(Columns) {
+				t.Errorf("csv rows = %v, want the current header and one row of %d fields", rows, len(Columns))
+			}
+		})
+	}
+}
+
+func TestReporter_Report_CSV_CurrentHeaderAppends(t *testing.T) {
+	csvFile := filepath.Join(t.TempDir(), "decisions.csv")
+	reporter := New(Options{CSVFile: csvFile})
+	reporter.Report(testDecision(OutcomeSkipped))
+	reporter.Report(testDecision(OutcomeSynced))
+
+	if _, err := os.Stat(csvFile + ".1"); !os.IsNotExist(err) {
+		t.Errorf("csv file with the current header was rotated, stat error = %v", err)
+	}
+}
+
+func TestReporter_Report_HTTP(t *testing.T) {
+	var gotRecord map[string]string
+	var gotAuth string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = r.Header.Get("Authorization")
+		json.NewDecoder(r.Body).Decode(&gotRecord)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	reporter := New(Options{
+		HTTPURL:     server.URL,
+		HTTPHeaders: map[string]string{"Authorization": "Bearer token"},
+		HTTPTimeout: 5 * time.Second,
+	})
+	reporter.Report(testDecision(OutcomeFailed))
+
+	if gotAuth != "Bearer token" {
+		t.Errorf("Authorization header = %q, want %q", gotAuth, "Bearer token")
+	}
+	if gotRecord["outcome"] != OutcomeFailed || gotRecord["cluster"] != "testnet" {
+		t.Errorf("posted record = %v, want outcome %s and cluster testnet", gotRecord, OutcomeFailed)
+	}
+}
+
+func TestReporter_postHTTP_ErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "nope", http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	reporter := New(Options{HTTPURL: server.URL, HTTPTimeout: 5 * time.Second})
+	if err := reporter.postHTTP(testDecision(OutcomeSynced)); err == nil {
+		t.Error("postHTTP() error = nil, want error on non-2xx status")
+	}
+}
+
+func TestReporter_Report_ReadOnly(t *testing.T) {
+	csvFile := filepath.Join(t.TempDir(), "decisions.csv")
+	reporter := New(Options{CSVFile: csvFile, ReadOnly: true})
+
+	reporter.Report(testDecision(OutcomeSkipped))
+
+	if _, err := os.Stat(csvFile); !os.IsNotExist(err) {
+		t.Errorf("csv file written in read-only mode, stat error = %v", err)
+	}
+}
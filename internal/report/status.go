@@ -0,0 +1,103 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/sol-strategies/solana-validator-version-sync/internal/cadence"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/state"
+)
+
+// Status is the outcome of the last run, written to the status file for external watchdogs
+type Status struct {
+	Decision
+	// FinishedAt is when the run finished - Time is when it started
+	FinishedAt time.Time `json:"finished_at"`
+	// DurationSeconds is how long the run took
+	DurationSeconds float64 `json:"duration_seconds"`
+	// NextRunAt is when the next run is scheduled - nil in single run mode
+	NextRunAt *time.Time `json:"next_run_at"`
+	// ReleaseCadence is the client's release cadence, for scheduling upgrade windows - nil when it could not be computed
+	ReleaseCadence *cadence.Cadence `json:"release_cadence"`
+	// CommandRuns are the commands executed by the run and their resource usage, in execution order
+	CommandRuns []state.CommandRun `json:"command_runs"`
+	// Daemon is daemon-level metadata such as uptime, build, loaded config and run counts - nil when unknown
+	Daemon *Daemon `json:"daemon"`
+}
+
+// NewStatus creates the status of a run that reached decision, with the time the next run is scheduled (if any)
+func NewStatus(decision Decision, nextRunAt *time.Time) Status {
+	status := Status{
+		Decision:        decision,
+		FinishedAt:      decision.Time.Add(decision.Duration).UTC(),
+		DurationSeconds: decision.Duration.Seconds(),
+	}
+	if nextRunAt != nil {
+		next := nextRunAt.UTC()
+		status.NextRunAt = &next
+	}
+	return status
+}
+
+// In returns a copy of the status with its timestamps in location - they still denote the same instants
+func (s Status) In(location *time.Location) Status {
+	s.Time = s.Time.In(location)
+	s.FinishedAt = s.FinishedAt.In(location)
+	if s.NextRunAt != nil {
+		next := s.NextRunAt.In(location)
+		s.NextRunAt = &next
+	}
+	if s.ReleaseCadence != nil {
+		releaseCadence := *s.ReleaseCadence
+		releaseCadence.LastReleaseAt = releaseCadence.LastReleaseAt.In(location)
+		releaseCadence.NextReleaseEstimate = releaseCadence.NextReleaseEstimate.In(location)
+		s.ReleaseCadence = &releaseCadence
+	}
+	if s.CommandRuns != nil {
+		commandRuns := make([]state.CommandRun, len(s.CommandRuns))
+		for i, commandRun := range s.CommandRuns {
+			commandRun.Time = commandRun.Time.In(location)
+			commandRuns[i] = commandRun
+		}
+		s.CommandRuns = commandRuns
+	}
+	if s.Daemon != nil {
+		daemon := *s.Daemon
+		daemon.StartedAt = daemon.StartedAt.In(location)
+		daemon.ConfigLoadedAt = daemon.ConfigLoadedAt.In(location)
+		s.Daemon = &daemon
+	}
+	return s
+}
+
+// WriteStatus atomically replaces the status file with the status, logging (but not returning) any errors
+func (r *Reporter) WriteStatus(status Status) {
+	if r.opts.StatusFile == "" {
+		return
+	}
+
+	if r.opts.ReadOnly {
+		r.logger.Warn("read-only mode - not writing status file", "outcome", status.Outcome)
+		return
+	}
+
+	if r.opts.Location != nil {
+		status = status.In(r.opts.Location)
+	}
+
+	if err := writeStatusFile(r.opts.StatusFile, status); err != nil {
+		r.logger.Error("failed to write status file", "file", r.opts.StatusFile, "error", err)
+		return
+	}
+	r.logger.Debug("wrote status file", "file", r.opts.StatusFile, "outcome", status.Outcome)
+}
+
+// writeStatusFile writes the status as indented JSON, renamed into place so watchdogs never read a partial file
+func writeStatusFile(file string, status Status) error {
+	content, err := json.MarshalIndent(status, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal status: %w", err)
+	}
+	return state.WriteFileAtomic(file, append(content, '\n'))
+}
@@ -0,0 +1,153 @@
+package report
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/sol-strategies/solana-validator-version-sync/internal/cadence"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/state"
+)
+
+func TestNewStatus(t *testing.T) {
+	decision := testDecision(OutcomeSynced)
+	decision.Duration = 90 * time.Second
+	nextRunAt := time.Date(2026, 1, 2, 4, 0, 0, 0, time.FixedZone("UTC+1", 3600))
+
+	tests := []struct {
+		name          string
+		nextRunAt     *time.Time
+		wantNextRunAt *time.Time
+	}{
+		{name: "single run", nextRunAt: nil, wantNextRunAt: nil},
+		{name: "on interval", nextRunAt: &nextRunAt, wantNextRunAt: &nextRunAt},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status := NewStatus(decision, tt.nextRunAt)
+
+			if want := time.Date(2026, 1, 2, 3, 5, 35, 0, time.UTC); !status.FinishedAt.Equal(want) {
+				t.Errorf("FinishedAt = %v, want %v", status.FinishedAt, want)
+			}
+			if status.DurationSeconds != 90 {
+				t.Errorf("DurationSeconds = %v, want 90", status.DurationSeconds)
+			}
+			if (status.NextRunAt == nil) != (tt.wantNextRunAt == nil) {
+				t.Fatalf("NextRunAt = %v, want %v", status.NextRunAt, tt.wantNextRunAt)
+			}
+			if tt.wantNextRunAt != nil && (!status.NextRunAt.Equal(*tt.wantNextRunAt) || status.NextRunAt.Location() != time.UTC) {
+				t.Errorf("NextRunAt = %v, want %v in UTC", status.NextRunAt, tt.wantNextRunAt)
+			}
+		})
+	}
+}
+
+func TestStatus_In(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatalf("failed to load location: %v", err)
+	}
+
+	nextRunAt := time.Date(2026, 1, 2, 4, 0, 0, 0, time.UTC)
+	status := NewStatus(testDecision(OutcomeSynced), &nextRunAt)
+	status.ReleaseCadence = &cadence.Cadence{LastReleaseAt: nextRunAt, NextReleaseEstimate: nextRunAt}
+	status.CommandRuns = []state.CommandRun{{Time: nextRunAt, Command: "build"}}
+	status.Daemon = &Daemon{StartedAt: nextRunAt, ConfigLoadedAt: nextRunAt}
+
+	in := status.In(tokyo)
+	times := map[string]time.Time{
+		"time":                    in.Time,
+		"finished_at":             in.FinishedAt,
+		"next_run_at":             *in.NextRunAt,
+		"last_release_at":         in.ReleaseCadence.LastReleaseAt,
+		"next_release_estimate":   in.ReleaseCadence.NextReleaseEstimate,
+		"command_runs[0].time":    in.CommandRuns[0].Time,
+		"daemon.started_at":       in.Daemon.StartedAt,
+		"daemon.config_loaded_at": in.Daemon.ConfigLoadedAt,
+	}
+	for name, got := range times {
+		if got.Location() != tokyo {
+			t.Errorf("%s location = %v, want %v", name, got.Location(), tokyo)
+		}
+	}
+	if !in.NextRunAt.Equal(nextRunAt) || !in.Time.Equal(status.Time) {
+		t.Errorf("In() changed the instants: time %v, next run %v", in.Time, in.NextRunAt)
+	}
+
+	// the original status is left as it is
+	if status.NextRunAt.Location() != time.UTC || status.CommandRuns[0].Time.Location() != time.UTC || status.ReleaseCadence.LastReleaseAt.Location() != time.UTC || status.Daemon.StartedAt.Location() != time.UTC {
+		t.Error("In() modified the original status")
+	}
+}
+
+func TestReporter_WriteStatus(t *testing.T) {
+	statusFile := filepath.Join(t.TempDir(), "status", "status.json")
+	reporter := New(Options{StatusFile: statusFile})
+
+	nextRunAt := time.Date(2026, 1, 2, 4, 0, 0, 0, time.UTC)
+	reporter.WriteStatus(NewStatus(testDecision(OutcomeFailed), &nextRunAt))
+	status := NewStatus(testDecision(OutcomeSynced), &nextRunAt)
+	status.ReleaseCadence = &cadence.Cadence{Releases: 5, DaysSinceLastRelease: 2.5}
+	reporter.WriteStatus(status)
+
+	content, err := os.ReadFile(statusFile)
+	if err != nil {
+		t.Fatalf("failed to read status file: %v", err)
+	}
+
+	var written map[string]interface{}
+	if err := json.Unmarshal(content, &written); err != nil {
+		t.Fatalf("status file is not valid JSON: %v", err)
+	}
+
+	// replaced rather than appended to, with the decision fields flattened alongside the run timestamps
+	for key, want := range map[string]interface{}{
+		"outcome":     OutcomeSynced,
+		"cluster":     "testnet",
+		"time":        "2026-01-02T03:04:05Z",
+		"finished_at": "2026-01-02T03:04:05Z",
+		"next_run_at": "2026-01-02T04:00:00Z",
+	} {
+		if written[key] != want {
+			t.Errorf("status %s = %v, want %v", key, written[key], want)
+		}
+	}
+
+	releaseCadence, ok := written["release_cadence"].(map[string]interface{})
+	if !ok || releaseCadence["days_since_last_release"] != 2.5 {
+		t.Errorf("status release_cadence = %v, want days_since_last_release 2.5", written["release_cadence"])
+	}
+
+	// no temporary files left behind
+	entries, err := os.ReadDir(filepath.Dir(statusFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("status directory has %d entries, want 1", len(entries))
+	}
+}
+
+func TestReporter_WriteStatus_Disabled(t *testing.T) {
+	statusFile := filepath.Join(t.TempDir(), "status.json")
+
+	tests := []struct {
+		name string
+		opts Options
+	}{
+		{name: "no status file", opts: Options{}},
+		{name: "read only", opts: Options{StatusFile: statusFile, ReadOnly: true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			New(tt.opts).WriteStatus(NewStatus(testDecision(OutcomeSynced), nil))
+			if _, err := os.Stat(statusFile); !os.IsNotExist(err) {
+				t.Errorf("status file written, want none: %v", err)
+			}
+		})
+	}
+}
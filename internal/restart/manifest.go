@@ -0,0 +1,117 @@
+package restart
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/go-version"
+)
+
+// maxManifestSize bounds manifest responses - manifests are a handful of fields
+const maxManifestSize = 1 << 20
+
+// Manifest represents an official cluster restart manifest, as published during coordinated cluster restarts:
+//
+//	{
+//	  "cluster": "mainnet-beta",
+//	  "version": "2.3.6",
+//	  "versions": {"firedancer": "0.505.20216"},
+//	  "expected_shred_version": 50093,
+//	  "snapshot_slot": 332000000,
+//	  "expected_bank_hash": "...",
+//	  "hard_forks": [332000000]
+//	}
+type Manifest struct {
+	// Cluster is the cluster restarting, the manifest applies to any cluster when empty
+	Cluster string `json:"cluster"`
+	// Version is the version validators must restart with
+	Version string `json:"version"`
+	// Versions are client-specific versions overriding Version, keyed by client name
+	Versions map[string]string `json:"versions"`
+	// ExpectedShredVersion is the shred version of the restarted cluster, e.g. for --expected-shred-version
+	ExpectedShredVersion uint16 `json:"expected_shred_version"`
+	// SnapshotSlot is the slot of the restart snapshot, e.g. for --wait-for-supermajority
+	SnapshotSlot uint64 `json:"snapshot_slot"`
+	// ExpectedBankHash is the bank hash at SnapshotSlot, e.g. for --expected-bank-hash - optional
+	ExpectedBankHash string `json:"expected_bank_hash"`
+	// HardForks are the hard fork slots of the restart, e.g. for --hard-fork - optional
+	HardForks []uint64 `json:"hard_forks"`
+}
+
+// Validate validates the manifest's fields
+func (m *Manifest) Validate() error {
+	if _, err := version.NewVersion(m.Version); err != nil {
+		return fmt.Errorf("version must be a version - got: %q", m.Version)
+	}
+	for client, clientVersion := range m.Versions {
+		if _, err := version.NewVersion(clientVersion); err != nil {
+			return fmt.Errorf("versions.%s must be a version - got: %q", client, clientVersion)
+		}
+	}
+	if m.ExpectedShredVersion == 0 {
+		return fmt.Errorf("expected_shred_version must be greater than 0")
+	}
+	if m.SnapshotSlot == 0 {
+		return fmt.Errorf("snapshot_slot must be greater than 0")
+	}
+	return nil
+}
+
+// VersionFor returns the version the given client must restart with
+func (m *Manifest) VersionFor(client string) (*version.Version, error) {
+	clientVersion, ok := m.Versions[client]
+	if !ok {
+		clientVersion = m.Version
+	}
+	return version.NewVersion(clientVersion)
+}
+
+// AppliesTo returns true when the manifest applies to the given cluster
+func (m *Manifest) AppliesTo(cluster string) bool {
+	return m.Cluster == "" || m.Cluster == cluster
+}
+
+// Key identifies the restart, so it is only executed once
+func (m *Manifest) Key() string {
+	return fmt.Sprintf("%d:%d", m.SnapshotSlot, m.ExpectedShredVersion)
+}
+
+// Fetch fetches the restart manifest from url - nil without an error when none is published (404 or 204)
+func Fetch(ctx context.Context, url string, timeout time.Duration) (manifest *Manifest, err error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch restart manifest: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusNoContent {
+		return nil, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return nil, fmt.Errorf("unexpected status code %d fetching restart manifest: %s", resp.StatusCode, string(respBody))
+	}
+
+	manifest = &Manifest{}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxManifestSize)).Decode(manifest); err != nil {
+		return nil, fmt.Errorf("failed to decode restart manifest: %w", err)
+	}
+	if err := manifest.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid restart manifest: %w", err)
+	}
+
+	return manifest, nil
+}
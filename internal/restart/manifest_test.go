@@ -0,0 +1,163 @@
+package restart
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestFetch(t *testing.T) {
+	tests := []struct {
+		name         string
+		status       int
+		body         string
+		wantManifest bool
+		wantErr      bool
+	}{
+		{
+			name:         "published",
+			status:       http.StatusOK,
+			body:         `{"cluster":"testnet","version":"2.3.6","expected_shred_version":9065,"snapshot_slot":332000000,"expected_bank_hash":"hash"}`,
+			wantManifest: true,
+		},
+		{
+			name:   "not published",
+			status: http.StatusNotFound,
+		},
+		{
+			name:   "withdrawn",
+			status: http.StatusNoContent,
+		},
+		{
+			name:    "server error",
+			status:  http.StatusInternalServerError,
+			wantErr: true,
+		},
+		{
+			name:    "invalid JSON",
+			status:  http.StatusOK,
+			body:    `{"version":`,
+			wantErr: true,
+		},
+		{
+			name:    "missing snapshot slot",
+			status:  http.StatusOK,
+			body:    `{"version":"2.3.6","expected_shred_version":9065}`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			manifest, err := Fetch(context.Background(), server.URL, time.Second)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Fetch() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if (manifest != nil) != tt.wantManifest {
+				t.Fatalf("Fetch() manifest = %+v, want manifest %v", manifest, tt.wantManifest)
+			}
+			if tt.wantManifest && (manifest.SnapshotSlot != 332000000 || manifest.ExpectedShredVersion != 9065 || manifest.ExpectedBankHash != "hash") {
+				t.Errorf("Fetch() manifest = %+v", manifest)
+			}
+		})
+	}
+}
+
+func TestManifest_Validate(t *testing.T) {
+	tests := []struct {
+		name     string
+		manifest Manifest
+		wantErr  bool
+	}{
+		{
+			name:     "valid",
+			manifest: Manifest{Version: "2.3.6", ExpectedShredVersion: 9065, SnapshotSlot: 1},
+		},
+		{
+			name:     "valid client versions",
+			manifest: Manifest{Version: "2.3.6", Versions: map[string]string{"firedancer": "0.505.20216"}, ExpectedShredVersion: 9065, SnapshotSlot: 1},
+		},
+		{
+			name:     "invalid version",
+			manifest: Manifest{Version: "latest", ExpectedShredVersion: 9065, SnapshotSlot: 1},
+			wantErr:  true,
+		},
+		{
+			name:     "invalid client version",
+			manifest: Manifest{Version: "2.3.6", Versions: map[string]string{"firedancer": ""}, ExpectedShredVersion: 9065, SnapshotSlot: 1},
+			wantErr:  true,
+		},
+		{
+			name:     "missing shred version",
+			manifest: Manifest{Version: "2.3.6", SnapshotSlot: 1},
+			wantErr:  true,
+		},
+		{
+			name:     "missing snapshot slot",
+			manifest: Manifest{Version: "2.3.6", ExpectedShredVersion: 9065},
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.manifest.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Manifest.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestManifest_VersionFor(t *testing.T) {
+	manifest := Manifest{Version: "2.3.6", Versions: map[string]string{"firedancer": "0.505.20216"}}
+
+	tests := []struct {
+		client string
+		want   string
+	}{
+		{client: "agave", want: "2.3.6"},
+		{client: "firedancer", want: "0.505.20216"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.client, func(t *testing.T) {
+			got, err := manifest.VersionFor(tt.client)
+			if err != nil {
+				t.Fatalf("VersionFor() error = %v", err)
+			}
+			if got.String() != tt.want {
+				t.Errorf("VersionFor() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestManifest_AppliesTo(t *testing.T) {
+	tests := []struct {
+		name     string
+		manifest Manifest
+		cluster  string
+		want     bool
+	}{
+		{name: "any cluster", manifest: Manifest{}, cluster: "testnet", want: true},
+		{name: "same cluster", manifest: Manifest{Cluster: "testnet"}, cluster: "testnet", want: true},
+		{name: "other cluster", manifest: Manifest{Cluster: "mainnet-beta"}, cluster: "testnet", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.manifest.AppliesTo(tt.cluster); got != tt.want {
+				t.Errorf("AppliesTo() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
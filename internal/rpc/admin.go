@@ -0,0 +1,92 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/logging"
+)
+
+// AdminClient is a client of an agave-based validator's admin RPC, served as JSON-RPC over the admin.rpc unix
+// socket in the validator's ledger directory
+type AdminClient struct {
+	socketPath string
+	timeout    time.Duration
+	logger     *log.Logger
+}
+
+// AdminContactInfo is the validator's contact info as reported by its admin RPC
+type AdminContactInfo struct {
+	ID string `json:"id"`
+	// Version is the validator's version, e.g. "2.2.14 (src:00000000; feat:3294202862, client:JitoLabs)"
+	Version string `json:"version"`
+}
+
+// NewAdminClient creates a new admin RPC client for the socket at socketPath
+func NewAdminClient(socketPath string) *AdminClient {
+	return &AdminClient{
+		socketPath: socketPath,
+		timeout:    10 * time.Second,
+		logger:     logging.WithPrefix("rpc"),
+	}
+}
+
+// makeAdminCall makes a JSON-RPC call over the admin socket, decoding its result into result
+func (c *AdminClient) makeAdminCall(ctx context.Context, method string, result interface{}) error {
+	dialer := net.Dialer{}
+	conn, err := dialer.DialContext(ctx, "unix", c.socketPath)
+	if err != nil {
+		return fmt.Errorf("failed to connect to admin RPC socket %s: %w", c.socketPath, err)
+	}
+	defer conn.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := conn.SetDeadline(deadline); err != nil {
+			return fmt.Errorf("failed to set admin RPC deadline: %w", err)
+		}
+	}
+
+	req := JSONRPCRequest{
+		JSONRPC: "2.0",
+		ID:      1,
+		Method:  method,
+		Params:  []interface{}{},
+	}
+	if err := json.NewEncoder(conn).Encode(req); err != nil {
+		return fmt.Errorf("failed to send admin RPC request: %w", err)
+	}
+
+	var resp struct {
+		Result json.RawMessage `json:"result"`
+		Error  *RPCError       `json:"error"`
+	}
+	if err := json.NewDecoder(conn).Decode(&resp); err != nil {
+		return fmt.Errorf("failed to decode admin RPC response: %w", err)
+	}
+	if resp.Error != nil {
+		return fmt.Errorf("admin RPC error: %s", resp.Error.Message)
+	}
+
+	if err := json.Unmarshal(resp.Result, result); err != nil {
+		return fmt.Errorf("invalid admin RPC %s response: %w", method, err)
+	}
+	return nil
+}
+
+// GetContactInfo gets the validator's contact info from its admin RPC
+func (c *AdminClient) GetContactInfo() (*AdminContactInfo, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	contactInfo := &AdminContactInfo{}
+	if err := c.makeAdminCall(ctx, "contactInfo", contactInfo); err != nil {
+		return nil, fmt.Errorf("failed to get contact info: %w", err)
+	}
+
+	c.logger.Debug("admin contact info response", "id", contactInfo.ID, "version", contactInfo.Version)
+	return contactInfo, nil
+}
@@ -0,0 +1,95 @@
+package rpc
+
+import (
+	"encoding/json"
+	"net"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestAdminClient_GetContactInfo(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Skipping on Windows")
+	}
+
+	tests := []struct {
+		name        string
+		response    string
+		noServer    bool
+		wantVersion string
+		wantErr     bool
+	}{
+		{
+			name:        "contact info",
+			response:    `{"jsonrpc":"2.0","id":1,"result":{"id":"identity-key","version":"2.2.14 (src:00000000; feat:3294202862, client:JitoLabs)"}}`,
+			wantVersion: "2.2.14 (src:00000000; feat:3294202862, client:JitoLabs)",
+		},
+		{
+			name:     "admin RPC error",
+			response: `{"jsonrpc":"2.0","id":1,"error":{"code":-32601,"message":"Method not found"}}`,
+			wantErr:  true,
+		},
+		{
+			name:     "invalid result",
+			response: `{"jsonrpc":"2.0","id":1,"result":"invalid"}`,
+			wantErr:  true,
+		},
+		{
+			name:     "socket unavailable",
+			noServer: true,
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// unix socket paths are length limited, so keep them short
+			dir, err := os.MkdirTemp("", "admin")
+			if err != nil {
+				t.Fatalf("failed to create temp dir: %v", err)
+			}
+			defer os.RemoveAll(dir)
+			socketPath := filepath.Join(dir, "admin.rpc")
+
+			gotMethod := make(chan string, 1)
+			if !tt.noServer {
+				listener, err := net.Listen("unix", socketPath)
+				if err != nil {
+					t.Fatalf("failed to listen on %s: %v", socketPath, err)
+				}
+				defer listener.Close()
+
+				go func() {
+					conn, err := listener.Accept()
+					if err != nil {
+						return
+					}
+					defer conn.Close()
+					var req JSONRPCRequest
+					if err := json.NewDecoder(conn).Decode(&req); err != nil {
+						return
+					}
+					gotMethod <- req.Method
+					_, _ = conn.Write([]byte(tt.response))
+				}()
+			}
+
+			contactInfo, err := NewAdminClient(socketPath).GetContactInfo()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetContactInfo() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			if method := <-gotMethod; method != "contactInfo" {
+				t.Errorf("GetContactInfo() called %s, want contactInfo", method)
+			}
+			if contactInfo.Version != tt.wantVersion {
+				t.Errorf("GetContactInfo() version = %q, want %q", contactInfo.Version, tt.wantVersion)
+			}
+		})
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/charmbracelet/log"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/logging"
 )
 
 // JSONRPCRequest represents a JSON-RPC request
@@ -42,12 +43,83 @@ type Client struct {
 
 // clusterNode represents a node in the cluster
 type clusterNodeResult struct {
-	Gossip string `json:"gossip"`
-	Pubkey string `json:"pubkey"`
+	Gossip  string `json:"gossip"`
+	Pubkey  string `json:"pubkey"`
+	Version string `json:"version"`
+	// ClientID is the client the node advertises in gossip (e.g. Agave, JitoLabs, Firedancer), empty when the RPC
+	// node does not expose it
+	ClientID string `json:"clientId"`
+	// ShredVersion is the shred version the node advertises in gossip, 0 when the RPC node does not expose it
+	ShredVersion uint16 `json:"shredVersion"`
 }
 
 type clusterNodeResults []clusterNodeResult
 
+// EpochInfo represents the result of a getEpochInfo call
+type EpochInfo struct {
+	AbsoluteSlot uint64 `json:"absoluteSlot"`
+	BlockHeight  uint64 `json:"blockHeight"`
+	Epoch        uint64 `json:"epoch"`
+	SlotIndex    uint64 `json:"slotIndex"`
+	SlotsInEpoch uint64 `json:"slotsInEpoch"`
+}
+
+// VoteAccount represents a vote account as returned by getVoteAccounts
+type VoteAccount struct {
+	VotePubkey     string `json:"votePubkey"`
+	NodePubkey     string `json:"nodePubkey"`
+	ActivatedStake uint64 `json:"activatedStake"`
+}
+
+// VoteAccounts represents the result of a getVoteAccounts call
+type VoteAccounts struct {
+	Current    []VoteAccount `json:"current"`
+	Delinquent []VoteAccount `json:"delinquent"`
+}
+
+// StakeProgramID is the address of the native stake program
+const StakeProgramID = "Stake11111111111111111111111111111111111111"
+
+// stakeVoterOffset is the offset of the delegated vote account in stake account data
+const stakeVoterOffset = 124
+
+// StakeDelegation represents a stake account's delegation as returned by getProgramAccounts with jsonParsed encoding
+type StakeDelegation struct {
+	StakePubkey       string
+	Voter             string
+	Stake             uint64
+	ActivationEpoch   uint64
+	DeactivationEpoch uint64
+}
+
+// stakeProgramAccountResult represents a stake account as returned by getProgramAccounts with jsonParsed encoding -
+// u64 values are encoded as strings
+type stakeProgramAccountResult struct {
+	Pubkey  string `json:"pubkey"`
+	Account struct {
+		Data struct {
+			Parsed struct {
+				Type string `json:"type"`
+				Info struct {
+					Stake *struct {
+						Delegation struct {
+							Voter             string `json:"voter"`
+							Stake             uint64 `json:"stake,string"`
+							ActivationEpoch   uint64 `json:"activationEpoch,string"`
+							DeactivationEpoch uint64 `json:"deactivationEpoch,string"`
+						} `json:"delegation"`
+					} `json:"stake"`
+				} `json:"info"`
+			} `json:"parsed"`
+		} `json:"data"`
+	} `json:"account"`
+}
+
+// All returns the current and delinquent vote accounts
+func (v VoteAccounts) All() []VoteAccount {
+	return append(append([]VoteAccount{}, v.Current...), v.Delinquent...)
+}
+
 // NewClient creates a new RPC client
 func NewClient(url string) *Client {
 	return &Client{
@@ -55,7 +127,7 @@ func NewClient(url string) *Client {
 		client: &http.Client{
 			Timeout: 30 * time.Second,
 		},
-		logger: log.WithPrefix("rpc"),
+		logger: logging.WithPrefix("rpc"),
 	}
 }
 
@@ -125,7 +197,7 @@ func (c *Client) getIdentity(ctx context.Context) (string, error) {
 		return "", fmt.Errorf("invalid response format")
 	}
 
-	c.logger.Debug("identity response", "result", resp.Result)
+	c.logger.Debug("identity response", "result", logging.Payload(resp.Result))
 
 	identity, ok := result["identity"].(string)
 	if !ok {
@@ -199,11 +271,133 @@ func (c *Client) getClusterNodes(ctx context.Context) (*clusterNodeResults, erro
 		if pubkey, ok := nodeMap["pubkey"].(string); ok {
 			node.Pubkey = pubkey
 		}
+		// version is null for nodes that haven't advertised one
+		if version, ok := nodeMap["version"].(string); ok {
+			node.Version = version
+		}
+		// clientId is only exposed by newer RPC nodes
+		if clientID, ok := nodeMap["clientId"].(string); ok {
+			node.ClientID = clientID
+		}
+		// shredVersion is null for nodes that haven't advertised one
+		if shredVersion, ok := nodeMap["shredVersion"].(float64); ok {
+			node.ShredVersion = uint16(shredVersion)
+		}
 		clusterNodeResults = append(clusterNodeResults, node)
 	}
+	c.logger.Debug("cluster nodes response", "nodes", logging.Payload(clusterNodeResults))
 	return &clusterNodeResults, nil
 }
 
+// getSlot gets the slot that has reached the given or default commitment level
+func (c *Client) getSlot(ctx context.Context) (uint64, error) {
+	resp, err := c.makeRPCCall(ctx, "getSlot", []interface{}{})
+	if err != nil {
+		return 0, fmt.Errorf("failed to get slot: %w", err)
+	}
+
+	// JSON numbers are decoded as float64
+	result, ok := resp.Result.(float64)
+	if !ok || result < 0 {
+		return 0, fmt.Errorf("invalid response format")
+	}
+
+	return uint64(result), nil
+}
+
+// getEpochInfo gets information about the current epoch
+func (c *Client) getEpochInfo(ctx context.Context) (*EpochInfo, error) {
+	resp, err := c.makeRPCCall(ctx, "getEpochInfo", []interface{}{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get epoch info: %w", err)
+	}
+
+	// round-trip the generic result through json to decode it into the typed struct
+	resultJSON, err := json.Marshal(resp.Result)
+	if err != nil {
+		return nil, fmt.Errorf("invalid response format: %w", err)
+	}
+
+	epochInfo := &EpochInfo{}
+	if err := json.Unmarshal(resultJSON, epochInfo); err != nil {
+		return nil, fmt.Errorf("invalid response format: %w", err)
+	}
+
+	if epochInfo.SlotsInEpoch == 0 {
+		return nil, fmt.Errorf("invalid epoch info: slotsInEpoch is 0")
+	}
+
+	return epochInfo, nil
+}
+
+// getVoteAccounts gets the current and delinquent vote accounts
+func (c *Client) getVoteAccounts(ctx context.Context) (*VoteAccounts, error) {
+	resp, err := c.makeRPCCall(ctx, "getVoteAccounts", []interface{}{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get vote accounts: %w", err)
+	}
+
+	// round-trip the generic result through json to decode it into the typed struct
+	resultJSON, err := json.Marshal(resp.Result)
+	if err != nil {
+		return nil, fmt.Errorf("invalid response format: %w", err)
+	}
+
+	voteAccounts := &VoteAccounts{}
+	if err := json.Unmarshal(resultJSON, voteAccounts); err != nil {
+		return nil, fmt.Errorf("invalid response format: %w", err)
+	}
+
+	return voteAccounts, nil
+}
+
+// getStakeDelegations gets the delegations of all stake accounts delegated to the given vote account
+func (c *Client) getStakeDelegations(ctx context.Context, votePubkey string) ([]StakeDelegation, error) {
+	resp, err := c.makeRPCCall(ctx, "getProgramAccounts", []interface{}{
+		StakeProgramID,
+		map[string]interface{}{
+			"encoding": "jsonParsed",
+			"filters": []interface{}{
+				map[string]interface{}{
+					"memcmp": map[string]interface{}{"offset": stakeVoterOffset, "bytes": votePubkey},
+				},
+			},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get stake accounts: %w", err)
+	}
+
+	// round-trip the generic result through json to decode it into the typed struct
+	resultJSON, err := json.Marshal(resp.Result)
+	if err != nil {
+		return nil, fmt.Errorf("invalid response format: %w", err)
+	}
+
+	results := []stakeProgramAccountResult{}
+	if err := json.Unmarshal(resultJSON, &results); err != nil {
+		return nil, fmt.Errorf("invalid response format: %w", err)
+	}
+
+	delegations := []StakeDelegation{}
+	for _, result := range results {
+		// initialized but undelegated stake accounts have no delegation
+		stake := result.Account.Data.Parsed.Info.Stake
+		if result.Account.Data.Parsed.Type != "delegated" || stake == nil {
+			continue
+		}
+		delegations = append(delegations, StakeDelegation{
+			StakePubkey:       result.Pubkey,
+			Voter:             stake.Delegation.Voter,
+			Stake:             stake.Delegation.Stake,
+			ActivationEpoch:   stake.Delegation.ActivationEpoch,
+			DeactivationEpoch: stake.Delegation.DeactivationEpoch,
+		})
+	}
+
+	return delegations, nil
+}
+
 // Health checks if the validator is healthy
 func (c *Client) GetHealth() (string, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
@@ -225,6 +419,54 @@ func (c *Client) GetIdentity() (string, error) {
 	return c.getIdentity(ctx)
 }
 
+// GetSlot gets the validator's current slot (public method)
+func (c *Client) GetSlot() (uint64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	return c.getSlot(ctx)
+}
+
+// GetEpochInfo gets information about the current epoch (public method)
+func (c *Client) GetEpochInfo() (*EpochInfo, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	return c.getEpochInfo(ctx)
+}
+
+// GetVoteAccounts gets the current and delinquent vote accounts (public method)
+func (c *Client) GetVoteAccounts() (*VoteAccounts, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	return c.getVoteAccounts(ctx)
+}
+
+// GetStakeDelegations gets the delegations of all stake accounts delegated to the given vote account (public method)
+func (c *Client) GetStakeDelegations(votePubkey string) ([]StakeDelegation, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	return c.getStakeDelegations(ctx, votePubkey)
+}
+
+// GetClusterNodeVersions gets the version advertised in gossip by each node, keyed by identity public key -
+// nodes not advertising a version are omitted (public method)
+func (c *Client) GetClusterNodeVersions() (map[string]string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	clusterNodes, err := c.getClusterNodes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	versions := make(map[string]string, len(*clusterNodes))
+	for _, n := range *clusterNodes {
+		if n.Version != "" {
+			versions[n.Pubkey] = n.Version
+		}
+	}
+	return versions, nil
+}
+
 // GetNodeWithIdentityPublicKey gets a validator with the given identity public key
 func (c *Client) GetNodeWithIdentityPublicKey(identityPublicKey string) (found bool, node *clusterNodeResult, err error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
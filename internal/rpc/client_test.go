@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
 	"testing"
 	"time"
 )
@@ -433,10 +434,12 @@ func TestClient_Timeout(t *testing.T) {
 
 func TestClient_getClusterNodes(t *testing.T) {
 	tests := []struct {
-		name           string
-		serverResponse JSONRPCResponse
-		wantNodes      int
-		wantErr        bool
+		name             string
+		serverResponse   JSONRPCResponse
+		wantNodes        int
+		wantClientID     string
+		wantShredVersion uint16
+		wantErr          bool
 	}{
 		{
 			name: "successful cluster nodes call",
@@ -445,8 +448,10 @@ func TestClient_getClusterNodes(t *testing.T) {
 				ID:      1,
 				Result: []interface{}{
 					map[string]interface{}{
-						"gossip": "127.0.0.1:8001",
-						"pubkey": "9WzDXwBbmkg8ZTbNMqUxvQRAyrZzDsGYdLVL9zYtAWWM",
+						"gossip":       "127.0.0.1:8001",
+						"pubkey":       "9WzDXwBbmkg8ZTbNMqUxvQRAyrZzDsGYdLVL9zYtAWWM",
+						"clientId":     "JitoLabs",
+						"shredVersion": 50093,
 					},
 					map[string]interface{}{
 						"gossip": "127.0.0.1:8002",
@@ -454,8 +459,10 @@ func TestClient_getClusterNodes(t *testing.T) {
 					},
 				},
 			},
-			wantNodes: 2,
-			wantErr:   false,
+			wantNodes:        2,
+			wantClientID:     "JitoLabs",
+			wantShredVersion: 50093,
+			wantErr:          false,
 		},
 		{
 			name: "empty cluster nodes",
@@ -514,6 +521,12 @@ func TestClient_getClusterNodes(t *testing.T) {
 				if len(*nodes) != tt.wantNodes {
 					t.Errorf("getClusterNodes() returned %d nodes, want %d", len(*nodes), tt.wantNodes)
 				}
+				if tt.wantClientID != "" && (*nodes)[0].ClientID != tt.wantClientID {
+					t.Errorf("getClusterNodes() first node clientId = %q, want %q", (*nodes)[0].ClientID, tt.wantClientID)
+				}
+				if tt.wantShredVersion != 0 && (*nodes)[0].ShredVersion != tt.wantShredVersion {
+					t.Errorf("getClusterNodes() first node shredVersion = %d, want %d", (*nodes)[0].ShredVersion, tt.wantShredVersion)
+				}
 			}
 		})
 	}
@@ -527,6 +540,7 @@ func TestClient_GetNodeWithIdentityPublicKey(t *testing.T) {
 		wantFound         bool
 		wantNodePubkey    string
 		wantNodeGossip    string
+		wantNodeVersion   string
 		wantErr           bool
 	}{
 		{
@@ -536,12 +550,14 @@ func TestClient_GetNodeWithIdentityPublicKey(t *testing.T) {
 				ID:      1,
 				Result: []interface{}{
 					map[string]interface{}{
-						"gossip": "127.0.0.1:8001",
-						"pubkey": "9WzDXwBbmkg8ZTbNMqUxvQRAyrZzDsGYdLVL9zYtAWWM",
+						"gossip":  "127.0.0.1:8001",
+						"pubkey":  "9WzDXwBbmkg8ZTbNMqUxvQRAyrZzDsGYdLVL9zYtAWWM",
+						"version": "2.3.6",
 					},
 					map[string]interface{}{
-						"gossip": "127.0.0.1:8002",
-						"pubkey": "AnotherKey123456789012345678901234567890",
+						"gossip":  "127.0.0.1:8002",
+						"pubkey":  "AnotherKey123456789012345678901234567890",
+						"version": nil,
 					},
 				},
 			},
@@ -549,6 +565,7 @@ func TestClient_GetNodeWithIdentityPublicKey(t *testing.T) {
 			wantFound:         true,
 			wantNodePubkey:    "9WzDXwBbmkg8ZTbNMqUxvQRAyrZzDsGYdLVL9zYtAWWM",
 			wantNodeGossip:    "127.0.0.1:8001",
+			wantNodeVersion:   "2.3.6",
 			wantErr:           false,
 		},
 		{
@@ -620,6 +637,356 @@ func TestClient_GetNodeWithIdentityPublicKey(t *testing.T) {
 				if node.Gossip != tt.wantNodeGossip {
 					t.Errorf("GetNodeWithIdentityPublicKey() node.Gossip = %v, want %v", node.Gossip, tt.wantNodeGossip)
 				}
+				if node.Version != tt.wantNodeVersion {
+					t.Errorf("GetNodeWithIdentityPublicKey() node.Version = %v, want %v", node.Version, tt.wantNodeVersion)
+				}
+			}
+		})
+	}
+}
+
+func TestClient_GetSlot(t *testing.T) {
+	tests := []struct {
+		name           string
+		serverResponse JSONRPCResponse
+		wantSlot       uint64
+		wantErr        bool
+	}{
+		{
+			name: "successful slot call",
+			serverResponse: JSONRPCResponse{
+				JSONRPC: "2.0",
+				ID:      1,
+				Result:  345678901,
+			},
+			wantSlot: 345678901,
+			wantErr:  false,
+		},
+		{
+			name: "invalid response format",
+			serverResponse: JSONRPCResponse{
+				JSONRPC: "2.0",
+				ID:      1,
+				Result:  "invalid format",
+			},
+			wantErr: true,
+		},
+		{
+			name: "RPC error",
+			serverResponse: JSONRPCResponse{
+				JSONRPC: "2.0",
+				ID:      1,
+				Error: &RPCError{
+					Code:    -32601,
+					Message: "Method not found",
+				},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				json.NewEncoder(w).Encode(tt.serverResponse)
+			}))
+			defer server.Close()
+
+			client := NewClient(server.URL)
+
+			slot, err := client.GetSlot()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetSlot() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && slot != tt.wantSlot {
+				t.Errorf("GetSlot() = %v, want %v", slot, tt.wantSlot)
+			}
+		})
+	}
+}
+
+func TestClient_GetEpochInfo(t *testing.T) {
+	tests := []struct {
+		name           string
+		serverResponse JSONRPCResponse
+		want           EpochInfo
+		wantErr        bool
+	}{
+		{
+			name: "successful epoch info call",
+			serverResponse: JSONRPCResponse{
+				JSONRPC: "2.0",
+				ID:      1,
+				Result: map[string]interface{}{
+					"absoluteSlot": 166598,
+					"blockHeight":  166500,
+					"epoch":        27,
+					"slotIndex":    2790,
+					"slotsInEpoch": 8192,
+				},
+			},
+			want: EpochInfo{
+				AbsoluteSlot: 166598,
+				BlockHeight:  166500,
+				Epoch:        27,
+				SlotIndex:    2790,
+				SlotsInEpoch: 8192,
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid response format",
+			serverResponse: JSONRPCResponse{
+				JSONRPC: "2.0",
+				ID:      1,
+				Result:  "invalid format",
+			},
+			wantErr: true,
+		},
+		{
+			name: "zero slots in epoch",
+			serverResponse: JSONRPCResponse{
+				JSONRPC: "2.0",
+				ID:      1,
+				Result: map[string]interface{}{
+					"absoluteSlot": 166598,
+					"epoch":        27,
+				},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				json.NewEncoder(w).Encode(tt.serverResponse)
+			}))
+			defer server.Close()
+
+			client := NewClient(server.URL)
+
+			epochInfo, err := client.GetEpochInfo()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetEpochInfo() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && *epochInfo != tt.want {
+				t.Errorf("GetEpochInfo() = %+v, want %+v", *epochInfo, tt.want)
+			}
+		})
+	}
+}
+
+func TestClient_GetClusterNodeVersions(t *testing.T) {
+	tests := []struct {
+		name           string
+		serverResponse JSONRPCResponse
+		want           map[string]string
+		wantErr        bool
+	}{
+		{
+			name: "nodes with and without versions",
+			serverResponse: JSONRPCResponse{
+				JSONRPC: "2.0",
+				ID:      1,
+				Result: []interface{}{
+					map[string]interface{}{"pubkey": "node-1", "version": "2.1.5"},
+					map[string]interface{}{"pubkey": "node-2", "version": nil},
+					map[string]interface{}{"pubkey": "node-3", "version": "2.2.0"},
+				},
+			},
+			want: map[string]string{"node-1": "2.1.5", "node-3": "2.2.0"},
+		},
+		{
+			name: "invalid response format",
+			serverResponse: JSONRPCResponse{
+				JSONRPC: "2.0",
+				ID:      1,
+				Result:  "invalid format",
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				json.NewEncoder(w).Encode(tt.serverResponse)
+			}))
+			defer server.Close()
+
+			got, err := NewClient(server.URL).GetClusterNodeVersions()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetClusterNodeVersions() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetClusterNodeVersions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClient_GetVoteAccounts(t *testing.T) {
+	tests := []struct {
+		name           string
+		serverResponse JSONRPCResponse
+		want           []VoteAccount
+		wantErr        bool
+	}{
+		{
+			name: "current and delinquent vote accounts",
+			serverResponse: JSONRPCResponse{
+				JSONRPC: "2.0",
+				ID:      1,
+				Result: map[string]interface{}{
+					"current": []interface{}{
+						map[string]interface{}{"votePubkey": "vote-1", "nodePubkey": "node-1", "activatedStake": 42000000000},
+					},
+					"delinquent": []interface{}{
+						map[string]interface{}{"votePubkey": "vote-2", "nodePubkey": "node-2", "activatedStake": 0},
+					},
+				},
+			},
+			want: []VoteAccount{
+				{VotePubkey: "vote-1", NodePubkey: "node-1", ActivatedStake: 42000000000},
+				{VotePubkey: "vote-2", NodePubkey: "node-2", ActivatedStake: 0},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid response format",
+			serverResponse: JSONRPCResponse{
+				JSONRPC: "2.0",
+				ID:      1,
+				Result:  "invalid format",
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				json.NewEncoder(w).Encode(tt.serverResponse)
+			}))
+			defer server.Close()
+
+			client := NewClient(server.URL)
+
+			voteAccounts, err := client.GetVoteAccounts()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetVoteAccounts() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			got := voteAccounts.All()
+			if len(got) != len(tt.want) {
+				t.Fatalf("GetVoteAccounts().All() = %+v, want %+v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("GetVoteAccounts().All()[%d] = %+v, want %+v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestClient_GetStakeDelegations(t *testing.T) {
+	delegatedAccount := func(pubkey string, stake string, activationEpoch string, deactivationEpoch string) map[string]interface{} {
+		return map[string]interface{}{
+			"pubkey": pubkey,
+			"account": map[string]interface{}{
+				"data": map[string]interface{}{
+					"parsed": map[string]interface{}{
+						"type": "delegated",
+						"info": map[string]interface{}{
+							"stake": map[string]interface{}{
+								"delegation": map[string]interface{}{
+									"voter":             "vote-key",
+									"stake":             stake,
+									"activationEpoch":   activationEpoch,
+									"deactivationEpoch": deactivationEpoch,
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name           string
+		serverResponse JSONRPCResponse
+		want           []StakeDelegation
+		wantErr        bool
+	}{
+		{
+			name: "delegated and initialized stake accounts",
+			serverResponse: JSONRPCResponse{
+				JSONRPC: "2.0",
+				ID:      1,
+				Result: []interface{}{
+					delegatedAccount("stake-1", "5000000000", "800", "18446744073709551615"),
+					map[string]interface{}{
+						"pubkey": "stake-2",
+						"account": map[string]interface{}{
+							"data": map[string]interface{}{
+								"parsed": map[string]interface{}{"type": "initialized", "info": map[string]interface{}{}},
+							},
+						},
+					},
+				},
+			},
+			want: []StakeDelegation{
+				{StakePubkey: "stake-1", Voter: "vote-key", Stake: 5000000000, ActivationEpoch: 800, DeactivationEpoch: 18446744073709551615},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid response format",
+			serverResponse: JSONRPCResponse{
+				JSONRPC: "2.0",
+				ID:      1,
+				Result:  "invalid format",
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotRequest JSONRPCRequest
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				json.NewDecoder(r.Body).Decode(&gotRequest)
+				json.NewEncoder(w).Encode(tt.serverResponse)
+			}))
+			defer server.Close()
+
+			client := NewClient(server.URL)
+
+			delegations, err := client.GetStakeDelegations("vote-key")
+			if gotRequest.Method != "getProgramAccounts" || len(gotRequest.Params) == 0 || gotRequest.Params[0] != StakeProgramID {
+				t.Errorf("request = %+v, want getProgramAccounts of %s", gotRequest, StakeProgramID)
+			}
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetStakeDelegations() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if len(delegations) != len(tt.want) {
+				t.Fatalf("GetStakeDelegations() = %+v, want %+v", delegations, tt.want)
+			}
+			for i := range delegations {
+				if delegations[i] != tt.want[i] {
+					t.Errorf("GetStakeDelegations()[%d] = %+v, want %+v", i, delegations[i], tt.want[i])
+				}
 			}
 		})
 	}
@@ -0,0 +1,236 @@
+// Package schemagen generates the config file JSON Schema from the koanf-tagged config structs.
+// It is only used at build time (go generate ./internal/config) and is not part of the binary.
+package schemagen
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/sol-strategies/solana-validator-version-sync/internal/config"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/constants"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/secrets"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/sync_commands"
+)
+
+var (
+	// schemaEnums are the allowed values of config fields, keyed by path
+	schemaEnums = map[string][]string{
+		"log.level":                    {"debug", "info", "warn", "error", "fatal"},
+		"log.format":                   {"text", "json", "logfmt"},
+		"log.levels.*":                 {"debug", "info", "warn", "error", "fatal"},
+		"validator.client":             append(append([]string{}, constants.ValidClientNames...), "rakurai", constants.ClientNameAuto),
+		"cluster.name":                 constants.ValidClusterNames,
+		"sync.commands[].phase":        {sync_commands.PhasePrepare, sync_commands.PhaseActivate, sync_commands.PhaseRollback},
+		"sync.commands[].run_once_per": sync_commands.RunOncePerValues,
+		"sync.adoption_gate.source":    config.AdoptionSources,
+		"sync.on_unknown_role":         config.OnUnknownRoleValues,
+		"sync.on_other_role.*":         config.OnUnknownRoleValues,
+	}
+
+	// schemaRequired are the required properties of config objects, keyed by path
+	schemaRequired = map[string][]string{
+		"":                     {"validator", "cluster"},
+		"validator":            {"client", "version_constraint", "identities"},
+		"validator.identities": {"active", "passive"},
+		"cluster":              {"name"},
+		"sync.commands[]":      {"name", "cmd"},
+	}
+
+	// schemaLegacyProperties are removed config keys still accepted (and ignored) so old configs keep loading, keyed by path
+	schemaLegacyProperties = map[string]map[string]*JSONSchema{
+		"sync": {
+			"allowed_semver_changes": {
+				Description: "Deprecated and ignored - constrain sync targets with validator.version_constraint instead",
+				Type:        "object",
+				Deprecated:  true,
+			},
+		},
+	}
+)
+
+// JSONSchema represents the subset of JSON Schema (draft 2020-12) used to describe the config
+type JSONSchema struct {
+	Schema               string                 `json:"$schema,omitempty"`
+	ID                   string                 `json:"$id,omitempty"`
+	Title                string                 `json:"title,omitempty"`
+	Description          string                 `json:"description,omitempty"`
+	Type                 string                 `json:"type,omitempty"`
+	Properties           map[string]*JSONSchema `json:"properties,omitempty"`
+	AdditionalProperties interface{}            `json:"additionalProperties,omitempty"`
+	Required             []string               `json:"required,omitempty"`
+	Items                *JSONSchema            `json:"items,omitempty"`
+	Enum                 []string               `json:"enum,omitempty"`
+	Pattern              string                 `json:"pattern,omitempty"`
+	Minimum              *float64               `json:"minimum,omitempty"`
+	MinProperties        *int                   `json:"minProperties,omitempty"`
+	MaxProperties        *int                   `json:"maxProperties,omitempty"`
+	OneOf                []*JSONSchema          `json:"oneOf,omitempty"`
+	Default              interface{}            `json:"default,omitempty"`
+	Deprecated           bool                   `json:"deprecated,omitempty"`
+}
+
+// Generate generates the JSON Schema of the config file from the koanf-tagged config structs,
+// describing fields with the supplied docs (see ParseFieldDocs) and the config defaults
+func Generate(docs map[string]string) ([]byte, error) {
+	generator := schemaGenerator{docs: docs}
+
+	schema := generator.schemaForType(reflect.TypeOf(config.Config{}), "")
+	schema.Schema = "https://json-schema.org/draft/2020-12/schema"
+	schema.ID = "https://github.com/sol-strategies/solana-validator-version-sync/config.schema.json"
+	schema.Title = "solana-validator-version-sync config"
+
+	content, err := json.MarshalIndent(schema, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal schema: %w", err)
+	}
+
+	return append(content, '\n'), nil
+}
+
+// ParseFieldDocs parses the doc comments of struct fields in the supplied package directories,
+// keyed by package.Type.Field
+func ParseFieldDocs(dirs ...string) (docs map[string]string, err error) {
+	docs = make(map[string]string)
+	fileSet := token.NewFileSet()
+
+	for _, dir := range dirs {
+		packages, err := parser.ParseDir(fileSet, dir, nil, parser.ParseComments)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", dir, err)
+		}
+
+		for packageName, pkg := range packages {
+			if strings.HasSuffix(packageName, "_test") {
+				continue
+			}
+			for _, file := range pkg.Files {
+				collectFieldDocs(packageName, file, docs)
+			}
+		}
+	}
+
+	return docs, nil
+}
+
+// collectFieldDocs collects the doc comments of struct fields declared in the file
+func collectFieldDocs(packageName string, file *ast.File, docs map[string]string) {
+	ast.Inspect(file, func(node ast.Node) bool {
+		typeSpec, ok := node.(*ast.TypeSpec)
+		if !ok {
+			return true
+		}
+		structType, ok := typeSpec.Type.(*ast.StructType)
+		if !ok {
+			return true
+		}
+
+		for _, field := range structType.Fields.List {
+			comment := field.Doc
+			if comment == nil {
+				comment = field.Comment
+			}
+			if comment == nil {
+				continue
+			}
+			for _, name := range field.Names {
+				docs[packageName+"."+typeSpec.Name.Name+"."+name.Name] = strings.Join(strings.Fields(comment.Text()), " ")
+			}
+		}
+		return true
+	})
+}
+
+// schemaGenerator generates JSON Schemas from config types
+type schemaGenerator struct {
+	docs map[string]string
+}
+
+// schemaForType returns the schema of a config type at the given path
+func (g *schemaGenerator) schemaForType(t reflect.Type, schemaPath string) *JSONSchema {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	schema := &JSONSchema{
+		Enum:    schemaEnums[schemaPath],
+		Default: schemaDefault(schemaPath),
+	}
+
+	if t == reflect.TypeOf(time.Duration(0)) {
+		schema.Type = "string"
+		schema.Pattern = config.DurationPattern
+		return schema
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		schema.Type = "string"
+	case reflect.Bool:
+		schema.Type = "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		schema.Type = "integer"
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		schema.Type = "integer"
+		minimum := float64(0)
+		schema.Minimum = &minimum
+	case reflect.Float32, reflect.Float64:
+		schema.Type = "number"
+	case reflect.Slice, reflect.Array:
+		schema.Type = "array"
+		schema.Items = g.schemaForType(t.Elem(), schemaPath+"[]")
+	case reflect.Map:
+		schema.Type = "object"
+		schema.AdditionalProperties = g.schemaForType(t.Elem(), schemaPath+".*")
+	case reflect.Struct:
+		schema.Type = "object"
+		schema.AdditionalProperties = false
+		schema.Required = schemaRequired[schemaPath]
+		schema.Properties = make(map[string]*JSONSchema)
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			key := field.Tag.Get("koanf")
+			if !field.IsExported() || key == "" || key == "-" {
+				continue
+			}
+
+			fieldPath := key
+			if schemaPath != "" {
+				fieldPath = schemaPath + "." + key
+			}
+
+			fieldSchema := g.schemaForType(field.Type, fieldPath)
+			fieldSchema.Description = g.docs[path.Base(t.PkgPath())+"."+t.Name()+"."+field.Name]
+			schema.Properties[key] = fieldSchema
+		}
+		for key, legacySchema := range schemaLegacyProperties[schemaPath] {
+			schema.Properties[key] = legacySchema
+		}
+	}
+
+	// command environment values are either templated strings or secret references
+	if schemaPath == "sync.commands[].environment.*" {
+		secretRefSchema := g.schemaForType(reflect.TypeOf(secrets.Ref{}), "sync.commands[].secrets.*")
+		return &JSONSchema{OneOf: []*JSONSchema{schema, secretRefSchema}}
+	}
+
+	// secret references must set exactly one source
+	if schemaPath == "sync.commands[].secrets.*" || schemaPath == "sync.nomad.token" || schemaPath == "report.consul.token" {
+		one := 1
+		schema.MinProperties = &one
+		schema.MaxProperties = &one
+	}
+
+	return schema
+}
+
+// schemaDefault returns the default of the config field at the given path, if any
+func schemaDefault(schemaPath string) interface{} {
+	return config.Defaults()[schemaPath]
+}
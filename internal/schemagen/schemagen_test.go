@@ -0,0 +1,102 @@
+package schemagen
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/sol-strategies/solana-validator-version-sync/internal/config"
+)
+
+// sourceDirs are the package directories whose doc comments describe config fields, relative to this package
+var sourceDirs = []string{"../config", "../sync_commands", "../secrets"}
+
+func TestSchema_UpToDate(t *testing.T) {
+	docs, err := ParseFieldDocs(sourceDirs...)
+	if err != nil {
+		t.Fatalf("ParseFieldDocs() error = %v", err)
+	}
+
+	generated, err := Generate(docs)
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	if !bytes.Equal(generated, config.Schema()) {
+		t.Fatal("embedded schema.json is out of date with the config structs - run: go generate ./internal/config")
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	docs, err := ParseFieldDocs(sourceDirs...)
+	if err != nil {
+		t.Fatalf("ParseFieldDocs() error = %v", err)
+	}
+
+	content, err := Generate(docs)
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	var schema JSONSchema
+	if err := json.Unmarshal(content, &schema); err != nil {
+		t.Fatalf("generated schema is not valid JSON: %v", err)
+	}
+
+	validator := schema.Properties["validator"]
+	if validator == nil {
+		t.Fatal("schema missing validator property")
+	}
+	if len(validator.Required) == 0 {
+		t.Error("validator schema has no required properties")
+	}
+	if validator.Properties["rpc_url"].Default != "http://127.0.0.1:8899" {
+		t.Errorf("validator.rpc_url default = %v, want http://127.0.0.1:8899", validator.Properties["rpc_url"].Default)
+	}
+	if validator.Properties["client"].Description == "" {
+		t.Error("validator.client has no description")
+	}
+	if len(validator.Properties["client"].Enum) == 0 {
+		t.Error("validator.client has no enum")
+	}
+	if _, ok := validator.Properties["identities"].Properties["active"]; !ok {
+		t.Error("validator.identities missing active property")
+	}
+	if _, ok := validator.Properties["identities"].Properties["ActiveKeyPair"]; ok {
+		t.Error("schema includes koanf:\"-\" field")
+	}
+
+	slotTrigger := schema.Properties["sync"].Properties["slot_trigger"]
+	if slotTrigger.Properties["poll_interval"].Pattern == "" || slotTrigger.Properties["poll_interval"].Default != "2s" {
+		t.Errorf("sync.slot_trigger.poll_interval = %+v, want duration pattern and 2s default", slotTrigger.Properties["poll_interval"])
+	}
+	if slotTrigger.Properties["slot"].Type != "integer" || slotTrigger.Properties["slot"].Minimum == nil {
+		t.Errorf("sync.slot_trigger.slot = %+v, want non-negative integer", slotTrigger.Properties["slot"])
+	}
+
+	command := schema.Properties["sync"].Properties["commands"].Items
+	if command == nil || command.Properties["cmd"] == nil || command.Properties["phase"].Description == "" {
+		t.Fatalf("sync.commands items = %+v, want described command properties", command)
+	}
+	environmentValue, ok := command.Properties["environment"].AdditionalProperties.(map[string]interface{})
+	if !ok || environmentValue["oneOf"] == nil {
+		t.Errorf("sync.commands[].environment values = %v, want oneOf string or secret reference", command.Properties["environment"].AdditionalProperties)
+	}
+}
+
+func TestParseFieldDocs(t *testing.T) {
+	docs, err := ParseFieldDocs(sourceDirs...)
+	if err != nil {
+		t.Fatalf("ParseFieldDocs() error = %v", err)
+	}
+
+	for _, key := range []string{"config.Config.Log", "config.SlotTrigger.MaxWait", "sync_commands.Command.Cmd", "secrets.Ref.FromVault"} {
+		if docs[key] == "" {
+			t.Errorf("ParseFieldDocs() missing doc for %s", key)
+		}
+	}
+
+	if _, err := ParseFieldDocs("/non/existent/dir"); err == nil {
+		t.Error("ParseFieldDocs() error = nil, want error for non-existent dir")
+	}
+}
@@ -0,0 +1,93 @@
+package secrets
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+const (
+	// Redacted replaces secret values wherever they would otherwise be logged
+	Redacted = "[REDACTED]"
+)
+
+// Ref is a reference to a secret value resolved at execution time - exactly one source must be set
+type Ref struct {
+	// FromEnv is the name of an environment variable of this process holding the secret
+	FromEnv string `koanf:"from_env"`
+	// FromFile is the path of a file holding the secret, e.g. /run/secrets/api-key
+	FromFile string `koanf:"from_file"`
+	// FromVault is a Vault API path and field holding the secret in the form path#field, e.g. secret/data/validator#api_key
+	FromVault string `koanf:"from_vault"`
+}
+
+// Validate validates the secret reference
+func (r *Ref) Validate() error {
+	sourcesCount := 0
+	for _, source := range []string{r.FromEnv, r.FromFile, r.FromVault} {
+		if source != "" {
+			sourcesCount++
+		}
+	}
+	if sourcesCount != 1 {
+		return fmt.Errorf("secret reference must set exactly one of from_env, from_file, from_vault - got %d", sourcesCount)
+	}
+
+	if r.FromVault != "" {
+		if _, _, err := splitVaultRef(r.FromVault); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Source returns a loggable description of where the secret is resolved from
+func (r *Ref) Source() string {
+	switch {
+	case r.FromEnv != "":
+		return "env:" + r.FromEnv
+	case r.FromFile != "":
+		return "file:" + r.FromFile
+	case r.FromVault != "":
+		return "vault:" + r.FromVault
+	default:
+		return "none"
+	}
+}
+
+// Resolve resolves the secret value from its source
+func (r *Ref) Resolve() (value string, err error) {
+	switch {
+	case r.FromEnv != "":
+		value, ok := os.LookupEnv(r.FromEnv)
+		if !ok {
+			return "", fmt.Errorf("environment variable %s is not set", r.FromEnv)
+		}
+		return value, nil
+	case r.FromFile != "":
+		content, err := os.ReadFile(r.FromFile)
+		if err != nil {
+			return "", fmt.Errorf("failed to read secret file: %w", err)
+		}
+		return strings.TrimRight(string(content), "\r\n"), nil
+	case r.FromVault != "":
+		path, field, err := splitVaultRef(r.FromVault)
+		if err != nil {
+			return "", err
+		}
+		return NewVaultClientFromEnv().ReadField(path, field)
+	default:
+		return "", fmt.Errorf("secret reference has no source")
+	}
+}
+
+// splitVaultRef splits a path#field vault reference
+func splitVaultRef(ref string) (path string, field string, err error) {
+	path, field, ok := strings.Cut(ref, "#")
+	path = strings.Trim(path, "/")
+	if !ok || path == "" || field == "" {
+		return "", "", fmt.Errorf("from_vault must be in the form path#field - got: %s", ref)
+	}
+	return path, field, nil
+}
@@ -0,0 +1,83 @@
+package secrets
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRef_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		ref     Ref
+		wantErr bool
+	}{
+		{name: "from env", ref: Ref{FromEnv: "MY_KEY"}},
+		{name: "from file", ref: Ref{FromFile: "/run/secrets/x"}},
+		{name: "from vault", ref: Ref{FromVault: "secret/data/validator#api_key"}},
+		{name: "no source", ref: Ref{}, wantErr: true},
+		{name: "multiple sources", ref: Ref{FromEnv: "MY_KEY", FromFile: "/run/secrets/x"}, wantErr: true},
+		{name: "vault without field", ref: Ref{FromVault: "secret/data/validator"}, wantErr: true},
+		{name: "vault without path", ref: Ref{FromVault: "#api_key"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.ref.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Ref.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRef_Source(t *testing.T) {
+	tests := []struct {
+		ref  Ref
+		want string
+	}{
+		{ref: Ref{FromEnv: "MY_KEY"}, want: "env:MY_KEY"},
+		{ref: Ref{FromFile: "/run/secrets/x"}, want: "file:/run/secrets/x"},
+		{ref: Ref{FromVault: "secret/data/v#k"}, want: "vault:secret/data/v#k"},
+		{ref: Ref{}, want: "none"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.ref.Source(); got != tt.want {
+			t.Errorf("Ref.Source() = %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func TestRef_Resolve(t *testing.T) {
+	secretFile := filepath.Join(t.TempDir(), "secret")
+	if err := os.WriteFile(secretFile, []byte("from-file\r\n"), 0o600); err != nil {
+		t.Fatalf("failed to write secret file: %v", err)
+	}
+	t.Setenv("TEST_SECRETS_REF", "from-env")
+
+	tests := []struct {
+		name    string
+		ref     Ref
+		want    string
+		wantErr bool
+	}{
+		{name: "from env", ref: Ref{FromEnv: "TEST_SECRETS_REF"}, want: "from-env"},
+		{name: "from env not set", ref: Ref{FromEnv: "TEST_SECRETS_REF_NOT_SET"}, wantErr: true},
+		{name: "from file trims trailing newline", ref: Ref{FromFile: secretFile}, want: "from-file"},
+		{name: "from missing file", ref: Ref{FromFile: filepath.Join(t.TempDir(), "missing")}, wantErr: true},
+		{name: "no source", ref: Ref{}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.ref.Resolve()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Ref.Resolve() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Ref.Resolve() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
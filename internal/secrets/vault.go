@@ -0,0 +1,90 @@
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// VaultClient reads secrets from a HashiCorp Vault server
+type VaultClient struct {
+	addr       string
+	token      string
+	namespace  string
+	httpClient *http.Client
+}
+
+// NewVaultClientFromEnv creates a new VaultClient configured with the standard VAULT_ADDR, VAULT_TOKEN and VAULT_NAMESPACE environment variables
+func NewVaultClientFromEnv() *VaultClient {
+	return &VaultClient{
+		addr:      strings.TrimRight(os.Getenv("VAULT_ADDR"), "/"),
+		token:     os.Getenv("VAULT_TOKEN"),
+		namespace: os.Getenv("VAULT_NAMESPACE"),
+		httpClient: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+	}
+}
+
+// vaultReadResponse represents a Vault read response - KV v2 nests the secret under data.data, KV v1 under data
+type vaultReadResponse struct {
+	Data map[string]interface{} `json:"data"`
+}
+
+// ReadField reads a single field of the secret at the given API path
+func (c *VaultClient) ReadField(path string, field string) (value string, err error) {
+	if c.addr == "" {
+		return "", fmt.Errorf("VAULT_ADDR is not set")
+	}
+	if c.token == "" {
+		return "", fmt.Errorf("VAULT_TOKEN is not set")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s/v1/%s", c.addr, path), nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("X-Vault-Token", c.token)
+	if c.namespace != "" {
+		req.Header.Set("X-Vault-Namespace", c.namespace)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to read vault secret %s: %w", path, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to read vault secret %s: unexpected status code %d", path, resp.StatusCode)
+	}
+
+	var readResponse vaultReadResponse
+	if err := json.NewDecoder(resp.Body).Decode(&readResponse); err != nil {
+		return "", fmt.Errorf("failed to decode vault secret %s: %w", path, err)
+	}
+
+	secretData := readResponse.Data
+	if nestedData, ok := secretData["data"].(map[string]interface{}); ok {
+		secretData = nestedData
+	}
+
+	rawValue, ok := secretData[field]
+	if !ok {
+		return "", fmt.Errorf("vault secret %s has no field %s", path, field)
+	}
+
+	switch typedValue := rawValue.(type) {
+	case string:
+		return typedValue, nil
+	default:
+		return fmt.Sprintf("%v", typedValue), nil
+	}
+}
@@ -0,0 +1,70 @@
+package secrets
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestVaultClient_ReadField(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "test-token" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		switch r.URL.Path {
+		case "/v1/secret/data/validator":
+			w.Write([]byte(`{"data":{"data":{"api_key":"kv2-secret"},"metadata":{"version":3}}}`))
+		case "/v1/kv/validator":
+			w.Write([]byte(`{"data":{"api_key":"kv1-secret","port":8899}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	t.Setenv("VAULT_ADDR", server.URL+"/")
+	t.Setenv("VAULT_TOKEN", "test-token")
+
+	tests := []struct {
+		name    string
+		path    string
+		field   string
+		want    string
+		wantErr bool
+	}{
+		{name: "kv v2", path: "secret/data/validator", field: "api_key", want: "kv2-secret"},
+		{name: "kv v1", path: "kv/validator", field: "api_key", want: "kv1-secret"},
+		{name: "non-string field", path: "kv/validator", field: "port", want: "8899"},
+		{name: "missing field", path: "kv/validator", field: "nope", wantErr: true},
+		{name: "missing path", path: "kv/nope", field: "api_key", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewVaultClientFromEnv().ReadField(tt.path, tt.field)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ReadField() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ReadField() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	// resolves through a vault secret reference too
+	ref := Ref{FromVault: "secret/data/validator#api_key"}
+	got, err := ref.Resolve()
+	if err != nil || got != "kv2-secret" {
+		t.Errorf("Ref.Resolve() = %q, %v, want kv2-secret", got, err)
+	}
+}
+
+func TestVaultClient_ReadField_NotConfigured(t *testing.T) {
+	t.Setenv("VAULT_ADDR", "")
+	t.Setenv("VAULT_TOKEN", "")
+
+	if _, err := NewVaultClientFromEnv().ReadField("secret/data/validator", "api_key"); err == nil {
+		t.Error("ReadField() error = nil, want error when VAULT_ADDR is not set")
+	}
+}
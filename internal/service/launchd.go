@@ -0,0 +1,77 @@
+package service
+
+import (
+	"fmt"
+	"path/filepath"
+)
+
+const (
+	// launchdLabel is the label of the launchd daemon
+	launchdLabel = "com.sol-strategies." + Name
+	// launchdDaemonDir is the directory system wide launchd daemons are installed to
+	launchdDaemonDir = "/Library/LaunchDaemons"
+	// launchdLogFile is where launchd writes the daemon's output - launchd has no journal to log to
+	launchdLogFile = "/var/log/" + Name + ".log"
+)
+
+// launchd manages the daemon as a system wide launchd daemon
+type launchd struct {
+	daemonDir string
+	run       runner
+}
+
+// newLaunchd creates a launchd service manager
+func newLaunchd() *launchd {
+	return &launchd{daemonDir: launchdDaemonDir, run: runCommand}
+}
+
+// plistFile returns the path of the daemon's property list
+func (l *launchd) plistFile() string {
+	return filepath.Join(l.daemonDir, launchdLabel+".plist")
+}
+
+// Install writes the daemon's property list and enables it at boot - launchd loads it on the next boot or start
+func (l *launchd) Install(opts Options) error {
+	if err := opts.Validate(); err != nil {
+		return err
+	}
+	if opts.User == "" {
+		return fmt.Errorf("user is required")
+	}
+
+	content, err := RenderLaunchdPlist(opts)
+	if err != nil {
+		return err
+	}
+
+	if err := writeDefinition(l.plistFile(), content, opts.Force); err != nil {
+		return err
+	}
+
+	return l.run("launchctl", "enable", "system/"+launchdLabel)
+}
+
+// Start loads the daemon, which starts it as it runs at load
+func (l *launchd) Start() error {
+	return l.run("launchctl", "bootstrap", "system", l.plistFile())
+}
+
+// Stop unloads the daemon, stopping it until the next boot or start
+func (l *launchd) Stop() error {
+	return l.run("launchctl", "bootout", "system/"+launchdLabel)
+}
+
+// Description returns the path of the daemon's property list
+func (l *launchd) Description() string {
+	return "launchd daemon " + l.plistFile()
+}
+
+// RenderLaunchdPlist renders a launchd property list that keeps the daemon syncing on the configured interval
+func RenderLaunchdPlist(opts Options) (string, error) {
+	return render("launchd.plist.tmpl", struct {
+		Label   string
+		Args    []string
+		User    string
+		LogFile string
+	}{launchdLabel, append([]string{opts.BinaryPath}, opts.Args()...), opts.User, launchdLogFile})
+}
@@ -0,0 +1,102 @@
+package service
+
+import (
+	"errors"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRenderLaunchdPlist(t *testing.T) {
+	opts := testOptions()
+	opts.ConfigFile = "/Users/sol & co/config.yaml"
+
+	content, err := RenderLaunchdPlist(opts)
+	if err != nil {
+		t.Fatalf("RenderLaunchdPlist() error = %v", err)
+	}
+
+	for _, want := range []string{
+		"<string>com.sol-strategies.solana-validator-version-sync</string>",
+		"<string>/usr/local/bin/solana-validator-version-sync</string>\n    <string>--config</string>\n    <string>/Users/sol &amp; co/config.yaml</string>\n    <string>run</string>\n    <string>--on-interval</string>\n    <string>30m0s</string>",
+		"<key>UserName</key>\n  <string>solana</string>",
+		"<key>RunAtLoad</key>\n  <true/>",
+		"<string>/var/log/solana-validator-version-sync.log</string>",
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("plist missing %q:\n%s", want, content)
+		}
+	}
+}
+
+func TestLaunchdInstall(t *testing.T) {
+	tests := []struct {
+		name         string
+		modify       func(o *Options)
+		runErr       error
+		wantErr      bool
+		wantPlist    bool
+		wantCommands []string
+	}{
+		{
+			name:         "installs and enables",
+			modify:       func(o *Options) {},
+			wantPlist:    true,
+			wantCommands: []string{"launchctl enable system/com.sol-strategies.solana-validator-version-sync"},
+		},
+		{
+			name:    "missing user",
+			modify:  func(o *Options) { o.User = "" },
+			wantErr: true,
+		},
+		{
+			name:         "launchctl failure",
+			modify:       func(o *Options) {},
+			runErr:       errors.New("boom"),
+			wantErr:      true,
+			wantPlist:    true,
+			wantCommands: []string{"launchctl enable system/com.sol-strategies.solana-validator-version-sync"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runner := &recordingRunner{err: tt.runErr}
+			l := &launchd{daemonDir: t.TempDir(), run: runner.run}
+
+			opts := testOptions()
+			tt.modify(&opts)
+			if err := l.Install(opts); (err != nil) != tt.wantErr {
+				t.Fatalf("Install() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if _, err := os.Stat(l.plistFile()); (err == nil) != tt.wantPlist {
+				t.Errorf("plist exists = %v, want %v", err == nil, tt.wantPlist)
+			}
+			if !reflect.DeepEqual(runner.commands, tt.wantCommands) {
+				t.Errorf("commands = %v, want %v", runner.commands, tt.wantCommands)
+			}
+		})
+	}
+}
+
+func TestLaunchdStartStop(t *testing.T) {
+	runner := &recordingRunner{}
+	l := &launchd{daemonDir: "/Library/LaunchDaemons", run: runner.run}
+
+	if err := l.Start(); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	if err := l.Stop(); err != nil {
+		t.Fatalf("Stop() error = %v", err)
+	}
+
+	want := []string{
+		"launchctl bootstrap system /Library/LaunchDaemons/com.sol-strategies.solana-validator-version-sync.plist",
+		"launchctl bootout system/com.sol-strategies.solana-validator-version-sync",
+	}
+	if !reflect.DeepEqual(runner.commands, want) {
+		t.Errorf("commands = %v, want %v", runner.commands, want)
+	}
+}
@@ -0,0 +1,140 @@
+package service
+
+import (
+	"bytes"
+	"embed"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/sol-strategies/solana-validator-version-sync/internal/starter"
+)
+
+// Name is the name the daemon is installed as with every service manager
+const Name = starter.UnitName
+
+// DefaultInterval is the sync interval the installed daemon runs on by default - matching the hourly starter timer
+const DefaultInterval = time.Hour
+
+//go:embed templates/*.tmpl
+var templatesFS embed.FS
+
+// templates use [[ ]] delimiters to match the starter templates
+var templates = template.Must(
+	template.New("service").
+		Delims("[[", "]]").
+		Option("missingkey=error").
+		Funcs(template.FuncMap{"xml": xmlEscape}).
+		ParseFS(templatesFS, "templates/*.tmpl"),
+)
+
+// Options represents the choices the daemon is installed with
+type Options struct {
+	// BinaryPath is the absolute path of the binary the service runs
+	BinaryPath string
+	// ConfigFile is the absolute path of the config file the service runs with
+	ConfigFile string
+	// Interval is the interval the daemon syncs on
+	Interval time.Duration
+	// User is the user the daemon runs as - ignored by the Windows service manager, which runs it as LocalSystem
+	User string
+	// Force overwrites an existing service definition on install
+	Force bool
+}
+
+// Validate validates the install options
+func (o *Options) Validate() error {
+	if o.BinaryPath == "" || o.ConfigFile == "" {
+		return fmt.Errorf("binary path and config file are required")
+	}
+	if !filepath.IsAbs(o.BinaryPath) || !filepath.IsAbs(o.ConfigFile) {
+		return fmt.Errorf("binary path and config file must be absolute paths")
+	}
+	if o.Interval <= 0 {
+		return fmt.Errorf("interval must be greater than 0")
+	}
+	return nil
+}
+
+// Args returns the arguments the installed daemon runs the binary with
+func (o *Options) Args() []string {
+	return []string{"--config", o.ConfigFile, "run", "--on-interval", o.Interval.String()}
+}
+
+// Manager installs, starts and stops the daemon with the platform's service manager
+type Manager interface {
+	// Install registers the daemon with the service manager and enables it at boot
+	Install(opts Options) error
+	// Start starts the installed daemon
+	Start() error
+	// Stop stops the running daemon
+	Stop() error
+	// Description describes where the daemon is installed, e.g. the unit file path
+	Description() string
+}
+
+// New returns the service manager for the current platform
+func New() (Manager, error) {
+	switch runtime.GOOS {
+	case "linux":
+		return newSystemd(), nil
+	case "darwin":
+		return newLaunchd(), nil
+	case "windows":
+		return newWindows()
+	default:
+		return nil, fmt.Errorf("no supported service manager on %s - run with run --on-interval under your own supervisor", runtime.GOOS)
+	}
+}
+
+// runner runs a service manager command - replaced in tests
+type runner func(name string, args ...string) error
+
+// runCommand runs a service manager command, including its output in the returned error on failure
+func runCommand(name string, args ...string) error {
+	output, err := exec.Command(name, args...).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("%s %s failed: %w: %s", name, strings.Join(args, " "), err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// writeDefinition writes a service definition file, refusing to overwrite an existing one unless forced
+func writeDefinition(file string, content string, force bool) error {
+	if _, err := os.Stat(file); err == nil && !force {
+		return fmt.Errorf("%s already exists - use --force to overwrite", file)
+	} else if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(file), 0o755); err != nil {
+		return err
+	}
+
+	return os.WriteFile(file, []byte(content), 0o644)
+}
+
+// render executes the named template with the supplied data
+func render(name string, data interface{}) (string, error) {
+	buf := bytes.Buffer{}
+	if err := templates.ExecuteTemplate(&buf, name, data); err != nil {
+		return "", fmt.Errorf("failed to render %s: %w", name, err)
+	}
+	return buf.String(), nil
+}
+
+// xmlEscape escapes a value for use as XML character data
+func xmlEscape(value string) (string, error) {
+	buf := bytes.Buffer{}
+	if err := xml.EscapeText(&buf, []byte(value)); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
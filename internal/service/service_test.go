@@ -0,0 +1,118 @@
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testOptions returns valid install options
+func testOptions() Options {
+	return Options{
+		BinaryPath: "/usr/local/bin/solana-validator-version-sync",
+		ConfigFile: "/home/solana/solana-validator-version-sync/config.yaml",
+		Interval:   30 * time.Minute,
+		User:       "solana",
+	}
+}
+
+// recordingRunner records the service manager commands run instead of running them
+type recordingRunner struct {
+	commands []string
+	err      error
+}
+
+func (r *recordingRunner) run(name string, args ...string) error {
+	r.commands = append(r.commands, strings.Join(append([]string{name}, args...), " "))
+	return r.err
+}
+
+func TestOptionsValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(o *Options)
+		wantErr bool
+	}{
+		{name: "valid", modify: func(o *Options) {}},
+		{name: "missing binary path", modify: func(o *Options) { o.BinaryPath = "" }, wantErr: true},
+		{name: "missing config file", modify: func(o *Options) { o.ConfigFile = "" }, wantErr: true},
+		{name: "relative config file", modify: func(o *Options) { o.ConfigFile = "config.yaml" }, wantErr: true},
+		{name: "zero interval", modify: func(o *Options) { o.Interval = 0 }, wantErr: true},
+		{name: "negative interval", modify: func(o *Options) { o.Interval = -time.Minute }, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := testOptions()
+			tt.modify(&opts)
+			if err := opts.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestOptionsArgs(t *testing.T) {
+	opts := testOptions()
+	want := []string{"--config", opts.ConfigFile, "run", "--on-interval", "30m0s"}
+	if got := opts.Args(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Args() = %v, want %v", got, want)
+	}
+}
+
+func TestWriteDefinition(t *testing.T) {
+	tests := []struct {
+		name     string
+		existing bool
+		force    bool
+		wantErr  bool
+	}{
+		{name: "new file", existing: false, force: false},
+		{name: "existing file without force", existing: true, force: false, wantErr: true},
+		{name: "existing file with force", existing: true, force: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file := filepath.Join(t.TempDir(), "nested", "unit.service")
+			if tt.existing {
+				if err := os.MkdirAll(filepath.Dir(file), 0o755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(file, []byte("old"), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			err := writeDefinition(file, "new", tt.force)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("writeDefinition() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			content, err := os.ReadFile(file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			want := "new"
+			if tt.wantErr {
+				want = "old"
+			}
+			if string(content) != want {
+				t.Errorf("file content = %q, want %q", content, want)
+			}
+		})
+	}
+}
+
+func TestXMLEscape(t *testing.T) {
+	got, err := xmlEscape(`/opt/a&b/<config>.yaml`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "/opt/a&amp;b/&lt;config&gt;.yaml"; got != want {
+		t.Errorf("xmlEscape() = %q, want %q", got, want)
+	}
+}
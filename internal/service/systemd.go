@@ -0,0 +1,73 @@
+package service
+
+import (
+	"fmt"
+	"path/filepath"
+)
+
+// systemdUnitDir is the directory systemd units are installed to
+const systemdUnitDir = "/etc/systemd/system"
+
+// systemd manages the daemon as a long running systemd service
+type systemd struct {
+	unitDir string
+	run     runner
+}
+
+// newSystemd creates a systemd service manager
+func newSystemd() *systemd {
+	return &systemd{unitDir: systemdUnitDir, run: runCommand}
+}
+
+// unitFile returns the path of the service unit
+func (s *systemd) unitFile() string {
+	return filepath.Join(s.unitDir, Name+".service")
+}
+
+// Install writes the service unit, reloads systemd and enables the service at boot
+func (s *systemd) Install(opts Options) error {
+	if err := opts.Validate(); err != nil {
+		return err
+	}
+	if opts.User == "" {
+		return fmt.Errorf("user is required")
+	}
+
+	content, err := RenderSystemdService(opts)
+	if err != nil {
+		return err
+	}
+
+	if err := writeDefinition(s.unitFile(), content, opts.Force); err != nil {
+		return err
+	}
+
+	if err := s.run("systemctl", "daemon-reload"); err != nil {
+		return err
+	}
+	return s.run("systemctl", "enable", Name+".service")
+}
+
+// Start starts the service
+func (s *systemd) Start() error {
+	return s.run("systemctl", "start", Name+".service")
+}
+
+// Stop stops the service
+func (s *systemd) Stop() error {
+	return s.run("systemctl", "stop", Name+".service")
+}
+
+// Description returns the path of the service unit
+func (s *systemd) Description() string {
+	return "systemd unit " + s.unitFile()
+}
+
+// RenderSystemdService renders a long running systemd service that syncs on the configured interval
+func RenderSystemdService(opts Options) (string, error) {
+	return render("systemd.service.tmpl", struct {
+		Options
+		Name     string
+		Interval string
+	}{opts, Name, opts.Interval.String()})
+}
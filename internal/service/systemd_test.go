@@ -0,0 +1,102 @@
+package service
+
+import (
+	"errors"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRenderSystemdService(t *testing.T) {
+	content, err := RenderSystemdService(testOptions())
+	if err != nil {
+		t.Fatalf("RenderSystemdService() error = %v", err)
+	}
+
+	for _, want := range []string{
+		"Type=simple",
+		"User=solana",
+		"StateDirectory=solana-validator-version-sync",
+		"ExecStart=/usr/local/bin/solana-validator-version-sync --config /home/solana/solana-validator-version-sync/config.yaml run --on-interval 30m0s",
+		"Restart=on-failure",
+		"WantedBy=multi-user.target",
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("service unit missing %q:\n%s", want, content)
+		}
+	}
+}
+
+func TestSystemdInstall(t *testing.T) {
+	tests := []struct {
+		name         string
+		modify       func(o *Options)
+		runErr       error
+		wantErr      bool
+		wantUnit     bool
+		wantCommands []string
+	}{
+		{
+			name:         "installs and enables",
+			modify:       func(o *Options) {},
+			wantUnit:     true,
+			wantCommands: []string{"systemctl daemon-reload", "systemctl enable solana-validator-version-sync.service"},
+		},
+		{
+			name:    "invalid options",
+			modify:  func(o *Options) { o.Interval = 0 },
+			wantErr: true,
+		},
+		{
+			name:    "missing user",
+			modify:  func(o *Options) { o.User = "" },
+			wantErr: true,
+		},
+		{
+			name:         "systemctl failure",
+			modify:       func(o *Options) {},
+			runErr:       errors.New("boom"),
+			wantErr:      true,
+			wantUnit:     true,
+			wantCommands: []string{"systemctl daemon-reload"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runner := &recordingRunner{err: tt.runErr}
+			s := &systemd{unitDir: t.TempDir(), run: runner.run}
+
+			opts := testOptions()
+			tt.modify(&opts)
+			if err := s.Install(opts); (err != nil) != tt.wantErr {
+				t.Fatalf("Install() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if _, err := os.Stat(s.unitFile()); (err == nil) != tt.wantUnit {
+				t.Errorf("unit file exists = %v, want %v", err == nil, tt.wantUnit)
+			}
+			if !reflect.DeepEqual(runner.commands, tt.wantCommands) {
+				t.Errorf("commands = %v, want %v", runner.commands, tt.wantCommands)
+			}
+		})
+	}
+}
+
+func TestSystemdStartStop(t *testing.T) {
+	runner := &recordingRunner{}
+	s := &systemd{unitDir: t.TempDir(), run: runner.run}
+
+	if err := s.Start(); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	if err := s.Stop(); err != nil {
+		t.Fatalf("Stop() error = %v", err)
+	}
+
+	want := []string{"systemctl start solana-validator-version-sync.service", "systemctl stop solana-validator-version-sync.service"}
+	if !reflect.DeepEqual(runner.commands, want) {
+		t.Errorf("commands = %v, want %v", runner.commands, want)
+	}
+}
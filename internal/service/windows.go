@@ -0,0 +1,154 @@
+//go:build windows
+
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"golang.org/x/sys/windows"
+	"golang.org/x/sys/windows/svc"
+	"golang.org/x/sys/windows/svc/mgr"
+)
+
+// windowsSCM manages the daemon as a Windows service
+type windowsSCM struct{}
+
+// newWindows creates a Windows service control manager
+func newWindows() (Manager, error) {
+	return &windowsSCM{}, nil
+}
+
+// Install creates (or with force, reconfigures) the service set to start automatically at boot
+func (w *windowsSCM) Install(opts Options) error {
+	if err := opts.Validate(); err != nil {
+		return err
+	}
+
+	m, err := mgr.Connect()
+	if err != nil {
+		return fmt.Errorf("failed to connect to service manager: %w", err)
+	}
+	defer m.Disconnect()
+
+	config := mgr.Config{
+		DisplayName: "Solana validator version sync",
+		Description: "Keeps the Solana validator in sync with the latest available version",
+		StartType:   mgr.StartAutomatic,
+	}
+
+	s, err := m.OpenService(Name)
+	if err != nil {
+		s, err = m.CreateService(Name, opts.BinaryPath, config, opts.Args()...)
+		if err != nil {
+			return fmt.Errorf("failed to create service: %w", err)
+		}
+		s.Close()
+		return nil
+	}
+	defer s.Close()
+
+	if !opts.Force {
+		return fmt.Errorf("service %s already exists - use --force to overwrite", Name)
+	}
+
+	current, err := s.Config()
+	if err != nil {
+		return fmt.Errorf("failed to read service config: %w", err)
+	}
+	current.DisplayName = config.DisplayName
+	current.Description = config.Description
+	current.StartType = config.StartType
+	current.BinaryPathName = commandLine(opts.BinaryPath, opts.Args())
+	if err := s.UpdateConfig(current); err != nil {
+		return fmt.Errorf("failed to update service: %w", err)
+	}
+	return nil
+}
+
+// Start starts the service
+func (w *windowsSCM) Start() error {
+	return w.withService(func(s *mgr.Service) error {
+		return s.Start()
+	})
+}
+
+// Stop stops the service
+func (w *windowsSCM) Stop() error {
+	return w.withService(func(s *mgr.Service) error {
+		_, err := s.Control(svc.Stop)
+		return err
+	})
+}
+
+// Description returns the name of the service
+func (w *windowsSCM) Description() string {
+	return "windows service " + Name
+}
+
+// withService runs fn against the installed service
+func (w *windowsSCM) withService(fn func(s *mgr.Service) error) error {
+	m, err := mgr.Connect()
+	if err != nil {
+		return fmt.Errorf("failed to connect to service manager: %w", err)
+	}
+	defer m.Disconnect()
+
+	s, err := m.OpenService(Name)
+	if err != nil {
+		return fmt.Errorf("failed to open service %s - is it installed?: %w", Name, err)
+	}
+	defer s.Close()
+
+	return fn(s)
+}
+
+// commandLine builds an escaped Windows command line, as CreateService does
+func commandLine(binaryPath string, args []string) string {
+	escaped := []string{windows.EscapeArg(binaryPath)}
+	for _, arg := range args {
+		escaped = append(escaped, windows.EscapeArg(arg))
+	}
+	return strings.Join(escaped, " ")
+}
+
+// NotifyContext returns a copy of ctx that is cancelled when the Windows service manager stops the daemon -
+// when not running as a Windows service it is only cancelled by the returned cancel func
+func NotifyContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(ctx)
+
+	isService, err := svc.IsWindowsService()
+	if err != nil || !isService {
+		return ctx, cancel
+	}
+
+	go func() {
+		// svc.Run returns once the handler has handled a stop, or when it fails to connect to the service manager -
+		// either way the daemon should stop
+		_ = svc.Run(Name, &windowsHandler{cancel: cancel})
+		cancel()
+	}()
+	return ctx, cancel
+}
+
+// windowsHandler reports the daemon running to the service manager and cancels it on stop or shutdown
+type windowsHandler struct {
+	cancel context.CancelFunc
+}
+
+// Execute handles service manager change requests until the daemon is asked to stop
+func (h *windowsHandler) Execute(args []string, requests <-chan svc.ChangeRequest, status chan<- svc.Status) (bool, uint32) {
+	status <- svc.Status{State: svc.Running, Accepts: svc.AcceptStop | svc.AcceptShutdown}
+	for request := range requests {
+		switch request.Cmd {
+		case svc.Interrogate:
+			status <- request.CurrentStatus
+		case svc.Stop, svc.Shutdown:
+			status <- svc.Status{State: svc.StopPending}
+			h.cancel()
+			return false, 0
+		}
+	}
+	return false, 0
+}
@@ -0,0 +1,18 @@
+//go:build !windows
+
+package service
+
+import (
+	"context"
+	"fmt"
+)
+
+// newWindows is only supported on Windows
+func newWindows() (Manager, error) {
+	return nil, fmt.Errorf("the windows service manager is only supported on windows")
+}
+
+// NotifyContext returns a copy of ctx - it is only cancelled by a Windows service stop on Windows
+func NotifyContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	return context.WithCancel(ctx)
+}
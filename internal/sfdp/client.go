@@ -5,12 +5,23 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/charmbracelet/log"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/budget"
 	"github.com/sol-strategies/solana-validator-version-sync/internal/constants"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/logging"
 )
 
+// baseURL is the SFDP API new clients use
+var baseURL = "https://api.solana.org/api"
+
+// SetBaseURL points new clients at another SFDP API, e.g. a fake SFDP server in integration tests
+func SetBaseURL(url string) {
+	baseURL = strings.TrimSuffix(url, "/")
+}
+
 // Client represents an SFDP API client
 type Client struct {
 	baseURL    string
@@ -18,6 +29,11 @@ type Client struct {
 	clientName string
 	client     *http.Client
 	logger     *log.Logger
+	// lastRequirements are the requirements last looked up, reused while the SFDP request budget is exhausted
+	lastRequirements *Requirements
+	// participants are the participations last looked up by identity, reused while the SFDP request budget is
+	// exhausted - nil for identities that don't participate
+	participants map[string]*Participant
 }
 
 // Options represents the options for creating a new SFDP client
@@ -29,13 +45,14 @@ type Options struct {
 // NewClient creates a new SFDP client
 func NewClient(opts Options) *Client {
 	return &Client{
-		baseURL:    "https://api.solana.org/api",
+		baseURL:    baseURL,
 		cluster:    opts.Cluster,
 		clientName: constants.NormalizeClientName(opts.Client),
 		client: &http.Client{
 			Timeout: 30 * time.Second,
 		},
-		logger: log.WithPrefix("sfdp"),
+		logger:       logging.WithPrefix("sfdp"),
+		participants: make(map[string]*Participant),
 	}
 }
 
@@ -45,8 +62,17 @@ type RequirementsResponse struct {
 	Data  []Requirements `json:"data"`
 }
 
-// GetLatestRequirements gets version requirements from SFDP for a given cluster
+// GetLatestRequirements gets version requirements from SFDP for a given cluster - the requirements last looked
+// up are returned with a warning while the SFDP request budget is exhausted
 func (c *Client) GetLatestRequirements() (latestRequirements *Requirements, err error) {
+	if !budget.Allow(budget.ProviderSFDP) {
+		if c.lastRequirements == nil {
+			return nil, budget.Exhausted(budget.ProviderSFDP)
+		}
+		c.logger.Warn("SFDP request budget exhausted - using the requirements last looked up", "epoch", c.lastRequirements.Epoch)
+		return c.lastRequirements, nil
+	}
+
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
@@ -89,7 +115,7 @@ func (c *Client) GetLatestRequirements() (latestRequirements *Requirements, err
 		}
 	}
 
-	c.logger.Debug("latest requirements", "requirements", latestRequirements, "epoch", latestRequirements.Epoch)
+	c.logger.Debug("latest requirements", "requirements", logging.Payload(latestRequirements), "epoch", latestRequirements.Epoch)
 
 	// set the client
 	err = latestRequirements.SetClient(c.clientName)
@@ -97,5 +123,6 @@ func (c *Client) GetLatestRequirements() (latestRequirements *Requirements, err
 		return nil, fmt.Errorf("failed to set client: %w", err)
 	}
 
+	c.lastRequirements = latestRequirements
 	return latestRequirements, nil
 }
@@ -2,11 +2,13 @@ package sfdp
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 	"time"
 
+	"github.com/sol-strategies/solana-validator-version-sync/internal/budget"
 	"github.com/sol-strategies/solana-validator-version-sync/internal/constants"
 )
 
@@ -40,6 +42,16 @@ func TestNewClient(t *testing.T) {
 	}
 }
 
+func TestSetBaseURL(t *testing.T) {
+	defaultBaseURL := baseURL
+	defer SetBaseURL(defaultBaseURL)
+
+	SetBaseURL("http://127.0.0.1:9000/api/")
+	if client := NewClient(Options{Cluster: "testnet", Client: "agave"}); client.baseURL != "http://127.0.0.1:9000/api" {
+		t.Errorf("NewClient() baseURL = %v, want %v", client.baseURL, "http://127.0.0.1:9000/api")
+	}
+}
+
 func TestOptions_StructFields(t *testing.T) {
 	opts := Options{
 		Cluster: "testnet",
@@ -247,3 +259,39 @@ func TestClient_GetLatestRequirements_URL(t *testing.T) {
 		t.Errorf("GetLatestRequirements() URL = %v, want %v", capturedURL, expectedURL)
 	}
 }
+
+func TestClient_GetLatestRequirements_BudgetExhausted(t *testing.T) {
+	budget.SetLimit(budget.ProviderSFDP, 1)
+	defer budget.SetLimit(budget.ProviderSFDP, 0)
+
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		_, _ = w.Write([]byte(`{"data":[{"epoch":500,"cluster":"testnet","agave_min_version":"2.3.0"}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(Options{Cluster: "testnet", Client: constants.ClientNameAgave})
+	client.baseURL = server.URL
+
+	first, err := client.GetLatestRequirements()
+	if err != nil {
+		t.Fatalf("GetLatestRequirements() error = %v", err)
+	}
+
+	// the requirements last looked up are reused once the budget is exhausted
+	second, err := client.GetLatestRequirements()
+	if err != nil || second != first {
+		t.Errorf("GetLatestRequirements() = %v, %v, want the requirements last looked up", second, err)
+	}
+	if requests != 1 {
+		t.Errorf("requests = %d, want 1 once the budget is exhausted", requests)
+	}
+
+	// without requirements looked up before there is nothing to degrade to
+	uncached := NewClient(Options{Cluster: "testnet", Client: constants.ClientNameAgave})
+	uncached.baseURL = server.URL
+	if _, err := uncached.GetLatestRequirements(); !errors.Is(err, budget.ErrExhausted) {
+		t.Errorf("GetLatestRequirements() error = %v, want ErrExhausted", err)
+	}
+}
@@ -0,0 +1,71 @@
+package sfdp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/sol-strategies/solana-validator-version-sync/internal/budget"
+)
+
+// Participant represents a validator's SFDP participation, as returned by the SFDP API
+type Participant struct {
+	MainnetBetaPubkey string `json:"mainnetBetaPubkey"`
+	TestnetPubkey     string `json:"testnetPubkey"`
+	// State is the participant's program stage, e.g. Approved, Pending, Rejected or Retired
+	State string `json:"state"`
+	// OnboardingNumber is the participant's onboarding cohort number
+	OnboardingNumber int `json:"onboardingNumber"`
+}
+
+// GetParticipant gets the SFDP participation of the validator with the given identity public key - nil without
+// an error when the validator is not an SFDP participant. The participation last looked up is returned with a
+// warning while the SFDP request budget is exhausted.
+func (c *Client) GetParticipant(identityPublicKey string) (participant *Participant, err error) {
+	if !budget.Allow(budget.ProviderSFDP) {
+		participant, looked := c.participants[identityPublicKey]
+		if !looked {
+			return nil, budget.Exhausted(budget.ProviderSFDP)
+		}
+		c.logger.Warn("SFDP request budget exhausted - using the participation last looked up", "identity", identityPublicKey)
+		return participant, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	participantURL := fmt.Sprintf("%s/validators/%s", c.baseURL, url.PathEscape(identityPublicKey))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, participantURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		c.logger.Debug("validator is not an SFDP participant", "identity", identityPublicKey)
+		c.participants[identityPublicKey] = nil
+		return nil, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("SFDP API returned status: %d", resp.StatusCode)
+	}
+
+	participant = &Participant{}
+	if err := json.NewDecoder(resp.Body).Decode(participant); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	c.logger.Debug("participant", "identity", identityPublicKey, "state", participant.State, "onboardingNumber", participant.OnboardingNumber)
+	c.participants[identityPublicKey] = participant
+
+	return participant, nil
+}
@@ -0,0 +1,135 @@
+package sfdp
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/sol-strategies/solana-validator-version-sync/internal/budget"
+)
+
+func TestClient_GetParticipant(t *testing.T) {
+	tests := []struct {
+		name            string
+		serverStatus    int
+		serverResponse  string
+		want            *Participant
+		wantErr         bool
+		wantRequestPath string
+	}{
+		{
+			name:            "participant",
+			serverStatus:    http.StatusOK,
+			serverResponse:  `{"mainnetBetaPubkey":"mainnet-key","testnetPubkey":"testnet-key","state":"Approved","onboardingNumber":42}`,
+			want:            &Participant{MainnetBetaPubkey: "mainnet-key", TestnetPubkey: "testnet-key", State: "Approved", OnboardingNumber: 42},
+			wantRequestPath: "/validators/testnet-key",
+		},
+		{
+			name:            "not a participant",
+			serverStatus:    http.StatusNotFound,
+			serverResponse:  `{"error":"not found"}`,
+			want:            nil,
+			wantRequestPath: "/validators/testnet-key",
+		},
+		{
+			name:           "server error",
+			serverStatus:   http.StatusInternalServerError,
+			serverResponse: `{}`,
+			wantErr:        true,
+		},
+		{
+			name:           "invalid JSON",
+			serverStatus:   http.StatusOK,
+			serverResponse: `{"state":`,
+			wantErr:        true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requestPath := ""
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requestPath = r.URL.Path
+				w.WriteHeader(tt.serverStatus)
+				_, _ = w.Write([]byte(tt.serverResponse))
+			}))
+			defer server.Close()
+
+			client := NewClient(Options{Cluster: "testnet", Client: "agave"})
+			client.baseURL = server.URL
+
+			got, err := client.GetParticipant("testnet-key")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetParticipant() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			if tt.wantRequestPath != "" && requestPath != tt.wantRequestPath {
+				t.Errorf("GetParticipant() requested %s, want %s", requestPath, tt.wantRequestPath)
+			}
+			if (got == nil) != (tt.want == nil) || (got != nil && *got != *tt.want) {
+				t.Errorf("GetParticipant() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClient_GetParticipant_BudgetExhausted(t *testing.T) {
+	budget.SetLimit(budget.ProviderSFDP, 2)
+	defer budget.SetLimit(budget.ProviderSFDP, 0)
+
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Path == "/validators/retired-key" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`{"testnetPubkey":"testnet-key","state":"Approved"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(Options{Cluster: "testnet", Client: "agave"})
+	client.baseURL = server.URL
+
+	// spend the budget on a participant and a non-participant
+	if _, err := client.GetParticipant("testnet-key"); err != nil {
+		t.Fatalf("GetParticipant() error = %v", err)
+	}
+	if _, err := client.GetParticipant("retired-key"); err != nil {
+		t.Fatalf("GetParticipant() error = %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		identity  string
+		wantState string
+		wantErr   error
+	}{
+		{name: "participant looked up before", identity: "testnet-key", wantState: "Approved"},
+		{name: "non-participant looked up before", identity: "retired-key"},
+		{name: "never looked up", identity: "other-key", wantErr: budget.ErrExhausted},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			participant, err := client.GetParticipant(tt.identity)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("GetParticipant() error = %v, want %v", err, tt.wantErr)
+			}
+			state := ""
+			if participant != nil {
+				state = participant.State
+			}
+			if state != tt.wantState {
+				t.Errorf("GetParticipant() state = %q, want %q", state, tt.wantState)
+			}
+		})
+	}
+
+	if requests != 2 {
+		t.Errorf("requests = %d, want 2 once the budget is exhausted", requests)
+	}
+}
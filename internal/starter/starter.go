@@ -0,0 +1,128 @@
+package starter
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"strconv"
+	"text/template"
+
+	"github.com/hashicorp/go-version"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/constants"
+)
+
+// UnitName is the name of the generated systemd service and timer units
+const UnitName = "solana-validator-version-sync"
+
+//go:embed templates/*.tmpl
+var templatesFS embed.FS
+
+// DefaultStateFile is the state file offered by default - prepared targets must survive between timer runs
+const DefaultStateFile = "/var/lib/" + UnitName + "/state.json"
+
+// templates use [[ ]] delimiters so the {{ }} command templates in the generated config are left as-is,
+// user supplied values are rendered with quote so they are always valid YAML scalars
+var templates = template.Must(
+	template.New("starter").
+		Delims("[[", "]]").
+		Option("missingkey=error").
+		Funcs(template.FuncMap{"quote": strconv.Quote}).
+		ParseFS(templatesFS, "templates/*.tmpl"),
+)
+
+// ConfigOptions represents the choices a starter config is generated from
+type ConfigOptions struct {
+	Client               string
+	Cluster              string
+	RPCURL               string
+	VersionConstraint    string
+	ActiveIdentityFile   string
+	PassiveIdentityFile  string
+	EnableSFDPCompliance bool
+	ValidatorService     string
+	StateFile            string
+}
+
+// SystemdOptions represents the choices systemd units are generated from
+type SystemdOptions struct {
+	BinaryPath string
+	ConfigFile string
+	User       string
+	OnCalendar string
+}
+
+// DefaultConfigOptions returns the defaults offered when generating a starter config
+func DefaultConfigOptions() ConfigOptions {
+	return ConfigOptions{
+		Client:               constants.ClientNameAgave,
+		Cluster:              constants.ClusterNameTestnet,
+		RPCURL:               "http://127.0.0.1:8899",
+		VersionConstraint:    ">= 0.0.0",
+		ActiveIdentityFile:   "/home/solana/active-identity.json",
+		PassiveIdentityFile:  "/home/solana/passive-identity.json",
+		EnableSFDPCompliance: true,
+		ValidatorService:     "solana-validator.service",
+		StateFile:            DefaultStateFile,
+	}
+}
+
+// Validate validates the starter config choices
+func (o *ConfigOptions) Validate() error {
+	if err := constants.ValidateClientName(o.Client); err != nil {
+		return err
+	}
+
+	if err := constants.ValidateClusterName(o.Cluster); err != nil {
+		return err
+	}
+
+	if _, err := version.NewConstraint(o.VersionConstraint); err != nil {
+		return fmt.Errorf("invalid version constraint %s: %w", o.VersionConstraint, err)
+	}
+
+	if o.RPCURL == "" || o.ActiveIdentityFile == "" || o.PassiveIdentityFile == "" || o.ValidatorService == "" {
+		return fmt.Errorf("rpc url, identity files and validator service are required")
+	}
+
+	return nil
+}
+
+// RenderConfig renders a commented starter config.yaml
+func RenderConfig(opts ConfigOptions) (string, error) {
+	if err := opts.Validate(); err != nil {
+		return "", err
+	}
+	opts.Client = constants.NormalizeClientName(opts.Client)
+	return render("config.yaml.tmpl", opts)
+}
+
+// RenderSystemdService renders a oneshot systemd service that runs a single sync
+func RenderSystemdService(opts SystemdOptions) (string, error) {
+	if opts.BinaryPath == "" || opts.ConfigFile == "" || opts.User == "" {
+		return "", fmt.Errorf("binary path, config file and user are required")
+	}
+	return render("systemd.service.tmpl", struct {
+		SystemdOptions
+		UnitName string
+	}{opts, UnitName})
+}
+
+// RenderSystemdTimer renders a systemd timer that triggers the service on the configured calendar
+func RenderSystemdTimer(opts SystemdOptions) (string, error) {
+	if opts.OnCalendar == "" {
+		return "", fmt.Errorf("on calendar is required")
+	}
+	return render("systemd.timer.tmpl", struct {
+		SystemdOptions
+		UnitName string
+	}{opts, UnitName})
+}
+
+// render executes the named template with the supplied data
+func render(name string, data interface{}) (string, error) {
+	buf := bytes.Buffer{}
+	if err := templates.ExecuteTemplate(&buf, name, data); err != nil {
+		return "", fmt.Errorf("failed to render %s: %w", name, err)
+	}
+	return buf.String(), nil
+}
@@ -0,0 +1,139 @@
+package starter
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sol-strategies/solana-validator-version-sync/internal/config"
+)
+
+func TestConfigOptions_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(o *ConfigOptions)
+		wantErr bool
+	}{
+		{name: "defaults", modify: func(o *ConfigOptions) {}},
+		{name: "legacy client alias", modify: func(o *ConfigOptions) { o.Client = "rakurai" }},
+		{name: "invalid client", modify: func(o *ConfigOptions) { o.Client = "solana" }, wantErr: true},
+		{name: "invalid cluster", modify: func(o *ConfigOptions) { o.Cluster = "devnet" }, wantErr: true},
+		{name: "invalid version constraint", modify: func(o *ConfigOptions) { o.VersionConstraint = "not a constraint" }, wantErr: true},
+		{name: "missing identity file", modify: func(o *ConfigOptions) { o.ActiveIdentityFile = "" }, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := DefaultConfigOptions()
+			tt.modify(&opts)
+			err := opts.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ConfigOptions.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRenderConfig(t *testing.T) {
+	opts := ConfigOptions{
+		Client:               "rakurai",
+		Cluster:              "mainnet-beta",
+		RPCURL:               "http://127.0.0.1:8900",
+		VersionConstraint:    ">= 2.3.6, < 3.0.0",
+		ActiveIdentityFile:   "/home/sol/keys #1/active.json",
+		PassiveIdentityFile:  "/home/sol/passive.json",
+		EnableSFDPCompliance: false,
+		ValidatorService:     "sol.service",
+		StateFile:            "/var/lib/sol: sync/state.json",
+	}
+
+	content, err := RenderConfig(opts)
+	if err != nil {
+		t.Fatalf("RenderConfig() error = %v", err)
+	}
+
+	// the generated config must load and pass sync validation (including dry-rendering its example commands)
+	configFile := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(configFile, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+	cfg, err := config.New()
+	if err != nil {
+		t.Fatalf("config.New() error = %v", err)
+	}
+	if err := cfg.LoadFromFile(configFile); err != nil {
+		t.Fatalf("LoadFromFile() error = %v\n%s", err, content)
+	}
+	if err := cfg.Sync.Validate(); err != nil {
+		t.Fatalf("Sync.Validate() error = %v", err)
+	}
+
+	if cfg.Validator.Client != "rakurai-validator" {
+		t.Errorf("Validator.Client = %s, want rakurai-validator", cfg.Validator.Client)
+	}
+	if cfg.Cluster.Name != "mainnet-beta" {
+		t.Errorf("Cluster.Name = %s, want mainnet-beta", cfg.Cluster.Name)
+	}
+	if cfg.Validator.RPCURL != opts.RPCURL || cfg.Validator.VersionConstraint != opts.VersionConstraint {
+		t.Errorf("Validator = %+v, want rpc url and constraint from options", cfg.Validator)
+	}
+	if cfg.Validator.Identities.ActiveKeyPairFile != opts.ActiveIdentityFile || cfg.Validator.Identities.PassiveKeyPairFile != opts.PassiveIdentityFile {
+		t.Errorf("Identities = %+v, want files from options", cfg.Validator.Identities)
+	}
+	if cfg.Sync.EnableSFDPCompliance {
+		t.Error("Sync.EnableSFDPCompliance = true, want false")
+	}
+	if cfg.State.File != opts.StateFile {
+		t.Errorf("State.File = %s, want %s", cfg.State.File, opts.StateFile)
+	}
+	if len(cfg.Sync.Commands) != 3 || cfg.Sync.Commands[2].Args[3] != "sol.service" {
+		t.Errorf("Sync.Commands = %+v, want 3 example commands restarting sol.service", cfg.Sync.Commands)
+	}
+	if !strings.Contains(content, "{{ .VersionToTag }}") {
+		t.Error("RenderConfig() did not preserve command template expressions")
+	}
+}
+
+func TestRenderConfig_Invalid(t *testing.T) {
+	opts := DefaultConfigOptions()
+	opts.Cluster = "devnet"
+	if _, err := RenderConfig(opts); err == nil {
+		t.Error("RenderConfig() error = nil, want error for invalid options")
+	}
+}
+
+func TestRenderSystemdUnits(t *testing.T) {
+	opts := SystemdOptions{
+		BinaryPath: "/usr/local/bin/solana-validator-version-sync",
+		ConfigFile: "/etc/solana-validator-version-sync/config.yaml",
+		User:       "solana",
+		OnCalendar: "*:0/15",
+	}
+
+	service, err := RenderSystemdService(opts)
+	if err != nil {
+		t.Fatalf("RenderSystemdService() error = %v", err)
+	}
+	if !strings.Contains(service, "ExecStart=/usr/local/bin/solana-validator-version-sync --config /etc/solana-validator-version-sync/config.yaml run") {
+		t.Errorf("RenderSystemdService() missing ExecStart:\n%s", service)
+	}
+	if !strings.Contains(service, "User=solana") || !strings.Contains(service, "Type=oneshot") || !strings.Contains(service, "StateDirectory="+UnitName) {
+		t.Errorf("RenderSystemdService() missing user or type:\n%s", service)
+	}
+
+	timer, err := RenderSystemdTimer(opts)
+	if err != nil {
+		t.Fatalf("RenderSystemdTimer() error = %v", err)
+	}
+	if !strings.Contains(timer, "OnCalendar=*:0/15") || !strings.Contains(timer, "Unit="+UnitName+".service") {
+		t.Errorf("RenderSystemdTimer() missing schedule or unit:\n%s", timer)
+	}
+
+	if _, err := RenderSystemdService(SystemdOptions{}); err == nil {
+		t.Error("RenderSystemdService() error = nil, want error for missing options")
+	}
+	if _, err := RenderSystemdTimer(SystemdOptions{}); err == nil {
+		t.Error("RenderSystemdTimer() error = nil, want error for missing calendar")
+	}
+}
@@ -0,0 +1,251 @@
+package state
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/logging"
+)
+
+// Data represents the sync state persisted across runs
+type Data struct {
+	// Prepared is the sync target that prepare commands last completed for
+	Prepared *PreparedTarget `json:"prepared,omitempty"`
+	// Observations are the most recent health and role observations, oldest first
+	Observations []Observation `json:"observations,omitempty"`
+	// CommandRuns are the most recent command executions and their resource usage, oldest first
+	CommandRuns []CommandRun `json:"command_runs,omitempty"`
+	// AssetDownloads are the most recent files downloaded by prepare commands with outputs, oldest first
+	AssetDownloads []AssetDownload `json:"asset_downloads,omitempty"`
+	// Notifications are the notifications last sent, keyed by notification kind
+	Notifications map[string]Notification `json:"notifications,omitempty"`
+	// CommandsRunOnce are the version lines commands with run_once_per last completed for, keyed by command name
+	CommandsRunOnce map[string]string `json:"commands_run_once,omitempty"`
+	// ToolVersion is the version of this tool that last ran against the state
+	ToolVersion string `json:"tool_version,omitempty"`
+	// PreviousToolVersion is the version of this tool that ran before ToolVersion, for whats-new
+	PreviousToolVersion string `json:"previous_tool_version,omitempty"`
+	// ConfigRevision is the configuration revision last applied, when sync.config_revision is enabled
+	ConfigRevision string `json:"config_revision,omitempty"`
+	// ClusterRestart identifies the cluster restart manifest last executed, when sync.cluster_restart is enabled
+	ClusterRestart string `json:"cluster_restart,omitempty"`
+}
+
+// Notification represents the last notification sent of a kind, so it is not repeated for the same subject
+type Notification struct {
+	// Subject is what the notification was about, e.g. the target version tag
+	Subject string    `json:"subject"`
+	SentAt  time.Time `json:"sent_at"`
+}
+
+// CommandRun represents a single command execution and its resource usage
+type CommandRun struct {
+	Time             time.Time `json:"time"`
+	Command          string    `json:"command"`
+	Phase            string    `json:"phase"`
+	VersionTo        string    `json:"version_to"`
+	WallSeconds      float64   `json:"wall_seconds"`
+	UserCPUSeconds   float64   `json:"user_cpu_seconds"`
+	SystemCPUSeconds float64   `json:"system_cpu_seconds"`
+	MaxRSSBytes      int64     `json:"max_rss_bytes"`
+	ExitCode         int       `json:"exit_code"`
+}
+
+// AssetDownload represents a file downloaded by a prepare command, so supply-chain reviews can confirm which
+// artifact was installed for each version
+type AssetDownload struct {
+	Time      time.Time `json:"time"`
+	Command   string    `json:"command"`
+	VersionTo string    `json:"version_to"`
+	Tag       string    `json:"tag"`
+	// Path is the command output the file was downloaded to
+	Path string `json:"path"`
+	Size int64  `json:"size"`
+	// SHA256 is the hex sha256 digest of the file as downloaded
+	SHA256 string `json:"sha256"`
+	// Name and URL are the target release's asset of the same file name - empty when the release has none
+	Name string `json:"name,omitempty"`
+	URL  string `json:"url,omitempty"`
+	// GitHubSHA256 is the digest GitHub publishes for the release asset, which SHA256 matched - empty when GitHub
+	// publishes none, e.g. for assets uploaded before it started computing digests
+	GitHubSHA256 string `json:"github_sha256,omitempty"`
+}
+
+// Observation represents the validator's health and role as observed by a single sync
+type Observation struct {
+	Time   time.Time `json:"time"`
+	Role   string    `json:"role"`
+	Health string    `json:"health"`
+}
+
+// PreparedTarget represents a sync target whose prepare phase completed successfully
+type PreparedTarget struct {
+	Version    string    `json:"version"`
+	Tag        string    `json:"tag"`
+	PreparedAt time.Time `json:"prepared_at"`
+	// ConfigRevision is the target configuration revision prepared along with the version, when sync.config_revision is enabled
+	ConfigRevision string `json:"config_revision,omitempty"`
+}
+
+// Store holds the sync state - it is persisted to file when one is configured, otherwise kept in memory only
+type Store struct {
+	file     string
+	readOnly bool
+	mu       sync.Mutex
+	data     Data
+	logger   *log.Logger
+}
+
+// NewStore creates a new Store, loading any existing state from the supplied file
+func NewStore(file string) (s *Store, err error) {
+	s = &Store{
+		file:   file,
+		logger: logging.WithPrefix("state"),
+	}
+
+	if s.file == "" {
+		s.logger.Debug("no state file configured - state will only be kept in memory")
+		return s, nil
+	}
+
+	content, err := os.ReadFile(s.file)
+	if errors.Is(err, os.ErrNotExist) {
+		s.logger.Debug("state file does not exist yet - starting with empty state", "file", s.file)
+		return s, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read state file %s: %w", s.file, err)
+	}
+
+	if err := json.Unmarshal(content, &s.data); err != nil {
+		return nil, fmt.Errorf("failed to parse state file %s: %w", s.file, err)
+	}
+
+	s.logger.Debug("loaded state", "file", s.file)
+	return s, nil
+}
+
+// SetReadOnly stops updates from being persisted to the state file - they are only kept in memory
+func (s *Store) SetReadOnly(readOnly bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.readOnly = readOnly
+}
+
+// Get returns a copy of the current state
+func (s *Store) Get() Data {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.data.copy()
+}
+
+// Update applies the supplied mutation to the state and persists it
+func (s *Store) Update(mutate func(data *Data)) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	updated := s.data.copy()
+	mutate(&updated)
+
+	if err := s.save(updated); err != nil {
+		return err
+	}
+	s.data = updated
+	return nil
+}
+
+// save atomically writes the supplied state to the state file by writing a temporary file and renaming it
+func (s *Store) save(data Data) error {
+	if s.file == "" {
+		return nil
+	}
+
+	if s.readOnly {
+		s.logger.Debug("read-only mode - not persisting state", "file", s.file)
+		return nil
+	}
+
+	content, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal state: %w", err)
+	}
+
+	return WriteFileAtomic(s.file, content)
+}
+
+// copy returns a deep copy of the state so callers can't mutate the store's state without Update
+func (d Data) copy() Data {
+	copied := d
+	if d.Prepared != nil {
+		prepared := *d.Prepared
+		copied.Prepared = &prepared
+	}
+	if d.Observations != nil {
+		copied.Observations = append([]Observation(nil), d.Observations...)
+	}
+	if d.CommandRuns != nil {
+		copied.CommandRuns = append([]CommandRun(nil), d.CommandRuns...)
+	}
+	if d.Notifications != nil {
+		copied.Notifications = make(map[string]Notification, len(d.Notifications))
+		for kind, notification := range d.Notifications {
+			copied.Notifications[kind] = notification
+		}
+	}
+	if d.CommandsRunOnce != nil {
+		copied.CommandsRunOnce = make(map[string]string, len(d.CommandsRunOnce))
+		for command, line := range d.CommandsRunOnce {
+			copied.CommandsRunOnce[command] = line
+		}
+	}
+	return copied
+}
+
+// WriteFileAtomic writes content to a temporary file next to path and renames it into place
+// so readers never observe a partially written file
+func WriteFileAtomic(path string, content []byte) error {
+	return WriteFileAtomicMode(path, content, 0600)
+}
+
+// WriteFileAtomicMode is WriteFileAtomic with the permissions the file is created with, e.g. for files read by
+// other users
+func WriteFileAtomicMode(path string, content []byte, perm os.FileMode) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create directory %s: %w", dir, err)
+	}
+
+	tmpFile, err := os.CreateTemp(dir, "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary file in %s: %w", dir, err)
+	}
+	tmpPath := tmpFile.Name()
+
+	if err := tmpFile.Chmod(perm); err != nil {
+		tmpFile.Close()
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to set permissions of %s: %w", tmpPath, err)
+	}
+	if _, err := tmpFile.Write(content); err != nil {
+		tmpFile.Close()
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to write %s: %w", tmpPath, err)
+	}
+	if err := tmpFile.Close(); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to close %s: %w", tmpPath, err)
+	}
+
+	if err := os.Rename(tmpPath, path); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to rename %s to %s: %w", tmpPath, path, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,211 @@
+package state
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestNewStore_InMemory(t *testing.T) {
+	store, err := NewStore("")
+	if err != nil {
+		t.Fatalf("NewStore() error = %v", err)
+	}
+	if store.file != "" {
+		t.Errorf("file = %q, want empty", store.file)
+	}
+
+	err = store.Update(func(data *Data) {
+		data.Prepared = &PreparedTarget{Version: "2.3.6", Tag: "v2.3.6"}
+	})
+	if err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	if got := store.Get().Prepared; got == nil || got.Tag != "v2.3.6" {
+		t.Errorf("Get().Prepared = %v, want tag v2.3.6", got)
+	}
+}
+
+func TestNewStore_PersistsAcrossInstances(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "nested", "state.json")
+
+	store, err := NewStore(file)
+	if err != nil {
+		t.Fatalf("NewStore() error = %v", err)
+	}
+
+	preparedAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	err = store.Update(func(data *Data) {
+		data.Prepared = &PreparedTarget{Version: "2.3.6", Tag: "v2.3.6", PreparedAt: preparedAt}
+	})
+	if err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	reloaded, err := NewStore(file)
+	if err != nil {
+		t.Fatalf("NewStore() reload error = %v", err)
+	}
+
+	prepared := reloaded.Get().Prepared
+	if prepared == nil {
+		t.Fatal("reloaded Get().Prepared is nil")
+	}
+	if prepared.Version != "2.3.6" || prepared.Tag != "v2.3.6" || !prepared.PreparedAt.Equal(preparedAt) {
+		t.Errorf("reloaded Get().Prepared = %+v", prepared)
+	}
+
+	// no temporary files should be left behind
+	entries, err := os.ReadDir(filepath.Dir(file))
+	if err != nil {
+		t.Fatalf("ReadDir() error = %v", err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("expected only the state file in dir, got %d entries", len(entries))
+	}
+}
+
+func TestNewStore_InvalidFile(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "state.json")
+	if err := os.WriteFile(file, []byte("not json"), 0644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	if _, err := NewStore(file); err == nil {
+		t.Error("NewStore() expected error for invalid state file")
+	}
+}
+
+func TestStore_GetReturnsCopy(t *testing.T) {
+	store, _ := NewStore("")
+	store.Update(func(data *Data) {
+		data.Prepared = &PreparedTarget{Tag: "v2.3.6"}
+	})
+
+	data := store.Get()
+	data.Prepared.Tag = "mutated"
+
+	if got := store.Get().Prepared.Tag; got != "v2.3.6" {
+		t.Errorf("Get() returned shared state, tag = %s", got)
+	}
+}
+
+func TestStore_GetReturnsCopyOfObservations(t *testing.T) {
+	store, _ := NewStore("")
+	store.Update(func(data *Data) {
+		data.Observations = append(data.Observations, Observation{Role: "active", Health: "ok"})
+	})
+
+	data := store.Get()
+	data.Observations[0].Role = "mutated"
+
+	if got := store.Get().Observations[0].Role; got != "active" {
+		t.Errorf("Get() returned shared observations, role = %s", got)
+	}
+}
+
+func TestStore_GetReturnsCopyOfCommandRuns(t *testing.T) {
+	store, _ := NewStore("")
+	store.Update(func(data *Data) {
+		data.CommandRuns = append(data.CommandRuns, CommandRun{Command: "build", WallSeconds: 2400})
+	})
+
+	data := store.Get()
+	data.CommandRuns[0].Command = "mutated"
+
+	if got := store.Get().CommandRuns[0].Command; got != "build" {
+		t.Errorf("Get() returned shared command runs, command = %s", got)
+	}
+}
+
+func TestStore_GetReturnsCopyOfNotifications(t *testing.T) {
+	store, _ := NewStore("")
+	store.Update(func(data *Data) {
+		data.Notifications = map[string]Notification{"heads_up": {Subject: "v3.0.10"}}
+	})
+
+	data := store.Get()
+	data.Notifications["heads_up"] = Notification{Subject: "mutated"}
+
+	if got := store.Get().Notifications["heads_up"].Subject; got != "v3.0.10" {
+		t.Errorf("Get() returned shared notifications, subject = %s", got)
+	}
+}
+
+func TestStore_GetReturnsCopyOfCommandsRunOnce(t *testing.T) {
+	store, _ := NewStore("")
+	store.Update(func(data *Data) {
+		data.CommandsRunOnce = map[string]string{"migrate-ledger": "3"}
+	})
+
+	data := store.Get()
+	data.CommandsRunOnce["migrate-ledger"] = "mutated"
+
+	if got := store.Get().CommandsRunOnce["migrate-ledger"]; got != "3" {
+		t.Errorf("Get() returned shared commands run once, line = %s", got)
+	}
+}
+
+func TestStore_SetReadOnly(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "state.json")
+
+	store, err := NewStore(file)
+	if err != nil {
+		t.Fatalf("NewStore() error = %v", err)
+	}
+	store.SetReadOnly(true)
+
+	err = store.Update(func(data *Data) {
+		data.Prepared = &PreparedTarget{Tag: "v2.3.6"}
+	})
+	if err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	if got := store.Get().Prepared; got == nil || got.Tag != "v2.3.6" {
+		t.Errorf("Get().Prepared = %v, want in-memory update", got)
+	}
+	if _, err := os.Stat(file); !os.IsNotExist(err) {
+		t.Errorf("state file should not be written in read-only mode, stat error = %v", err)
+	}
+}
+
+func TestWriteFileAtomicMode(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Skipping on Windows")
+	}
+
+	tests := []struct {
+		name string
+		perm os.FileMode
+	}{
+		{name: "private", perm: 0o600},
+		{name: "world readable", perm: 0o644},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "nested", "config.yaml")
+			if err := WriteFileAtomicMode(path, []byte("content"), tt.perm); err != nil {
+				t.Fatalf("WriteFileAtomicMode() error = %v", err)
+			}
+
+			info, err := os.Stat(path)
+			if err != nil {
+				t.Fatalf("Stat() error = %v", err)
+			}
+			if info.Mode().Perm() != tt.perm {
+				t.Errorf("file mode = %o, want %o", info.Mode().Perm(), tt.perm)
+			}
+			if content, _ := os.ReadFile(path); string(content) != "content" {
+				t.Errorf("file content = %q, want %q", string(content), "content")
+			}
+			if entries, _ := os.ReadDir(filepath.Dir(path)); len(entries) != 1 {
+				t.Errorf("directory has %d entries, want only the written file", len(entries))
+			}
+		})
+	}
+}
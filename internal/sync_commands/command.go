@@ -1,17 +1,32 @@
 package sync_commands
 
 import (
-	"bufio"
 	"bytes"
+	"context"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
+	"sort"
 	"strings"
 	"sync"
 	"text/template"
+	"time"
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/charmbracelet/log"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/logging"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/secrets"
+)
+
+const (
+	// PhasePrepare is the phase of commands that stage a sync target ahead of time (download, build, verify)
+	PhasePrepare = "prepare"
+	// PhaseActivate is the phase of commands that switch the validator to a sync target (install, restart)
+	PhaseActivate = "activate"
+	// PhaseRollback is the phase of commands that undo a partial activation when the binary check aborts a sync
+	PhaseRollback = "rollback"
 )
 
 var (
@@ -28,26 +43,59 @@ type ExecOptions struct {
 	Cmd                string
 	Args               []string
 	Environment        map[string]string
+	Secrets            map[string]string // resolved secret environment values - never logged
 	InheritEnvironment bool
+	EnvironmentPolicy  *EnvironmentPolicy // filters inherited environment variables, nil passes all
 	StreamOutput       bool
+	Stdin              string // rendered content written to the command's stdin
+	StdinFile          string // rendered path of a file streamed to the command's stdin
 }
 
 // Command is a command to run, contains valid templated strings
 type Command struct {
-	Name               string            `koanf:"name"`
-	Disabled           bool              `koanf:"disabled"`
-	AllowFailure       bool              `koanf:"allow_failure"`
-	Cmd                string            `koanf:"cmd"`
-	Args               []string          `koanf:"args"`
-	Environment        map[string]string `koanf:"environment"`
-	InheritEnvironment bool              `koanf:"inherit_environment"`
-	StreamOutput       bool              `koanf:"stream_output"`
+	// Name is a vanity name for the command used in logs
+	Name string `koanf:"name"`
+	// Disabled skips the command when true
+	Disabled bool `koanf:"disabled"`
+	// AllowFailure logs command errors and carries on with subsequent commands when true
+	AllowFailure bool `koanf:"allow_failure"`
+	// RunOncePer runs the command only on the first successful sync to each new major, minor or version line (one of major, minor, version), tracked in the state store - empty runs it on every sync
+	RunOncePer string `koanf:"run_once_per"`
+	// Cmd is the command to run, supports templated strings
+	Cmd string `koanf:"cmd"`
+	// Args are the arguments passed to the command, support templated strings
+	Args []string `koanf:"args"`
+	// Environment is the environment passed to the command - values support templated strings or secret references
+	Environment map[string]string `koanf:"environment"`
+	// Secrets are secret environment values resolved at execution time and never logged
+	Secrets map[string]secrets.Ref `koanf:"secrets"`
+	// InheritEnvironment passes the parent process environment to the command, overlaid with Environment
+	InheritEnvironment bool `koanf:"inherit_environment"`
+	// EnvironmentPolicy filters the inherited environment passed to the command, replacing sync.environment_policy
+	EnvironmentPolicy *EnvironmentPolicy `koanf:"environment_policy"`
+	// StreamOutput streams the command output as it runs rather than logging it on completion
+	StreamOutput bool `koanf:"stream_output"`
+	// Phase is the phase the command runs in - one of prepare, activate, rollback, defaults to activate
+	Phase string `koanf:"phase"`
+	// Stdin is content written to the command's stdin, e.g. answers to prompts - supports templated strings
+	Stdin string `koanf:"stdin"`
+	// StdinFile is the path of a file streamed to the command's stdin - supports templated strings, the file content is passed as-is
+	StdinFile string `koanf:"stdin_file"`
+	// Outputs are the paths of files a prepare command downloads or writes, e.g. a release tarball - support
+	// templated strings, hashed once the command succeeds
+	Outputs []string `koanf:"outputs"`
 
 	logPrefix            string
 	logger               *log.Logger
 	cmdTemplate          *template.Template
 	argsTemplates        []*template.Template
 	environmentTemplates map[string]*template.Template
+	stdinTemplate        *template.Template
+	stdinFileTemplate    *template.Template
+	outputsTemplates     []*template.Template
+	lastUsage            *Usage
+	// defaultEnvironmentPolicy is the sync-wide policy applied when the command has no policy of its own
+	defaultEnvironmentPolicy *EnvironmentPolicy
 }
 
 // CommandTemplateData represents the data available for command template interpolation
@@ -65,6 +113,49 @@ type CommandTemplateData struct {
 	VersionTo                   string
 	VersionToTag                string // full original tag from upstream repo, e.g. "v4.0.0-beta.2-jito"
 	SyncIsSFDPComplianceEnabled bool
+	SyncPhase                   string   // phase of the commands being executed, one of prepare|activate|rollback
+	SFDPParticipantStage        string   // SFDP participant stage of the active identity, e.g. Approved - empty unless sync.sfdp_participant is enabled and the identity participates
+	ConfigRevisionFrom          string   // configuration revision last applied - empty unless sync.config_revision is enabled
+	ConfigRevisionTo            string   // target configuration revision - empty unless sync.config_revision is enabled
+	SyncIsConfigOnly            bool     // true when only the configuration revision changed and VersionFrom equals VersionTo
+	ClusterRestart              bool     // true while a sync.cluster_restart manifest is published for the cluster
+	RestartExpectedShredVersion uint16   // expected shred version of the restart manifest - 0 unless ClusterRestart
+	RestartSnapshotSlot         uint64   // snapshot slot of the restart manifest, e.g. for --wait-for-supermajority - 0 unless ClusterRestart
+	RestartExpectedBankHash     string   // expected bank hash of the restart manifest - empty unless ClusterRestart and published
+	ShredVersion                uint16   // shred version the validator advertises in gossip - 0 when it could not be looked up
+	ExpectedShredVersion        uint16   // RestartExpectedShredVersion during a cluster restart, otherwise ShredVersion - e.g. for --expected-shred-version
+	HardForks                   []uint64 // hard fork slots from cluster.hard_forks and the restart manifest, ascending - e.g. for --hard-fork arguments
+}
+
+// SampleTemplateData returns template data with every field populated, used to dry-render command templates
+func SampleTemplateData() CommandTemplateData {
+	return CommandTemplateData{
+		CommandIndex:                0,
+		CommandsCount:               1,
+		ValidatorClient:             "agave",
+		ValidatorRPCURL:             "http://127.0.0.1:8899",
+		ValidatorRole:               "passive",
+		ValidatorRoleIsPassive:      true,
+		ValidatorRoleIsActive:       false,
+		ValidatorIdentityPublicKey:  "11111111111111111111111111111111",
+		ClusterName:                 "testnet",
+		VersionFrom:                 "0.0.0",
+		VersionTo:                   "0.0.1",
+		VersionToTag:                "v0.0.1",
+		SyncIsSFDPComplianceEnabled: true,
+		SyncPhase:                   PhaseActivate,
+		SFDPParticipantStage:        "Approved",
+		ConfigRevisionFrom:          "0000000",
+		ConfigRevisionTo:            "0000001",
+		SyncIsConfigOnly:            false,
+		ClusterRestart:              true,
+		RestartExpectedShredVersion: 1,
+		RestartSnapshotSlot:         1,
+		RestartExpectedBankHash:     "11111111111111111111111111111111",
+		ShredVersion:                1,
+		ExpectedShredVersion:        1,
+		HardForks:                   []uint64{1},
+	}
 }
 
 // NewCommand creates a new Command from a config
@@ -73,11 +164,24 @@ func (c *Command) Parse() (err error) {
 		return fmt.Errorf("command name is required")
 	}
 
+	// validate the phase, defaulting to activate
+	switch c.Phase {
+	case "":
+		c.Phase = PhaseActivate
+	case PhasePrepare, PhaseActivate, PhaseRollback:
+	default:
+		return fmt.Errorf("command phase must be one of %s, %s, %s - got: %s", PhasePrepare, PhaseActivate, PhaseRollback, c.Phase)
+	}
+
+	if err = c.validateRunOncePer(); err != nil {
+		return err
+	}
+
 	// parse and store the command
 	if c.Cmd == "" {
 		return fmt.Errorf("command cmd is required")
 	}
-	c.cmdTemplate, err = template.New("cmd").Parse(c.Cmd)
+	c.cmdTemplate, err = newTemplate("cmd").Parse(c.Cmd)
 	if err != nil {
 		return fmt.Errorf("invalid golang template string: %w", err)
 	}
@@ -86,7 +190,7 @@ func (c *Command) Parse() (err error) {
 	c.argsTemplates = make([]*template.Template, len(c.Args))
 	for j, arg := range c.Args {
 		argTemplateName := fmt.Sprintf("arg[%d]", j)
-		c.argsTemplates[j], err = template.New(argTemplateName).Parse(arg)
+		c.argsTemplates[j], err = newTemplate(argTemplateName).Parse(arg)
 		if err != nil {
 			return fmt.Errorf("invalid golang template string %s: %w", argTemplateName, err)
 		}
@@ -96,60 +200,156 @@ func (c *Command) Parse() (err error) {
 	c.environmentTemplates = make(map[string]*template.Template)
 	for envName, envValue := range c.Environment {
 		envTemplateName := fmt.Sprintf("env[%s]", envName)
-		c.environmentTemplates[envName], err = template.New(envTemplateName).Parse(envValue)
+		c.environmentTemplates[envName], err = newTemplate(envTemplateName).Parse(envValue)
 		if err != nil {
 			return fmt.Errorf("invalid golang template string %s: %w", envTemplateName, err)
 		}
 	}
 
+	// parse the stdin template - content and file are mutually exclusive
+	if c.Stdin != "" && c.StdinFile != "" {
+		return fmt.Errorf("command stdin and stdin_file are mutually exclusive")
+	}
+	c.stdinTemplate, err = newTemplate("stdin").Parse(c.Stdin)
+	if err != nil {
+		return fmt.Errorf("invalid golang template string stdin: %w", err)
+	}
+	c.stdinFileTemplate, err = newTemplate("stdin_file").Parse(c.StdinFile)
+	if err != nil {
+		return fmt.Errorf("invalid golang template string stdin_file: %w", err)
+	}
+
+	// parse the output templates - only prepare commands stage files
+	if len(c.Outputs) > 0 && c.Phase != PhasePrepare {
+		return fmt.Errorf("command outputs are only supported in the %s phase - got: %s", PhasePrepare, c.Phase)
+	}
+	c.outputsTemplates = make([]*template.Template, len(c.Outputs))
+	for j, output := range c.Outputs {
+		outputTemplateName := fmt.Sprintf("outputs[%d]", j)
+		c.outputsTemplates[j], err = newTemplate(outputTemplateName).Parse(output)
+		if err != nil {
+			return fmt.Errorf("invalid golang template string %s: %w", outputTemplateName, err)
+		}
+	}
+
+	// validate the environment policy
+	if c.EnvironmentPolicy != nil {
+		if err = c.EnvironmentPolicy.Validate(); err != nil {
+			return fmt.Errorf("invalid environment_policy: %w", err)
+		}
+	}
+
+	// validate the secret references
+	for envName, secretRef := range c.Secrets {
+		if _, ok := c.Environment[envName]; ok {
+			return fmt.Errorf("secret %s is also defined in environment", envName)
+		}
+		if err = secretRef.Validate(); err != nil {
+			return fmt.Errorf("invalid secret %s: %w", envName, err)
+		}
+	}
+
 	// create the logger
-	c.logger = log.WithPrefix(fmt.Sprintf("command[%s]", c.Name)).
+	c.logger = logging.WithPrefix(fmt.Sprintf("command[%s]", c.Name)).
 		With(
 			"cmd", c.Cmd,
 			"args", c.Args,
-			"environment", c.Environment,
+			"environment", logging.Payload(c.Environment),
+			"secrets", c.SecretNames(),
 			"inherit_environment", c.InheritEnvironment,
+			"environment_policy", c.EnvironmentPolicy,
 			"disabled", c.Disabled,
 			"allow_failure", c.AllowFailure,
+			"run_once_per", c.RunOncePer,
+			"phase", c.Phase,
+			"stdin_file", c.StdinFile,
 		)
 
 	return nil
 }
 
+// SecretNames returns the sorted names of the command's secret environment variables
+func (c *Command) SecretNames() (names []string) {
+	for envName := range c.Secrets {
+		names = append(names, envName)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// DryRender renders every template of a parsed command with SampleTemplateData so that
+// mistakes such as unknown template fields surface before a sync needs them
+func (c *Command) DryRender() (err error) {
+	if _, _, _, err = c.render(SampleTemplateData()); err != nil {
+		return err
+	}
+	if _, _, err = c.renderStdin(SampleTemplateData()); err != nil {
+		return err
+	}
+	_, err = c.RenderOutputs(SampleTemplateData())
+	return err
+}
+
+// RenderOutputs renders the command's output paths with the provided data
+func (c *Command) RenderOutputs(data CommandTemplateData) (outputs []string, err error) {
+	for j, outputTemplate := range c.outputsTemplates {
+		outputBuf := bytes.Buffer{}
+		if err = outputTemplate.Execute(&outputBuf, data); err != nil {
+			return nil, fmt.Errorf("failed to render outputs[%d]: %w", j, err)
+		}
+		outputs = append(outputs, outputBuf.String())
+	}
+	return outputs, nil
+}
+
+// Output renders the command with the provided data and runs it, returning its combined stdout and stderr - for
+// checks whose output is inspected rather than logged, e.g. a binary's --version. The environment is built like
+// ExecuteWithData's, with the inherited environment filtered by the environment policy, and the command is
+// killed when ctx is done.
+func (c *Command) Output(ctx context.Context, data CommandTemplateData) (output string, err error) {
+	compiledCmd, compiledArgs, compiledEnvironment, err := c.render(data)
+	if err != nil {
+		return "", err
+	}
+
+	resolvedSecrets, err := c.resolveSecrets()
+	if err != nil {
+		return "", err
+	}
+
+	opts := ExecOptions{
+		ExecLogger:         logging.WithPrefix(fmt.Sprintf("sync:%s", c.Name)),
+		Environment:        compiledEnvironment,
+		Secrets:            resolvedSecrets,
+		InheritEnvironment: c.InheritEnvironment,
+		EnvironmentPolicy:  c.environmentPolicy(),
+	}
+
+	cmd := exec.CommandContext(ctx, compiledCmd, compiledArgs...)
+	cmd.Env = opts.EnvironmentSlice()
+	out, err := cmd.CombinedOutput()
+	return opts.Redact(string(out)), err
+}
+
 func (c *Command) setLogPrefix(prefix string) {
 	c.logPrefix = prefix
 }
 
 // ExecuteWithData executes the command with the provided template data
 func (c *Command) ExecuteWithData(data CommandTemplateData) (err error) {
-	var (
-		compiledCmd         string
-		compiledArgs        []string
-		compiledEnvironment map[string]string
-	)
-
 	c.setLogPrefix(fmt.Sprintf("sync:commands[%d/%d %s]", data.CommandIndex+1, data.CommandsCount, c.Name))
 
-	execLogger := log.WithPrefix(c.logPrefix)
-
-	// compiled command
-	cmdBuf := bytes.Buffer{}
-	c.cmdTemplate.Execute(&cmdBuf, data)
-	compiledCmd = cmdBuf.String()
+	execLogger := logging.WithPrefix(c.logPrefix)
+	c.lastUsage = nil
 
-	compiledArgs = make([]string, 0, len(c.argsTemplates))
-	for _, argTemplate := range c.argsTemplates {
-		argBuf := bytes.Buffer{}
-		argTemplate.Execute(&argBuf, data)
-		compiledArgs = append(compiledArgs, argBuf.String())
+	compiledCmd, compiledArgs, compiledEnvironment, err := c.render(data)
+	if err != nil {
+		return fmt.Errorf("failed %s: %w", c.logPrefix, err)
 	}
 
-	// compiled environment
-	compiledEnvironment = make(map[string]string)
-	for envName, envTemplate := range c.environmentTemplates {
-		envBuf := bytes.Buffer{}
-		envTemplate.Execute(&envBuf, data)
-		compiledEnvironment[envName] = envBuf.String()
+	compiledStdin, compiledStdinFile, err := c.renderStdin(data)
+	if err != nil {
+		return fmt.Errorf("failed %s: %w", c.logPrefix, err)
 	}
 
 	if c.Disabled {
@@ -157,6 +357,16 @@ func (c *Command) ExecuteWithData(data CommandTemplateData) (err error) {
 		return nil
 	}
 
+	// resolve secrets last so they are only read when the command actually runs
+	resolvedSecrets, err := c.resolveSecrets()
+	if err != nil && c.AllowFailure {
+		execLogger.Warn("failed to resolve secrets with allow failure enabled - continuing", "error", err)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed %s: %w", c.logPrefix, err)
+	}
+
 	return c.exec(ExecOptions{
 		ExecLogger:         execLogger,
 		CommandIndex:       data.CommandIndex,
@@ -165,11 +375,95 @@ func (c *Command) ExecuteWithData(data CommandTemplateData) (err error) {
 		Cmd:                compiledCmd,
 		Args:               compiledArgs,
 		Environment:        compiledEnvironment,
+		Secrets:            resolvedSecrets,
 		InheritEnvironment: c.InheritEnvironment,
+		EnvironmentPolicy:  c.environmentPolicy(),
 		StreamOutput:       c.StreamOutput,
+		Stdin:              compiledStdin,
+		StdinFile:          compiledStdinFile,
 	})
 }
 
+// SetDefaultEnvironmentPolicy sets the policy filtering the inherited environment when the command has no
+// environment_policy of its own
+func (c *Command) SetDefaultEnvironmentPolicy(policy EnvironmentPolicy) {
+	c.defaultEnvironmentPolicy = &policy
+}
+
+// environmentPolicy returns the policy filtering the command's inherited environment - nil passes all
+func (c *Command) environmentPolicy() *EnvironmentPolicy {
+	if c.EnvironmentPolicy != nil {
+		return c.EnvironmentPolicy
+	}
+	return c.defaultEnvironmentPolicy
+}
+
+// render executes the command's cmd, args and environment templates with the provided data
+func (c *Command) render(data CommandTemplateData) (compiledCmd string, compiledArgs []string, compiledEnvironment map[string]string, err error) {
+	// compiled command
+	cmdBuf := bytes.Buffer{}
+	if err = c.cmdTemplate.Execute(&cmdBuf, data); err != nil {
+		return "", nil, nil, fmt.Errorf("failed to render cmd: %w", err)
+	}
+	compiledCmd = cmdBuf.String()
+
+	// compiled args
+	compiledArgs = make([]string, 0, len(c.argsTemplates))
+	for j, argTemplate := range c.argsTemplates {
+		argBuf := bytes.Buffer{}
+		if err = argTemplate.Execute(&argBuf, data); err != nil {
+			return "", nil, nil, fmt.Errorf("failed to render arg[%d]: %w", j, err)
+		}
+		compiledArgs = append(compiledArgs, argBuf.String())
+	}
+
+	// compiled environment
+	compiledEnvironment = make(map[string]string)
+	for envName, envTemplate := range c.environmentTemplates {
+		envBuf := bytes.Buffer{}
+		if err = envTemplate.Execute(&envBuf, data); err != nil {
+			return "", nil, nil, fmt.Errorf("failed to render env[%s]: %w", envName, err)
+		}
+		compiledEnvironment[envName] = envBuf.String()
+	}
+
+	return compiledCmd, compiledArgs, compiledEnvironment, nil
+}
+
+// renderStdin executes the command's stdin and stdin_file templates with the provided data
+func (c *Command) renderStdin(data CommandTemplateData) (compiledStdin string, compiledStdinFile string, err error) {
+	stdinBuf := bytes.Buffer{}
+	if err = c.stdinTemplate.Execute(&stdinBuf, data); err != nil {
+		return "", "", fmt.Errorf("failed to render stdin: %w", err)
+	}
+
+	stdinFileBuf := bytes.Buffer{}
+	if err = c.stdinFileTemplate.Execute(&stdinFileBuf, data); err != nil {
+		return "", "", fmt.Errorf("failed to render stdin_file: %w", err)
+	}
+
+	return stdinBuf.String(), stdinFileBuf.String(), nil
+}
+
+// newTemplate creates a new named template - unknown fields of the struct template data always fail to
+// execute, missingkey=error additionally fails on missing map keys rather than rendering "<no value>"
+func newTemplate(name string) *template.Template {
+	return template.New(name).Option("missingkey=error")
+}
+
+// resolveSecrets resolves the command's secret references to their values
+func (c *Command) resolveSecrets() (resolved map[string]string, err error) {
+	resolved = make(map[string]string, len(c.Secrets))
+	for _, envName := range c.SecretNames() {
+		secretRef := c.Secrets[envName]
+		resolved[envName], err = secretRef.Resolve()
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve secret %s from %s: %w", envName, secretRef.Source(), err)
+		}
+	}
+	return resolved, nil
+}
+
 func (c *Command) exec(opts ExecOptions) error {
 	sanitizedArgs := []string{}
 	opts.ExecLogger.Debug("sanitizing args", "args", opts.Args)
@@ -184,134 +478,210 @@ func (c *Command) exec(opts ExecOptions) error {
 	opts.ExecLogger.With(
 		"cmd", opts.Cmd,
 		"args", sanitizedArgs,
-		"env", opts.Environment,
+		"env", logging.Payload(opts.Environment),
+		"secrets", opts.SecretNames(),
+		"stdin", opts.stdinSource(),
 	).Info("running")
 
 	// run it
-	var cmdErr error
 	cmd := exec.Command(opts.Cmd, sanitizedArgs...)
 	cmd.Env = opts.EnvironmentSlice()
 
-	if opts.StreamOutput {
-		// Capture stdout and stderr, then stream through logger
-		stdout, err := cmd.StdoutPipe()
-		if err != nil {
-			return fmt.Errorf("failed to create stdout pipe: %w", err)
-		}
-		stderr, err := cmd.StderrPipe()
-		if err != nil {
-			return fmt.Errorf("failed to create stderr pipe: %w", err)
-		}
+	stdin, cmdErr := opts.openStdin()
+	if stdin != nil {
+		defer stdin.Close()
+		cmd.Stdin = stdin
+	}
 
-		// Start command
-		err = cmd.Start()
+	startedAt := time.Now()
+	switch {
+	case cmdErr != nil:
+		// failing to open the stdin file follows the allow failure policy below
+	case opts.StreamOutput:
+		cmdErr = c.runStreaming(cmd, opts)
+	default:
+		cmdErr = c.runCombined(cmd, opts)
+	}
 
-		if err != nil && c.AllowFailure {
-			opts.ExecLogger.Warn("failed to start command with allow failure enabled - continuing", "error", err)
-			return nil
-		}
+	// commands that started and exited have a process state to account resource usage from
+	if cmd.ProcessState != nil {
+		usage := newUsage(cmd.ProcessState, time.Since(startedAt))
+		c.lastUsage = &usage
+		opts.ExecLogger.Info("resource usage",
+			"wallTime", usage.WallTime.Round(time.Millisecond).String(),
+			"userCPUTime", usage.UserCPUTime.Round(time.Millisecond).String(),
+			"systemCPUTime", usage.SystemCPUTime.Round(time.Millisecond).String(),
+			"maxRSSBytes", usage.MaxRSSBytes,
+			"exitCode", usage.ExitCode,
+		)
+	}
 
-		if err != nil {
-			return fmt.Errorf("failed %s: %w", c.logPrefix, err)
-		}
+	// start, output and exit failures all follow the same allow failure policy
+	if cmdErr != nil && opts.AllowFailure {
+		opts.ExecLogger.Warn("command failed with allow failure enabled - continuing", "error", cmdErr)
+		return nil
+	}
 
-		// get the command pid (only after successful start)
-		pid := cmd.Process.Pid
-		opts.ExecLogger.Debug("command pid", "pid", pid)
+	// if failed, return error
+	if cmdErr != nil {
+		opts.ExecLogger.Error("command failed", "error", cmdErr)
+		cmdErr = fmt.Errorf("failed %s: %w", c.logPrefix, cmdErr)
+	}
+
+	return cmdErr
+}
 
-		// Use WaitGroup to ensure goroutines complete before function returns
-		var wg sync.WaitGroup
-		wg.Add(2)
+// runStreaming runs cmd, logging its stdout and stderr line by line as they are written - both streams are read
+// to the end before waiting for the command, as Wait closes them, and read failures are returned with the exit error
+func (c *Command) runStreaming(cmd *exec.Cmd, opts ExecOptions) error {
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return fmt.Errorf("failed to create stdout pipe: %w", err)
+	}
+	stderr, err := cmd.StderrPipe()
+	if err != nil {
+		return fmt.Errorf("failed to create stderr pipe: %w", err)
+	}
 
-		// Stream stdout
-		go func() {
-			defer wg.Done()
-			defer stdout.Close()
-			scanner := bufio.NewScanner(stdout)
-			for scanner.Scan() {
-				opts.ExecLogger.Info(
-					styledStreamOutputString("stdout", scanner.Text()),
-				)
-			}
-			if err := scanner.Err(); err != nil {
-				opts.ExecLogger.Error("error reading stdout", "error", err)
-			}
-		}()
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to start command: %w", err)
+	}
+	opts.ExecLogger.Debug("command pid", "pid", cmd.Process.Pid)
+
+	streams := []struct {
+		name   string
+		reader io.Reader
+	}{
+		{name: "stdout", reader: stdout},
+		{name: "stderr", reader: stderr},
+	}
+	streamErrs := make([]error, len(streams))
 
-		// Stream stderr
+	var wg sync.WaitGroup
+	for i, stream := range streams {
+		wg.Add(1)
 		go func() {
 			defer wg.Done()
-			defer stderr.Close()
-			scanner := bufio.NewScanner(stderr)
-			for scanner.Scan() {
-				opts.ExecLogger.Info(
-					styledStreamOutputString("stderr", scanner.Text()),
-				)
-			}
-			if err := scanner.Err(); err != nil {
-				opts.ExecLogger.Error("error reading stderr", "error", err)
+			err := streamLines(stream.reader, maxStreamLineSize, func(line string) {
+				opts.ExecLogger.Info(styledStreamOutputString(stream.name, opts.Redact(line)))
+			})
+			if err != nil {
+				streamErrs[i] = fmt.Errorf("failed to read %s: %w", stream.name, err)
 			}
 		}()
+	}
+	wg.Wait()
 
-		// Wait for command to complete
-		cmdErr = cmd.Wait()
+	return errors.Join(append([]error{cmd.Wait()}, streamErrs...)...)
+}
 
-		// Wait for streaming goroutines to complete
-		wg.Wait()
+// runCombined runs cmd, logging its combined output once it has exited
+func (c *Command) runCombined(cmd *exec.Cmd, opts ExecOptions) error {
+	combinedOutput, err := cmd.CombinedOutput()
+	outputMessage := "command output:\n" + opts.Redact(string(combinedOutput))
+	if err != nil {
+		opts.ExecLogger.Error(outputMessage)
 	} else {
-		var combinedOutput []byte
-		combinedOutput, cmdErr = cmd.CombinedOutput()
-		outputMessage := "command output:\n" + string(combinedOutput)
-		if cmdErr != nil {
-			opts.ExecLogger.Error(outputMessage)
-		} else {
-			opts.ExecLogger.Info(outputMessage)
-		}
+		opts.ExecLogger.Info(outputMessage)
 	}
+	return err
+}
 
-	// if failed and allowed to fail, collect stderr output into a string and return as error
-	if cmdErr != nil && opts.AllowFailure {
-		opts.ExecLogger.Warn("command failed with allow failure enabled - continuing", "error", cmdErr)
-		return nil
+// openStdin opens the command's stdin - nil when the command has no stdin, so it reads from the null device
+func (o *ExecOptions) openStdin() (io.ReadCloser, error) {
+	switch {
+	case o.StdinFile != "":
+		file, err := os.Open(o.StdinFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open stdin file: %w", err)
+		}
+		return file, nil
+	case o.Stdin != "":
+		return io.NopCloser(strings.NewReader(o.Stdin)), nil
+	default:
+		return nil, nil
 	}
+}
 
-	// if failed, return error
-	if cmdErr != nil {
-		opts.ExecLogger.Error("command failed", "error", cmdErr)
-		cmdErr = fmt.Errorf("failed %s: %w", c.logPrefix, cmdErr)
+// stdinSource returns a loggable description of the command's stdin - inline content may hold answers that
+// shouldn't be logged, so only its size is
+func (o *ExecOptions) stdinSource() string {
+	switch {
+	case o.StdinFile != "":
+		return "file:" + o.StdinFile
+	case o.Stdin != "":
+		return fmt.Sprintf("inline:%d bytes", len(o.Stdin))
+	default:
+		return "none"
 	}
-
-	return cmdErr
 }
 
-// EnvironmentSlice returns the environment variables as a slice of strings
+// EnvironmentSlice returns the environment variables (including secrets) as a slice of strings
 func (o *ExecOptions) EnvironmentSlice() []string {
 	if o.InheritEnvironment {
 		return o.inheritedEnvironmentSlice()
 	}
 
-	env := make([]string, 0, len(o.Environment))
+	env := make([]string, 0, len(o.Environment)+len(o.Secrets))
 	for k, v := range o.Environment {
 		env = append(env, fmt.Sprintf("%s=%s", strings.TrimSpace(k), strings.TrimSpace(v)))
 	}
+	for k, v := range o.Secrets {
+		env = append(env, fmt.Sprintf("%s=%s", strings.TrimSpace(k), v))
+	}
 	return env
 }
 
+// SecretNames returns the sorted names of the secret environment variables
+func (o *ExecOptions) SecretNames() (names []string) {
+	for k := range o.Secrets {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Redact replaces any secret values in text so command output can be logged safely
+func (o *ExecOptions) Redact(text string) string {
+	for _, v := range o.Secrets {
+		if v == "" {
+			continue
+		}
+		text = strings.ReplaceAll(text, v, secrets.Redacted)
+	}
+	return text
+}
+
+// inheritedEnvironmentSlice returns the parent process environment permitted by the environment policy,
+// overlaid with the explicit environment and secrets
 func (o *ExecOptions) inheritedEnvironmentSlice() []string {
 	merged := make(map[string]string, len(o.Environment))
 
+	withheld := []string{}
 	for _, envVar := range os.Environ() {
 		k, v, ok := strings.Cut(envVar, "=")
 		if !ok {
 			continue
 		}
+		if !o.EnvironmentPolicy.Permits(k) {
+			withheld = append(withheld, k)
+			continue
+		}
 		merged[k] = v
 	}
+	if len(withheld) > 0 && o.ExecLogger != nil {
+		sort.Strings(withheld)
+		o.ExecLogger.Debug("withheld inherited environment variables by environment policy", "names", withheld)
+	}
 
 	for k, v := range o.Environment {
 		merged[strings.TrimSpace(k)] = strings.TrimSpace(v)
 	}
 
+	for k, v := range o.Secrets {
+		merged[strings.TrimSpace(k)] = v
+	}
+
 	env := make([]string, 0, len(merged))
 	for k, v := range merged {
 		env = append(env, fmt.Sprintf("%s=%s", k, v))
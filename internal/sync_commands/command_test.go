@@ -1,10 +1,16 @@
 package sync_commands
 
 import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
 	"runtime"
 	"strings"
 	"testing"
 	"time"
+
+	"github.com/sol-strategies/solana-validator-version-sync/internal/secrets"
 )
 
 func TestExecOptions_StructFields(t *testing.T) {
@@ -173,6 +179,52 @@ func TestCommand_Parse(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "valid command with secrets",
+			command: Command{
+				Name:    "secret-command",
+				Cmd:     "echo",
+				Secrets: map[string]secrets.Ref{"API_KEY": {FromEnv: "MY_KEY"}},
+			},
+			wantErr: false,
+		},
+		{
+			name: "secret also defined in environment",
+			command: Command{
+				Name:        "secret-command",
+				Cmd:         "echo",
+				Environment: map[string]string{"API_KEY": "inline"},
+				Secrets:     map[string]secrets.Ref{"API_KEY": {FromEnv: "MY_KEY"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid secret reference",
+			command: Command{
+				Name:    "secret-command",
+				Cmd:     "echo",
+				Secrets: map[string]secrets.Ref{"API_KEY": {FromEnv: "MY_KEY", FromFile: "/run/secrets/x"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid run_once_per",
+			command: Command{
+				Name:       "migrate-ledger",
+				Cmd:        "echo",
+				RunOncePer: RunOncePerMinor,
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid run_once_per",
+			command: Command{
+				Name:       "migrate-ledger",
+				Cmd:        "echo",
+				RunOncePer: "patch",
+			},
+			wantErr: true,
+		},
 		{
 			name: "missing command name",
 			command: Command{
@@ -215,6 +267,33 @@ func TestCommand_Parse(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "valid prepare phase",
+			command: Command{
+				Name:  "test-command",
+				Cmd:   "echo",
+				Phase: PhasePrepare,
+			},
+			wantErr: false,
+		},
+		{
+			name: "valid rollback phase",
+			command: Command{
+				Name:  "test-command",
+				Cmd:   "echo",
+				Phase: PhaseRollback,
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid phase",
+			command: Command{
+				Name:  "test-command",
+				Cmd:   "echo",
+				Phase: "later",
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -238,6 +317,9 @@ func TestCommand_Parse(t *testing.T) {
 				if tt.command.logger == nil {
 					t.Error("Parse() should set logger")
 				}
+				if tt.command.Phase == "" {
+					t.Error("Parse() should default Phase")
+				}
 			}
 		})
 	}
@@ -391,6 +473,179 @@ func TestCommand_ExecuteWithData_StreamOutput(t *testing.T) {
 	}
 }
 
+func TestCommand_ExecuteWithData_FailurePolicy(t *testing.T) {
+	// Skip if not on Unix-like system
+	if runtime.GOOS == "windows" {
+		t.Skip("Skipping on Windows")
+	}
+
+	tests := []struct {
+		name         string
+		cmd          string
+		args         []string
+		streamOutput bool
+		allowFailure bool
+		wantErr      bool
+	}{
+		{name: "streamed exit failure", cmd: "sh", args: []string{"-c", "echo out; echo err >&2; exit 3"}, streamOutput: true, wantErr: true},
+		{name: "streamed exit failure allowed", cmd: "sh", args: []string{"-c", "echo out; exit 3"}, streamOutput: true, allowFailure: true},
+		{name: "streamed start failure", cmd: "/nonexistent/command", streamOutput: true, wantErr: true},
+		{name: "streamed start failure allowed", cmd: "/nonexistent/command", streamOutput: true, allowFailure: true},
+		{name: "streamed long line", cmd: "sh", args: []string{"-c", "head -c 200000 /dev/zero | tr '\\0' x; echo; echo done"}, streamOutput: true},
+		{name: "combined exit failure", cmd: "sh", args: []string{"-c", "exit 3"}, wantErr: true},
+		{name: "combined exit failure allowed", cmd: "sh", args: []string{"-c", "exit 3"}, allowFailure: true},
+		{name: "combined start failure", cmd: "/nonexistent/command", wantErr: true},
+		{name: "combined start failure allowed", cmd: "/nonexistent/command", allowFailure: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			command := Command{
+				Name:         tt.name,
+				Cmd:          tt.cmd,
+				Args:         tt.args,
+				StreamOutput: tt.streamOutput,
+				AllowFailure: tt.allowFailure,
+			}
+			if err := command.Parse(); err != nil {
+				t.Fatalf("Parse() failed: %v", err)
+			}
+
+			err := command.ExecuteWithData(CommandTemplateData{})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ExecuteWithData() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCommand_ExecuteWithData_Stdin(t *testing.T) {
+	// Skip if not on Unix-like system
+	if runtime.GOOS == "windows" {
+		t.Skip("Skipping on Windows")
+	}
+
+	stdinFile := filepath.Join(t.TempDir(), "answers-1.18.0.txt")
+	if err := os.WriteFile(stdinFile, []byte("1.18.0\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	// succeeds only when the first line of stdin is the sync target version
+	readsVersion := []string{"-c", `read answer && test "$answer" = "1.18.0"`}
+
+	tests := []struct {
+		name         string
+		stdin        string
+		stdinFile    string
+		streamOutput bool
+		allowFailure bool
+		wantErr      bool
+	}{
+		{name: "inline template", stdin: "{{ .VersionTo }}\n"},
+		{name: "inline template streamed", stdin: "{{ .VersionTo }}\n", streamOutput: true},
+		{name: "inline wrong answer", stdin: "0.0.1\n", wantErr: true},
+		{name: "file", stdinFile: stdinFile},
+		{name: "templated file path", stdinFile: filepath.Join(filepath.Dir(stdinFile), "answers-{{ .VersionTo }}.txt")},
+		{name: "missing file", stdinFile: stdinFile + ".missing", wantErr: true},
+		{name: "missing file allowed", stdinFile: stdinFile + ".missing", allowFailure: true},
+		{name: "no stdin", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			command := Command{
+				Name:         tt.name,
+				Cmd:          "sh",
+				Args:         readsVersion,
+				Stdin:        tt.stdin,
+				StdinFile:    tt.stdinFile,
+				StreamOutput: tt.streamOutput,
+				AllowFailure: tt.allowFailure,
+			}
+			if err := command.Parse(); err != nil {
+				t.Fatalf("Parse() failed: %v", err)
+			}
+
+			err := command.ExecuteWithData(CommandTemplateData{VersionTo: "1.18.0", CommandsCount: 1})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ExecuteWithData() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCommand_Parse_Stdin(t *testing.T) {
+	tests := []struct {
+		name      string
+		stdin     string
+		stdinFile string
+		wantErr   bool
+	}{
+		{name: "inline", stdin: "yes\n"},
+		{name: "file", stdinFile: "/etc/answers"},
+		{name: "both", stdin: "yes\n", stdinFile: "/etc/answers", wantErr: true},
+		{name: "invalid inline template", stdin: "{{ .VersionTo", wantErr: true},
+		{name: "invalid file template", stdinFile: "{{ .VersionTo", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			command := Command{Name: tt.name, Cmd: "cat", Stdin: tt.stdin, StdinFile: tt.stdinFile}
+			if err := command.Parse(); (err != nil) != tt.wantErr {
+				t.Errorf("Parse() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCommand_environmentPolicy(t *testing.T) {
+	defaultPolicy := EnvironmentPolicy{Deny: []string{"AWS_*"}}
+	commandPolicy := &EnvironmentPolicy{Allow: []string{"PATH"}}
+
+	tests := []struct {
+		name       string
+		command    Command
+		setDefault bool
+		want       *EnvironmentPolicy
+	}{
+		{name: "no policy", command: Command{}, want: nil},
+		{name: "default policy", command: Command{}, setDefault: true, want: &defaultPolicy},
+		{name: "command policy replaces default", command: Command{EnvironmentPolicy: commandPolicy}, setDefault: true, want: commandPolicy},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.setDefault {
+				tt.command.SetDefaultEnvironmentPolicy(defaultPolicy)
+			}
+			got := tt.command.environmentPolicy()
+			if (got == nil) != (tt.want == nil) || (got != nil && !reflect.DeepEqual(*got, *tt.want)) {
+				t.Errorf("environmentPolicy() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExecOptions_StdinSource(t *testing.T) {
+	tests := []struct {
+		name string
+		opts ExecOptions
+		want string
+	}{
+		{name: "none", opts: ExecOptions{}, want: "none"},
+		{name: "inline", opts: ExecOptions{Stdin: "secret answer\n"}, want: "inline:14 bytes"},
+		{name: "file", opts: ExecOptions{StdinFile: "/etc/answers"}, want: "file:/etc/answers"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.opts.stdinSource(); got != tt.want {
+				t.Errorf("stdinSource() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestExecOptions_EnvironmentSlice(t *testing.T) {
 	testsEnvMap := func(t *testing.T, env []string) map[string]string {
 		t.Helper()
@@ -415,6 +670,7 @@ func TestExecOptions_EnvironmentSlice(t *testing.T) {
 		opts     ExecOptions
 		setup    func(t *testing.T)
 		expected map[string]string
+		absent   []string
 		exact    bool
 	}{
 		{
@@ -509,6 +765,51 @@ func TestExecOptions_EnvironmentSlice(t *testing.T) {
 				"SVVS_TEST_OVERRIDE": "child",
 			},
 		},
+		{
+			name: "environment policy withholds denied inherited values",
+			opts: ExecOptions{
+				Environment:        map[string]string{},
+				InheritEnvironment: true,
+				EnvironmentPolicy:  &EnvironmentPolicy{Deny: []string{"SVVS_TEST_CLOUD_*"}},
+			},
+			setup: func(t *testing.T) {
+				t.Setenv("SVVS_TEST_CLOUD_KEY", "credential")
+				t.Setenv("SVVS_TEST_KEPT", "parent")
+			},
+			expected: map[string]string{
+				"SVVS_TEST_KEPT": "parent",
+			},
+			absent: []string{"SVVS_TEST_CLOUD_KEY"},
+		},
+		{
+			name: "environment policy only passes allowed inherited values",
+			opts: ExecOptions{
+				Environment:        map[string]string{},
+				InheritEnvironment: true,
+				EnvironmentPolicy:  &EnvironmentPolicy{Allow: []string{"SVVS_TEST_ALLOWED"}},
+			},
+			setup: func(t *testing.T) {
+				t.Setenv("SVVS_TEST_ALLOWED", "parent")
+				t.Setenv("SVVS_TEST_OTHER", "parent")
+			},
+			expected: map[string]string{
+				"SVVS_TEST_ALLOWED": "parent",
+			},
+			absent: []string{"SVVS_TEST_OTHER", "PATH"},
+		},
+		{
+			name: "environment policy does not filter explicit environment",
+			opts: ExecOptions{
+				Environment: map[string]string{
+					"SVVS_TEST_CLOUD_REGION": "eu-west-1",
+				},
+				InheritEnvironment: true,
+				EnvironmentPolicy:  &EnvironmentPolicy{Deny: []string{"SVVS_TEST_CLOUD_*"}},
+			},
+			expected: map[string]string{
+				"SVVS_TEST_CLOUD_REGION": "eu-west-1",
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -533,6 +834,12 @@ func TestExecOptions_EnvironmentSlice(t *testing.T) {
 					t.Errorf("EnvironmentSlice() value for %s = %q, want %q", expectedKey, actualValue, expectedValue)
 				}
 			}
+
+			for _, absentKey := range tt.absent {
+				if _, found := result[absentKey]; found {
+					t.Errorf("EnvironmentSlice() passed withheld key: %s", absentKey)
+				}
+			}
 		})
 	}
 }
@@ -648,3 +955,294 @@ func TestCommand_ExecuteWithData_InvalidCommandWithAllowFailure(t *testing.T) {
 		t.Errorf("ExecuteWithData() should not have failed with AllowFailure=true, got error: %v", err)
 	}
 }
+
+func TestCommand_ExecuteWithData_Secrets(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Skipping on Windows")
+	}
+
+	secretFile := filepath.Join(t.TempDir(), "api-key")
+	if err := os.WriteFile(secretFile, []byte("file-secret\n"), 0o600); err != nil {
+		t.Fatalf("failed to write secret file: %v", err)
+	}
+	t.Setenv("TEST_SYNC_SECRET", "env-secret")
+
+	outFile := filepath.Join(t.TempDir(), "out")
+	command := Command{
+		Name: "secret-command",
+		Cmd:  "sh",
+		Args: []string{"-c", "printf '%s %s' \"$FROM_ENV\" \"$FROM_FILE\" > " + outFile},
+		Secrets: map[string]secrets.Ref{
+			"FROM_ENV":  {FromEnv: "TEST_SYNC_SECRET"},
+			"FROM_FILE": {FromFile: secretFile},
+		},
+		InheritEnvironment: true,
+	}
+	if err := command.Parse(); err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	if err := command.ExecuteWithData(CommandTemplateData{CommandsCount: 1}); err != nil {
+		t.Fatalf("ExecuteWithData() error = %v", err)
+	}
+
+	content, err := os.ReadFile(outFile)
+	if err != nil {
+		t.Fatalf("failed to read output file: %v", err)
+	}
+	if string(content) != "env-secret file-secret" {
+		t.Errorf("command saw secrets %q, want %q", string(content), "env-secret file-secret")
+	}
+
+	// unresolvable secrets fail the command unless failure is allowed
+	command.Secrets["MISSING"] = secrets.Ref{FromEnv: "TEST_SYNC_SECRET_NOT_SET"}
+	if err := command.ExecuteWithData(CommandTemplateData{CommandsCount: 1}); err == nil {
+		t.Error("ExecuteWithData() error = nil, want error for unresolvable secret")
+	}
+	command.AllowFailure = true
+	if err := command.ExecuteWithData(CommandTemplateData{CommandsCount: 1}); err != nil {
+		t.Errorf("ExecuteWithData() with allow_failure error = %v, want nil", err)
+	}
+}
+
+func TestExecOptions_Redact(t *testing.T) {
+	opts := ExecOptions{
+		Secrets: map[string]string{"API_KEY": "s3cr3t", "EMPTY": ""},
+	}
+
+	got := opts.Redact("token=s3cr3t and again s3cr3t")
+	want := "token=" + secrets.Redacted + " and again " + secrets.Redacted
+	if got != want {
+		t.Errorf("Redact() = %q, want %q", got, want)
+	}
+
+	if names := opts.SecretNames(); strings.Join(names, ",") != "API_KEY,EMPTY" {
+		t.Errorf("SecretNames() = %v, want [API_KEY EMPTY]", names)
+	}
+
+	env := opts.EnvironmentSlice()
+	if len(env) != 2 {
+		t.Errorf("EnvironmentSlice() = %v, want secrets included", env)
+	}
+}
+
+func TestCommand_DryRender(t *testing.T) {
+	tests := []struct {
+		name    string
+		command Command
+		wantErr bool
+	}{
+		{
+			name: "all template fields",
+			command: Command{
+				Name: "all-fields",
+				Cmd:  "{{ .ValidatorClient }}-install",
+				Args: []string{
+					"{{ .CommandIndex }}/{{ .CommandsCount }}",
+					"{{ .ValidatorRPCURL }} {{ .ValidatorRole }} {{ .ValidatorRoleIsPassive }} {{ .ValidatorRoleIsActive }}",
+					"{{ .ValidatorIdentityPublicKey }} {{ .ClusterName }}",
+					"{{ .VersionFrom }} {{ .VersionTo }} {{ .VersionToTag }}",
+					"{{ .SyncIsSFDPComplianceEnabled }} {{ .SyncPhase }}",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name:    "unknown field in cmd",
+			command: Command{Name: "bad-cmd", Cmd: "{{ .Client }}"},
+			wantErr: true,
+		},
+		{
+			name:    "unknown field in env",
+			command: Command{Name: "bad-env", Cmd: "echo", Environment: map[string]string{"V": "{{ .Version }}"}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.command.Parse(); err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			err := tt.command.DryRender()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("DryRender() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCommand_RenderOutputs(t *testing.T) {
+	tests := []struct {
+		name      string
+		command   Command
+		want      []string
+		wantErr   bool
+		wantParse bool
+	}{
+		{
+			name: "rendered outputs",
+			command: Command{
+				Name:    "download",
+				Cmd:     "curl",
+				Phase:   PhasePrepare,
+				Outputs: []string{"/home/solana/releases/{{ .VersionToTag }}/solana-release.tar.bz2", "/tmp/{{ .VersionTo }}.sha256"},
+			},
+			want: []string{"/home/solana/releases/v3.0.10/solana-release.tar.bz2", "/tmp/3.0.10.sha256"},
+		},
+		{
+			name:    "no outputs",
+			command: Command{Name: "download", Cmd: "curl", Phase: PhasePrepare},
+		},
+		{
+			name:    "unknown template field",
+			command: Command{Name: "download", Cmd: "curl", Phase: PhasePrepare, Outputs: []string{"/tmp/{{ .Nope }}"}},
+			wantErr: true,
+		},
+		{
+			name:      "outputs of an activate command",
+			command:   Command{Name: "install", Cmd: "curl", Outputs: []string{"/tmp/release.tar.bz2"}},
+			wantParse: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.command.Parse()
+			if (err != nil) != tt.wantParse {
+				t.Fatalf("Parse() error = %v, wantErr %v", err, tt.wantParse)
+			}
+			if tt.wantParse {
+				return
+			}
+
+			got, err := tt.command.RenderOutputs(CommandTemplateData{VersionTo: "3.0.10", VersionToTag: "v3.0.10"})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("RenderOutputs() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("RenderOutputs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCommand_Output(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Skipping test on Windows")
+	}
+
+	tests := []struct {
+		name    string
+		command Command
+		want    string
+		wantErr bool
+	}{
+		{
+			name:    "rendered output",
+			command: Command{Name: "version", Cmd: "echo", Args: []string{"agave-validator {{ .VersionTo }}"}},
+			want:    "agave-validator 3.0.10\n",
+		},
+		{
+			name:    "stderr included",
+			command: Command{Name: "version", Cmd: "sh", Args: []string{"-c", "echo {{ .VersionTo }} >&2"}},
+			want:    "3.0.10\n",
+		},
+		{
+			name:    "command fails",
+			command: Command{Name: "version", Cmd: "false"},
+			wantErr: true,
+		},
+		{
+			name:    "command not found",
+			command: Command{Name: "version", Cmd: "/nonexistent/agave-validator"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.command.Parse(); err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+
+			got, err := tt.command.Output(context.Background(), CommandTemplateData{VersionTo: "3.0.10"})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Output() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("Output() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCommand_Output_EnvironmentPolicy(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Skipping test on Windows")
+	}
+
+	t.Setenv("SVVS_TEST_ALLOWED", "allowed")
+	t.Setenv("SVVS_TEST_CLOUD_KEY", "denied")
+
+	tests := []struct {
+		name          string
+		command       Command
+		defaultPolicy *EnvironmentPolicy
+		want          string
+	}{
+		{
+			name:    "no inherited environment",
+			command: Command{Name: "version", Cmd: "sh", Args: []string{"-c", "echo ${SVVS_TEST_ALLOWED:-unset} ${SVVS_TEST_CLOUD_KEY:-unset}"}},
+			want:    "unset unset\n",
+		},
+		{
+			name:          "sync-wide policy denies",
+			command:       Command{Name: "version", Cmd: "sh", Args: []string{"-c", "echo ${SVVS_TEST_ALLOWED:-unset} ${SVVS_TEST_CLOUD_KEY:-unset}"}, InheritEnvironment: true},
+			defaultPolicy: &EnvironmentPolicy{Deny: []string{"SVVS_TEST_CLOUD_*"}},
+			want:          "allowed unset\n",
+		},
+		{
+			name: "command policy replaces sync-wide policy",
+			command: Command{
+				Name:               "version",
+				Cmd:                "sh",
+				Args:               []string{"-c", "echo ${SVVS_TEST_ALLOWED:-unset} ${SVVS_TEST_CLOUD_KEY:-unset}"},
+				InheritEnvironment: true,
+				EnvironmentPolicy:  &EnvironmentPolicy{Allow: []string{"SVVS_TEST_CLOUD_*"}},
+			},
+			defaultPolicy: &EnvironmentPolicy{Deny: []string{"SVVS_TEST_CLOUD_*"}},
+			want:          "unset denied\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.command.Parse(); err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			if tt.defaultPolicy != nil {
+				tt.command.SetDefaultEnvironmentPolicy(*tt.defaultPolicy)
+			}
+
+			got, err := tt.command.Output(context.Background(), CommandTemplateData{})
+			if err != nil {
+				t.Fatalf("Output() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Output() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCommand_ExecuteWithData_RenderError(t *testing.T) {
+	command := Command{Name: "bad-arg", Cmd: "echo", Args: []string{"{{ .Nope }}"}, AllowFailure: true}
+	if err := command.Parse(); err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	if err := command.ExecuteWithData(CommandTemplateData{}); err == nil {
+		t.Error("ExecuteWithData() error = nil, want render error")
+	}
+}
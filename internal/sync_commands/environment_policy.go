@@ -0,0 +1,51 @@
+package sync_commands
+
+import (
+	"fmt"
+	"path"
+)
+
+// EnvironmentPolicy controls which inherited environment variables reach commands with inherit_environment
+// enabled - explicitly configured environment and secrets are always passed
+type EnvironmentPolicy struct {
+	// Allow are glob patterns of inherited variable names passed to commands, e.g. PATH or LC_* - all when empty
+	Allow []string `koanf:"allow"`
+	// Deny are glob patterns of inherited variable names never passed to commands, e.g. AWS_* - deny wins over allow
+	Deny []string `koanf:"deny"`
+}
+
+// Validate validates the environment policy glob patterns
+func (p *EnvironmentPolicy) Validate() error {
+	for i, pattern := range p.Allow {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("allow[%d] is not a valid glob pattern - got: %s", i, pattern)
+		}
+	}
+	for i, pattern := range p.Deny {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("deny[%d] is not a valid glob pattern - got: %s", i, pattern)
+		}
+	}
+	return nil
+}
+
+// Permits returns true when the policy lets an inherited variable with the given name reach commands
+func (p *EnvironmentPolicy) Permits(name string) bool {
+	if p == nil {
+		return true
+	}
+	if matchesAny(p.Deny, name) {
+		return false
+	}
+	return len(p.Allow) == 0 || matchesAny(p.Allow, name)
+}
+
+// matchesAny returns true when name matches any of the (validated) glob patterns
+func matchesAny(patterns []string, name string) bool {
+	for _, pattern := range patterns {
+		if matched, _ := path.Match(pattern, name); matched {
+			return true
+		}
+	}
+	return false
+}
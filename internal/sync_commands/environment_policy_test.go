@@ -0,0 +1,101 @@
+package sync_commands
+
+import "testing"
+
+func TestEnvironmentPolicy_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  EnvironmentPolicy
+		wantErr bool
+	}{
+		{
+			name:    "empty",
+			policy:  EnvironmentPolicy{},
+			wantErr: false,
+		},
+		{
+			name:    "valid patterns",
+			policy:  EnvironmentPolicy{Allow: []string{"PATH", "LC_*"}, Deny: []string{"AWS_*", "*_TOKEN"}},
+			wantErr: false,
+		},
+		{
+			name:    "invalid allow pattern",
+			policy:  EnvironmentPolicy{Allow: []string{"LC_["}},
+			wantErr: true,
+		},
+		{
+			name:    "invalid deny pattern",
+			policy:  EnvironmentPolicy{Deny: []string{"AWS_["}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.policy.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("EnvironmentPolicy.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestEnvironmentPolicy_Permits(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  *EnvironmentPolicy
+		envName string
+		want    bool
+	}{
+		{
+			name:    "nil policy permits everything",
+			policy:  nil,
+			envName: "AWS_SECRET_ACCESS_KEY",
+			want:    true,
+		},
+		{
+			name:    "empty policy permits everything",
+			policy:  &EnvironmentPolicy{},
+			envName: "AWS_SECRET_ACCESS_KEY",
+			want:    true,
+		},
+		{
+			name:    "denied",
+			policy:  &EnvironmentPolicy{Deny: []string{"AWS_*"}},
+			envName: "AWS_SECRET_ACCESS_KEY",
+			want:    false,
+		},
+		{
+			name:    "not denied",
+			policy:  &EnvironmentPolicy{Deny: []string{"AWS_*"}},
+			envName: "PATH",
+			want:    true,
+		},
+		{
+			name:    "allowed",
+			policy:  &EnvironmentPolicy{Allow: []string{"PATH", "LC_*"}},
+			envName: "LC_ALL",
+			want:    true,
+		},
+		{
+			name:    "not allowed",
+			policy:  &EnvironmentPolicy{Allow: []string{"PATH", "LC_*"}},
+			envName: "GOOGLE_APPLICATION_CREDENTIALS",
+			want:    false,
+		},
+		{
+			name:    "deny wins over allow",
+			policy:  &EnvironmentPolicy{Allow: []string{"*"}, Deny: []string{"*_TOKEN"}},
+			envName: "GITHUB_TOKEN",
+			want:    false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.policy.Permits(tt.envName); got != tt.want {
+				t.Errorf("EnvironmentPolicy.Permits(%s) = %v, want %v", tt.envName, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package sync_commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/go-version"
+)
+
+const (
+	// RunOncePerMajor runs a command once per new major version line, e.g. 3
+	RunOncePerMajor = "major"
+	// RunOncePerMinor runs a command once per new minor version line, e.g. 3.0
+	RunOncePerMinor = "minor"
+	// RunOncePerVersion runs a command once per new version, e.g. 3.0.10
+	RunOncePerVersion = "version"
+)
+
+// RunOncePerValues are the valid run_once_per values
+var RunOncePerValues = []string{RunOncePerMajor, RunOncePerMinor, RunOncePerVersion}
+
+// validateRunOncePer validates the command's run_once_per value - empty runs the command on every sync
+func (c *Command) validateRunOncePer() error {
+	switch c.RunOncePer {
+	case "", RunOncePerMajor, RunOncePerMinor, RunOncePerVersion:
+		return nil
+	default:
+		return fmt.Errorf("command run_once_per must be one of %s - got: %s", strings.Join(RunOncePerValues, ", "), c.RunOncePer)
+	}
+}
+
+// RunOnceLine returns the version line of rawVersion the command runs once per, e.g. 3.0 for run_once_per: minor
+// and 3.0.10 - empty when the command runs on every sync
+func (c *Command) RunOnceLine(rawVersion string) (line string, err error) {
+	if c.RunOncePer == "" {
+		return "", nil
+	}
+
+	parsedVersion, err := version.NewVersion(rawVersion)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse version %s for run_once_per: %w", rawVersion, err)
+	}
+
+	segments := parsedVersion.Segments()
+	switch c.RunOncePer {
+	case RunOncePerMajor:
+		return fmt.Sprintf("%d", segments[0]), nil
+	case RunOncePerMinor:
+		return fmt.Sprintf("%d.%d", segments[0], segments[1]), nil
+	default:
+		return parsedVersion.Core().String(), nil
+	}
+}
@@ -0,0 +1,59 @@
+package sync_commands
+
+import "testing"
+
+func TestCommand_validateRunOncePer(t *testing.T) {
+	tests := []struct {
+		name       string
+		runOncePer string
+		wantErr    bool
+	}{
+		{name: "empty", runOncePer: "", wantErr: false},
+		{name: "major", runOncePer: RunOncePerMajor, wantErr: false},
+		{name: "minor", runOncePer: RunOncePerMinor, wantErr: false},
+		{name: "version", runOncePer: RunOncePerVersion, wantErr: false},
+		{name: "unknown", runOncePer: "patch", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := &Command{RunOncePer: tt.runOncePer}
+			err := cmd.validateRunOncePer()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateRunOncePer() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCommand_RunOnceLine(t *testing.T) {
+	tests := []struct {
+		name       string
+		runOncePer string
+		version    string
+		want       string
+		wantErr    bool
+	}{
+		{name: "unset", runOncePer: "", version: "3.0.10", want: ""},
+		{name: "unset ignores invalid version", runOncePer: "", version: "invalid", want: ""},
+		{name: "major", runOncePer: RunOncePerMajor, version: "3.0.10", want: "3"},
+		{name: "minor", runOncePer: RunOncePerMinor, version: "3.0.10", want: "3.0"},
+		{name: "version", runOncePer: RunOncePerVersion, version: "3.0.10", want: "3.0.10"},
+		{name: "version drops prerelease", runOncePer: RunOncePerVersion, version: "3.0.10-rc1", want: "3.0.10"},
+		{name: "minor of short version", runOncePer: RunOncePerMinor, version: "3", want: "3.0"},
+		{name: "invalid version", runOncePer: RunOncePerMinor, version: "invalid", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := &Command{RunOncePer: tt.runOncePer}
+			got, err := cmd.RunOnceLine(tt.version)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("RunOnceLine() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("RunOnceLine() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package sync_commands
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"strings"
+)
+
+const (
+	// maxStreamLineSize is the longest streamed output line logged whole - longer lines (e.g. progress bars
+	// redrawn with carriage returns) are logged truncated so memory use stays bounded
+	maxStreamLineSize = 64 * 1024
+	// truncatedLineSuffix marks a streamed output line that was truncated
+	truncatedLineSuffix = " ...[truncated]"
+)
+
+// streamLines calls emit with every line read from r until EOF, truncating lines longer than maxLineSize.
+// On a read error the rest of r is drained so the command never blocks writing to a full pipe, and the error
+// is returned.
+func streamLines(r io.Reader, maxLineSize int, emit func(line string)) error {
+	reader := bufio.NewReaderSize(r, maxLineSize)
+	truncating := false
+	for {
+		chunk, err := reader.ReadSlice('\n')
+		switch {
+		case err == nil:
+			// end of a line - skip it when it is the tail of a truncated line
+			if !truncating {
+				emit(strings.TrimRight(string(chunk), "\r\n"))
+			}
+			truncating = false
+		case errors.Is(err, bufio.ErrBufferFull):
+			// line longer than maxLineSize - emit its start and skip the rest
+			if !truncating {
+				emit(string(chunk) + truncatedLineSuffix)
+			}
+			truncating = true
+		case errors.Is(err, io.EOF):
+			// final line without a trailing newline
+			if len(chunk) > 0 && !truncating {
+				emit(strings.TrimRight(string(chunk), "\r\n"))
+			}
+			return nil
+		default:
+			if len(chunk) > 0 && !truncating {
+				emit(strings.TrimRight(string(chunk), "\r\n"))
+			}
+			_, _ = io.Copy(io.Discard, reader)
+			return err
+		}
+	}
+}
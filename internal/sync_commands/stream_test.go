@@ -0,0 +1,93 @@
+package sync_commands
+
+import (
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestStreamLines(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       string
+		maxLineSize int
+		want        []string
+	}{
+		{
+			name:        "lines",
+			input:       "first\nsecond\n",
+			maxLineSize: 16,
+			want:        []string{"first", "second"},
+		},
+		{
+			name:        "final line without newline",
+			input:       "first\nsecond",
+			maxLineSize: 16,
+			want:        []string{"first", "second"},
+		},
+		{
+			name:        "crlf line endings",
+			input:       "first\r\nsecond\r\n",
+			maxLineSize: 16,
+			want:        []string{"first", "second"},
+		},
+		{
+			name:        "empty lines kept",
+			input:       "first\n\nthird\n",
+			maxLineSize: 16,
+			want:        []string{"first", "", "third"},
+		},
+		{
+			name:        "long line truncated",
+			input:       "short\n" + strings.Repeat("x", 40) + "\nafter\n",
+			maxLineSize: 16,
+			want:        []string{"short", strings.Repeat("x", 16) + truncatedLineSuffix, "after"},
+		},
+		{
+			name:        "long final line truncated",
+			input:       strings.Repeat("y", 40),
+			maxLineSize: 16,
+			want:        []string{strings.Repeat("y", 16) + truncatedLineSuffix},
+		},
+		{
+			name:        "no output",
+			input:       "",
+			maxLineSize: 16,
+			want:        nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			err := streamLines(strings.NewReader(tt.input), tt.maxLineSize, func(line string) {
+				got = append(got, line)
+			})
+			if err != nil {
+				t.Fatalf("streamLines() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("streamLines() lines = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStreamLines_ReadError(t *testing.T) {
+	readErr := errors.New("read failed")
+	reader := io.MultiReader(strings.NewReader("first\npartial"), iotest.ErrReader(readErr))
+
+	var got []string
+	err := streamLines(reader, 16, func(line string) {
+		got = append(got, line)
+	})
+	if !errors.Is(err, readErr) {
+		t.Fatalf("streamLines() error = %v, want %v", err, readErr)
+	}
+	if want := []string{"first", "partial"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("streamLines() lines = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,37 @@
+package sync_commands
+
+import (
+	"os"
+	"time"
+)
+
+// Usage represents the resource usage of an executed command, for planning upgrade windows
+type Usage struct {
+	// WallTime is how long the command ran for
+	WallTime time.Duration
+	// UserCPUTime is the user CPU time used by the command and its waited-for children
+	UserCPUTime time.Duration
+	// SystemCPUTime is the system CPU time used by the command and its waited-for children
+	SystemCPUTime time.Duration
+	// MaxRSSBytes is the peak resident set size of the largest waited-for process - 0 where the platform does not report it
+	MaxRSSBytes int64
+	// ExitCode is the command's exit code, -1 when it was killed by a signal
+	ExitCode int
+}
+
+// newUsage returns the resource usage of an exited command that ran for wallTime
+func newUsage(processState *os.ProcessState, wallTime time.Duration) Usage {
+	return Usage{
+		WallTime:      wallTime,
+		UserCPUTime:   processState.UserTime(),
+		SystemCPUTime: processState.SystemTime(),
+		MaxRSSBytes:   maxRSSBytes(processState),
+		ExitCode:      processState.ExitCode(),
+	}
+}
+
+// LastUsage returns the resource usage of the command's most recent execution - nil when it was skipped or
+// did not start
+func (c *Command) LastUsage() *Usage {
+	return c.lastUsage
+}
@@ -0,0 +1,15 @@
+package sync_commands
+
+import (
+	"os"
+	"syscall"
+)
+
+// maxRSSBytes returns the peak resident set size from the process rusage - linux reports it in kilobytes
+func maxRSSBytes(processState *os.ProcessState) int64 {
+	rusage, ok := processState.SysUsage().(*syscall.Rusage)
+	if !ok {
+		return 0
+	}
+	return rusage.Maxrss * 1024
+}
@@ -0,0 +1,10 @@
+//go:build !linux && !darwin
+
+package sync_commands
+
+import "os"
+
+// maxRSSBytes is not reported on this platform
+func maxRSSBytes(processState *os.ProcessState) int64 {
+	return 0
+}
@@ -0,0 +1,76 @@
+package sync_commands
+
+import (
+	"runtime"
+	"testing"
+)
+
+func TestCommand_LastUsage(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Skipping on Windows")
+	}
+
+	tests := []struct {
+		name         string
+		command      Command
+		wantUsage    bool
+		wantExitCode int
+	}{
+		{
+			name:         "successful command",
+			command:      Command{Name: "true", Cmd: "sh", Args: []string{"-c", "exit 0"}},
+			wantUsage:    true,
+			wantExitCode: 0,
+		},
+		{
+			name:         "failed command with allow failure",
+			command:      Command{Name: "false", Cmd: "sh", Args: []string{"-c", "exit 3"}, AllowFailure: true},
+			wantUsage:    true,
+			wantExitCode: 3,
+		},
+		{
+			name:         "streamed command",
+			command:      Command{Name: "streamed", Cmd: "sh", Args: []string{"-c", "echo streamed"}, StreamOutput: true},
+			wantUsage:    true,
+			wantExitCode: 0,
+		},
+		{
+			name:      "command that did not start",
+			command:   Command{Name: "missing", Cmd: "this-command-does-not-exist-12345", AllowFailure: true},
+			wantUsage: false,
+		},
+		{
+			name:      "disabled command",
+			command:   Command{Name: "disabled", Cmd: "sh", Args: []string{"-c", "exit 0"}, Disabled: true},
+			wantUsage: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.command.Parse(); err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			if err := tt.command.ExecuteWithData(CommandTemplateData{}); err != nil {
+				t.Fatalf("ExecuteWithData() error = %v", err)
+			}
+
+			usage := tt.command.LastUsage()
+			if (usage != nil) != tt.wantUsage {
+				t.Fatalf("LastUsage() = %v, want usage %v", usage, tt.wantUsage)
+			}
+			if usage == nil {
+				return
+			}
+			if usage.ExitCode != tt.wantExitCode {
+				t.Errorf("LastUsage().ExitCode = %d, want %d", usage.ExitCode, tt.wantExitCode)
+			}
+			if usage.WallTime <= 0 {
+				t.Errorf("LastUsage().WallTime = %s, want > 0", usage.WallTime)
+			}
+			if runtime.GOOS == "linux" && usage.MaxRSSBytes <= 0 {
+				t.Errorf("LastUsage().MaxRSSBytes = %d, want > 0 on linux", usage.MaxRSSBytes)
+			}
+		})
+	}
+}
@@ -0,0 +1,243 @@
+// Package githubfake is a fake GitHub API serving the subset of the releases and tags API the github client uses,
+// so tests run without network and with crafted edge cases - pagination, prereleases, drafts and rate limits.
+package githubfake
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// defaultPerPage and maxPerPage are GitHub's listing page sizes
+	defaultPerPage = 30
+	maxPerPage     = 100
+	// defaultRateLimit is GitHub's unauthenticated requests per hour
+	defaultRateLimit = 60
+)
+
+// Release is a release served by the fake
+type Release struct {
+	TagName string `json:"tag_name"`
+	Name    string `json:"name"`
+	Body    string `json:"body"`
+	// Draft releases are listed as given, as for authenticated users with push access
+	Draft       bool       `json:"draft"`
+	Prerelease  bool       `json:"prerelease"`
+	PublishedAt *time.Time `json:"published_at,omitempty"`
+	HTMLURL     string     `json:"html_url,omitempty"`
+}
+
+// tag is a tag served by the fake
+type tag struct {
+	Name string `json:"name"`
+}
+
+// Server is a fake GitHub API - safe for concurrent use
+type Server struct {
+	server *httptest.Server
+
+	mu       sync.Mutex
+	releases map[string][]Release
+	tags     map[string][]tag
+	// rateLimitRemaining is the requests left before requests are rate limited, -1 when unlimited
+	rateLimitRemaining int
+	rateLimitReset     time.Time
+	requests           []string
+}
+
+// New starts a fake GitHub API serving no repos - Close it when done
+func New() *Server {
+	s := &Server{
+		releases:           make(map[string][]Release),
+		tags:               make(map[string][]tag),
+		rateLimitRemaining: -1,
+	}
+	s.server = httptest.NewServer(http.HandlerFunc(s.handle))
+	return s
+}
+
+// URL returns the API base URL, e.g. for go-github's BaseURL or --github-api-url
+func (s *Server) URL() string {
+	return s.server.URL + "/"
+}
+
+// Close stops the server
+func (s *Server) Close() {
+	s.server.Close()
+}
+
+// SetReleases sets the releases of owner/repo, newest first as GitHub lists them
+func (s *Server) SetReleases(owner string, repo string, releases ...Release) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.releases[owner+"/"+repo] = append([]Release{}, releases...)
+}
+
+// SetTags sets the tags of owner/repo, newest first as GitHub lists them
+func (s *Server) SetTags(owner string, repo string, names ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	tags := make([]tag, 0, len(names))
+	for _, name := range names {
+		tags = append(tags, tag{Name: name})
+	}
+	s.tags[owner+"/"+repo] = tags
+}
+
+// SetRateLimit rate limits requests once remaining more requests were served, until reset - a negative remaining
+// removes the rate limit
+func (s *Server) SetRateLimit(remaining int, reset time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.rateLimitRemaining = remaining
+	s.rateLimitReset = reset
+}
+
+// Requests returns the paths and queries of the requests served so far, oldest first
+func (s *Server) Requests() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string{}, s.requests...)
+}
+
+// handle serves /repos/{owner}/{repo}/releases and /repos/{owner}/{repo}/tags
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.requests = append(s.requests, r.URL.RequestURI())
+
+	if !s.takeRateLimit(w) {
+		return
+	}
+
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if r.Method != http.MethodGet || len(parts) != 4 || parts[0] != "repos" {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+
+	repo := parts[1] + "/" + parts[2]
+	var listing []interface{}
+	var found bool
+	switch parts[3] {
+	case "releases":
+		var releases []Release
+		releases, found = s.releases[repo]
+		for _, release := range releases {
+			listing = append(listing, release)
+		}
+	case "tags":
+		var tags []tag
+		tags, found = s.tags[repo]
+		for _, t := range tags {
+			listing = append(listing, t)
+		}
+	}
+	if !found {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+
+	page, perPage, err := pagination(r.URL.Query())
+	if err != nil {
+		writeError(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+
+	lastPage := max((len(listing)+perPage-1)/perPage, 1)
+	start := min((page-1)*perPage, len(listing))
+	end := min(start+perPage, len(listing))
+	if links := pageLinks(r, page, perPage, lastPage); links != "" {
+		w.Header().Set("Link", links)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	items := listing[start:end]
+	if items == nil {
+		items = []interface{}{}
+	}
+	_ = json.NewEncoder(w).Encode(items)
+}
+
+// takeRateLimit sets the rate limit headers and takes one request from the rate limit, writing a rate limit
+// error and returning false when none are left
+func (s *Server) takeRateLimit(w http.ResponseWriter) bool {
+	limit := defaultRateLimit
+	remaining := s.rateLimitRemaining
+	if remaining < 0 {
+		remaining = limit
+	}
+	reset := s.rateLimitReset
+	if reset.IsZero() {
+		reset = time.Now().Add(time.Hour)
+	}
+
+	w.Header().Set("X-RateLimit-Limit", strconv.Itoa(limit))
+	w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(reset.Unix(), 10))
+
+	if remaining == 0 {
+		w.Header().Set("X-RateLimit-Remaining", "0")
+		writeError(w, http.StatusForbidden, "API rate limit exceeded for 127.0.0.1.")
+		return false
+	}
+
+	if s.rateLimitRemaining > 0 {
+		s.rateLimitRemaining--
+		remaining = s.rateLimitRemaining
+	}
+	w.Header().Set("X-RateLimit-Remaining", strconv.Itoa(remaining))
+	return true
+}
+
+// pagination returns the requested page and page size, defaulting as GitHub does
+func pagination(query url.Values) (page int, perPage int, err error) {
+	page, perPage = 1, defaultPerPage
+	if raw := query.Get("page"); raw != "" {
+		if page, err = strconv.Atoi(raw); err != nil || page < 1 {
+			return 0, 0, fmt.Errorf("invalid page: %s", raw)
+		}
+	}
+	if raw := query.Get("per_page"); raw != "" {
+		if perPage, err = strconv.Atoi(raw); err != nil || perPage < 1 {
+			return 0, 0, fmt.Errorf("invalid per_page: %s", raw)
+		}
+	}
+	return page, min(perPage, maxPerPage), nil
+}
+
+// pageLinks returns the Link header pointing to the next and last pages, empty on the only page
+func pageLinks(r *http.Request, page int, perPage int, lastPage int) string {
+	pageURL := func(p int) string {
+		query := r.URL.Query()
+		query.Set("page", strconv.Itoa(p))
+		query.Set("per_page", strconv.Itoa(perPage))
+		return fmt.Sprintf("<http://%s%s?%s>", r.Host, r.URL.Path, query.Encode())
+	}
+
+	var links []string
+	if page < lastPage {
+		links = append(links, pageURL(page+1)+`; rel="next"`, pageURL(lastPage)+`; rel="last"`)
+	}
+	if page > 1 {
+		links = append(links, pageURL(1)+`; rel="first"`, pageURL(page-1)+`; rel="prev"`)
+	}
+	return strings.Join(links, ", ")
+}
+
+// writeError writes a GitHub API error response
+func writeError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]string{
+		"message":           message,
+		"documentation_url": "https://docs.github.com/rest",
+	})
+}
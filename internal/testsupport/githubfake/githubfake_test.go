@@ -0,0 +1,179 @@
+package githubfake
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v74/github"
+)
+
+// newAPIClient returns a go-github client of the fake
+func newAPIClient(t *testing.T, s *Server) *github.Client {
+	t.Helper()
+	apiClient := github.NewClient(nil)
+	baseURL, err := url.Parse(s.URL())
+	if err != nil {
+		t.Fatalf("failed to parse fake URL: %v", err)
+	}
+	apiClient.BaseURL = baseURL
+	return apiClient
+}
+
+func TestServer_ListReleases(t *testing.T) {
+	publishedAt := time.Date(2025, 10, 8, 0, 0, 0, 0, time.UTC)
+	releases := []Release{
+		{TagName: "v3.0.12", Draft: true},
+		{TagName: "v3.0.11", Prerelease: true, PublishedAt: &publishedAt},
+		{TagName: "v3.0.10", Body: "This is a stable release", PublishedAt: &publishedAt},
+	}
+
+	tests := []struct {
+		name      string
+		opts      *github.ListOptions
+		wantTags  []string
+		wantNext  int
+		wantLast  int
+		wantPrev  int
+		wantFirst int
+	}{
+		{
+			name:     "single page",
+			opts:     &github.ListOptions{PerPage: 20},
+			wantTags: []string{"v3.0.12", "v3.0.11", "v3.0.10"},
+		},
+		{
+			name:     "first page",
+			opts:     &github.ListOptions{PerPage: 2},
+			wantTags: []string{"v3.0.12", "v3.0.11"},
+			wantNext: 2,
+			wantLast: 2,
+		},
+		{
+			name:      "last page",
+			opts:      &github.ListOptions{Page: 2, PerPage: 2},
+			wantTags:  []string{"v3.0.10"},
+			wantPrev:  1,
+			wantFirst: 1,
+		},
+		{
+			name:      "past the last page",
+			opts:      &github.ListOptions{Page: 5, PerPage: 2},
+			wantTags:  []string{},
+			wantPrev:  4,
+			wantFirst: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := New()
+			defer s.Close()
+			s.SetReleases("anza-xyz", "agave", releases...)
+
+			got, resp, err := newAPIClient(t, s).Repositories.ListReleases(context.Background(), "anza-xyz", "agave", tt.opts)
+			if err != nil {
+				t.Fatalf("ListReleases() error = %v", err)
+			}
+
+			gotTags := make([]string, 0, len(got))
+			for _, release := range got {
+				gotTags = append(gotTags, release.GetTagName())
+			}
+			if len(gotTags) != len(tt.wantTags) {
+				t.Fatalf("ListReleases() tags = %v, want %v", gotTags, tt.wantTags)
+			}
+			for i := range gotTags {
+				if gotTags[i] != tt.wantTags[i] {
+					t.Errorf("ListReleases() tags = %v, want %v", gotTags, tt.wantTags)
+				}
+			}
+
+			if resp.NextPage != tt.wantNext || resp.LastPage != tt.wantLast || resp.PrevPage != tt.wantPrev || resp.FirstPage != tt.wantFirst {
+				t.Errorf("ListReleases() pages next=%d last=%d prev=%d first=%d, want next=%d last=%d prev=%d first=%d",
+					resp.NextPage, resp.LastPage, resp.PrevPage, resp.FirstPage, tt.wantNext, tt.wantLast, tt.wantPrev, tt.wantFirst)
+			}
+		})
+	}
+
+	t.Run("release fields", func(t *testing.T) {
+		s := New()
+		defer s.Close()
+		s.SetReleases("anza-xyz", "agave", releases...)
+
+		got, _, err := newAPIClient(t, s).Repositories.ListReleases(context.Background(), "anza-xyz", "agave", nil)
+		if err != nil {
+			t.Fatalf("ListReleases() error = %v", err)
+		}
+		if !got[0].GetDraft() || got[0].PublishedAt != nil {
+			t.Errorf("draft release = %+v, want draft without published_at", got[0])
+		}
+		if !got[1].GetPrerelease() || !got[1].GetPublishedAt().Time.Equal(publishedAt) {
+			t.Errorf("prerelease = %+v, want prerelease published at %s", got[1], publishedAt)
+		}
+		if got[2].GetBody() != "This is a stable release" {
+			t.Errorf("release body = %q, want %q", got[2].GetBody(), "This is a stable release")
+		}
+	})
+}
+
+func TestServer_ListTags(t *testing.T) {
+	s := New()
+	defer s.Close()
+	s.SetTags("rakurai-io", "rakurai-validator", "release/v3.0.10-rakurai.1", "release/v3.0.10-rakurai.1_testnet")
+
+	got, _, err := newAPIClient(t, s).Repositories.ListTags(context.Background(), "rakurai-io", "rakurai-validator", &github.ListOptions{PerPage: 100})
+	if err != nil {
+		t.Fatalf("ListTags() error = %v", err)
+	}
+	if len(got) != 2 || got[0].GetName() != "release/v3.0.10-rakurai.1" || got[1].GetName() != "release/v3.0.10-rakurai.1_testnet" {
+		t.Errorf("ListTags() = %v, want both tags in order", got)
+	}
+
+	if requests := s.Requests(); len(requests) != 1 || requests[0] != "/repos/rakurai-io/rakurai-validator/tags?per_page=100" {
+		t.Errorf("Requests() = %v, want the tags listing", requests)
+	}
+}
+
+func TestServer_UnknownRepo(t *testing.T) {
+	s := New()
+	defer s.Close()
+
+	_, resp, err := newAPIClient(t, s).Repositories.ListReleases(context.Background(), "anza-xyz", "agave", nil)
+	var errorResponse *github.ErrorResponse
+	if !errors.As(err, &errorResponse) || resp.StatusCode != 404 {
+		t.Errorf("ListReleases() error = %v, want a 404 error response", err)
+	}
+}
+
+func TestServer_SetRateLimit(t *testing.T) {
+	s := New()
+	defer s.Close()
+	s.SetReleases("anza-xyz", "agave", Release{TagName: "v3.0.10"})
+	reset := time.Now().Add(10 * time.Minute).Truncate(time.Second)
+	s.SetRateLimit(1, reset)
+
+	apiClient := newAPIClient(t, s)
+	if _, resp, err := apiClient.Repositories.ListReleases(context.Background(), "anza-xyz", "agave", nil); err != nil {
+		t.Fatalf("ListReleases() error = %v, want the request within the rate limit served", err)
+	} else if resp.Rate.Remaining != 0 {
+		t.Errorf("ListReleases() rate remaining = %d, want 0", resp.Rate.Remaining)
+	}
+
+	_, _, err := apiClient.Repositories.ListReleases(context.Background(), "anza-xyz", "agave", nil)
+	var rateLimitErr *github.RateLimitError
+	if !errors.As(err, &rateLimitErr) {
+		t.Fatalf("ListReleases() error = %v, want a rate limit error", err)
+	}
+	if !rateLimitErr.Rate.Reset.Time.Equal(reset) {
+		t.Errorf("rate limit reset = %s, want %s", rateLimitErr.Rate.Reset.Time, reset)
+	}
+
+	// lifting the rate limit serves requests again - to a new client, go-github holds back requests until reset
+	s.SetRateLimit(-1, time.Time{})
+	if _, _, err := newAPIClient(t, s).Repositories.ListReleases(context.Background(), "anza-xyz", "agave", nil); err != nil {
+		t.Errorf("ListReleases() error = %v after lifting the rate limit", err)
+	}
+}
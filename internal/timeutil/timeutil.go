@@ -0,0 +1,19 @@
+package timeutil
+
+import (
+	"context"
+	"time"
+)
+
+// Sleep sleeps for the given duration, returning early with the context error when ctx is cancelled
+func Sleep(ctx context.Context, duration time.Duration) error {
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,42 @@
+package timeutil
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestSleep(t *testing.T) {
+	tests := []struct {
+		name     string
+		duration time.Duration
+		cancel   bool
+		wantErr  error
+	}{
+		{name: "sleeps for the duration", duration: 10 * time.Millisecond},
+		{name: "returns early when cancelled", duration: time.Hour, cancel: true, wantErr: context.Canceled},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			if tt.cancel {
+				cancel()
+			}
+
+			startedAt := time.Now()
+			err := Sleep(ctx, tt.duration)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Sleep() error = %v, want %v", err, tt.wantErr)
+			}
+			if elapsed := time.Since(startedAt); !tt.cancel && elapsed < tt.duration {
+				t.Errorf("Sleep() returned after %s, want at least %s", elapsed, tt.duration)
+			}
+			if elapsed := time.Since(startedAt); tt.cancel && elapsed >= tt.duration {
+				t.Errorf("Sleep() returned after %s, want early return", elapsed)
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package validator
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/charmbracelet/log"
+	"github.com/hashicorp/go-version"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/adoption"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/config"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/report"
+)
+
+// adoptionGateAllowsSync decides whether enough of the cluster's stake runs the target version or newer to
+// activate it, recording a skipped outcome when it doesn't. Upgrades leaving a running version below the SFDP
+// minimum are critical and never held back, nor are SFDP participants in a strict stage.
+func (v *Validator) adoptionGateAllowsSync(ctx context.Context, syncLogger *log.Logger, targetVersion *version.Version) (allowed bool, err error) {
+	adoptionGate := v.syncConfig.AdoptionGate
+	if !adoptionGate.Enabled {
+		return true, nil
+	}
+
+	if v.runningVersionBelowSFDPMinimum() {
+		syncLogger.Warn("running version is below the SFDP minimum - not waiting for adoption",
+			"sfdpMinVersion", v.sfdpRequirements.MinVersion.String(),
+		)
+		return true, nil
+	}
+
+	if v.sfdpStageIsStrict() {
+		syncLogger.Info("SFDP participant stage tracks releases strictly - not waiting for adoption", "sfdpStage", v.sfdpParticipantStage())
+		return true, nil
+	}
+
+	distribution, err := v.adoptionGateSource().Distribution(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to get stake-weighted version distribution: %w", err)
+	}
+
+	adoptedPercent, err := distribution.StakePercentAtOrAbove(targetVersion)
+	if err != nil {
+		return false, err
+	}
+
+	logger := syncLogger.With(
+		"source", distribution.Source,
+		"targetVersion", targetVersion.Core().String(),
+		"adoptedStakePercent", fmt.Sprintf("%.2f", adoptedPercent),
+		"minStakePercent", adoptionGate.MinStakePercent,
+	)
+
+	if adoptedPercent < adoptionGate.MinStakePercent {
+		logger.Info("not enough stake runs the target version yet - skipping sync", "topVersions", distribution.TopVersions(3))
+		v.recordOutcome(report.OutcomeSkipped, report.ReasonCodeAdoptionBelowThreshold, fmt.Sprintf(
+			"%.2f%% of stake runs %s or newer, below sync.adoption_gate.min_stake_percent=%v",
+			adoptedPercent, targetVersion.Core().String(), adoptionGate.MinStakePercent,
+		))
+		return false, nil
+	}
+
+	logger.Info("enough stake runs the target version")
+	return true, nil
+}
+
+// adoptionGateSource returns the configured distribution source, created on first use and kept for the daemon
+// lifetime so provider responses are cached across syncs
+func (v *Validator) adoptionGateSource() adoption.Source {
+	if v.adoptionSource != nil {
+		return v.adoptionSource
+	}
+
+	adoptionGate := v.syncConfig.AdoptionGate
+	switch adoptionGate.Source {
+	case config.AdoptionSourceProvider:
+		v.adoptionSource = adoption.NewProviderSource(adoption.ProviderOptions{
+			URL:      adoptionGate.Provider.URL,
+			Headers:  adoptionGate.Provider.Headers,
+			Timeout:  adoptionGate.Provider.Timeout,
+			CacheTTL: adoptionGate.Provider.CacheTTL,
+		})
+	default:
+		rpcURL := adoptionGate.RPCURL
+		if rpcURL == "" {
+			rpcURL = v.cfg.RPCURL
+		}
+		v.adoptionSource = adoption.NewRPCSource(rpcURL)
+	}
+	return v.adoptionSource
+}
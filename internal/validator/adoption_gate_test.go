@@ -0,0 +1,172 @@
+package validator
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/charmbracelet/log"
+	goversion "github.com/hashicorp/go-version"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/adoption"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/config"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/report"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/sfdp"
+)
+
+// fakeAdoptionSource returns a fixed distribution or error
+type fakeAdoptionSource struct {
+	distribution adoption.Distribution
+	err          error
+}
+
+func (s *fakeAdoptionSource) Distribution(ctx context.Context) (adoption.Distribution, error) {
+	return s.distribution, s.err
+}
+
+func TestValidator_adoptionGateAllowsSync(t *testing.T) {
+	distribution := adoption.Distribution{Source: "fake", Entries: []adoption.Entry{
+		{Version: "2.1.5", Stake: 40},
+		{Version: "2.1.4", Stake: 60},
+	}}
+
+	tests := []struct {
+		name           string
+		enabled        bool
+		source         *fakeAdoptionSource
+		targetVersion  string
+		runningVersion string
+		sfdpMinVersion string
+		sfdpStage      string
+		wantAllowed    bool
+		wantErr        bool
+	}{
+		{
+			name:          "disabled",
+			enabled:       false,
+			source:        &fakeAdoptionSource{err: errors.New("never called")},
+			targetVersion: "2.1.5",
+			wantAllowed:   true,
+		},
+		{
+			name:          "adoption above threshold",
+			enabled:       true,
+			source:        &fakeAdoptionSource{distribution: distribution},
+			targetVersion: "2.1.4",
+			wantAllowed:   true,
+		},
+		{
+			name:          "adoption below threshold",
+			enabled:       true,
+			source:        &fakeAdoptionSource{distribution: distribution},
+			targetVersion: "2.1.5",
+			wantAllowed:   false,
+		},
+		{
+			name:           "running version below SFDP minimum is never held back",
+			enabled:        true,
+			source:         &fakeAdoptionSource{distribution: distribution},
+			targetVersion:  "2.1.5",
+			runningVersion: "2.1.3",
+			sfdpMinVersion: "2.1.4",
+			wantAllowed:    true,
+		},
+		{
+			name:          "strict SFDP participant stage is never held back",
+			enabled:       true,
+			source:        &fakeAdoptionSource{err: errors.New("never called")},
+			targetVersion: "2.1.5",
+			sfdpStage:     "Approved",
+			wantAllowed:   true,
+		},
+		{
+			name:          "other SFDP participant stage is held back",
+			enabled:       true,
+			source:        &fakeAdoptionSource{distribution: distribution},
+			targetVersion: "2.1.5",
+			sfdpStage:     "Pending",
+			wantAllowed:   false,
+		},
+		{
+			name:          "source error",
+			enabled:       true,
+			source:        &fakeAdoptionSource{err: errors.New("provider down")},
+			targetVersion: "2.1.5",
+			wantErr:       true,
+		},
+		{
+			name:          "empty distribution",
+			enabled:       true,
+			source:        &fakeAdoptionSource{},
+			targetVersion: "2.1.5",
+			wantErr:       true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := &Validator{
+				syncConfig: config.Sync{
+					AdoptionGate: config.AdoptionGate{
+						Enabled:         tt.enabled,
+						MinStakePercent: 50,
+						Source:          config.AdoptionSourceProvider,
+					},
+					SFDPParticipant: config.SFDPParticipant{Enabled: true, StrictStages: []string{"Approved"}},
+				},
+				adoptionSource: tt.source,
+				logger:         log.WithPrefix("validator"),
+			}
+			if tt.sfdpStage != "" {
+				v.sfdpParticipant = &sfdp.Participant{State: tt.sfdpStage}
+			}
+			if tt.runningVersion != "" {
+				v.State.Version = goversion.Must(goversion.NewVersion(tt.runningVersion))
+				v.sfdpRequirements = &sfdp.Requirements{
+					HasMinVersion: true,
+					MinVersion:    goversion.Must(goversion.NewVersion(tt.sfdpMinVersion)),
+				}
+			}
+
+			allowed, err := v.adoptionGateAllowsSync(context.Background(), log.WithPrefix("sync"), goversion.Must(goversion.NewVersion(tt.targetVersion)))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("adoptionGateAllowsSync() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if allowed != tt.wantAllowed {
+				t.Errorf("adoptionGateAllowsSync() = %v, want %v", allowed, tt.wantAllowed)
+			}
+			if !tt.wantAllowed && !tt.wantErr && v.LastDecision().ReasonCode != report.ReasonCodeAdoptionBelowThreshold {
+				t.Errorf("LastDecision().ReasonCode = %q, want %q", v.LastDecision().ReasonCode, report.ReasonCodeAdoptionBelowThreshold)
+			}
+		})
+	}
+}
+
+func TestValidator_adoptionGateSource(t *testing.T) {
+	tests := []struct {
+		name         string
+		source       string
+		wantProvider bool
+	}{
+		{name: "rpc", source: config.AdoptionSourceRPC, wantProvider: false},
+		{name: "provider", source: config.AdoptionSourceProvider, wantProvider: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := &Validator{
+				cfg:        config.Validator{RPCURL: "http://127.0.0.1:8899"},
+				syncConfig: config.Sync{AdoptionGate: config.AdoptionGate{Source: tt.source}},
+			}
+
+			source := v.adoptionGateSource()
+			if _, isProvider := source.(*adoption.ProviderSource); isProvider != tt.wantProvider {
+				t.Errorf("adoptionGateSource() = %T, want provider source %v", source, tt.wantProvider)
+			}
+
+			// kept for the daemon lifetime so provider responses stay cached across syncs
+			if v.adoptionGateSource() != source {
+				t.Error("adoptionGateSource() created a new source on second call")
+			}
+		})
+	}
+}
@@ -0,0 +1,141 @@
+package validator
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/github"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/report"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/state"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/sync_commands"
+)
+
+// assetDownloadHistorySize is the number of most recent asset downloads kept in the state store
+const assetDownloadHistorySize = 100
+
+// recordAssetDownloads hashes the outputs of the prepare commands that succeeded in the current sync, logging
+// each one for supply-chain audits and appending them to the asset download history kept in the state store,
+// dropping the oldest beyond assetDownloadHistorySize. Outputs named like one of the target release's assets
+// must match the digest GitHub publishes for it - a missing output or a mismatch fails the sync, while failing to
+// look up the release's assets or to persist the history is only logged.
+func (v *Validator) recordAssetDownloads(syncLogger *log.Logger, templateData sync_commands.CommandTemplateData) (err error) {
+	templateData.SyncPhase = sync_commands.PhasePrepare
+	now := time.Now().UTC()
+
+	var assetDownloads []state.AssetDownload
+	for _, commandRun := range v.lastCommandRuns {
+		if commandRun.Phase != sync_commands.PhasePrepare || commandRun.ExitCode != 0 {
+			continue
+		}
+		for cmd_i := range v.syncConfig.Commands {
+			cmd := &v.syncConfig.Commands[cmd_i]
+			if cmd.Name != commandRun.Command {
+				continue
+			}
+			templateData.CommandIndex = cmd_i
+			outputs, err := cmd.RenderOutputs(templateData)
+			if err != nil {
+				return fmt.Errorf("failed command %s: %w", cmd.Name, err)
+			}
+			for _, output := range outputs {
+				sha256Digest, size, err := hashFile(output)
+				if err != nil {
+					return fmt.Errorf("failed to hash output of command %s: %w", cmd.Name, err)
+				}
+				assetDownloads = append(assetDownloads, state.AssetDownload{
+					Time:      now,
+					Command:   cmd.Name,
+					VersionTo: templateData.VersionTo,
+					Tag:       templateData.VersionToTag,
+					Path:      output,
+					Size:      size,
+					SHA256:    sha256Digest,
+				})
+			}
+			break
+		}
+	}
+	if len(assetDownloads) == 0 {
+		return nil
+	}
+
+	var assets []github.ReleaseAsset
+	if v.githubClient != nil {
+		assets, err = v.githubClient.GetReleaseAssets(templateData.VersionToTag)
+		if err != nil {
+			syncLogger.Warn("failed to look up release assets - not verifying downloaded files against their digests", "tag", templateData.VersionToTag, "error", err)
+		}
+	}
+
+	assetDownloads, verifyErr := verifyAssetDownloads(assetDownloads, assets)
+	for _, assetDownload := range assetDownloads {
+		syncLogger.Info("release asset downloaded",
+			"command", assetDownload.Command,
+			"tag", assetDownload.Tag,
+			"path", assetDownload.Path,
+			"size", assetDownload.Size,
+			"sha256", assetDownload.SHA256,
+			"asset", assetDownload.Name,
+			"url", assetDownload.URL,
+			"githubSHA256", assetDownload.GitHubSHA256,
+		)
+	}
+
+	err = v.stateStore.Update(func(data *state.Data) {
+		data.AssetDownloads = append(data.AssetDownloads, assetDownloads...)
+		if len(data.AssetDownloads) > assetDownloadHistorySize {
+			data.AssetDownloads = data.AssetDownloads[len(data.AssetDownloads)-assetDownloadHistorySize:]
+		}
+	})
+	if err != nil {
+		v.logger.Warn("failed to record asset downloads in state", "tag", templateData.VersionToTag, "error", err)
+	}
+
+	return verifyErr
+}
+
+// verifyAssetDownloads matches downloaded files to the release assets of the same file name, failing when a file's
+// digest isn't the one GitHub publishes for its asset
+func verifyAssetDownloads(assetDownloads []state.AssetDownload, assets []github.ReleaseAsset) (verified []state.AssetDownload, err error) {
+	for _, assetDownload := range assetDownloads {
+		for _, asset := range assets {
+			if asset.Name != filepath.Base(assetDownload.Path) {
+				continue
+			}
+			assetDownload.Name = asset.Name
+			assetDownload.URL = asset.URL
+			assetDownload.GitHubSHA256 = asset.SHA256
+			if asset.SHA256 != "" && !strings.EqualFold(asset.SHA256, assetDownload.SHA256) && err == nil {
+				err = report.WithReasonCode(report.ReasonCodeAssetDigestMismatch,
+					fmt.Errorf("downloaded %s has sha256 %s, but GitHub publishes %s for release asset %s", assetDownload.Path, assetDownload.SHA256, asset.SHA256, asset.Name),
+				)
+			}
+			break
+		}
+		verified = append(verified, assetDownload)
+	}
+	return verified, err
+}
+
+// hashFile returns the hex sha256 digest and size of a file
+func hashFile(path string) (sha256Digest string, size int64, err error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", 0, err
+	}
+	defer f.Close()
+
+	hash := sha256.New()
+	size, err = io.Copy(hash, f)
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return hex.EncodeToString(hash.Sum(nil)), size, nil
+}
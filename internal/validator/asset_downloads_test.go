@@ -0,0 +1,159 @@
+package validator
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/config"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/github"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/report"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/state"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/sync_commands"
+)
+
+// sha256 digest of "release"
+const releaseSHA256 = "a4d451ec23463726f72c43d64c710968f6b602cd653b4de8adee1b556240a829"
+
+func TestVerifyAssetDownloads(t *testing.T) {
+	tarball := github.ReleaseAsset{
+		Name:   "solana-release-x86_64-unknown-linux-gnu.tar.bz2",
+		URL:    "https://github.com/anza-xyz/agave/releases/download/v3.0.10/solana-release-x86_64-unknown-linux-gnu.tar.bz2",
+		Size:   1024,
+		SHA256: "4f2a9c",
+	}
+	download := state.AssetDownload{Command: "download", Path: "/home/solana/releases/v3.0.10/solana-release-x86_64-unknown-linux-gnu.tar.bz2", SHA256: "4f2a9c"}
+
+	tests := []struct {
+		name           string
+		assetDownloads []state.AssetDownload
+		assets         []github.ReleaseAsset
+		want           []state.AssetDownload
+		wantErr        bool
+	}{
+		{
+			name:           "matching digest",
+			assetDownloads: []state.AssetDownload{download},
+			assets:         []github.ReleaseAsset{tarball},
+			want:           []state.AssetDownload{{Command: "download", Path: download.Path, SHA256: "4f2a9c", Name: tarball.Name, URL: tarball.URL, GitHubSHA256: "4f2a9c"}},
+		},
+		{
+			name:           "matching digest in another case",
+			assetDownloads: []state.AssetDownload{download},
+			assets:         []github.ReleaseAsset{{Name: tarball.Name, URL: tarball.URL, SHA256: "4F2A9C"}},
+			want:           []state.AssetDownload{{Command: "download", Path: download.Path, SHA256: "4f2a9c", Name: tarball.Name, URL: tarball.URL, GitHubSHA256: "4F2A9C"}},
+		},
+		{
+			name:           "mismatching digest",
+			assetDownloads: []state.AssetDownload{{Command: "download", Path: download.Path, SHA256: "0badc0de"}},
+			assets:         []github.ReleaseAsset{tarball},
+			want:           []state.AssetDownload{{Command: "download", Path: download.Path, SHA256: "0badc0de", Name: tarball.Name, URL: tarball.URL, GitHubSHA256: "4f2a9c"}},
+			wantErr:        true,
+		},
+		{
+			name:           "asset without digest",
+			assetDownloads: []state.AssetDownload{download},
+			assets:         []github.ReleaseAsset{{Name: tarball.Name, URL: tarball.URL}},
+			want:           []state.AssetDownload{{Command: "download", Path: download.Path, SHA256: "4f2a9c", Name: tarball.Name, URL: tarball.URL}},
+		},
+		{
+			name:           "file not on the release",
+			assetDownloads: []state.AssetDownload{{Command: "download", Path: "/tmp/mirror.tar.bz2", SHA256: "4f2a9c"}},
+			assets:         []github.ReleaseAsset{tarball},
+			want:           []state.AssetDownload{{Command: "download", Path: "/tmp/mirror.tar.bz2", SHA256: "4f2a9c"}},
+		},
+		{
+			name:           "release assets not looked up",
+			assetDownloads: []state.AssetDownload{download},
+			want:           []state.AssetDownload{download},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := verifyAssetDownloads(tt.assetDownloads, tt.assets)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("verifyAssetDownloads() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr && report.ReasonCodeOf(err) != report.ReasonCodeAssetDigestMismatch {
+				t.Errorf("verifyAssetDownloads() reason code = %q, want %q", report.ReasonCodeOf(err), report.ReasonCodeAssetDigestMismatch)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("verifyAssetDownloads() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidator_recordAssetDownloads(t *testing.T) {
+	tests := []struct {
+		name        string
+		outputs     []string
+		writeOutput bool
+		exitCode    int
+		wantHashed  bool
+		wantErr     bool
+	}{
+		{name: "output hashed", outputs: []string{"{{ .VersionToTag }}.tar.bz2"}, writeOutput: true, wantHashed: true},
+		{name: "no outputs"},
+		{name: "missing output", outputs: []string{"{{ .VersionToTag }}.tar.bz2"}, wantErr: true},
+		{name: "failed command", outputs: []string{"{{ .VersionToTag }}.tar.bz2"}, exitCode: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			stateStore, err := state.NewStore(filepath.Join(dir, "state.json"))
+			if err != nil {
+				t.Fatalf("state.NewStore() error = %v", err)
+			}
+			if tt.writeOutput {
+				if err := os.WriteFile(filepath.Join(dir, "v3.0.10.tar.bz2"), []byte("release"), 0o644); err != nil {
+					t.Fatalf("failed to write output: %v", err)
+				}
+			}
+
+			var outputs []string
+			for _, output := range tt.outputs {
+				outputs = append(outputs, filepath.Join(dir, output))
+			}
+			command := sync_commands.Command{Name: "download", Cmd: "curl", Phase: sync_commands.PhasePrepare, Outputs: outputs}
+			if err := command.Parse(); err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+
+			// without a github client the release's assets are not looked up
+			v := &Validator{
+				syncConfig:      config.Sync{Commands: []sync_commands.Command{command}},
+				stateStore:      stateStore,
+				logger:          log.WithPrefix("validator"),
+				lastCommandRuns: []state.CommandRun{{Command: "download", Phase: sync_commands.PhasePrepare, ExitCode: tt.exitCode}},
+			}
+			err = v.recordAssetDownloads(v.logger, sync_commands.CommandTemplateData{VersionTo: "3.0.10", VersionToTag: "v3.0.10"})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("recordAssetDownloads() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			assetDownloads := stateStore.Get().AssetDownloads
+			if !tt.wantHashed {
+				if len(assetDownloads) != 0 {
+					t.Errorf("AssetDownloads = %+v, want none", assetDownloads)
+				}
+				return
+			}
+			if len(assetDownloads) != 1 {
+				t.Fatalf("AssetDownloads = %+v, want one", assetDownloads)
+			}
+			got := assetDownloads[0]
+			if got.Path != filepath.Join(dir, "v3.0.10.tar.bz2") || got.Size != int64(len("release")) || got.SHA256 != releaseSHA256 || got.Tag != "v3.0.10" {
+				t.Errorf("AssetDownloads[0] = %+v, want path %s, size %d and sha256 %s", got, filepath.Join(dir, "v3.0.10.tar.bz2"), len("release"), releaseSHA256)
+			}
+			if got.Time.IsZero() || got.Time.After(time.Now()) {
+				t.Errorf("AssetDownloads[0].Time = %s, want the time it was recorded", got.Time)
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package validator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/charmbracelet/log"
+	"github.com/hashicorp/go-version"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/report"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/sync_commands"
+)
+
+// errBinaryCheckFailed fails syncs whose installed target binary doesn't report the target version
+var errBinaryCheckFailed = errors.New("binary check failed")
+
+// reportedVersionRegex matches the version in a binary's --version output, e.g. agave-validator 3.0.10 (src:...)
+var reportedVersionRegex = regexp.MustCompile(`\d+\.\d+\.\d+`)
+
+// checkTargetBinary runs sync.binary_check.binary and fails when the version it reports isn't the sync target
+func (v *Validator) checkTargetBinary(syncLogger *log.Logger, templateData sync_commands.CommandTemplateData) error {
+	command := v.syncConfig.BinaryCheck.Command()
+	err := command.Parse()
+	if err != nil {
+		return fmt.Errorf("failed to parse binary check command: %w", err)
+	}
+	command.SetDefaultEnvironmentPolicy(v.syncConfig.EnvironmentPolicy)
+
+	ctx, cancel := context.WithTimeout(context.Background(), v.syncConfig.BinaryCheck.Timeout)
+	defer cancel()
+
+	output, err := command.Output(ctx, templateData)
+	if err != nil {
+		return report.WithReasonCode(report.ReasonCodeBinaryCheckFailed,
+			fmt.Errorf("%w: failed to run %s: %w - output: %s", errBinaryCheckFailed, command.Cmd, err, strings.TrimSpace(output)),
+		)
+	}
+
+	reportedVersion, err := parseReportedVersion(output)
+	if err != nil {
+		return report.WithReasonCode(report.ReasonCodeBinaryCheckFailed, fmt.Errorf("%w: %w", errBinaryCheckFailed, err))
+	}
+
+	if reportedVersion.Core().String() != templateData.VersionTo {
+		return report.WithReasonCode(report.ReasonCodeBinaryCheckFailed,
+			fmt.Errorf("%w: installed binary reports version %s, want %s", errBinaryCheckFailed, reportedVersion.Core().String(), templateData.VersionTo),
+		)
+	}
+
+	syncLogger.Info("installed binary reports the target version", "binary", command.Cmd, "reportedVersion", reportedVersion.Original())
+	return nil
+}
+
+// parseReportedVersion parses the first version in a binary's --version output
+func parseReportedVersion(output string) (*version.Version, error) {
+	match := reportedVersionRegex.FindString(output)
+	if match == "" {
+		return nil, fmt.Errorf("no version in binary output: %s", strings.TrimSpace(output))
+	}
+	return version.NewVersion(match)
+}
+
+// rollbackAfterBinaryCheck runs the rollback commands, if any, after the binary check aborted a sync
+func (v *Validator) rollbackAfterBinaryCheck(syncLogger *log.Logger, templateData sync_commands.CommandTemplateData, checkErr error) error {
+	if !v.hasCommandsInPhase(sync_commands.PhaseRollback) {
+		syncLogger.Warn("binary check failed - no rollback commands configured", "error", checkErr)
+		return checkErr
+	}
+
+	syncLogger.Warn("binary check failed - executing rollback commands", "error", checkErr)
+	err := v.executeCommands(syncLogger, sync_commands.PhaseRollback, templateData)
+	if err != nil {
+		syncLogger.Error("rollback commands failed", "error", err)
+		return fmt.Errorf("%w - rollback failed: %w", checkErr, err)
+	}
+	return checkErr
+}
@@ -0,0 +1,173 @@
+package validator
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/config"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/report"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/state"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/sync_commands"
+)
+
+func TestParseReportedVersion(t *testing.T) {
+	tests := []struct {
+		name    string
+		output  string
+		want    string
+		wantErr bool
+	}{
+		{name: "agave", output: "agave-validator 3.0.10 (src:a1b2c3d4; feat:123456, client:Agave)\n", want: "3.0.10"},
+		{name: "jito", output: "agave-validator 2.3.6 (src:devbuild; feat:798020478, client:JitoLabs)\n", want: "2.3.6"},
+		{name: "firedancer", output: "0.503.20214 (a1b2c3d4)\n", want: "0.503.20214"},
+		{name: "no version", output: "error while loading shared libraries\n", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseReportedVersion(tt.output)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseReportedVersion() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && got.String() != tt.want {
+				t.Errorf("parseReportedVersion() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidator_checkTargetBinary(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Skipping on Windows")
+	}
+
+	t.Setenv("SVVS_TEST_REPORTED_VERSION", "3.0.9")
+
+	tests := []struct {
+		name              string
+		binary            string
+		args              []string
+		environmentPolicy sync_commands.EnvironmentPolicy
+		wantErr           bool
+	}{
+		{name: "target version", binary: "echo", args: []string{"agave-validator {{ .VersionTo }} (src:a1b2c3d4)"}},
+		{name: "other version", binary: "echo", args: []string{"agave-validator 3.0.9 (src:a1b2c3d4)"}, wantErr: true},
+		{name: "no version", binary: "echo", args: []string{"Illegal instruction"}, wantErr: true},
+		{name: "binary fails", binary: "sh", args: []string{"-c", "echo exec format error >&2; exit 126"}, wantErr: true},
+		{name: "binary missing", binary: "/nonexistent/{{ .VersionToTag }}/bin/agave-validator", wantErr: true},
+		{name: "inherited environment", binary: "sh", args: []string{"-c", "echo agave-validator ${SVVS_TEST_REPORTED_VERSION:-3.0.10}"}, wantErr: true},
+		{
+			name:              "environment denied by sync environment policy",
+			binary:            "sh",
+			args:              []string{"-c", "echo agave-validator ${SVVS_TEST_REPORTED_VERSION:-3.0.10}"},
+			environmentPolicy: sync_commands.EnvironmentPolicy{Deny: []string{"SVVS_TEST_*"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := &Validator{
+				syncConfig: config.Sync{
+					BinaryCheck:       config.BinaryCheck{Enabled: true, Binary: tt.binary, Args: tt.args, BeforeCommand: "restart", Timeout: 5 * time.Second},
+					EnvironmentPolicy: tt.environmentPolicy,
+				},
+			}
+
+			err := v.checkTargetBinary(log.WithPrefix("sync"), sync_commands.CommandTemplateData{VersionTo: "3.0.10", VersionToTag: "v3.0.10"})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("checkTargetBinary() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr && !errors.Is(err, errBinaryCheckFailed) {
+				t.Errorf("checkTargetBinary() error = %v, want %v", err, errBinaryCheckFailed)
+			}
+			if tt.wantErr && report.ReasonCodeOf(err) != report.ReasonCodeBinaryCheckFailed {
+				t.Errorf("checkTargetBinary() reason code = %q, want %q", report.ReasonCodeOf(err), report.ReasonCodeBinaryCheckFailed)
+			}
+		})
+	}
+}
+
+func TestValidator_executeCommands_BinaryCheck(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Skipping on Windows")
+	}
+
+	tests := []struct {
+		name             string
+		reportedTag      string
+		restartDisabled  bool
+		hasRollback      bool
+		rollbackDisabled bool
+		wantErr          bool
+		wantRestarted    bool
+		wantFollowUp     bool
+		wantRolledBack   bool
+	}{
+		{name: "target installed", reportedTag: "3.0.10", wantRestarted: true, wantFollowUp: true},
+		{name: "wrong version installed", reportedTag: "3.0.9", hasRollback: true, wantErr: true, wantRolledBack: true},
+		{name: "wrong version installed without rollback", reportedTag: "3.0.9", wantErr: true},
+		{name: "wrong version installed with disabled rollback", reportedTag: "3.0.9", hasRollback: true, rollbackDisabled: true, wantErr: true},
+		{name: "target installed with disabled before command", reportedTag: "3.0.10", restartDisabled: true, wantFollowUp: true},
+		{name: "wrong version installed with disabled before command", reportedTag: "3.0.9", restartDisabled: true, hasRollback: true, wantErr: true, wantRolledBack: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			restarted := filepath.Join(dir, "restarted")
+			followedUp := filepath.Join(dir, "followed-up")
+			rolledBack := filepath.Join(dir, "rolled-back")
+
+			commands := []sync_commands.Command{
+				{Name: "install", Cmd: "true"},
+				{Name: "restart", Cmd: "touch", Args: []string{restarted}, Disabled: tt.restartDisabled},
+				{Name: "notify", Cmd: "touch", Args: []string{followedUp}},
+			}
+			if tt.hasRollback {
+				commands = append(commands, sync_commands.Command{Name: "relink", Cmd: "touch", Args: []string{rolledBack}, Phase: sync_commands.PhaseRollback, Disabled: tt.rollbackDisabled})
+			}
+			for i := range commands {
+				if err := commands[i].Parse(); err != nil {
+					t.Fatalf("Parse() error = %v", err)
+				}
+			}
+
+			store, err := state.NewStore("")
+			if err != nil {
+				t.Fatalf("NewStore() error = %v", err)
+			}
+			v := &Validator{
+				logger:     log.WithPrefix("validator"),
+				stateStore: store,
+				syncConfig: config.Sync{
+					BinaryCheck: config.BinaryCheck{Enabled: true, Binary: "echo", Args: []string{"agave-validator " + tt.reportedTag}, BeforeCommand: "restart", Timeout: 5 * time.Second},
+					Commands:    commands,
+				},
+			}
+
+			templateData := sync_commands.CommandTemplateData{VersionFrom: "3.0.8", VersionTo: "3.0.10", VersionToTag: "v3.0.10"}
+			err = v.executeCommands(log.WithPrefix("sync"), sync_commands.PhaseActivate, templateData)
+			if errors.Is(err, errBinaryCheckFailed) {
+				err = v.rollbackAfterBinaryCheck(log.WithPrefix("sync"), templateData, err)
+			}
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("executeCommands() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if _, statErr := os.Stat(restarted); (statErr == nil) != tt.wantRestarted {
+				t.Errorf("restarted = %v, want %v", statErr == nil, tt.wantRestarted)
+			}
+			if _, statErr := os.Stat(followedUp); (statErr == nil) != tt.wantFollowUp {
+				t.Errorf("followed up = %v, want %v", statErr == nil, tt.wantFollowUp)
+			}
+			if _, statErr := os.Stat(rolledBack); (statErr == nil) != tt.wantRolledBack {
+				t.Errorf("rolled back = %v, want %v", statErr == nil, tt.wantRolledBack)
+			}
+		})
+	}
+}
@@ -0,0 +1,174 @@
+package validator
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/go-version"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/constants"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/recipes"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/rpc"
+)
+
+// agaveBasedClients are the clients built from agave, which report agave versions and serve the admin RPC
+var agaveBasedClients = []string{constants.ClientNameAgave, constants.ClientNameJitoSolana, constants.ClientNameRakurai}
+
+// clientIDClients maps the client IDs validators advertise, lowercased, to client names
+var clientIDClients = map[string]string{
+	"solanalabs":    constants.ClientNameAgave,
+	"agave":         constants.ClientNameAgave,
+	"jitolabs":      constants.ClientNameJitoSolana,
+	"rakurai":       constants.ClientNameRakurai,
+	"frankendancer": constants.ClientNameFiredancer,
+	"firedancer":    constants.ClientNameFiredancer,
+}
+
+// adminVersionClientIDRegex matches the client ID in an admin RPC version, e.g. "2.2.14 (src:...; feat:..., client:JitoLabs)"
+var adminVersionClientIDRegex = regexp.MustCompile(`client:\s*([A-Za-z]+)`)
+
+// clientSignals are what the running validator reveals about its client - empty when unavailable
+type clientSignals struct {
+	// rpcVersion is the solana-core version from the RPC getVersion response
+	rpcVersion string
+	// gossipClientID is the client ID of the validator's gossip entry
+	gossipClientID string
+	// adminAvailable is set when the admin RPC answered
+	adminAvailable bool
+	// adminVersion is the version from the admin RPC contact info
+	adminVersion string
+}
+
+// clientEvidence is the clients a signal allows
+type clientEvidence struct {
+	source  string
+	clients []string
+}
+
+// evidence returns the clients each available signal allows
+func (s clientSignals) evidence() (evidence []clientEvidence) {
+	// firedancer versions are 0.x, agave-based clients report agave versions
+	if parsed, err := version.NewVersion(s.rpcVersion); err == nil {
+		if parsed.Segments()[0] == 0 {
+			evidence = append(evidence, clientEvidence{source: "rpc version " + s.rpcVersion, clients: []string{constants.ClientNameFiredancer}})
+		} else {
+			evidence = append(evidence, clientEvidence{source: "rpc version " + s.rpcVersion, clients: agaveBasedClients})
+		}
+	}
+
+	if client, ok := clientIDClients[strings.ToLower(s.gossipClientID)]; ok {
+		evidence = append(evidence, clientEvidence{source: "gossip client id " + s.gossipClientID, clients: []string{client}})
+	}
+
+	// only agave-based clients serve the admin RPC
+	if s.adminAvailable {
+		evidence = append(evidence, clientEvidence{source: "admin rpc", clients: agaveBasedClients})
+		if match := adminVersionClientIDRegex.FindStringSubmatch(s.adminVersion); match != nil {
+			if client, ok := clientIDClients[strings.ToLower(match[1])]; ok {
+				evidence = append(evidence, clientEvidence{source: "admin rpc client id " + match[1], clients: []string{client}})
+			}
+		}
+	}
+
+	return evidence
+}
+
+// inferClient infers the client from the signals - the clients every signal allows, in constants.ValidClientNames
+// order. More than one candidate means the signals are ambiguous.
+func (s clientSignals) inferClient() (candidates []string, err error) {
+	evidence := s.evidence()
+	if len(evidence) == 0 {
+		return nil, fmt.Errorf("no client signals available (rpc version %q)", s.rpcVersion)
+	}
+
+	for _, client := range constants.ValidClientNames {
+		allowed := true
+		for _, e := range evidence {
+			allowed = allowed && slices.Contains(e.clients, client)
+		}
+		if allowed {
+			candidates = append(candidates, client)
+		}
+	}
+
+	sources := make([]string, 0, len(evidence))
+	for _, e := range evidence {
+		sources = append(sources, fmt.Sprintf("%s: %s", e.source, strings.Join(e.clients, "|")))
+	}
+
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("client signals conflict (%s)", strings.Join(sources, ", "))
+	}
+	return candidates, nil
+}
+
+// collectClientSignals collects the client signals of the running validator - the RPC version is required, the
+// gossip entry and admin RPC are used when available
+func (v *Validator) collectClientSignals() (signals clientSignals, err error) {
+	signals.rpcVersion, err = v.rpcClient.GetVersion()
+	if err != nil {
+		return signals, fmt.Errorf("failed to get validator version: %w", err)
+	}
+
+	identity, err := v.rpcClient.GetIdentity()
+	if err != nil {
+		v.logger.Warn("failed to get identity - detecting client without its gossip entry", "error", err)
+	} else if found, node, err := v.rpcClient.GetNodeWithIdentityPublicKey(identity); err != nil {
+		v.logger.Warn("failed to get gossip entry - detecting client without it", "error", err)
+	} else if found {
+		signals.gossipClientID = node.ClientID
+	}
+
+	if v.cfg.AdminRPCPath != "" {
+		contactInfo, err := rpc.NewAdminClient(v.cfg.AdminRPCPath).GetContactInfo()
+		if err != nil {
+			v.logger.Warn("failed to query admin RPC - detecting client without it", "path", v.cfg.AdminRPCPath, "error", err)
+		} else {
+			signals.adminAvailable = true
+			signals.adminVersion = contactInfo.Version
+		}
+	}
+
+	return signals, nil
+}
+
+// detectClient detects the client of the running validator for validator.client auto, warning when the signals
+// are ambiguous and falling back to the first candidate
+func (v *Validator) detectClient() (client string, err error) {
+	signals, err := v.collectClientSignals()
+	if err != nil {
+		return "", err
+	}
+
+	candidates, err := signals.inferClient()
+	if err != nil {
+		return "", fmt.Errorf("%w - set validator.client explicitly", err)
+	}
+
+	client = candidates[0]
+	if len(candidates) > 1 {
+		v.logger.Warn("validator client is ambiguous - set validator.client explicitly to avoid syncing the wrong client",
+			"candidates", strings.Join(candidates, ", "),
+			"using", client,
+			"rpcVersion", signals.rpcVersion,
+			"gossipClientId", signals.gossipClientID,
+			"adminRPC", signals.adminAvailable,
+		)
+	} else {
+		v.logger.Info("detected validator client", "client", client)
+	}
+
+	// recipes install one client, which auto-detected clients could only be checked against now
+	if v.syncConfig.Recipe != "" {
+		recipe, err := recipes.Get(v.syncConfig.Recipe)
+		if err != nil {
+			return "", fmt.Errorf("invalid sync.recipe: %w", err)
+		}
+		if recipe.Client != client {
+			return "", fmt.Errorf("sync.recipe %s installs %s but the detected validator client is %s", recipe.Name, recipe.Client, client)
+		}
+	}
+
+	return client, nil
+}
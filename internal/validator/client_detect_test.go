@@ -0,0 +1,156 @@
+package validator
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/charmbracelet/log"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/config"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/constants"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/rpc"
+)
+
+func TestClientSignals_inferClient(t *testing.T) {
+	tests := []struct {
+		name           string
+		signals        clientSignals
+		wantCandidates []string
+		wantErr        bool
+	}{
+		{
+			name:           "firedancer version",
+			signals:        clientSignals{rpcVersion: "0.503.20214"},
+			wantCandidates: []string{constants.ClientNameFiredancer},
+		},
+		{
+			name:           "agave version alone is ambiguous",
+			signals:        clientSignals{rpcVersion: "2.3.6"},
+			wantCandidates: []string{constants.ClientNameAgave, constants.ClientNameJitoSolana, constants.ClientNameRakurai},
+		},
+		{
+			name:           "gossip client id",
+			signals:        clientSignals{rpcVersion: "2.3.6", gossipClientID: "JitoLabs"},
+			wantCandidates: []string{constants.ClientNameJitoSolana},
+		},
+		{
+			name:           "unknown gossip client id is ignored",
+			signals:        clientSignals{rpcVersion: "0.503.20214", gossipClientID: "Unknown"},
+			wantCandidates: []string{constants.ClientNameFiredancer},
+		},
+		{
+			name:           "admin rpc client id",
+			signals:        clientSignals{rpcVersion: "2.3.6", adminAvailable: true, adminVersion: "2.3.6 (src:00000000; feat:3294202862, client:Agave)"},
+			wantCandidates: []string{constants.ClientNameAgave},
+		},
+		{
+			name:           "admin rpc without client id",
+			signals:        clientSignals{rpcVersion: "2.3.6", adminAvailable: true, adminVersion: "2.3.6"},
+			wantCandidates: []string{constants.ClientNameAgave, constants.ClientNameJitoSolana, constants.ClientNameRakurai},
+		},
+		{
+			name:    "firedancer version conflicts with gossip client id",
+			signals: clientSignals{rpcVersion: "0.503.20214", gossipClientID: "JitoLabs"},
+			wantErr: true,
+		},
+		{
+			name:    "firedancer version conflicts with admin rpc",
+			signals: clientSignals{rpcVersion: "0.503.20214", adminAvailable: true},
+			wantErr: true,
+		},
+		{
+			name:    "no signals",
+			signals: clientSignals{rpcVersion: "not-a-version"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			candidates, err := tt.signals.inferClient()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("inferClient() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !slices.Equal(candidates, tt.wantCandidates) {
+				t.Errorf("inferClient() = %v, want %v", candidates, tt.wantCandidates)
+			}
+		})
+	}
+}
+
+func TestValidator_detectClient(t *testing.T) {
+	tests := []struct {
+		name       string
+		version    string
+		clientID   string
+		recipe     string
+		wantClient string
+		wantErr    bool
+	}{
+		{
+			name:       "detected from gossip",
+			version:    "2.3.6",
+			clientID:   "JitoLabs",
+			wantClient: constants.ClientNameJitoSolana,
+		},
+		{
+			name:       "ambiguous falls back to first candidate",
+			version:    "2.3.6",
+			wantClient: constants.ClientNameAgave,
+		},
+		{
+			name:       "recipe for detected client",
+			version:    "0.503.20214",
+			recipe:     "firedancer-default",
+			wantClient: constants.ClientNameFiredancer,
+		},
+		{
+			name:    "recipe for another client",
+			version: "0.503.20214",
+			recipe:  "agave-default",
+			wantErr: true,
+		},
+		{
+			name:     "conflicting signals",
+			version:  "0.503.20214",
+			clientID: "Agave",
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var req rpc.JSONRPCRequest
+				_ = json.NewDecoder(r.Body).Decode(&req)
+				var result interface{}
+				switch req.Method {
+				case "getVersion":
+					result = map[string]interface{}{"solana-core": tt.version}
+				case "getIdentity":
+					result = map[string]interface{}{"identity": "identity-key"}
+				case "getClusterNodes":
+					result = []interface{}{map[string]interface{}{"pubkey": "identity-key", "version": tt.version, "clientId": tt.clientID}}
+				}
+				json.NewEncoder(w).Encode(rpc.JSONRPCResponse{JSONRPC: "2.0", ID: 1, Result: result})
+			}))
+			defer server.Close()
+
+			v := &Validator{
+				syncConfig: config.Sync{Recipe: tt.recipe},
+				rpcClient:  rpc.NewClient(server.URL),
+				logger:     log.WithPrefix("validator"),
+			}
+
+			client, err := v.detectClient()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("detectClient() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if client != tt.wantClient {
+				t.Errorf("detectClient() = %q, want %q", client, tt.wantClient)
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package validator
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/charmbracelet/log"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/restart"
+)
+
+// lookupRestartManifest fetches the cluster restart manifest when sync.cluster_restart is enabled, keeping it
+// for the current sync when published for the validator's cluster. Failing to fetch it fails the sync rather
+// than syncing to another version during a restart.
+func (v *Validator) lookupRestartManifest(ctx context.Context, syncLogger *log.Logger) (err error) {
+	restartConfig := v.syncConfig.ClusterRestart
+	if !restartConfig.Enabled {
+		return nil
+	}
+
+	manifest, err := restart.Fetch(ctx, restartConfig.ManifestURL, restartConfig.Timeout)
+	if err != nil {
+		return fmt.Errorf("failed to look up cluster restart manifest: %w", err)
+	}
+	if manifest == nil {
+		syncLogger.Debug("no cluster restart manifest published", "url", restartConfig.ManifestURL)
+		return nil
+	}
+	if !manifest.AppliesTo(v.State.Cluster) {
+		syncLogger.Info("cluster restart manifest published for another cluster - ignoring", "manifestCluster", manifest.Cluster)
+		return nil
+	}
+
+	v.restartManifest = manifest
+	syncLogger.Warn("cluster restart manifest published - targeting its version",
+		"version", manifest.Version,
+		"expectedShredVersion", manifest.ExpectedShredVersion,
+		"snapshotSlot", manifest.SnapshotSlot,
+		"executed", !v.restartPending(),
+	)
+	return nil
+}
+
+// restartPending returns true when a cluster restart manifest is published and its restart has not been
+// executed yet
+func (v *Validator) restartPending() bool {
+	return v.restartManifest != nil && v.stateStore.Get().ClusterRestart != v.restartManifest.Key()
+}
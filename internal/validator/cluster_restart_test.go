@@ -0,0 +1,159 @@
+package validator
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/log"
+	goversion "github.com/hashicorp/go-version"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/config"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/constants"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/report"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/restart"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/state"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/sync_commands"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/versiondiff"
+)
+
+func TestValidator_lookupRestartManifest(t *testing.T) {
+	tests := []struct {
+		name         string
+		disabled     bool
+		status       int
+		body         string
+		executed     string
+		wantManifest bool
+		wantPending  bool
+		wantErr      bool
+	}{
+		{
+			name:     "disabled",
+			disabled: true,
+		},
+		{
+			name:   "none published",
+			status: http.StatusNotFound,
+		},
+		{
+			name:   "published for another cluster",
+			status: http.StatusOK,
+			body:   `{"cluster":"mainnet-beta","version":"2.3.6","expected_shred_version":50093,"snapshot_slot":332000000}`,
+		},
+		{
+			name:         "published",
+			status:       http.StatusOK,
+			body:         `{"cluster":"testnet","version":"2.3.6","expected_shred_version":9065,"snapshot_slot":332000000}`,
+			wantManifest: true,
+			wantPending:  true,
+		},
+		{
+			name:         "published and executed",
+			status:       http.StatusOK,
+			body:         `{"version":"2.3.6","expected_shred_version":9065,"snapshot_slot":332000000}`,
+			executed:     "332000000:9065",
+			wantManifest: true,
+			wantPending:  false,
+		},
+		{
+			name:    "fetch failure",
+			status:  http.StatusBadGateway,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			stateStore, err := state.NewStore("")
+			if err != nil {
+				t.Fatalf("state.NewStore() error = %v", err)
+			}
+			_ = stateStore.Update(func(data *state.Data) {
+				data.ClusterRestart = tt.executed
+			})
+
+			v := &Validator{
+				State: State{Cluster: constants.ClusterNameTestnet},
+				syncConfig: config.Sync{ClusterRestart: config.ClusterRestart{
+					Enabled:     !tt.disabled,
+					ManifestURL: server.URL,
+					Timeout:     time.Second,
+				}},
+				stateStore: stateStore,
+				logger:     log.WithPrefix("validator"),
+			}
+
+			err = v.lookupRestartManifest(context.Background(), v.logger)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("lookupRestartManifest() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if (v.restartManifest != nil) != tt.wantManifest {
+				t.Errorf("restartManifest = %+v, want manifest %v", v.restartManifest, tt.wantManifest)
+			}
+			if pending := v.restartPending(); pending != tt.wantPending {
+				t.Errorf("restartPending() = %v, want %v", pending, tt.wantPending)
+			}
+		})
+	}
+}
+
+func TestValidator_syncToTarget_ClusterRestart(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Skipping on Windows")
+	}
+
+	markerFile := filepath.Join(t.TempDir(), "restart")
+	commands := []sync_commands.Command{
+		{
+			Name: "restart",
+			Cmd:  "sh",
+			Args: []string{"-c", "echo {{ .ClusterRestart }} {{ .RestartSnapshotSlot }} {{ .RestartExpectedShredVersion }} {{ .RestartExpectedBankHash }}" +
+				" {{ .ShredVersion }} {{ .ExpectedShredVersion }}{{ range .HardForks }} --hard-fork {{ . }}{{ end }} >> " + markerFile},
+		},
+	}
+	if err := commands[0].Parse(); err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	v := newSyncToTargetValidator(t, markerFile, true)
+	v.syncConfig.Commands = commands
+	// the slot trigger can't be reached on a halted cluster and must not be waited for
+	v.syncConfig.SlotTrigger = config.SlotTrigger{Enabled: true, Slot: 1 << 62, PollInterval: time.Millisecond}
+	v.hardForks = []uint64{331000000, 332000000}
+	v.restartManifest = &restart.Manifest{Version: "2.3.6", ExpectedShredVersion: 9065, SnapshotSlot: 332000000, ExpectedBankHash: "hash", HardForks: []uint64{332000000}}
+
+	sameVersion := versiondiff.VersionDiff{
+		From: goversion.Must(goversion.NewVersion("2.3.6")),
+		To:   goversion.Must(goversion.NewVersion("2.3.6")),
+	}
+	if err := v.syncToTarget(context.Background(), v.logger, sameVersion); err != nil {
+		t.Fatalf("syncToTarget() error = %v", err)
+	}
+
+	content, _ := os.ReadFile(markerFile)
+	if string(content) != "true 332000000 9065 hash 2405 9065 --hard-fork 331000000 --hard-fork 332000000\n" {
+		t.Errorf("restart command output = %q, want the manifest fields", string(content))
+	}
+
+	decision := v.LastDecision()
+	if decision.Outcome != report.OutcomeSynced || decision.Reason != "cluster restart at slot 332000000 (shred version 9065) v2.3.6 -> v2.3.6" {
+		t.Errorf("LastDecision() = %s (%s), want synced cluster restart", decision.Outcome, decision.Reason)
+	}
+	if executed := v.stateStore.Get().ClusterRestart; executed != "332000000:9065" {
+		t.Errorf("state ClusterRestart = %q, want 332000000:9065", executed)
+	}
+	if v.restartPending() {
+		t.Errorf("restartPending() = true after the restart executed")
+	}
+}
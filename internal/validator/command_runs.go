@@ -0,0 +1,50 @@
+package validator
+
+import (
+	"time"
+
+	"github.com/sol-strategies/solana-validator-version-sync/internal/state"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/sync_commands"
+)
+
+// commandRunHistorySize is the number of most recent command runs kept in the state store
+const commandRunHistorySize = 100
+
+// recordCommandRun records the resource usage of a command's most recent execution for the current sync and
+// appends it to the command run history kept in the state store, dropping the oldest runs beyond
+// commandRunHistorySize - commands that were skipped or did not start have no usage to record.
+// Failing to persist the history is logged rather than failing the sync.
+func (v *Validator) recordCommandRun(cmd *sync_commands.Command, templateData sync_commands.CommandTemplateData) {
+	usage := cmd.LastUsage()
+	if usage == nil {
+		return
+	}
+
+	commandRun := state.CommandRun{
+		Time:             time.Now().UTC(),
+		Command:          cmd.Name,
+		Phase:            templateData.SyncPhase,
+		VersionTo:        templateData.VersionTo,
+		WallSeconds:      usage.WallTime.Seconds(),
+		UserCPUSeconds:   usage.UserCPUTime.Seconds(),
+		SystemCPUSeconds: usage.SystemCPUTime.Seconds(),
+		MaxRSSBytes:      usage.MaxRSSBytes,
+		ExitCode:         usage.ExitCode,
+	}
+	v.lastCommandRuns = append(v.lastCommandRuns, commandRun)
+
+	err := v.stateStore.Update(func(data *state.Data) {
+		data.CommandRuns = append(data.CommandRuns, commandRun)
+		if len(data.CommandRuns) > commandRunHistorySize {
+			data.CommandRuns = data.CommandRuns[len(data.CommandRuns)-commandRunHistorySize:]
+		}
+	})
+	if err != nil {
+		v.logger.Warn("failed to record command run in state", "command", cmd.Name, "error", err)
+	}
+}
+
+// LastCommandRuns returns the command runs of the most recent SyncVersion call, in execution order
+func (v *Validator) LastCommandRuns() []state.CommandRun {
+	return v.lastCommandRuns
+}
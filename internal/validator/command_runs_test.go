@@ -0,0 +1,98 @@
+package validator
+
+import (
+	"runtime"
+	"testing"
+
+	"github.com/charmbracelet/log"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/state"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/sync_commands"
+)
+
+func TestValidator_recordCommandRun(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Skipping on Windows")
+	}
+
+	tests := []struct {
+		name            string
+		command         sync_commands.Command
+		execute         bool
+		previousRuns    int
+		wantLastRuns    int
+		wantHistoryRuns int
+	}{
+		{
+			name:            "executed command is recorded",
+			command:         sync_commands.Command{Name: "build", Cmd: "sh", Args: []string{"-c", "exit 0"}},
+			execute:         true,
+			previousRuns:    2,
+			wantLastRuns:    1,
+			wantHistoryRuns: 3,
+		},
+		{
+			name:            "history drops the oldest runs",
+			command:         sync_commands.Command{Name: "build", Cmd: "sh", Args: []string{"-c", "exit 0"}},
+			execute:         true,
+			previousRuns:    commandRunHistorySize,
+			wantLastRuns:    1,
+			wantHistoryRuns: commandRunHistorySize,
+		},
+		{
+			name:            "command that did not run is not recorded",
+			command:         sync_commands.Command{Name: "build", Cmd: "sh", Args: []string{"-c", "exit 0"}},
+			execute:         false,
+			previousRuns:    2,
+			wantLastRuns:    0,
+			wantHistoryRuns: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store, err := state.NewStore("")
+			if err != nil {
+				t.Fatalf("NewStore() error = %v", err)
+			}
+			store.Update(func(data *state.Data) {
+				for i := 0; i < tt.previousRuns; i++ {
+					data.CommandRuns = append(data.CommandRuns, state.CommandRun{Command: "previous"})
+				}
+			})
+
+			v := &Validator{stateStore: store, logger: log.WithPrefix("validator")}
+
+			if err := tt.command.Parse(); err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			templateData := sync_commands.CommandTemplateData{SyncPhase: sync_commands.PhasePrepare, VersionTo: "3.0.10"}
+			if tt.execute {
+				if err := tt.command.ExecuteWithData(templateData); err != nil {
+					t.Fatalf("ExecuteWithData() error = %v", err)
+				}
+			}
+
+			v.recordCommandRun(&tt.command, templateData)
+
+			lastRuns := v.LastCommandRuns()
+			if len(lastRuns) != tt.wantLastRuns {
+				t.Fatalf("LastCommandRuns() = %d runs, want %d", len(lastRuns), tt.wantLastRuns)
+			}
+			history := store.Get().CommandRuns
+			if len(history) != tt.wantHistoryRuns {
+				t.Fatalf("state command runs = %d, want %d", len(history), tt.wantHistoryRuns)
+			}
+			if tt.wantLastRuns == 0 {
+				return
+			}
+
+			latest := history[len(history)-1]
+			if latest != lastRuns[0] {
+				t.Errorf("latest state command run = %+v, want %+v", latest, lastRuns[0])
+			}
+			if latest.Command != "build" || latest.Phase != sync_commands.PhasePrepare || latest.VersionTo != "3.0.10" {
+				t.Errorf("latest state command run = %+v, want build prepare run to 3.0.10", latest)
+			}
+		})
+	}
+}
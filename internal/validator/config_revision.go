@@ -0,0 +1,77 @@
+package validator
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/charmbracelet/log"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/state"
+)
+
+// configRevision represents the configuration revision last applied and the target one
+type configRevision struct {
+	From string
+	To   string
+}
+
+// changed returns true when the target configuration revision differs from the one last applied
+func (c configRevision) changed() bool {
+	return c.From != c.To
+}
+
+// lookupConfigRevision looks up the target configuration revision when sync.config_revision is enabled. Without a
+// revision recorded as applied in state, the target is recorded as applied rather than re-applying whatever the
+// validator already runs.
+func (v *Validator) lookupConfigRevision(ctx context.Context, syncLogger *log.Logger) (err error) {
+	if !v.syncConfig.ConfigRevision.Enabled {
+		return nil
+	}
+
+	target, err := v.targetConfigRevision(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to look up target configuration revision: %w", err)
+	}
+
+	applied := v.stateStore.Get().ConfigRevision
+	if applied == "" {
+		syncLogger.Info("no configuration revision recorded as applied - recording target as applied", "configRevision", target)
+		err = v.stateStore.Update(func(data *state.Data) {
+			data.ConfigRevision = target
+		})
+		if err != nil {
+			return fmt.Errorf("failed to record configuration revision in state: %w", err)
+		}
+		applied = target
+	}
+
+	v.configRevision = configRevision{From: applied, To: target}
+	syncLogger.Debug("configuration revision", "from", v.configRevision.From, "to", v.configRevision.To)
+	return nil
+}
+
+// targetConfigRevision reads the target configuration revision from the config-version file, or resolves the
+// ops repo's git ref to its commit SHA
+func (v *Validator) targetConfigRevision(ctx context.Context) (revision string, err error) {
+	revisionConfig := v.syncConfig.ConfigRevision
+
+	if revisionConfig.File != "" {
+		content, err := os.ReadFile(revisionConfig.File)
+		if err != nil {
+			return "", err
+		}
+		revision = strings.TrimSpace(string(content))
+		if revision == "" {
+			return "", fmt.Errorf("config-version file %s is empty", revisionConfig.File)
+		}
+		return revision, nil
+	}
+
+	output, err := exec.CommandContext(ctx, "git", "-C", revisionConfig.GitRepo, "rev-parse", "--verify", "--quiet", revisionConfig.GitRef+"^{commit}").Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve %s in git repo %s: %w", revisionConfig.GitRef, revisionConfig.GitRepo, err)
+	}
+	return strings.TrimSpace(string(output)), nil
+}
@@ -0,0 +1,177 @@
+package validator
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/log"
+	goversion "github.com/hashicorp/go-version"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/config"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/report"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/state"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/versiondiff"
+)
+
+func TestValidator_lookupConfigRevision(t *testing.T) {
+	revisionFile := filepath.Join(t.TempDir(), "config-version")
+
+	tests := []struct {
+		name        string
+		fileContent string
+		applied     string
+		disabled    bool
+		want        configRevision
+		wantApplied string
+		wantErr     bool
+	}{
+		{
+			name:     "disabled",
+			disabled: true,
+			want:     configRevision{},
+		},
+		{
+			name:        "nothing applied records target as applied",
+			fileContent: "r41\n",
+			want:        configRevision{From: "r41", To: "r41"},
+			wantApplied: "r41",
+		},
+		{
+			name:        "target applied",
+			fileContent: "r41",
+			applied:     "r41",
+			want:        configRevision{From: "r41", To: "r41"},
+			wantApplied: "r41",
+		},
+		{
+			name:        "new target",
+			fileContent: " r42 \n",
+			applied:     "r41",
+			want:        configRevision{From: "r41", To: "r42"},
+			wantApplied: "r41",
+		},
+		{
+			name:        "empty file",
+			fileContent: "\n",
+			applied:     "r41",
+			wantErr:     true,
+			wantApplied: "r41",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := os.WriteFile(revisionFile, []byte(tt.fileContent), 0o600); err != nil {
+				t.Fatalf("WriteFile() error = %v", err)
+			}
+			stateStore, err := state.NewStore("")
+			if err != nil {
+				t.Fatalf("state.NewStore() error = %v", err)
+			}
+			_ = stateStore.Update(func(data *state.Data) {
+				data.ConfigRevision = tt.applied
+			})
+
+			v := &Validator{
+				syncConfig: config.Sync{ConfigRevision: config.ConfigRevision{Enabled: !tt.disabled, File: revisionFile}},
+				stateStore: stateStore,
+				logger:     log.WithPrefix("validator"),
+			}
+
+			err = v.lookupConfigRevision(context.Background(), v.logger)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("lookupConfigRevision() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && v.configRevision != tt.want {
+				t.Errorf("configRevision = %+v, want %+v", v.configRevision, tt.want)
+			}
+			if applied := stateStore.Get().ConfigRevision; applied != tt.wantApplied {
+				t.Errorf("state ConfigRevision = %q, want %q", applied, tt.wantApplied)
+			}
+		})
+	}
+}
+
+func TestValidator_targetConfigRevision_GitRepo(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+
+	repo := t.TempDir()
+	git := func(args ...string) string {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-C", repo, "-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+		output, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %v error = %v: %s", args, err, output)
+		}
+		return strings.TrimSpace(string(output))
+	}
+	git("init", "--quiet")
+	git("commit", "--quiet", "--allow-empty", "-m", "validator flags")
+	head := git("rev-parse", "HEAD")
+
+	tests := []struct {
+		name    string
+		ref     string
+		want    string
+		wantErr bool
+	}{
+		{name: "head", ref: "HEAD", want: head},
+		{name: "unknown ref", ref: "does-not-exist", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := &Validator{
+				syncConfig: config.Sync{ConfigRevision: config.ConfigRevision{Enabled: true, GitRepo: repo, GitRef: tt.ref}},
+			}
+			got, err := v.targetConfigRevision(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("targetConfigRevision() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("targetConfigRevision() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidator_syncToTarget_ConfigOnly(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Skipping on Windows")
+	}
+
+	markerFile := filepath.Join(t.TempDir(), "phases")
+	v := newSyncToTargetValidator(t, markerFile, true)
+	v.syncConfig.ConfigRevision = config.ConfigRevision{Enabled: true, File: "config-version"}
+	v.syncConfig.ReferenceValidator.Identity = "reference-identity-without-rpc"
+	v.configRevision = configRevision{From: "r41", To: "r42"}
+
+	sameVersion := versiondiff.VersionDiff{
+		From: goversion.Must(goversion.NewVersion("2.3.6")),
+		To:   goversion.Must(goversion.NewVersion("2.3.6")),
+	}
+	if err := v.syncToTarget(context.Background(), v.logger, sameVersion); err != nil {
+		t.Fatalf("syncToTarget() error = %v", err)
+	}
+
+	content, _ := os.ReadFile(markerFile)
+	if string(content) != "prepare\nactivate\n" {
+		t.Errorf("executed phases = %q, want prepare then activate", string(content))
+	}
+
+	decision := v.LastDecision()
+	if decision.Outcome != report.OutcomeSynced || decision.Reason != "configuration revision r41 -> r42 on v2.3.6" {
+		t.Errorf("LastDecision() = %s (%s), want synced configuration revision", decision.Outcome, decision.Reason)
+	}
+
+	data := v.stateStore.Get()
+	if data.ConfigRevision != "r42" || data.Prepared != nil {
+		t.Errorf("state ConfigRevision = %q, Prepared = %+v, want r42 applied and nothing prepared", data.ConfigRevision, data.Prepared)
+	}
+}
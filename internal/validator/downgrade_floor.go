@@ -0,0 +1,43 @@
+package validator
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/go-version"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/report"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/versiondiff"
+)
+
+// setDowngradeFloor sets the downgrade floor from validator.downgrade_floor, leaving it nil when not set
+func (v *Validator) setDowngradeFloor() (err error) {
+	if v.cfg.DowngradeFloor == "" {
+		return nil
+	}
+
+	v.downgradeFloor, err = version.NewVersion(v.cfg.DowngradeFloor)
+	if err != nil {
+		return fmt.Errorf("failed to parse validator.downgrade_floor: %w", err)
+	}
+
+	v.logger.Debug("set downgrade floor", "floor", v.downgradeFloor.String())
+
+	return nil
+}
+
+// checkDowngradeFloor refuses downgrades to a target below the downgrade floor - upgrades are always allowed,
+// even while the running version is still below the floor
+func (v *Validator) checkDowngradeFloor(versionDiff versiondiff.VersionDiff) error {
+	if v.downgradeFloor == nil || !versionDiff.IsDowngrade() {
+		return nil
+	}
+
+	if versionDiff.To.Core().LessThan(v.downgradeFloor) {
+		return report.WithReasonCode(report.ReasonCodeBelowDowngradeFloor,
+			fmt.Errorf("downgrade target version %s is below validator.downgrade_floor %s - refusing to downgrade from %s",
+				versionDiff.To.Core().String(), v.downgradeFloor.String(), versionDiff.From.Core().String(),
+			),
+		)
+	}
+
+	return nil
+}
@@ -0,0 +1,55 @@
+package validator
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/charmbracelet/log"
+	goversion "github.com/hashicorp/go-version"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/config"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/report"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/versiondiff"
+)
+
+func TestValidator_checkDowngradeFloor(t *testing.T) {
+	tests := []struct {
+		name    string
+		floor   string
+		from    string
+		to      string
+		wantErr bool
+	}{
+		{name: "no floor", from: "2.3.6", to: "2.2.0"},
+		{name: "downgrade above floor", floor: "2.3.0", from: "2.3.6", to: "2.3.5"},
+		{name: "downgrade to floor", floor: "2.3.5", from: "2.3.6", to: "2.3.5"},
+		{name: "downgrade below floor", floor: "2.3.5", from: "2.3.6", to: "2.3.4", wantErr: true},
+		{name: "prerelease downgrade below floor", floor: "3.0.0", from: "3.0.1", to: "2.3.9-jito", wantErr: true},
+		{name: "upgrade below floor", floor: "2.3.5", from: "2.3.0", to: "2.3.4"},
+		{name: "same version below floor", floor: "2.3.5", from: "2.3.4", to: "2.3.4"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := &Validator{
+				cfg:    config.Validator{DowngradeFloor: tt.floor},
+				logger: log.WithPrefix("validator"),
+			}
+			if err := v.setDowngradeFloor(); err != nil {
+				t.Fatalf("setDowngradeFloor() error = %v", err)
+			}
+
+			err := v.checkDowngradeFloor(versiondiff.VersionDiff{
+				From: goversion.Must(goversion.NewVersion(tt.from)),
+				To:   goversion.Must(goversion.NewVersion(tt.to)),
+			})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("checkDowngradeFloor() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			var reasonErr *report.ReasonError
+			if tt.wantErr && (!errors.As(err, &reasonErr) || reasonErr.Code != report.ReasonCodeBelowDowngradeFloor) {
+				t.Errorf("checkDowngradeFloor() error = %v, want reason code %s", err, report.ReasonCodeBelowDowngradeFloor)
+			}
+		})
+	}
+}
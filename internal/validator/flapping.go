@@ -0,0 +1,71 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/sol-strategies/solana-validator-version-sync/internal/state"
+)
+
+// HealthStatusUnhealthy is the health recorded when the validator's health check fails
+const HealthStatusUnhealthy = "unhealthy"
+
+// errUnhealthy wraps a failed health check so the observation can still be recorded before the sync fails
+var errUnhealthy = errors.New("validator unhealthy")
+
+// recordObservation appends the validator's current health and role to the observation history kept in the
+// state store, dropping the oldest observations beyond sync.flap_detection.history_size
+func (v *Validator) recordObservation() error {
+	historySize := v.syncConfig.FlapDetection.HistorySize
+	if historySize < 1 {
+		return nil
+	}
+
+	observation := state.Observation{
+		Time:   time.Now().UTC(),
+		Role:   v.Role(),
+		Health: v.State.HealthStatus,
+	}
+
+	return v.stateStore.Update(func(data *state.Data) {
+		data.Observations = append(data.Observations, observation)
+		if len(data.Observations) > historySize {
+			data.Observations = data.Observations[len(data.Observations)-historySize:]
+		}
+	})
+}
+
+// flapping reports whether the validator's role or health changed more often across the observation history
+// than sync.flap_detection allows, along with the reason. Always false when flap detection is disabled.
+func (v *Validator) flapping() (reason string, flapping bool) {
+	flapDetection := v.syncConfig.FlapDetection
+	if !flapDetection.Enabled {
+		return "", false
+	}
+
+	observations := v.stateStore.Get().Observations
+	roleChanges, healthChanges := 0, 0
+	for i := 1; i < len(observations); i++ {
+		if observations[i].Role != observations[i-1].Role {
+			roleChanges++
+		}
+		if observations[i].Health != observations[i-1].Health {
+			healthChanges++
+		}
+	}
+
+	reasons := []string{}
+	if roleChanges > flapDetection.MaxRoleChanges {
+		reasons = append(reasons, fmt.Sprintf("role changed %d times (max %d)", roleChanges, flapDetection.MaxRoleChanges))
+	}
+	if healthChanges > flapDetection.MaxHealthChanges {
+		reasons = append(reasons, fmt.Sprintf("health changed %d times (max %d)", healthChanges, flapDetection.MaxHealthChanges))
+	}
+	if len(reasons) == 0 {
+		return "", false
+	}
+
+	return fmt.Sprintf("%s in the last %d observations", strings.Join(reasons, " and "), len(observations)), true
+}
@@ -0,0 +1,167 @@
+package validator
+
+import (
+	"testing"
+
+	"github.com/sol-strategies/solana-validator-version-sync/internal/config"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/state"
+)
+
+func TestValidator_recordObservation(t *testing.T) {
+	store, err := state.NewStore("")
+	if err != nil {
+		t.Fatalf("NewStore() error = %v", err)
+	}
+
+	v := &Validator{
+		ActiveIdentityPublicKey:  "active-key",
+		PassiveIdentityPublicKey: "passive-key",
+		syncConfig:               config.Sync{FlapDetection: config.FlapDetection{HistorySize: 3}},
+		stateStore:               store,
+	}
+
+	observed := []struct {
+		identity string
+		health   string
+	}{
+		{identity: "active-key", health: "ok"},
+		{identity: "passive-key", health: "ok"},
+		{identity: "passive-key", health: HealthStatusUnhealthy},
+		{identity: "unknown-key", health: "ok"},
+	}
+	for _, o := range observed {
+		v.State.IdentityPublicKey = o.identity
+		v.State.HealthStatus = o.health
+		if err := v.recordObservation(); err != nil {
+			t.Fatalf("recordObservation() error = %v", err)
+		}
+	}
+
+	observations := store.Get().Observations
+	if len(observations) != 3 {
+		t.Fatalf("len(Observations) = %d, want 3 (oldest dropped)", len(observations))
+	}
+
+	wantRoles := []string{RolePassive, RolePassive, RoleUnknown}
+	wantHealth := []string{"ok", HealthStatusUnhealthy, "ok"}
+	for i, observation := range observations {
+		if observation.Role != wantRoles[i] || observation.Health != wantHealth[i] {
+			t.Errorf("Observations[%d] = %s/%s, want %s/%s", i, observation.Role, observation.Health, wantRoles[i], wantHealth[i])
+		}
+		if observation.Time.IsZero() {
+			t.Errorf("Observations[%d].Time is zero", i)
+		}
+	}
+}
+
+func TestValidator_flapping(t *testing.T) {
+	tests := []struct {
+		name         string
+		enabled      bool
+		observations []state.Observation
+		wantFlapping bool
+		wantReason   string
+	}{
+		{
+			name:    "disabled",
+			enabled: false,
+			observations: []state.Observation{
+				{Role: RoleActive, Health: "ok"},
+				{Role: RolePassive, Health: "ok"},
+				{Role: RoleActive, Health: "ok"},
+				{Role: RolePassive, Health: "ok"},
+			},
+			wantFlapping: false,
+		},
+		{
+			name:    "stable",
+			enabled: true,
+			observations: []state.Observation{
+				{Role: RolePassive, Health: "ok"},
+				{Role: RolePassive, Health: "ok"},
+				{Role: RolePassive, Health: "ok"},
+			},
+			wantFlapping: false,
+		},
+		{
+			name:    "single failover within limits",
+			enabled: true,
+			observations: []state.Observation{
+				{Role: RoleActive, Health: "ok"},
+				{Role: RolePassive, Health: "ok"},
+				{Role: RolePassive, Health: "ok"},
+			},
+			wantFlapping: false,
+		},
+		{
+			name:    "role flipping",
+			enabled: true,
+			observations: []state.Observation{
+				{Role: RoleActive, Health: "ok"},
+				{Role: RolePassive, Health: "ok"},
+				{Role: RoleActive, Health: "ok"},
+				{Role: RolePassive, Health: "ok"},
+			},
+			wantFlapping: true,
+			wantReason:   "role changed 3 times (max 2) in the last 4 observations",
+		},
+		{
+			name:    "health oscillating",
+			enabled: true,
+			observations: []state.Observation{
+				{Role: RolePassive, Health: "ok"},
+				{Role: RolePassive, Health: HealthStatusUnhealthy},
+				{Role: RolePassive, Health: "ok"},
+				{Role: RolePassive, Health: HealthStatusUnhealthy},
+				{Role: RolePassive, Health: "ok"},
+				{Role: RolePassive, Health: HealthStatusUnhealthy},
+			},
+			wantFlapping: true,
+			wantReason:   "health changed 5 times (max 4) in the last 6 observations",
+		},
+		{
+			name:    "role and health flapping",
+			enabled: true,
+			observations: []state.Observation{
+				{Role: RoleActive, Health: "ok"},
+				{Role: RolePassive, Health: HealthStatusUnhealthy},
+				{Role: RoleActive, Health: "ok"},
+				{Role: RolePassive, Health: HealthStatusUnhealthy},
+				{Role: RoleActive, Health: "ok"},
+				{Role: RolePassive, Health: HealthStatusUnhealthy},
+			},
+			wantFlapping: true,
+			wantReason:   "role changed 5 times (max 2) and health changed 5 times (max 4) in the last 6 observations",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store, err := state.NewStore("")
+			if err != nil {
+				t.Fatalf("NewStore() error = %v", err)
+			}
+			store.Update(func(data *state.Data) {
+				data.Observations = tt.observations
+			})
+
+			v := &Validator{
+				syncConfig: config.Sync{FlapDetection: config.FlapDetection{
+					Enabled:          tt.enabled,
+					HistorySize:      10,
+					MaxRoleChanges:   2,
+					MaxHealthChanges: 4,
+				}},
+				stateStore: store,
+			}
+
+			reason, flapping := v.flapping()
+			if flapping != tt.wantFlapping {
+				t.Errorf("flapping() flapping = %v, want %v", flapping, tt.wantFlapping)
+			}
+			if reason != tt.wantReason {
+				t.Errorf("flapping() reason = %q, want %q", reason, tt.wantReason)
+			}
+		})
+	}
+}
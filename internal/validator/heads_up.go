@@ -0,0 +1,86 @@
+package validator
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/notify"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/sync_commands"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/versiondiff"
+)
+
+// sendHeadsUp notifies once per target version when sync.heads_up is enabled, as soon as the target is detected -
+// ahead of preparing it and of any gate holding activation. Failures are logged and retried on the next sync
+// rather than failing this one.
+func (v *Validator) sendHeadsUp(syncLogger *log.Logger, versionDiff versiondiff.VersionDiff, templateData sync_commands.CommandTemplateData) {
+	if !v.syncConfig.HeadsUp.Enabled || !v.notifier.Enabled() {
+		return
+	}
+
+	now := time.Now().UTC()
+	if !v.notificationDue(notify.KindHeadsUp, templateData.VersionToTag, 0, now) {
+		syncLogger.Debug("heads-up already sent for target", "versionToTag", templateData.VersionToTag)
+		return
+	}
+
+	releaseNotes, releaseURL, err := v.githubClient.GetReleaseNotes(versionDiff.To)
+	if err != nil {
+		syncLogger.Warn("failed to get release notes - sending heads-up without them", "error", err)
+	}
+
+	v.sendNotification(syncLogger, v.headsUpEvent(versionDiff, templateData, releaseNotes, releaseURL, now), templateData.VersionToTag)
+}
+
+// headsUpEvent creates the heads-up notification for a detected target - the projected apply time is the earliest
+// activation could happen, after preparing the target, if no gate holds it
+func (v *Validator) headsUpEvent(versionDiff versiondiff.VersionDiff, templateData sync_commands.CommandTemplateData, releaseNotes string, releaseURL string, now time.Time) notify.Event {
+	projectedApplyAt := now.Add(v.estimatedPrepareDuration(templateData.VersionToTag))
+
+	text := fmt.Sprintf("heads-up: %s %s on %s (%s) will %s v%s -> v%s - projected to apply %s at the earliest",
+		v.cfg.Client, v.State.IdentityPublicKey, v.State.Cluster, v.Role(), versionDiff.Direction(),
+		templateData.VersionFrom, templateData.VersionTo, v.notifier.FormatTime(projectedApplyAt, now),
+	)
+	if releaseURL != "" {
+		text += "\n" + releaseURL
+	}
+
+	return notify.Event{
+		Kind:              notify.KindHeadsUp,
+		Time:              now,
+		Cluster:           v.State.Cluster,
+		Client:            v.cfg.Client,
+		IdentityPublicKey: v.State.IdentityPublicKey,
+		VersionFrom:       templateData.VersionFrom,
+		VersionTo:         templateData.VersionTo,
+		VersionToTag:      templateData.VersionToTag,
+		ReleaseURL:        releaseURL,
+		ReleaseNotes:      notify.Summarize(releaseNotes, v.syncConfig.HeadsUp.ReleaseNotesMaxLength),
+		ProjectedApplyAt:  &projectedApplyAt,
+		SFDPStage:         v.sfdpParticipantStage(),
+		Text:              text,
+	}
+}
+
+// estimatedPrepareDuration estimates how long preparing the target takes from the last recorded run of each
+// enabled prepare command - 0 when the target is already prepared or no runs were recorded
+func (v *Validator) estimatedPrepareDuration(versionToTag string) (estimate time.Duration) {
+	data := v.stateStore.Get()
+	if data.Prepared != nil && data.Prepared.Tag == versionToTag {
+		return 0
+	}
+
+	for _, cmd := range v.syncConfig.Commands {
+		if cmd.Phase != sync_commands.PhasePrepare || cmd.Disabled {
+			continue
+		}
+		for i := len(data.CommandRuns) - 1; i >= 0; i-- {
+			commandRun := data.CommandRuns[i]
+			if commandRun.Command == cmd.Name && commandRun.Phase == sync_commands.PhasePrepare {
+				estimate += time.Duration(commandRun.WallSeconds * float64(time.Second))
+				break
+			}
+		}
+	}
+	return estimate
+}
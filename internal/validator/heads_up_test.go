@@ -0,0 +1,147 @@
+package validator
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/log"
+	goversion "github.com/hashicorp/go-version"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/config"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/constants"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/github"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/notify"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/state"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/sync_commands"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/versiondiff"
+)
+
+func TestValidator_sendHeadsUp(t *testing.T) {
+	status := http.StatusOK
+	var received []notify.Event
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var event notify.Event
+		if err := json.NewDecoder(r.Body).Decode(&event); err != nil {
+			t.Errorf("failed to decode event: %v", err)
+		}
+		received = append(received, event)
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	// rakurai publishes tags only, so no release notes are looked up from the API
+	githubClient, err := github.NewClient(github.Options{Cluster: constants.ClusterNameMainnetBeta, Client: constants.ClientNameRakurai})
+	if err != nil {
+		t.Fatalf("github.NewClient() error = %v", err)
+	}
+	stateStore, err := state.NewStore("")
+	if err != nil {
+		t.Fatalf("state.NewStore() error = %v", err)
+	}
+
+	v := &Validator{
+		State:        State{Cluster: constants.ClusterNameMainnetBeta, IdentityPublicKey: "active-key"},
+		cfg:          config.Validator{Client: constants.ClientNameRakurai},
+		syncConfig:   config.Sync{HeadsUp: config.HeadsUp{Enabled: true}},
+		githubClient: githubClient,
+		stateStore:   stateStore,
+		notifier:     notify.New(notify.Options{URL: server.URL, Timeout: time.Second}),
+		logger:       log.WithPrefix("validator"),
+	}
+
+	sendHeadsUp := func(versionTo string, wantReceived int, reason string) {
+		t.Helper()
+		versionDiff := versiondiff.VersionDiff{
+			From: goversion.Must(goversion.NewVersion("3.0.9")),
+			To:   goversion.Must(goversion.NewVersion(versionTo)),
+		}
+		templateData := sync_commands.CommandTemplateData{VersionFrom: "3.0.9", VersionTo: versionTo, VersionToTag: "v" + versionTo}
+		v.sendHeadsUp(v.logger, versionDiff, templateData)
+		if len(received) != wantReceived {
+			t.Fatalf("received %d heads-ups, want %d %s", len(received), wantReceived, reason)
+		}
+	}
+
+	sendHeadsUp("3.0.10", 1, "for a new target")
+	if event := received[0]; event.Kind != notify.KindHeadsUp || event.VersionToTag != "v3.0.10" || event.ProjectedApplyAt == nil {
+		t.Errorf("heads-up = %+v, want heads_up to v3.0.10 with a projected apply time", event)
+	}
+	if !strings.Contains(received[0].Text, "upgrade v3.0.9 -> v3.0.10") {
+		t.Errorf("heads-up text = %q, want it to describe the upgrade", received[0].Text)
+	}
+	if !strings.Contains(received[0].Text, "projected to apply now at ") {
+		t.Errorf("heads-up text = %q, want the projected apply time relative to now", received[0].Text)
+	}
+
+	sendHeadsUp("3.0.10", 1, "once already sent for the target")
+
+	status = http.StatusInternalServerError
+	sendHeadsUp("3.0.11", 2, "for the next target")
+	status = http.StatusOK
+	sendHeadsUp("3.0.11", 3, "as failed heads-ups are retried")
+	sendHeadsUp("3.0.11", 3, "once the retry succeeded")
+
+	v.syncConfig.HeadsUp.Enabled = false
+	sendHeadsUp("3.0.12", 3, "when disabled")
+}
+
+func TestValidator_estimatedPrepareDuration(t *testing.T) {
+	commands := []sync_commands.Command{
+		{Name: "download", Phase: sync_commands.PhasePrepare},
+		{Name: "build", Phase: sync_commands.PhasePrepare},
+		{Name: "verify", Phase: sync_commands.PhasePrepare, Disabled: true},
+		{Name: "restart", Phase: sync_commands.PhaseActivate},
+	}
+
+	tests := []struct {
+		name     string
+		data     state.Data
+		tag      string
+		expected time.Duration
+	}{
+		{
+			name:     "no recorded runs",
+			tag:      "v3.0.10",
+			expected: 0,
+		},
+		{
+			name: "last run of each enabled prepare command",
+			data: state.Data{CommandRuns: []state.CommandRun{
+				{Command: "build", Phase: sync_commands.PhasePrepare, WallSeconds: 3000},
+				{Command: "download", Phase: sync_commands.PhasePrepare, WallSeconds: 60},
+				{Command: "build", Phase: sync_commands.PhasePrepare, WallSeconds: 2400},
+				{Command: "verify", Phase: sync_commands.PhasePrepare, WallSeconds: 600},
+				{Command: "restart", Phase: sync_commands.PhaseActivate, WallSeconds: 30},
+			}},
+			tag:      "v3.0.10",
+			expected: 41 * time.Minute,
+		},
+		{
+			name: "already prepared",
+			data: state.Data{
+				Prepared:    &state.PreparedTarget{Tag: "v3.0.10"},
+				CommandRuns: []state.CommandRun{{Command: "build", Phase: sync_commands.PhasePrepare, WallSeconds: 2400}},
+			},
+			tag:      "v3.0.10",
+			expected: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stateStore, err := state.NewStore("")
+			if err != nil {
+				t.Fatalf("state.NewStore() error = %v", err)
+			}
+			stateStore.Update(func(data *state.Data) { *data = tt.data })
+
+			v := &Validator{syncConfig: config.Sync{Commands: commands}, stateStore: stateStore}
+			if got := v.estimatedPrepareDuration(tt.tag); got != tt.expected {
+				t.Errorf("estimatedPrepareDuration() = %s, want %s", got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package validator
+
+import (
+	"github.com/sol-strategies/solana-validator-version-sync/internal/rpc"
+)
+
+const (
+	// identityRolesOK means the active identity is the vote account's validator identity
+	identityRolesOK = "ok"
+	// identityRolesSwapped means the passive identity is the vote account's validator identity and the active one isn't
+	identityRolesSwapped = "swapped"
+	// identityRolesUnstaked means neither identity is a vote account's validator identity
+	identityRolesUnstaked = "unstaked"
+)
+
+// checkIdentityRoles warns when the configured active and passive identities look swapped relative to what's
+// staked on-chain - i.e. the passive identity is a vote account's validator identity. Only checked once per
+// validator as identities don't change, failures to check are logged and never fail the sync.
+func (v *Validator) checkIdentityRoles() {
+	if v.identityRolesChecked {
+		return
+	}
+
+	voteAccounts, err := v.rpcClient.GetVoteAccounts()
+	if err != nil {
+		v.logger.Warn("failed to check identities against vote accounts - will retry next sync", "error", err)
+		return
+	}
+	v.identityRolesChecked = true
+
+	switch identityRoles(voteAccounts.All(), v.ActiveIdentityPublicKey, v.PassiveIdentityPublicKey) {
+	case identityRolesSwapped:
+		v.logger.Warn("passive identity is the staked vote account's validator identity - validator.identities.active and validator.identities.passive look swapped",
+			"activePubkey", v.ActiveIdentityPublicKey,
+			"passivePubkey", v.PassiveIdentityPublicKey,
+		)
+	case identityRolesUnstaked:
+		v.logger.Warn("neither the active nor the passive identity is a vote account's validator identity",
+			"activePubkey", v.ActiveIdentityPublicKey,
+			"passivePubkey", v.PassiveIdentityPublicKey,
+		)
+	default:
+		v.logger.Debug("active identity is the vote account's validator identity", "activePubkey", v.ActiveIdentityPublicKey)
+	}
+}
+
+// identityRoles classifies the active and passive identities by which one vote accounts name as their
+// validator identity - one with activated stake takes precedence over one without
+func identityRoles(voteAccounts []rpc.VoteAccount, activeIdentityPublicKey string, passiveIdentityPublicKey string) string {
+	var activeStake, passiveStake uint64
+	activeVotes, passiveVotes := false, false
+	for _, voteAccount := range voteAccounts {
+		switch voteAccount.NodePubkey {
+		case activeIdentityPublicKey:
+			activeVotes = true
+			activeStake += voteAccount.ActivatedStake
+		case passiveIdentityPublicKey:
+			passiveVotes = true
+			passiveStake += voteAccount.ActivatedStake
+		}
+	}
+
+	switch {
+	case !activeVotes && !passiveVotes:
+		return identityRolesUnstaked
+	case passiveVotes && (!activeVotes || passiveStake > activeStake):
+		return identityRolesSwapped
+	default:
+		return identityRolesOK
+	}
+}
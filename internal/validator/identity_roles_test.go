@@ -0,0 +1,126 @@
+package validator
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/charmbracelet/log"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/rpc"
+)
+
+func TestIdentityRoles(t *testing.T) {
+	tests := []struct {
+		name         string
+		voteAccounts []rpc.VoteAccount
+		want         string
+	}{
+		{
+			name: "active identity staked",
+			voteAccounts: []rpc.VoteAccount{
+				{VotePubkey: "vote-key", NodePubkey: "active-key", ActivatedStake: 100},
+				{VotePubkey: "other-vote-key", NodePubkey: "other-key", ActivatedStake: 50},
+			},
+			want: identityRolesOK,
+		},
+		{
+			name: "passive identity staked",
+			voteAccounts: []rpc.VoteAccount{
+				{VotePubkey: "vote-key", NodePubkey: "passive-key", ActivatedStake: 100},
+			},
+			want: identityRolesSwapped,
+		},
+		{
+			name: "passive identity has more stake",
+			voteAccounts: []rpc.VoteAccount{
+				{VotePubkey: "old-vote-key", NodePubkey: "active-key", ActivatedStake: 0},
+				{VotePubkey: "vote-key", NodePubkey: "passive-key", ActivatedStake: 100},
+			},
+			want: identityRolesSwapped,
+		},
+		{
+			name: "both unstaked vote accounts",
+			voteAccounts: []rpc.VoteAccount{
+				{VotePubkey: "vote-key", NodePubkey: "active-key", ActivatedStake: 0},
+				{VotePubkey: "other-vote-key", NodePubkey: "passive-key", ActivatedStake: 0},
+			},
+			want: identityRolesOK,
+		},
+		{
+			name: "no vote account",
+			voteAccounts: []rpc.VoteAccount{
+				{VotePubkey: "other-vote-key", NodePubkey: "other-key", ActivatedStake: 50},
+			},
+			want: identityRolesUnstaked,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := identityRoles(tt.voteAccounts, "active-key", "passive-key"); got != tt.want {
+				t.Errorf("identityRoles() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidator_checkIdentityRoles(t *testing.T) {
+	tests := []struct {
+		name        string
+		failing     bool
+		wantChecked bool
+	}{
+		{
+			name:        "checked once vote accounts are fetched",
+			failing:     false,
+			wantChecked: true,
+		},
+		{
+			name:        "retried when vote accounts can't be fetched",
+			failing:     true,
+			wantChecked: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				calls++
+				if tt.failing {
+					w.WriteHeader(http.StatusBadGateway)
+					return
+				}
+				json.NewEncoder(w).Encode(rpc.JSONRPCResponse{JSONRPC: "2.0", ID: 1, Result: map[string]interface{}{
+					"current": []interface{}{
+						map[string]interface{}{"votePubkey": "vote-key", "nodePubkey": "passive-key", "activatedStake": 100},
+					},
+					"delinquent": []interface{}{},
+				}})
+			}))
+			defer server.Close()
+
+			v := &Validator{
+				ActiveIdentityPublicKey:  "active-key",
+				PassiveIdentityPublicKey: "passive-key",
+				rpcClient:                rpc.NewClient(server.URL),
+				logger:                   log.WithPrefix("validator"),
+			}
+
+			v.checkIdentityRoles()
+			v.checkIdentityRoles()
+
+			if v.identityRolesChecked != tt.wantChecked {
+				t.Errorf("identityRolesChecked = %v, want %v", v.identityRolesChecked, tt.wantChecked)
+			}
+			wantCalls := 1
+			if tt.failing {
+				wantCalls = 2
+			}
+			if calls != wantCalls {
+				t.Errorf("getVoteAccounts calls = %d, want %d", calls, wantCalls)
+			}
+		})
+	}
+}
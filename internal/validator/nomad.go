@@ -0,0 +1,48 @@
+package validator
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/charmbracelet/log"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/nomad"
+)
+
+// activateNomadJob sets the Nomad job's meta key to the target version when sync.nomad is enabled - registering
+// the updated job triggers its deployment. Jobs already at the target version are left as they are.
+func (v *Validator) activateNomadJob(ctx context.Context, syncLogger *log.Logger, versionTo string) (err error) {
+	nomadConfig := v.syncConfig.Nomad
+	if !nomadConfig.Enabled {
+		return nil
+	}
+
+	// resolve the token at execution time so rotated tokens are picked up without a restart
+	token := ""
+	if nomadConfig.HasToken() {
+		token, err = nomadConfig.Token.Resolve()
+		if err != nil {
+			return fmt.Errorf("failed to resolve sync.nomad.token from %s: %w", nomadConfig.Token.Source(), err)
+		}
+	}
+
+	client := nomad.NewClient(nomad.Options{
+		Address:   nomadConfig.Address,
+		Namespace: nomadConfig.Namespace,
+		Region:    nomadConfig.Region,
+		Token:     token,
+		Timeout:   nomadConfig.Timeout,
+	})
+
+	syncLogger.Info("setting nomad job meta to target version", "job", nomadConfig.Job, "metaKey", nomadConfig.MetaKey, "versionTo", versionTo)
+	evalID, err := client.SetJobMeta(ctx, nomadConfig.Job, nomadConfig.MetaKey, versionTo)
+	if err != nil {
+		return fmt.Errorf("failed to activate nomad job: %w", err)
+	}
+	if evalID == "" {
+		syncLogger.Info("nomad job meta already at target version - not registering it again", "job", nomadConfig.Job)
+		return nil
+	}
+
+	syncLogger.Info("registered nomad job - deployment triggered", "job", nomadConfig.Job, "evalID", evalID)
+	return nil
+}
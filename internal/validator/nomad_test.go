@@ -0,0 +1,100 @@
+package validator
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/config"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/secrets"
+)
+
+func TestValidator_activateNomadJob(t *testing.T) {
+	t.Setenv("TEST_NOMAD_TOKEN", "secret-token")
+
+	tests := []struct {
+		name           string
+		disabled       bool
+		token          secrets.Ref
+		registerStatus int
+		wantRegistered bool
+		wantToken      string
+		wantErr        bool
+	}{
+		{
+			name:     "disabled",
+			disabled: true,
+		},
+		{
+			name:           "registers job",
+			registerStatus: http.StatusOK,
+			wantRegistered: true,
+		},
+		{
+			name:           "sends resolved token",
+			token:          secrets.Ref{FromEnv: "TEST_NOMAD_TOKEN"},
+			registerStatus: http.StatusOK,
+			wantRegistered: true,
+			wantToken:      "secret-token",
+		},
+		{
+			name:    "unresolvable token",
+			token:   secrets.Ref{FromEnv: "TEST_NOMAD_TOKEN_UNSET"},
+			wantErr: true,
+		},
+		{
+			name:           "register fails",
+			registerStatus: http.StatusBadRequest,
+			wantRegistered: true,
+			wantErr:        true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests := 0
+			registered := false
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				if got := r.Header.Get("X-Nomad-Token"); got != tt.wantToken {
+					t.Errorf("X-Nomad-Token = %q, want %q", got, tt.wantToken)
+				}
+				if r.Method == http.MethodGet {
+					_, _ = w.Write([]byte(`{"ID":"validator","JobModifyIndex":3,"Meta":{"validator_version":"2.3.5"}}`))
+					return
+				}
+				registered = true
+				w.WriteHeader(tt.registerStatus)
+				_, _ = w.Write([]byte(`{"EvalID":"eval-1"}`))
+			}))
+			defer server.Close()
+
+			v := &Validator{
+				syncConfig: config.Sync{Nomad: config.Nomad{
+					Enabled:   !tt.disabled,
+					Address:   server.URL,
+					Namespace: "default",
+					Job:       "validator",
+					MetaKey:   "validator_version",
+					Token:     tt.token,
+					Timeout:   time.Second,
+				}},
+				logger: log.WithPrefix("validator"),
+			}
+
+			err := v.activateNomadJob(context.Background(), v.logger, "2.3.6")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("activateNomadJob() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if registered != tt.wantRegistered {
+				t.Errorf("activateNomadJob() registered = %v, want %v", registered, tt.wantRegistered)
+			}
+			if tt.disabled && requests != 0 {
+				t.Errorf("activateNomadJob() made %d requests while disabled, want none", requests)
+			}
+		})
+	}
+}
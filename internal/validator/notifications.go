@@ -0,0 +1,138 @@
+package validator
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/config"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/notify"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/report"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/state"
+)
+
+// activationHeldReasonCodes are the reason codes of skipped syncs whose activation is held back by a gate until
+// a later sync - as opposed to syncs skipped for good, e.g. for a target outside the version constraint
+var activationHeldReasonCodes = []string{
+	report.ReasonCodeRoleActive,
+	report.ReasonCodeReferenceValidatorBehind,
+	report.ReasonCodeAdoptionBelowThreshold,
+	report.ReasonCodeStakeActivationPending,
+	report.ReasonCodeSlotTriggerNotReached,
+}
+
+// notificationDue returns true when a notification of a kind about subject should be sent - it is not sent
+// again for the same subject until realertInterval passed since it was, never when realertInterval is 0
+func (v *Validator) notificationDue(kind string, subject string, realertInterval time.Duration, now time.Time) bool {
+	sent, ok := v.stateStore.Get().Notifications[kind]
+	if !ok || sent.Subject != subject {
+		return true
+	}
+	return realertInterval > 0 && now.Sub(sent.SentAt) >= realertInterval
+}
+
+// sendNotification sends a notification about subject and records it in state so it is deduplicated - failures
+// are logged and retried on the next sync rather than failing this one
+func (v *Validator) sendNotification(logger *log.Logger, event notify.Event, subject string) {
+	if err := v.notifier.Notify(event); err != nil {
+		logger.Warn("failed to send notification - retrying next sync", "kind", event.Kind, "error", err)
+		return
+	}
+
+	err := v.stateStore.Update(func(data *state.Data) {
+		if data.Notifications == nil {
+			data.Notifications = make(map[string]state.Notification)
+		}
+		data.Notifications[event.Kind] = state.Notification{Subject: subject, SentAt: event.Time}
+	})
+	if err != nil {
+		logger.Warn("failed to record notification in state - it may be sent again", "kind", event.Kind, "error", err)
+	}
+}
+
+// clearNotifications forgets the notifications of the given kinds, so the next occurrence is notified straight away
+func (v *Validator) clearNotifications(logger *log.Logger, kinds ...string) {
+	data := v.stateStore.Get()
+	if !slices.ContainsFunc(kinds, func(kind string) bool { _, ok := data.Notifications[kind]; return ok }) {
+		return
+	}
+
+	err := v.stateStore.Update(func(data *state.Data) {
+		for _, kind := range kinds {
+			delete(data.Notifications, kind)
+		}
+	})
+	if err != nil {
+		logger.Warn("failed to clear notifications in state", "kinds", kinds, "error", err)
+	}
+}
+
+// notifyPendingState notifies about the state the last decision left the validator in when enabled - drift
+// while it runs a version other than the target, and held activation while a gate holds the sync back. Repeats
+// for the same pending state are deduplicated, re-alerting after the configured interval, and both are cleared
+// once the validator is synced or up to date.
+func (v *Validator) notifyPendingState() {
+	if !v.notifier.Enabled() {
+		return
+	}
+
+	decision := v.lastDecision
+	logger := v.logger.With("outcome", decision.Outcome, "reasonCode", decision.ReasonCode)
+	if decision.Outcome == report.OutcomeSynced || decision.Outcome == report.OutcomeUpToDate {
+		v.clearNotifications(logger, notify.KindDriftDetected, notify.KindActivationHeld)
+		return
+	}
+
+	// without a target there is nothing to be pending on
+	if decision.VersionTo == "" || decision.VersionFrom == decision.VersionTo {
+		return
+	}
+
+	now := time.Now().UTC()
+	drift := fmt.Sprintf("%s -> %s", decision.VersionFrom, decision.VersionToTag)
+	v.notifyPendingStateKind(logger, notify.KindDriftDetected, v.notifyConfig.DriftDetected, drift, now,
+		fmt.Sprintf("drift detected: %s %s on %s (%s) runs v%s, target is v%s - %s: %s",
+			decision.Client, decision.IdentityPublicKey, decision.Cluster, decision.Role,
+			decision.VersionFrom, decision.VersionTo, decision.Outcome, decision.Reason,
+		),
+	)
+
+	if decision.Outcome == report.OutcomeSkipped && slices.Contains(activationHeldReasonCodes, decision.ReasonCode) {
+		v.notifyPendingStateKind(logger, notify.KindActivationHeld, v.notifyConfig.ActivationHeld, drift+" "+decision.ReasonCode, now,
+			fmt.Sprintf("activation held: %s %s on %s (%s) is waiting to sync v%s -> v%s - %s",
+				decision.Client, decision.IdentityPublicKey, decision.Cluster, decision.Role,
+				decision.VersionFrom, decision.VersionTo, decision.Reason,
+			),
+		)
+	}
+}
+
+// notifyPendingStateKind sends a pending state notification of a kind when enabled and due
+func (v *Validator) notifyPendingStateKind(logger *log.Logger, kind string, eventConfig config.NotifyEvent, subject string, now time.Time, text string) {
+	if !eventConfig.Enabled {
+		return
+	}
+
+	if !v.notificationDue(kind, subject, eventConfig.RealertInterval, now) {
+		logger.Debug("notification already sent for pending state", "kind", kind, "subject", subject)
+		return
+	}
+
+	decision := v.lastDecision
+	v.sendNotification(logger, notify.Event{
+		Kind:              kind,
+		Time:              now,
+		Cluster:           decision.Cluster,
+		Client:            decision.Client,
+		IdentityPublicKey: decision.IdentityPublicKey,
+		VersionFrom:       decision.VersionFrom,
+		VersionTo:         decision.VersionTo,
+		VersionToTag:      decision.VersionToTag,
+		Outcome:           decision.Outcome,
+		ReasonCode:        decision.ReasonCode,
+		Reason:            decision.Reason,
+		SFDPStage:         v.sfdpParticipantStage(),
+		Text:              text,
+	}, subject)
+}
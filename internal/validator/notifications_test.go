@@ -0,0 +1,114 @@
+package validator
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/config"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/notify"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/report"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/state"
+)
+
+func TestValidator_notificationDue(t *testing.T) {
+	sentAt := time.Date(2026, 1, 2, 3, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name            string
+		kind            string
+		subject         string
+		realertInterval time.Duration
+		now             time.Time
+		want            bool
+	}{
+		{name: "never sent", kind: notify.KindActivationHeld, subject: "v3.0.10", now: sentAt, want: true},
+		{name: "new subject", kind: notify.KindDriftDetected, subject: "v3.0.11", now: sentAt.Add(time.Minute), want: true},
+		{name: "same subject without realert", kind: notify.KindDriftDetected, subject: "v3.0.10", now: sentAt.Add(48 * time.Hour), want: false},
+		{name: "same subject before realert", kind: notify.KindDriftDetected, subject: "v3.0.10", realertInterval: 12 * time.Hour, now: sentAt.Add(11 * time.Hour), want: false},
+		{name: "same subject after realert", kind: notify.KindDriftDetected, subject: "v3.0.10", realertInterval: 12 * time.Hour, now: sentAt.Add(12 * time.Hour), want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stateStore, err := state.NewStore("")
+			if err != nil {
+				t.Fatalf("state.NewStore() error = %v", err)
+			}
+			stateStore.Update(func(data *state.Data) {
+				data.Notifications = map[string]state.Notification{notify.KindDriftDetected: {Subject: "v3.0.10", SentAt: sentAt}}
+			})
+
+			v := &Validator{stateStore: stateStore}
+			if got := v.notificationDue(tt.kind, tt.subject, tt.realertInterval, tt.now); got != tt.want {
+				t.Errorf("notificationDue() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidator_notifyPendingState(t *testing.T) {
+	var received []notify.Event
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var event notify.Event
+		if err := json.NewDecoder(r.Body).Decode(&event); err != nil {
+			t.Errorf("failed to decode event: %v", err)
+		}
+		received = append(received, event)
+	}))
+	defer server.Close()
+
+	stateStore, err := state.NewStore("")
+	if err != nil {
+		t.Fatalf("state.NewStore() error = %v", err)
+	}
+
+	v := &Validator{
+		stateStore: stateStore,
+		notifier:   notify.New(notify.Options{URL: server.URL, Timeout: time.Second}),
+		notifyConfig: config.Notify{
+			DriftDetected:  config.NotifyEvent{Enabled: true, RealertInterval: 12 * time.Hour},
+			ActivationHeld: config.NotifyEvent{Enabled: true, RealertInterval: 12 * time.Hour},
+		},
+		logger: log.WithPrefix("validator"),
+	}
+
+	decide := func(outcome string, reasonCode string, wantKinds ...string) {
+		t.Helper()
+		received = nil
+		v.lastDecision = report.Decision{
+			VersionFrom:  "3.0.9",
+			VersionTo:    "3.0.10",
+			VersionToTag: "v3.0.10",
+			Outcome:      outcome,
+			ReasonCode:   reasonCode,
+		}
+		v.notifyPendingState()
+
+		if len(received) != len(wantKinds) {
+			t.Fatalf("%s/%s: received %d notifications, want %v", outcome, reasonCode, len(received), wantKinds)
+		}
+		for i, kind := range wantKinds {
+			if received[i].Kind != kind {
+				t.Errorf("%s/%s: notification[%d] kind = %s, want %s", outcome, reasonCode, i, received[i].Kind, kind)
+			}
+		}
+	}
+
+	decide(report.OutcomeSkipped, report.ReasonCodeAdoptionBelowThreshold, notify.KindDriftDetected, notify.KindActivationHeld)
+	decide(report.OutcomeSkipped, report.ReasonCodeAdoptionBelowThreshold)
+	decide(report.OutcomeSkipped, report.ReasonCodeStakeActivationPending, notify.KindActivationHeld)
+	decide(report.OutcomeFailed, report.ReasonCodeError)
+	decide(report.OutcomeSynced, report.ReasonCodeSynced)
+	decide(report.OutcomeFailed, report.ReasonCodeError, notify.KindDriftDetected)
+
+	if notifications := stateStore.Get().Notifications; len(notifications) != 1 {
+		t.Errorf("state notifications = %v, want only the drift notification after syncing cleared both", notifications)
+	}
+
+	v.notifyConfig.ActivationHeld.Enabled = false
+	decide(report.OutcomeSkipped, report.ReasonCodeRoleActive)
+}
@@ -0,0 +1,97 @@
+package validator
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/log"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/config"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/report"
+)
+
+// otherRole returns the name of the validator.identities.others identity the validator is running, false when
+// it runs none of them
+func (v *Validator) otherRole() (name string, ok bool) {
+	for name, publicKey := range v.OtherIdentityPublicKeys {
+		if v.State.IdentityPublicKey == publicKey {
+			return name, true
+		}
+	}
+	return "", false
+}
+
+// IsRoleOther checks if the validator is running one of the other identities rather than active or passive
+func (v *Validator) IsRoleOther() bool {
+	_, ok := v.otherRole()
+	return ok && !v.IsActive() && !v.IsPassive()
+}
+
+// otherRolePolicy returns what syncs do for the validator's role when it is neither active nor passive -
+// sync.on_other_role for an other identity, falling back to sync.on_unknown_role
+func (v *Validator) otherRolePolicy() (policy string, setting string) {
+	role := v.Role()
+	if policy, ok := v.syncConfig.OnOtherRole[role]; ok {
+		return policy, "sync.on_other_role." + role
+	}
+	return v.syncConfig.OnUnknownRole, "sync.on_unknown_role"
+}
+
+// syncRole returns the role syncs are gated on - the validator's role, or passive for other and unknown roles
+// whose policy is treat_as_passive
+func (v *Validator) syncRole() string {
+	role := v.Role()
+	if role == RoleActive || role == RolePassive {
+		return role
+	}
+	if policy, _ := v.otherRolePolicy(); policy == config.OnUnknownRoleTreatAsPassive {
+		return RolePassive
+	}
+	return role
+}
+
+// otherRoleAllowsSync applies sync.on_other_role or sync.on_unknown_role to a validator running neither the
+// active nor the passive identity, recording a skipped outcome when it skips the sync
+func (v *Validator) otherRoleAllowsSync(syncLogger *log.Logger) (allowed bool, err error) {
+	policy, setting := v.otherRolePolicy()
+	switch policy {
+	case config.OnUnknownRoleSkip:
+		syncLogger.Warn("validator identity is neither active nor passive - skipping sync",
+			"policy", setting+"=skip",
+			"identityPubkey", v.State.IdentityPublicKey,
+		)
+		v.recordOutcome(report.OutcomeSkipped, v.otherRoleReasonCode(),
+			fmt.Sprintf("%s and %s=skip", v.otherRoleDescription(), setting),
+		)
+		return false, nil
+	case config.OnUnknownRoleTreatAsPassive:
+		syncLogger.Warn("validator identity is neither active nor passive - syncing as passive",
+			"policy", setting+"=treat_as_passive",
+			"identityPubkey", v.State.IdentityPublicKey,
+		)
+		return true, nil
+	default:
+		return false, v.otherRoleError()
+	}
+}
+
+// otherRoleError is the error failing syncs of a validator running neither the active nor the passive identity
+func (v *Validator) otherRoleError() error {
+	return report.WithReasonCode(v.otherRoleReasonCode(),
+		fmt.Errorf("%s - skipping sync", v.otherRoleDescription()),
+	)
+}
+
+// otherRoleReasonCode returns the reason code of a validator running neither the active nor the passive identity
+func (v *Validator) otherRoleReasonCode() string {
+	if v.IsRoleOther() {
+		return report.ReasonCodeRoleOther
+	}
+	return report.ReasonCodeRoleUnknown
+}
+
+// otherRoleDescription describes the identity of a validator running neither the active nor the passive identity
+func (v *Validator) otherRoleDescription() string {
+	if v.IsRoleOther() {
+		return fmt.Sprintf("validator identity public key %s is the %s identity, not %s or %s", v.State.IdentityPublicKey, v.Role(), RoleActive, RolePassive)
+	}
+	return fmt.Sprintf("validator identity public key %s is not %s or %s", v.State.IdentityPublicKey, RoleActive, RolePassive)
+}
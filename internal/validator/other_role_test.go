@@ -0,0 +1,146 @@
+package validator
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/log"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/config"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/report"
+)
+
+func TestValidator_otherRoleAllowsSync(t *testing.T) {
+	tests := []struct {
+		name           string
+		identity       string
+		onUnknownRole  string
+		onOtherRole    map[string]string
+		wantRole       string
+		wantAllowed    bool
+		wantErr        bool
+		wantOutcome    string
+		wantReasonCode string
+		wantSyncRole   string
+	}{
+		{
+			name:           "unknown by default fails",
+			identity:       "unlisted-key",
+			wantRole:       RoleUnknown,
+			wantErr:        true,
+			wantReasonCode: report.ReasonCodeRoleUnknown,
+			wantSyncRole:   RoleUnknown,
+		},
+		{
+			name:           "unknown with error",
+			identity:       "unlisted-key",
+			onUnknownRole:  config.OnUnknownRoleError,
+			wantRole:       RoleUnknown,
+			wantErr:        true,
+			wantReasonCode: report.ReasonCodeRoleUnknown,
+			wantSyncRole:   RoleUnknown,
+		},
+		{
+			name:           "unknown with skip",
+			identity:       "unlisted-key",
+			onUnknownRole:  config.OnUnknownRoleSkip,
+			wantRole:       RoleUnknown,
+			wantOutcome:    report.OutcomeSkipped,
+			wantReasonCode: report.ReasonCodeRoleUnknown,
+			wantSyncRole:   RoleUnknown,
+		},
+		{
+			name:          "unknown treated as passive",
+			identity:      "unlisted-key",
+			onUnknownRole: config.OnUnknownRoleTreatAsPassive,
+			wantRole:      RoleUnknown,
+			wantAllowed:   true,
+			wantSyncRole:  RolePassive,
+		},
+		{
+			name:           "other falls back to on unknown role",
+			identity:       "maintenance-key",
+			onUnknownRole:  config.OnUnknownRoleSkip,
+			wantRole:       "maintenance",
+			wantOutcome:    report.OutcomeSkipped,
+			wantReasonCode: report.ReasonCodeRoleOther,
+			wantSyncRole:   "maintenance",
+		},
+		{
+			name:           "other with its own policy",
+			identity:       "maintenance-key",
+			onUnknownRole:  config.OnUnknownRoleSkip,
+			onOtherRole:    map[string]string{"maintenance": config.OnUnknownRoleError},
+			wantRole:       "maintenance",
+			wantErr:        true,
+			wantReasonCode: report.ReasonCodeRoleOther,
+			wantSyncRole:   "maintenance",
+		},
+		{
+			name:          "other treated as passive",
+			identity:      "spare-key",
+			onUnknownRole: config.OnUnknownRoleError,
+			onOtherRole:   map[string]string{"spare": config.OnUnknownRoleTreatAsPassive},
+			wantRole:      "spare",
+			wantAllowed:   true,
+			wantSyncRole:  RolePassive,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := &Validator{
+				ActiveIdentityPublicKey:  "active-key",
+				PassiveIdentityPublicKey: "passive-key",
+				OtherIdentityPublicKeys:  map[string]string{"maintenance": "maintenance-key", "spare": "spare-key"},
+				State:                    State{IdentityPublicKey: tt.identity},
+				syncConfig:               config.Sync{OnUnknownRole: tt.onUnknownRole, OnOtherRole: tt.onOtherRole},
+			}
+
+			if got := v.Role(); got != tt.wantRole {
+				t.Errorf("Role() = %q, want %q", got, tt.wantRole)
+			}
+
+			allowed, err := v.otherRoleAllowsSync(log.WithPrefix("sync"))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("otherRoleAllowsSync() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr && report.ReasonCodeOf(err) != tt.wantReasonCode {
+				t.Errorf("otherRoleAllowsSync() reason code = %q, want %q", report.ReasonCodeOf(err), tt.wantReasonCode)
+			}
+			if allowed != tt.wantAllowed {
+				t.Errorf("otherRoleAllowsSync() = %v, want %v", allowed, tt.wantAllowed)
+			}
+			if !tt.wantErr && (v.lastDecision.Outcome != tt.wantOutcome || v.lastDecision.ReasonCode != tt.wantReasonCode) {
+				t.Errorf("decision = %s/%s, want %s/%s", v.lastDecision.Outcome, v.lastDecision.ReasonCode, tt.wantOutcome, tt.wantReasonCode)
+			}
+			if got := v.syncRole(); got != tt.wantSyncRole {
+				t.Errorf("syncRole() = %q, want %q", got, tt.wantSyncRole)
+			}
+		})
+	}
+}
+
+func TestValidator_IsRoleOther(t *testing.T) {
+	tests := []struct {
+		identity string
+		want     bool
+	}{
+		{identity: "active-key", want: false},
+		{identity: "passive-key", want: false},
+		{identity: "maintenance-key", want: true},
+		{identity: "unlisted-key", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.identity, func(t *testing.T) {
+			v := &Validator{
+				ActiveIdentityPublicKey:  "active-key",
+				PassiveIdentityPublicKey: "passive-key",
+				OtherIdentityPublicKeys:  map[string]string{"maintenance": "maintenance-key"},
+				State:                    State{IdentityPublicKey: tt.identity},
+			}
+			if got := v.IsRoleOther(); got != tt.want {
+				t.Errorf("IsRoleOther() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
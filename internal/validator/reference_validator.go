@@ -0,0 +1,34 @@
+package validator
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/go-version"
+)
+
+// referenceValidatorReachedVersion checks whether the configured reference validator is seen in gossip running
+// the target version or a newer one - e.g. when SFDP or the version constraint clamp the target below the
+// reference's - returning the version it advertises when found
+func (v *Validator) referenceValidatorReachedVersion(targetVersion *version.Version) (reachedVersion bool, referenceVersion string, err error) {
+	identity := v.syncConfig.ReferenceValidator.Identity
+
+	found, node, err := v.rpcClient.GetNodeWithIdentityPublicKey(identity)
+	if err != nil {
+		return false, "", fmt.Errorf("failed to look up reference validator %s in gossip: %w", identity, err)
+	}
+
+	if !found || node.Version == "" {
+		v.logger.Debug("reference validator not found in gossip or not advertising a version", "referenceIdentity", identity, "found", found)
+		return false, "", nil
+	}
+
+	parsedVersion, err := version.NewVersion(node.Version)
+	if err != nil {
+		return false, node.Version, fmt.Errorf("failed to parse reference validator %s version %s: %w", identity, node.Version, err)
+	}
+
+	// translate to the tag-format equivalent so it compares like the local running version does
+	normalizedVersion := v.githubClient.NormalizeToTagVersion(parsedVersion)
+
+	return normalizedVersion.GreaterThanOrEqual(targetVersion), node.Version, nil
+}
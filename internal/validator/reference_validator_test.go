@@ -0,0 +1,118 @@
+package validator
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/charmbracelet/log"
+	goversion "github.com/hashicorp/go-version"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/config"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/constants"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/github"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/rpc"
+)
+
+const testReferenceIdentity = "9WzDXwBbmkg8ZTbNMqUxvQRAyrZzDsGYdLVL9zYtAWWM"
+
+func TestValidator_referenceValidatorReachedVersion(t *testing.T) {
+	tests := []struct {
+		name               string
+		clusterNodes       []interface{}
+		targetVersion      string
+		wantReachedVersion bool
+		wantVersion        string
+		wantErr            bool
+	}{
+		{
+			name: "reference runs target version",
+			clusterNodes: []interface{}{
+				map[string]interface{}{"pubkey": testReferenceIdentity, "version": "2.3.6"},
+			},
+			targetVersion:      "2.3.6",
+			wantReachedVersion: true,
+			wantVersion:        "2.3.6",
+		},
+		{
+			name: "reference runs newer version",
+			clusterNodes: []interface{}{
+				map[string]interface{}{"pubkey": testReferenceIdentity, "version": "2.3.7"},
+			},
+			targetVersion:      "2.3.6",
+			wantReachedVersion: true,
+			wantVersion:        "2.3.7",
+		},
+		{
+			name: "reference runs older version",
+			clusterNodes: []interface{}{
+				map[string]interface{}{"pubkey": testReferenceIdentity, "version": "2.3.5"},
+			},
+			targetVersion:      "2.3.6",
+			wantReachedVersion: false,
+			wantVersion:        "2.3.5",
+		},
+		{
+			name:               "reference not in gossip",
+			clusterNodes:       []interface{}{},
+			targetVersion:      "2.3.6",
+			wantReachedVersion: false,
+		},
+		{
+			name: "reference without advertised version",
+			clusterNodes: []interface{}{
+				map[string]interface{}{"pubkey": testReferenceIdentity, "version": nil},
+			},
+			targetVersion:      "2.3.6",
+			wantReachedVersion: false,
+		},
+		{
+			name: "reference with unparsable version",
+			clusterNodes: []interface{}{
+				map[string]interface{}{"pubkey": testReferenceIdentity, "version": "not-a-version"},
+			},
+			targetVersion: "2.3.6",
+			wantErr:       true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				json.NewEncoder(w).Encode(rpc.JSONRPCResponse{JSONRPC: "2.0", ID: 1, Result: tt.clusterNodes})
+			}))
+			defer server.Close()
+
+			githubClient, err := github.NewClient(github.Options{
+				Cluster: constants.ClusterNameMainnetBeta,
+				Client:  constants.ClientNameAgave,
+			})
+			if err != nil {
+				t.Fatalf("github.NewClient() error = %v", err)
+			}
+
+			v := &Validator{
+				syncConfig: config.Sync{
+					ReferenceValidator: config.ReferenceValidator{Identity: testReferenceIdentity},
+				},
+				rpcClient:    rpc.NewClient(server.URL),
+				githubClient: githubClient,
+				logger:       log.WithPrefix("validator"),
+			}
+
+			reachedVersion, referenceVersion, err := v.referenceValidatorReachedVersion(goversion.Must(goversion.NewVersion(tt.targetVersion)))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("referenceValidatorReachedVersion() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if reachedVersion != tt.wantReachedVersion {
+				t.Errorf("referenceValidatorReachedVersion() reachedVersion = %v, want %v", reachedVersion, tt.wantReachedVersion)
+			}
+			if referenceVersion != tt.wantVersion {
+				t.Errorf("referenceValidatorReachedVersion() referenceVersion = %q, want %q", referenceVersion, tt.wantVersion)
+			}
+		})
+	}
+}
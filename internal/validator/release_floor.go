@@ -0,0 +1,32 @@
+package validator
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/go-version"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/constants"
+)
+
+// releaseFloor returns the minimum version considered when looking up the latest release - the running version
+// minus sync.release_floor.minor_distance minor versions, within the running major version. Returns nil when
+// the floor is disabled, the running version is unknown or the client is firedancer, whose running version
+// only maps to release versions once releases have been listed.
+func (v *Validator) releaseFloor() *version.Version {
+	distance := v.syncConfig.ReleaseFloor.MinorDistance
+	if distance == 0 || v.State.Version == nil {
+		return nil
+	}
+
+	if constants.NormalizeClientName(v.cfg.Client) == constants.ClientNameFiredancer {
+		v.logger.Debug("release floor not applied to firedancer")
+		return nil
+	}
+
+	segments := v.State.Version.Segments()
+	floor, err := version.NewVersion(fmt.Sprintf("%d.%d.0", segments[0], max(segments[1]-distance, 0)))
+	if err != nil {
+		return nil
+	}
+
+	return floor
+}
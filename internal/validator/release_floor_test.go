@@ -0,0 +1,80 @@
+package validator
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/log"
+	"github.com/hashicorp/go-version"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/config"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/constants"
+)
+
+func TestValidator_releaseFloor(t *testing.T) {
+	tests := []struct {
+		name           string
+		client         string
+		runningVersion string
+		minorDistance  int
+		wantFloor      string
+	}{
+		{
+			name:           "disabled",
+			client:         constants.ClientNameAgave,
+			runningVersion: "3.0.10",
+			minorDistance:  0,
+			wantFloor:      "",
+		},
+		{
+			name:           "minor distance below running version",
+			client:         constants.ClientNameAgave,
+			runningVersion: "3.4.10",
+			minorDistance:  2,
+			wantFloor:      "3.2.0",
+		},
+		{
+			name:           "clamped to the running major version",
+			client:         constants.ClientNameJitoSolana,
+			runningVersion: "3.0.10",
+			minorDistance:  2,
+			wantFloor:      "3.0.0",
+		},
+		{
+			name:           "unknown running version",
+			client:         constants.ClientNameAgave,
+			runningVersion: "",
+			minorDistance:  2,
+			wantFloor:      "",
+		},
+		{
+			name:           "not applied to firedancer",
+			client:         constants.ClientNameFiredancer,
+			runningVersion: "0.808.30014",
+			minorDistance:  2,
+			wantFloor:      "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := &Validator{
+				cfg:        config.Validator{Client: tt.client},
+				syncConfig: config.Sync{ReleaseFloor: config.ReleaseFloor{MinorDistance: tt.minorDistance}},
+				logger:     log.WithPrefix("validator"),
+			}
+			if tt.runningVersion != "" {
+				v.State.Version = version.Must(version.NewVersion(tt.runningVersion))
+			}
+
+			floor := v.releaseFloor()
+			if tt.wantFloor == "" {
+				if floor != nil {
+					t.Errorf("releaseFloor() = %s, want nil", floor)
+				}
+				return
+			}
+			if floor == nil || floor.String() != tt.wantFloor {
+				t.Errorf("releaseFloor() = %v, want %s", floor, tt.wantFloor)
+			}
+		})
+	}
+}
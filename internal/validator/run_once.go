@@ -0,0 +1,40 @@
+package validator
+
+import (
+	"github.com/sol-strategies/solana-validator-version-sync/internal/state"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/sync_commands"
+)
+
+// runOnceDue decides whether a command with run_once_per runs this sync - only when the target starts a new
+// version line, i.e. is on another line than the running version, and the command has not completed for that
+// line yet. Commands without run_once_per are always due.
+func (v *Validator) runOnceDue(cmd *sync_commands.Command, templateData sync_commands.CommandTemplateData) (line string, due bool, err error) {
+	line, err = cmd.RunOnceLine(templateData.VersionTo)
+	if err != nil || line == "" {
+		return line, line == "", err
+	}
+
+	fromLine, err := cmd.RunOnceLine(templateData.VersionFrom)
+	if err != nil {
+		return line, false, err
+	}
+	if fromLine == line {
+		return line, false, nil
+	}
+
+	return line, v.stateStore.Get().CommandsRunOnce[cmd.Name] != line, nil
+}
+
+// recordRunOnce records that a command with run_once_per completed for a version line so it is not run again
+// for it - failing to persist is logged, the command may then run again on a later sync
+func (v *Validator) recordRunOnce(cmd *sync_commands.Command, line string) {
+	err := v.stateStore.Update(func(data *state.Data) {
+		if data.CommandsRunOnce == nil {
+			data.CommandsRunOnce = make(map[string]string)
+		}
+		data.CommandsRunOnce[cmd.Name] = line
+	})
+	if err != nil {
+		v.logger.Warn("failed to record run once command in state", "command", cmd.Name, "line", line, "error", err)
+	}
+}
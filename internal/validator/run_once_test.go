@@ -0,0 +1,160 @@
+package validator
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/log"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/config"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/state"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/sync_commands"
+)
+
+func TestValidator_runOnceDue(t *testing.T) {
+	tests := []struct {
+		name        string
+		runOncePer  string
+		versionFrom string
+		versionTo   string
+		recorded    map[string]string
+		wantLine    string
+		wantDue     bool
+		wantErr     bool
+	}{
+		{
+			name:        "unset is always due",
+			versionFrom: "3.0.9",
+			versionTo:   "3.0.10",
+			wantDue:     true,
+		},
+		{
+			name:        "new minor line is due",
+			runOncePer:  sync_commands.RunOncePerMinor,
+			versionFrom: "2.3.6",
+			versionTo:   "3.0.10",
+			wantLine:    "3.0",
+			wantDue:     true,
+		},
+		{
+			name:        "same minor line is not due",
+			runOncePer:  sync_commands.RunOncePerMinor,
+			versionFrom: "3.0.9",
+			versionTo:   "3.0.10",
+			wantLine:    "3.0",
+			wantDue:     false,
+		},
+		{
+			name:        "new minor line already recorded is not due",
+			runOncePer:  sync_commands.RunOncePerMinor,
+			versionFrom: "2.3.6",
+			versionTo:   "3.0.10",
+			recorded:    map[string]string{"migrate": "3.0"},
+			wantLine:    "3.0",
+			wantDue:     false,
+		},
+		{
+			name:        "new minor line recorded for an older line is due",
+			runOncePer:  sync_commands.RunOncePerMinor,
+			versionFrom: "2.3.6",
+			versionTo:   "3.0.10",
+			recorded:    map[string]string{"migrate": "2.3"},
+			wantLine:    "3.0",
+			wantDue:     true,
+		},
+		{
+			name:        "new major line is due",
+			runOncePer:  sync_commands.RunOncePerMajor,
+			versionFrom: "2.3.6",
+			versionTo:   "3.0.10",
+			wantLine:    "3",
+			wantDue:     true,
+		},
+		{
+			name:        "new version is due",
+			runOncePer:  sync_commands.RunOncePerVersion,
+			versionFrom: "3.0.9",
+			versionTo:   "3.0.10",
+			wantLine:    "3.0.10",
+			wantDue:     true,
+		},
+		{
+			name:        "invalid target version",
+			runOncePer:  sync_commands.RunOncePerMinor,
+			versionFrom: "3.0.9",
+			versionTo:   "invalid",
+			wantErr:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stateStore, err := state.NewStore("")
+			if err != nil {
+				t.Fatalf("state.NewStore() error = %v", err)
+			}
+			if err := stateStore.Update(func(data *state.Data) { data.CommandsRunOnce = tt.recorded }); err != nil {
+				t.Fatalf("stateStore.Update() error = %v", err)
+			}
+
+			v := &Validator{stateStore: stateStore, logger: log.WithPrefix("validator")}
+			cmd := &sync_commands.Command{Name: "migrate", RunOncePer: tt.runOncePer}
+			line, due, err := v.runOnceDue(cmd, sync_commands.CommandTemplateData{VersionFrom: tt.versionFrom, VersionTo: tt.versionTo})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("runOnceDue() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if line != tt.wantLine || due != tt.wantDue {
+				t.Errorf("runOnceDue() = %q, %v, want %q, %v", line, due, tt.wantLine, tt.wantDue)
+			}
+		})
+	}
+}
+
+func TestValidator_executeCommands_RunOncePer(t *testing.T) {
+	commands := []sync_commands.Command{
+		{Name: "migrate", Cmd: "true", RunOncePer: sync_commands.RunOncePerMinor},
+		{Name: "failing-migrate", Cmd: "false", RunOncePer: sync_commands.RunOncePerMinor, AllowFailure: true},
+	}
+	for i := range commands {
+		if err := commands[i].Parse(); err != nil {
+			t.Fatalf("Parse() error = %v", err)
+		}
+	}
+
+	stateStore, err := state.NewStore("")
+	if err != nil {
+		t.Fatalf("state.NewStore() error = %v", err)
+	}
+
+	v := &Validator{
+		syncConfig: config.Sync{Commands: commands},
+		stateStore: stateStore,
+		logger:     log.WithPrefix("validator"),
+	}
+
+	templateData := sync_commands.CommandTemplateData{VersionFrom: "2.3.6", VersionTo: "3.0.10", CommandsCount: len(commands)}
+	if err := v.executeCommands(v.logger, sync_commands.PhaseActivate, templateData); err != nil {
+		t.Fatalf("executeCommands() error = %v", err)
+	}
+
+	// only commands that succeeded are recorded, failed ones run again on the next sync
+	got := stateStore.Get().CommandsRunOnce
+	if len(got) != 1 || got["migrate"] != "3.0" {
+		t.Errorf("CommandsRunOnce = %v, want map[migrate:3.0]", got)
+	}
+
+	tests := []struct {
+		command string
+		wantDue bool
+	}{
+		{command: "migrate", wantDue: false},
+		{command: "failing-migrate", wantDue: true},
+	}
+	for i, tt := range tests {
+		_, due, err := v.runOnceDue(&v.syncConfig.Commands[i], templateData)
+		if err != nil || due != tt.wantDue {
+			t.Errorf("runOnceDue(%s) = %v, %v, want %v", tt.command, due, err, tt.wantDue)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package validator
+
+import (
+	"github.com/charmbracelet/log"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/report"
+)
+
+// lookupSFDPParticipant looks up the SFDP participation of the active identity when sync.sfdp_participant is
+// enabled. Failures are logged and leave the stage unknown, so strict stage tracking is not applied this sync.
+func (v *Validator) lookupSFDPParticipant(syncLogger *log.Logger) {
+	if !v.syncConfig.SFDPParticipant.Enabled {
+		return
+	}
+
+	participant, err := v.sfdpClient.GetParticipant(v.ActiveIdentityPublicKey)
+	if err != nil {
+		v.markSourceUnavailable(report.SourceSFDP)
+		syncLogger.Warn("failed to look up SFDP participant stage - continuing without it", "activePubkey", v.ActiveIdentityPublicKey, "error", err)
+		return
+	}
+	if participant == nil {
+		syncLogger.Info("active identity is not an SFDP participant", "activePubkey", v.ActiveIdentityPublicKey)
+		return
+	}
+
+	v.sfdpParticipant = participant
+	syncLogger.Info("looked up SFDP participant stage",
+		"sfdpStage", participant.State,
+		"sfdpOnboardingNumber", participant.OnboardingNumber,
+		"strictStage", v.sfdpStageIsStrict(),
+	)
+}
+
+// sfdpParticipantStage returns the SFDP participant stage looked up during the current sync, empty when unknown
+func (v *Validator) sfdpParticipantStage() string {
+	if v.sfdpParticipant == nil {
+		return ""
+	}
+	return v.sfdpParticipant.State
+}
+
+// sfdpStageIsStrict returns true when the SFDP participant stage looked up during the current sync is one of
+// sync.sfdp_participant.strict_stages - activation is then never held by the reference validator, adoption gate
+// or stake activation so the validator tracks SFDP compliant releases as soon as they are available
+func (v *Validator) sfdpStageIsStrict() bool {
+	stage := v.sfdpParticipantStage()
+	return stage != "" && v.syncConfig.SFDPParticipant.IsStrictStage(stage)
+}
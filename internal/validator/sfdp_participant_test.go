@@ -0,0 +1,70 @@
+package validator
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/log"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/config"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/sfdp"
+)
+
+func TestValidator_sfdpStageIsStrict(t *testing.T) {
+	tests := []struct {
+		name         string
+		participant  *sfdp.Participant
+		strictStages []string
+		wantStage    string
+		wantStrict   bool
+	}{
+		{
+			name:         "stage unknown",
+			strictStages: []string{"Approved"},
+			wantStage:    "",
+			wantStrict:   false,
+		},
+		{
+			name:         "strict stage",
+			participant:  &sfdp.Participant{State: "Approved"},
+			strictStages: []string{"Approved"},
+			wantStage:    "Approved",
+			wantStrict:   true,
+		},
+		{
+			name:         "other stage",
+			participant:  &sfdp.Participant{State: "Pending"},
+			strictStages: []string{"Approved"},
+			wantStage:    "Pending",
+			wantStrict:   false,
+		},
+		{
+			name:        "no strict stages",
+			participant: &sfdp.Participant{State: "Approved"},
+			wantStage:   "Approved",
+			wantStrict:  false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := &Validator{
+				syncConfig:      config.Sync{SFDPParticipant: config.SFDPParticipant{Enabled: true, StrictStages: tt.strictStages}},
+				sfdpParticipant: tt.participant,
+			}
+			if got := v.sfdpParticipantStage(); got != tt.wantStage {
+				t.Errorf("sfdpParticipantStage() = %q, want %q", got, tt.wantStage)
+			}
+			if got := v.sfdpStageIsStrict(); got != tt.wantStrict {
+				t.Errorf("sfdpStageIsStrict() = %v, want %v", got, tt.wantStrict)
+			}
+		})
+	}
+}
+
+func TestValidator_lookupSFDPParticipant_Disabled(t *testing.T) {
+	// the SFDP client is never called when disabled
+	v := &Validator{logger: log.WithPrefix("validator")}
+	v.lookupSFDPParticipant(log.WithPrefix("sync"))
+	if v.sfdpParticipant != nil {
+		t.Errorf("sfdpParticipant = %+v, want nil", v.sfdpParticipant)
+	}
+}
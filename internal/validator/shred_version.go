@@ -0,0 +1,36 @@
+package validator
+
+import (
+	"slices"
+
+	"github.com/charmbracelet/log"
+)
+
+// shredVersion looks up the shred version the validator advertises in gossip, exposed to commands - failures are
+// logged and leave it 0 rather than failing the sync, as only commands of coordinated restarts need it
+func (v *Validator) shredVersion(syncLogger *log.Logger) uint16 {
+	found, node, err := v.rpcClient.GetNodeWithIdentityPublicKey(v.State.IdentityPublicKey)
+	if err != nil {
+		syncLogger.Warn("failed to look up shred version - exposing it to commands as 0", "error", err)
+		return 0
+	}
+	if !found {
+		syncLogger.Warn("validator not found in gossip - exposing shred version to commands as 0", "identity", v.State.IdentityPublicKey)
+		return 0
+	}
+
+	syncLogger.Debug("shred version", "shredVersion", node.ShredVersion)
+	return node.ShredVersion
+}
+
+// templateHardForks returns the configured hard fork slots and those of the cluster restart manifest, if any,
+// ascending and without duplicates
+func (v *Validator) templateHardForks() (hardForks []uint64) {
+	hardForks = append(hardForks, v.hardForks...)
+	if v.restartManifest != nil {
+		hardForks = append(hardForks, v.restartManifest.HardForks...)
+	}
+
+	slices.Sort(hardForks)
+	return slices.Compact(hardForks)
+}
@@ -0,0 +1,94 @@
+package validator
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/log"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/restart"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/rpc"
+)
+
+func TestValidator_shredVersion(t *testing.T) {
+	identity := "9WzDXwBbmkg8ZTbNMqUxvQRAyrZzDsGYdLVL9zYtAWWM"
+
+	tests := []struct {
+		name         string
+		clusterNodes []interface{}
+		want         uint16
+	}{
+		{
+			name: "advertised in gossip",
+			clusterNodes: []interface{}{
+				map[string]interface{}{"pubkey": "other", "shredVersion": 1},
+				map[string]interface{}{"pubkey": identity, "shredVersion": 50093},
+			},
+			want: 50093,
+		},
+		{
+			name:         "not in gossip",
+			clusterNodes: []interface{}{map[string]interface{}{"pubkey": "other", "shredVersion": 1}},
+			want:         0,
+		},
+		{
+			name:         "lookup failure",
+			clusterNodes: nil,
+			want:         0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newSnapshotServer(t, identity, true, tt.clusterNodes)
+			v := &Validator{
+				State:     State{IdentityPublicKey: identity},
+				rpcClient: rpc.NewClient(server.URL),
+				logger:    log.WithPrefix("validator"),
+			}
+
+			if got := v.shredVersion(v.logger); got != tt.want {
+				t.Errorf("shredVersion() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidator_templateHardForks(t *testing.T) {
+	tests := []struct {
+		name            string
+		hardForks       []uint64
+		restartManifest *restart.Manifest
+		want            []uint64
+	}{
+		{
+			name: "none",
+			want: nil,
+		},
+		{
+			name:      "configured",
+			hardForks: []uint64{332000000, 331000000},
+			want:      []uint64{331000000, 332000000},
+		},
+		{
+			name:            "configured and restart manifest",
+			hardForks:       []uint64{331000000, 332000000},
+			restartManifest: &restart.Manifest{HardForks: []uint64{333000000, 332000000}},
+			want:            []uint64{331000000, 332000000, 333000000},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := &Validator{hardForks: tt.hardForks, restartManifest: tt.restartManifest}
+			got := v.templateHardForks()
+			if len(got) != len(tt.want) {
+				t.Fatalf("templateHardForks() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("templateHardForks() = %v, want %v", got, tt.want)
+					break
+				}
+			}
+		})
+	}
+}
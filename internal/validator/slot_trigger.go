@@ -0,0 +1,104 @@
+package validator
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/failinject"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/report"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/rpc"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/timeutil"
+)
+
+// approximateSlotDuration is only used to estimate wait times in logs
+const approximateSlotDuration = 400 * time.Millisecond
+
+// resolveTriggerSlot resolves the slot at which sync commands should be executed
+func (v *Validator) resolveTriggerSlot() (triggerSlot uint64, err error) {
+	if v.syncConfig.SlotTrigger.Slot != 0 {
+		return v.syncConfig.SlotTrigger.Slot, nil
+	}
+
+	epochInfo, err := v.rpcClient.GetEpochInfo()
+	if err != nil {
+		return 0, err
+	}
+
+	return epochBoundaryTriggerSlot(epochInfo, v.syncConfig.SlotTrigger.EpochBoundaryOffset), nil
+}
+
+// waitForTriggerSlot blocks until the validator has reached the configured trigger slot,
+// polling getSlot every sync.slot_trigger.poll_interval - failed polls are retried until max_wait, and the
+// wait is abandoned when ctx is cancelled
+func (v *Validator) waitForTriggerSlot(ctx context.Context, logger *log.Logger) (err error) {
+	err = v.failureInjector.Check(failinject.StageSlotTrigger)
+	if err != nil {
+		return err
+	}
+
+	triggerSlot, err := v.resolveTriggerSlot()
+	if err != nil {
+		return fmt.Errorf("failed to resolve sync.slot_trigger slot: %w", err)
+	}
+
+	var deadline time.Time
+	if v.syncConfig.SlotTrigger.MaxWait > 0 {
+		deadline = time.Now().Add(v.syncConfig.SlotTrigger.MaxWait)
+	}
+
+	logged := false
+	for {
+		currentSlot, err := v.rpcClient.GetSlot()
+		if err != nil {
+			// a transient RPC failure shouldn't throw away a long wait - retry on the next poll
+			logger.Warn("failed to get slot while waiting for trigger slot - retrying", "triggerSlot", triggerSlot, "error", err)
+			if !deadline.IsZero() && time.Now().After(deadline) {
+				return report.WithReasonCode(report.ReasonCodeSlotTriggerNotReached,
+					fmt.Errorf("trigger slot %d not reached within sync.slot_trigger.max_wait=%s: %w", triggerSlot, v.syncConfig.SlotTrigger.MaxWait, err),
+				)
+			}
+			if err := timeutil.Sleep(ctx, v.syncConfig.SlotTrigger.PollInterval); err != nil {
+				return fmt.Errorf("stopped waiting for trigger slot %d: %w", triggerSlot, err)
+			}
+			continue
+		}
+
+		if currentSlot >= triggerSlot {
+			logger.Info("trigger slot reached - executing", "triggerSlot", triggerSlot, "currentSlot", currentSlot)
+			return nil
+		}
+
+		if !deadline.IsZero() && time.Now().After(deadline) {
+			return report.WithReasonCode(report.ReasonCodeSlotTriggerNotReached,
+				fmt.Errorf("trigger slot %d not reached within sync.slot_trigger.max_wait=%s (current slot %d)", triggerSlot, v.syncConfig.SlotTrigger.MaxWait, currentSlot),
+			)
+		}
+
+		if !logged {
+			remainingSlots := triggerSlot - currentSlot
+			logger.Info("waiting for trigger slot",
+				"triggerSlot", triggerSlot,
+				"currentSlot", currentSlot,
+				"remainingSlots", remainingSlots,
+				"estimatedWait", (time.Duration(remainingSlots) * approximateSlotDuration).String(),
+			)
+			logged = true
+		}
+
+		if err := timeutil.Sleep(ctx, v.syncConfig.SlotTrigger.PollInterval); err != nil {
+			return fmt.Errorf("stopped waiting for trigger slot %d: %w", triggerSlot, err)
+		}
+	}
+}
+
+// epochBoundaryTriggerSlot returns the first slot that is offset slots past an epoch boundary and not yet
+// passed - the current epoch's boundary is used while still within the offset, otherwise the next one
+func epochBoundaryTriggerSlot(epochInfo *rpc.EpochInfo, offset uint64) uint64 {
+	currentEpochStartSlot := epochInfo.AbsoluteSlot - epochInfo.SlotIndex
+	if epochInfo.SlotIndex <= offset {
+		return currentEpochStartSlot + offset
+	}
+	return currentEpochStartSlot + epochInfo.SlotsInEpoch + offset
+}
@@ -0,0 +1,283 @@
+package validator
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/config"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/rpc"
+)
+
+func TestEpochBoundaryTriggerSlot(t *testing.T) {
+	tests := []struct {
+		name      string
+		epochInfo rpc.EpochInfo
+		offset    uint64
+		want      uint64
+	}{
+		{
+			name:      "past offset targets next epoch boundary",
+			epochInfo: rpc.EpochInfo{AbsoluteSlot: 1000, SlotIndex: 500, SlotsInEpoch: 1000},
+			offset:    100,
+			want:      1600,
+		},
+		{
+			name:      "within offset targets current epoch boundary",
+			epochInfo: rpc.EpochInfo{AbsoluteSlot: 1050, SlotIndex: 50, SlotsInEpoch: 1000},
+			offset:    100,
+			want:      1100,
+		},
+		{
+			name:      "zero offset targets next epoch boundary",
+			epochInfo: rpc.EpochInfo{AbsoluteSlot: 1999, SlotIndex: 999, SlotsInEpoch: 1000},
+			offset:    0,
+			want:      2000,
+		},
+		{
+			name:      "exactly at offset targets current slot",
+			epochInfo: rpc.EpochInfo{AbsoluteSlot: 1100, SlotIndex: 100, SlotsInEpoch: 1000},
+			offset:    100,
+			want:      1100,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := epochBoundaryTriggerSlot(&tt.epochInfo, tt.offset)
+			if got != tt.want {
+				t.Errorf("epochBoundaryTriggerSlot() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+// newSlotServer returns a mock RPC server whose slot advances by one on every getSlot call,
+// failing the first failingPolls getSlot calls
+func newSlotServer(t *testing.T, startSlot uint64, failingPolls int) *httptest.Server {
+	t.Helper()
+	slot := startSlot
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req rpc.JSONRPCRequest
+		json.NewDecoder(r.Body).Decode(&req)
+
+		if req.Method == "getSlot" && failingPolls > 0 {
+			failingPolls--
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+
+		resp := rpc.JSONRPCResponse{JSONRPC: "2.0", ID: req.ID}
+		switch req.Method {
+		case "getSlot":
+			resp.Result = slot
+			slot++
+		case "getEpochInfo":
+			resp.Result = map[string]interface{}{
+				"absoluteSlot": slot,
+				"slotIndex":    slot % 100,
+				"slotsInEpoch": 100,
+			}
+		}
+		json.NewEncoder(w).Encode(resp)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestValidator_waitForTriggerSlot(t *testing.T) {
+	tests := []struct {
+		name         string
+		startSlot    uint64
+		failingPolls int
+		slotTrigger  config.SlotTrigger
+		wantErr      bool
+	}{
+		{
+			name:      "absolute slot already reached",
+			startSlot: 500,
+			slotTrigger: config.SlotTrigger{
+				Enabled:      true,
+				Slot:         400,
+				PollInterval: time.Millisecond,
+			},
+			wantErr: false,
+		},
+		{
+			name:      "absolute slot reached after polling",
+			startSlot: 500,
+			slotTrigger: config.SlotTrigger{
+				Enabled:      true,
+				Slot:         503,
+				PollInterval: time.Millisecond,
+			},
+			wantErr: false,
+		},
+		{
+			name:      "epoch boundary offset reached after polling",
+			startSlot: 198,
+			slotTrigger: config.SlotTrigger{
+				Enabled:             true,
+				EpochBoundaryOffset: 1,
+				PollInterval:        time.Millisecond,
+			},
+			wantErr: false,
+		},
+		{
+			name:         "transient getSlot failures are retried",
+			startSlot:    500,
+			failingPolls: 3,
+			slotTrigger: config.SlotTrigger{
+				Enabled:      true,
+				Slot:         501,
+				PollInterval: time.Millisecond,
+			},
+			wantErr: false,
+		},
+		{
+			name:         "persistent getSlot failures give up at max wait",
+			startSlot:    500,
+			failingPolls: 1_000_000,
+			slotTrigger: config.SlotTrigger{
+				Enabled:      true,
+				Slot:         501,
+				PollInterval: time.Millisecond,
+				MaxWait:      20 * time.Millisecond,
+			},
+			wantErr: true,
+		},
+		{
+			name:      "max wait exceeded",
+			startSlot: 500,
+			slotTrigger: config.SlotTrigger{
+				Enabled:      true,
+				Slot:         1_000_000,
+				PollInterval: time.Millisecond,
+				MaxWait:      20 * time.Millisecond,
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newSlotServer(t, tt.startSlot, tt.failingPolls)
+			v := &Validator{
+				syncConfig: config.Sync{SlotTrigger: tt.slotTrigger},
+				rpcClient:  rpc.NewClient(server.URL),
+				logger:     log.WithPrefix("validator"),
+			}
+
+			err := v.waitForTriggerSlot(context.Background(), v.logger)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("waitForTriggerSlot() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidator_waitForTriggerSlot_Cancelled(t *testing.T) {
+	server := newSlotServer(t, 500, 0)
+	v := &Validator{
+		syncConfig: config.Sync{SlotTrigger: config.SlotTrigger{
+			Enabled:      true,
+			Slot:         1_000_000,
+			PollInterval: time.Hour,
+		}},
+		rpcClient: rpc.NewClient(server.URL),
+		logger:    log.WithPrefix("validator"),
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(20*time.Millisecond, cancel)
+
+	started := time.Now()
+	err := v.waitForTriggerSlot(ctx, v.logger)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("waitForTriggerSlot() error = %v, want context.Canceled", err)
+	}
+	if time.Since(started) > 5*time.Second {
+		t.Errorf("waitForTriggerSlot() took %s to stop after cancellation", time.Since(started))
+	}
+}
+
+// newRoleServer returns a mock RPC server reporting the given identity, with no nodes in gossip
+func newRoleServer(t *testing.T, identity *string) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req rpc.JSONRPCRequest
+		json.NewDecoder(r.Body).Decode(&req)
+
+		resp := rpc.JSONRPCResponse{JSONRPC: "2.0", ID: req.ID}
+		switch req.Method {
+		case "getVersion":
+			resp.Result = map[string]interface{}{"solana-core": "2.3.6"}
+		case "getIdentity":
+			resp.Result = map[string]interface{}{"identity": *identity}
+		case "getHealth":
+			resp.Result = "ok"
+		case "getClusterNodes":
+			resp.Result = []interface{}{}
+		}
+		json.NewEncoder(w).Encode(resp)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestValidator_recheckRoleAfterWait(t *testing.T) {
+	const activeIdentity = "9WzDXwBbmkg8ZTbNMqUxvQRAyrZzDsGYdLVL9zYtAWWM"
+	const passiveIdentity = "7xKXtg2CW87d97TXJSDpbD5jBkheTqA83TZRuJosgAsU"
+
+	tests := []struct {
+		name             string
+		identityAfter    string
+		enabledWhenNoLdr bool
+		wantErr          bool
+	}{
+		{
+			name:             "still passive",
+			identityAfter:    passiveIdentity,
+			enabledWhenNoLdr: true,
+			wantErr:          false,
+		},
+		{
+			name:             "failed over to active during wait",
+			identityAfter:    activeIdentity,
+			enabledWhenNoLdr: true,
+			wantErr:          true,
+		},
+		{
+			name:             "active leader left gossip during wait",
+			identityAfter:    passiveIdentity,
+			enabledWhenNoLdr: false,
+			wantErr:          true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			identity := passiveIdentity
+			server := newRoleServer(t, &identity)
+			v := &Validator{
+				ActiveIdentityPublicKey:  activeIdentity,
+				PassiveIdentityPublicKey: passiveIdentity,
+				State:                    State{IdentityPublicKey: passiveIdentity},
+				syncConfig:               config.Sync{EnabledWhenNoActiveLeaderInGossip: tt.enabledWhenNoLdr},
+				rpcClient:                rpc.NewClient(server.URL),
+				logger:                   log.WithPrefix("validator"),
+			}
+
+			identity = tt.identityAfter
+			err := v.recheckRoleAfterWait(v.logger)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("recheckRoleAfterWait() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
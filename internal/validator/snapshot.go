@@ -0,0 +1,119 @@
+package validator
+
+import (
+	"strings"
+	"time"
+)
+
+// Snapshot represents a point-in-time view of the validator and the sources syncs decide on, for display.
+// Each source is looked up independently so one failing source still leaves the others visible - its
+// error is recorded in the source's Error field.
+type Snapshot struct {
+	Time                     time.Time
+	Cluster                  string
+	Client                   string
+	ActiveIdentityPublicKey  string
+	PassiveIdentityPublicKey string
+	State                    StateSnapshot
+	ActiveLeader             ActiveLeaderSnapshot
+	LatestRelease            LatestReleaseSnapshot
+	SFDP                     SFDPSnapshot
+}
+
+// StateSnapshot represents the validator's refreshed state
+type StateSnapshot struct {
+	VersionString     string
+	IdentityPublicKey string
+	Role              string
+	HealthStatus      string
+	Error             string
+}
+
+// ActiveLeaderSnapshot represents whether the active identity is present in gossip
+type ActiveLeaderSnapshot struct {
+	InGossip      bool
+	GossipAddress string
+	Version       string
+	Error         string
+}
+
+// LatestReleaseSnapshot represents the latest release syncs would target, before SFDP compliance is applied
+type LatestReleaseSnapshot struct {
+	Version string
+	Tag     string
+	Error   string
+}
+
+// SFDPSnapshot represents the latest SFDP version requirements for the validator's client
+type SFDPSnapshot struct {
+	Epoch       int
+	Requirement string
+	Error       string
+}
+
+// Snapshot refreshes the validator's state and looks up gossip, the latest release and SFDP requirements
+// without making a sync decision, executing commands or recording state
+func (v *Validator) Snapshot() Snapshot {
+	return Snapshot{
+		Time:                     time.Now().UTC(),
+		Cluster:                  v.State.Cluster,
+		Client:                   v.cfg.Client,
+		ActiveIdentityPublicKey:  v.ActiveIdentityPublicKey,
+		PassiveIdentityPublicKey: v.PassiveIdentityPublicKey,
+		State:                    v.snapshotState(),
+		ActiveLeader:             v.snapshotActiveLeader(),
+		LatestRelease:            v.snapshotLatestRelease(),
+		SFDP:                     v.snapshotSFDP(),
+	}
+}
+
+// snapshotState refreshes and returns the validator's state - whatever was refreshed before a failure is kept
+func (v *Validator) snapshotState() (snapshot StateSnapshot) {
+	if err := v.refreshState(); err != nil {
+		snapshot.Error = err.Error()
+	}
+	snapshot.VersionString = v.State.VersionString
+	snapshot.IdentityPublicKey = v.State.IdentityPublicKey
+	snapshot.HealthStatus = v.State.HealthStatus
+	snapshot.Role = v.Role()
+	return snapshot
+}
+
+// snapshotActiveLeader looks up the active identity in gossip
+func (v *Validator) snapshotActiveLeader() (snapshot ActiveLeaderSnapshot) {
+	found, node, err := v.rpcClient.GetNodeWithIdentityPublicKey(v.ActiveIdentityPublicKey)
+	if err != nil {
+		snapshot.Error = err.Error()
+		return snapshot
+	}
+	if found {
+		snapshot.InGossip = true
+		snapshot.GossipAddress = strings.Split(node.Gossip, ":")[0]
+		snapshot.Version = node.Version
+	}
+	return snapshot
+}
+
+// snapshotLatestRelease looks up the release syncs would target
+func (v *Validator) snapshotLatestRelease() (snapshot LatestReleaseSnapshot) {
+	latestVersion, err := v.lookupTargetVersion()
+	if err != nil {
+		snapshot.Error = err.Error()
+		return snapshot
+	}
+	snapshot.Version = latestVersion.Core().String()
+	snapshot.Tag = v.githubClient.TagNameForVersion(latestVersion)
+	return snapshot
+}
+
+// snapshotSFDP looks up the latest SFDP requirements
+func (v *Validator) snapshotSFDP() (snapshot SFDPSnapshot) {
+	requirements, err := v.sfdpClient.GetLatestRequirements()
+	if err != nil {
+		snapshot.Error = err.Error()
+		return snapshot
+	}
+	snapshot.Epoch = requirements.Epoch
+	snapshot.Requirement = requirements.Constraints.String()
+	return snapshot
+}
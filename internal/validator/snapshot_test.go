@@ -0,0 +1,122 @@
+package validator
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/charmbracelet/log"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/rpc"
+)
+
+// newSnapshotServer returns a mock RPC server for a validator running identity, reporting unhealthy
+// when healthy is false, with clusterNodes in gossip
+func newSnapshotServer(t *testing.T, identity string, healthy bool, clusterNodes []interface{}) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req rpc.JSONRPCRequest
+		json.NewDecoder(r.Body).Decode(&req)
+
+		resp := rpc.JSONRPCResponse{JSONRPC: "2.0", ID: req.ID}
+		switch req.Method {
+		case "getVersion":
+			resp.Result = map[string]interface{}{"solana-core": "2.3.6"}
+		case "getIdentity":
+			resp.Result = map[string]interface{}{"identity": identity}
+		case "getHealth":
+			if healthy {
+				resp.Result = "ok"
+			} else {
+				resp.Error = &rpc.RPCError{Code: -32005, Message: "Node is behind by 42 slots"}
+			}
+		case "getClusterNodes":
+			resp.Result = clusterNodes
+		}
+		json.NewEncoder(w).Encode(resp)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestValidator_snapshotState(t *testing.T) {
+	tests := []struct {
+		name       string
+		healthy    bool
+		wantHealth string
+		wantErr    bool
+	}{
+		{
+			name:       "healthy",
+			healthy:    true,
+			wantHealth: "ok",
+			wantErr:    false,
+		},
+		{
+			name:       "unhealthy keeps refreshed state",
+			healthy:    false,
+			wantHealth: HealthStatusUnhealthy,
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newSnapshotServer(t, "passive-key", tt.healthy, nil)
+			v := &Validator{
+				ActiveIdentityPublicKey:  "active-key",
+				PassiveIdentityPublicKey: "passive-key",
+				rpcClient:                rpc.NewClient(server.URL),
+				logger:                   log.WithPrefix("validator"),
+			}
+
+			snapshot := v.snapshotState()
+			if (snapshot.Error != "") != tt.wantErr {
+				t.Errorf("snapshotState() error = %q, wantErr %v", snapshot.Error, tt.wantErr)
+			}
+			if snapshot.VersionString != "2.3.6" || snapshot.IdentityPublicKey != "passive-key" || snapshot.Role != RolePassive {
+				t.Errorf("snapshotState() = %+v, want version 2.3.6, identity passive-key and role %s", snapshot, RolePassive)
+			}
+			if snapshot.HealthStatus != tt.wantHealth {
+				t.Errorf("snapshotState() health = %q, want %q", snapshot.HealthStatus, tt.wantHealth)
+			}
+		})
+	}
+}
+
+func TestValidator_snapshotActiveLeader(t *testing.T) {
+	tests := []struct {
+		name         string
+		clusterNodes []interface{}
+		want         ActiveLeaderSnapshot
+	}{
+		{
+			name: "active leader in gossip",
+			clusterNodes: []interface{}{
+				map[string]interface{}{"pubkey": "active-key", "gossip": "10.0.0.1:8001", "version": "2.3.5"},
+			},
+			want: ActiveLeaderSnapshot{InGossip: true, GossipAddress: "10.0.0.1", Version: "2.3.5"},
+		},
+		{
+			name: "active leader not in gossip",
+			clusterNodes: []interface{}{
+				map[string]interface{}{"pubkey": "passive-key", "gossip": "10.0.0.2:8001", "version": "2.3.5"},
+			},
+			want: ActiveLeaderSnapshot{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newSnapshotServer(t, "passive-key", true, tt.clusterNodes)
+			v := &Validator{
+				ActiveIdentityPublicKey: "active-key",
+				rpcClient:               rpc.NewClient(server.URL),
+			}
+
+			if got := v.snapshotActiveLeader(); got != tt.want {
+				t.Errorf("snapshotActiveLeader() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
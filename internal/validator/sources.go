@@ -0,0 +1,40 @@
+package validator
+
+import (
+	"slices"
+
+	"github.com/charmbracelet/log"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/failinject"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/report"
+)
+
+// markSourceUnavailable records that a data source could not be queried during the current sync, in the
+// decision's unavailable sources
+func (v *Validator) markSourceUnavailable(source string) {
+	if !slices.Contains(v.lastDecision.UnavailableSources, source) {
+		v.lastDecision.UnavailableSources = append(v.lastDecision.UnavailableSources, source)
+	}
+}
+
+// lookupSFDPRequirements looks up the latest SFDP requirements for the current sync. When SFDP is unavailable
+// and sync.sfdp_required is false the sync proceeds without the SFDP clamp - false is returned without an error.
+func (v *Validator) lookupSFDPRequirements(syncLogger *log.Logger) (available bool, err error) {
+	err = v.failureInjector.Check(failinject.StageSFDP)
+	if err != nil {
+		return false, err
+	}
+
+	sfdpRequirements, err := v.sfdpClient.GetLatestRequirements()
+	if err != nil {
+		v.markSourceUnavailable(report.SourceSFDP)
+		if v.syncConfig.SFDPRequired {
+			return false, err
+		}
+		syncLogger.Warn("SFDP unavailable and sync.sfdp_required=false - proceeding without the SFDP clamp", "error", err)
+		return false, nil
+	}
+	v.sfdpRequirements = sfdpRequirements
+
+	v.logger.Debug("got latest requirements from SFDP", "sfdpRequirements", sfdpRequirements.Constraints.String())
+	return true, nil
+}
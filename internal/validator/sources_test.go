@@ -0,0 +1,89 @@
+package validator
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/charmbracelet/log"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/config"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/constants"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/report"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/sfdp"
+)
+
+func TestValidator_markSourceUnavailable(t *testing.T) {
+	v := &Validator{}
+	v.markSourceUnavailable(report.SourceSFDP)
+	v.markSourceUnavailable(report.SourceGitHub)
+	v.markSourceUnavailable(report.SourceSFDP)
+
+	want := []string{report.SourceSFDP, report.SourceGitHub}
+	if !slices.Equal(v.lastDecision.UnavailableSources, want) {
+		t.Errorf("UnavailableSources = %v, want %v", v.lastDecision.UnavailableSources, want)
+	}
+}
+
+func TestValidator_lookupSFDPRequirements(t *testing.T) {
+	tests := []struct {
+		name            string
+		serverStatus    int
+		sfdpRequired    bool
+		wantAvailable   bool
+		wantErr         bool
+		wantUnavailable []string
+	}{
+		{
+			name:          "available",
+			serverStatus:  http.StatusOK,
+			sfdpRequired:  true,
+			wantAvailable: true,
+		},
+		{
+			name:            "unavailable and required",
+			serverStatus:    http.StatusServiceUnavailable,
+			sfdpRequired:    true,
+			wantErr:         true,
+			wantUnavailable: []string{report.SourceSFDP},
+		},
+		{
+			name:            "unavailable and not required",
+			serverStatus:    http.StatusServiceUnavailable,
+			wantUnavailable: []string{report.SourceSFDP},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.serverStatus)
+				_, _ = w.Write([]byte(`{"data":[{"epoch":500,"cluster":"testnet","agave_min_version":"2.0.0"}]}`))
+			}))
+			defer server.Close()
+
+			sfdp.SetBaseURL(server.URL)
+			defer sfdp.SetBaseURL("https://api.solana.org/api")
+
+			v := &Validator{
+				logger:     log.WithPrefix("validator"),
+				syncConfig: config.Sync{SFDPRequired: tt.sfdpRequired},
+				sfdpClient: sfdp.NewClient(sfdp.Options{Cluster: constants.ClusterNameTestnet, Client: constants.ClientNameAgave}),
+			}
+
+			available, err := v.lookupSFDPRequirements(v.logger)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("lookupSFDPRequirements() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if available != tt.wantAvailable {
+				t.Errorf("lookupSFDPRequirements() = %v, want %v", available, tt.wantAvailable)
+			}
+			if available && v.sfdpRequirements == nil {
+				t.Error("lookupSFDPRequirements() should set sfdpRequirements when available")
+			}
+			if !slices.Equal(v.lastDecision.UnavailableSources, tt.wantUnavailable) {
+				t.Errorf("UnavailableSources = %v, want %v", v.lastDecision.UnavailableSources, tt.wantUnavailable)
+			}
+		})
+	}
+}
@@ -0,0 +1,128 @@
+package validator
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/log"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/report"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/rpc"
+)
+
+// lamportsPerSOL is the number of lamports in one SOL
+const lamportsPerSOL = 1_000_000_000
+
+// stakeActivationAllowsSync decides whether pending stake changes for the validator's vote account allow
+// syncing, recording a skipped outcome when they don't. Upgrades leaving a running version below the SFDP
+// minimum are critical and never deferred, nor are SFDP participants in a strict stage.
+func (v *Validator) stakeActivationAllowsSync(syncLogger *log.Logger) (allowed bool, err error) {
+	stakeActivation := v.syncConfig.StakeActivation
+	if !stakeActivation.Enabled {
+		return true, nil
+	}
+
+	if v.runningVersionBelowSFDPMinimum() {
+		syncLogger.Warn("running version is below the SFDP minimum - not deferring for pending stake changes",
+			"sfdpMinVersion", v.sfdpRequirements.MinVersion.String(),
+		)
+		return true, nil
+	}
+
+	if v.sfdpStageIsStrict() {
+		syncLogger.Info("SFDP participant stage tracks releases strictly - not deferring for pending stake changes", "sfdpStage", v.sfdpParticipantStage())
+		return true, nil
+	}
+
+	voteAccount, err := v.voteAccountPublicKey()
+	if err != nil {
+		return false, err
+	}
+
+	epochInfo, err := v.rpcClient.GetEpochInfo()
+	if err != nil {
+		return false, fmt.Errorf("failed to get current epoch for stake activation check: %w", err)
+	}
+
+	delegations, err := v.stakeRPCClient().GetStakeDelegations(voteAccount)
+	if err != nil {
+		return false, fmt.Errorf("failed to get stake delegated to vote account %s: %w", voteAccount, err)
+	}
+
+	activating, deactivating := pendingStakeChanges(delegations, epochInfo.Epoch)
+	pendingSOL := float64(activating+deactivating) / lamportsPerSOL
+	logger := syncLogger.With(
+		"voteAccount", voteAccount,
+		"epoch", epochInfo.Epoch,
+		"activatingSOL", float64(activating)/lamportsPerSOL,
+		"deactivatingSOL", float64(deactivating)/lamportsPerSOL,
+	)
+
+	if pendingSOL > stakeActivation.MaxPendingStakeSOL {
+		logger.Warn("large stake changes pending this epoch - deferring sync", "maxPendingStakeSOL", stakeActivation.MaxPendingStakeSOL)
+		v.recordOutcome(report.OutcomeSkipped, report.ReasonCodeStakeActivationPending, fmt.Sprintf(
+			"%.0f SOL of stake changes pending in epoch %d exceeds sync.stake_activation.max_pending_stake_sol=%.0f",
+			pendingSOL, epochInfo.Epoch, stakeActivation.MaxPendingStakeSOL,
+		))
+		return false, nil
+	}
+
+	logger.Debug("pending stake changes within limits")
+	return true, nil
+}
+
+// pendingStakeChanges sums the stake activating and deactivating in the given epoch, in lamports - stake
+// activated and deactivated in the same epoch never becomes effective so is not counted
+func pendingStakeChanges(delegations []rpc.StakeDelegation, epoch uint64) (activating uint64, deactivating uint64) {
+	for _, delegation := range delegations {
+		switch {
+		case delegation.ActivationEpoch == epoch && delegation.DeactivationEpoch == epoch:
+			continue
+		case delegation.ActivationEpoch == epoch:
+			activating += delegation.Stake
+		case delegation.DeactivationEpoch == epoch:
+			deactivating += delegation.Stake
+		}
+	}
+	return activating, deactivating
+}
+
+// voteAccountPublicKey returns the configured vote account, otherwise looks up the vote account whose
+// validator identity is the active identity - lookups are cached as vote accounts don't change
+func (v *Validator) voteAccountPublicKey() (voteAccount string, err error) {
+	if v.syncConfig.StakeActivation.VoteAccount != "" {
+		return v.syncConfig.StakeActivation.VoteAccount, nil
+	}
+	if v.voteAccount != "" {
+		return v.voteAccount, nil
+	}
+
+	voteAccounts, err := v.rpcClient.GetVoteAccounts()
+	if err != nil {
+		return "", fmt.Errorf("failed to look up vote account: %w", err)
+	}
+
+	for _, account := range voteAccounts.All() {
+		if account.NodePubkey == v.ActiveIdentityPublicKey {
+			v.voteAccount = account.VotePubkey
+			return v.voteAccount, nil
+		}
+	}
+
+	return "", fmt.Errorf("no vote account found for active identity %s - set sync.stake_activation.vote_account", v.ActiveIdentityPublicKey)
+}
+
+// stakeRPCClient returns the client stake accounts are queried with
+func (v *Validator) stakeRPCClient() *rpc.Client {
+	if v.syncConfig.StakeActivation.RPCURL != "" {
+		return rpc.NewClient(v.syncConfig.StakeActivation.RPCURL)
+	}
+	return v.rpcClient
+}
+
+// runningVersionBelowSFDPMinimum returns true when the SFDP requirements looked up during this sync have a
+// minimum version the running version is below
+func (v *Validator) runningVersionBelowSFDPMinimum() bool {
+	if v.sfdpRequirements == nil || !v.sfdpRequirements.HasMinVersion || v.State.Version == nil {
+		return false
+	}
+	return v.State.Version.Core().LessThan(v.sfdpRequirements.MinVersion)
+}
@@ -0,0 +1,221 @@
+package validator
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/charmbracelet/log"
+	goversion "github.com/hashicorp/go-version"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/config"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/report"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/rpc"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/sfdp"
+)
+
+func TestPendingStakeChanges(t *testing.T) {
+	delegations := []rpc.StakeDelegation{
+		{Stake: 100, ActivationEpoch: 800, DeactivationEpoch: 18446744073709551615}, // activating
+		{Stake: 200, ActivationEpoch: 700, DeactivationEpoch: 800},                  // deactivating
+		{Stake: 400, ActivationEpoch: 800, DeactivationEpoch: 800},                  // never effective
+		{Stake: 800, ActivationEpoch: 700, DeactivationEpoch: 18446744073709551615}, // active
+		{Stake: 1600, ActivationEpoch: 600, DeactivationEpoch: 700},                 // inactive
+	}
+
+	activating, deactivating := pendingStakeChanges(delegations, 800)
+	if activating != 100 || deactivating != 200 {
+		t.Errorf("pendingStakeChanges() = %d, %d, want 100, 200", activating, deactivating)
+	}
+}
+
+// newStakeServer returns a mock RPC server at epoch 800 with a vote account for active-key and stake
+// delegated to it activating activatingSOL in the current epoch
+func newStakeServer(t *testing.T, activatingSOL uint64) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req rpc.JSONRPCRequest
+		json.NewDecoder(r.Body).Decode(&req)
+
+		resp := rpc.JSONRPCResponse{JSONRPC: "2.0", ID: req.ID}
+		switch req.Method {
+		case "getVoteAccounts":
+			resp.Result = map[string]interface{}{
+				"current": []interface{}{
+					map[string]interface{}{"votePubkey": "vote-key", "nodePubkey": "active-key", "activatedStake": 100},
+				},
+			}
+		case "getEpochInfo":
+			resp.Result = map[string]interface{}{"epoch": 800, "slotsInEpoch": 432000}
+		case "getProgramAccounts":
+			delegation := map[string]interface{}{
+				"voter":             "vote-key",
+				"stake":             strconv.FormatUint(activatingSOL*lamportsPerSOL, 10),
+				"activationEpoch":   "800",
+				"deactivationEpoch": "18446744073709551615",
+			}
+			resp.Result = []interface{}{
+				map[string]interface{}{
+					"pubkey": "stake-key",
+					"account": map[string]interface{}{"data": map[string]interface{}{"parsed": map[string]interface{}{
+						"type": "delegated",
+						"info": map[string]interface{}{"stake": map[string]interface{}{"delegation": delegation}},
+					}}},
+				},
+			}
+		}
+		json.NewEncoder(w).Encode(resp)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestValidator_stakeActivationAllowsSync(t *testing.T) {
+	tests := []struct {
+		name           string
+		enabled        bool
+		activatingSOL  uint64
+		runningVersion string
+		sfdpMinVersion string
+		sfdpStage      string
+		wantAllowed    bool
+	}{
+		{
+			name:          "disabled",
+			enabled:       false,
+			activatingSOL: 50000,
+			wantAllowed:   true,
+		},
+		{
+			name:          "pending stake within limits",
+			enabled:       true,
+			activatingSOL: 5000,
+			wantAllowed:   true,
+		},
+		{
+			name:          "pending stake exceeds limit",
+			enabled:       true,
+			activatingSOL: 50000,
+			wantAllowed:   false,
+		},
+		{
+			name:           "running version below SFDP minimum is never deferred",
+			enabled:        true,
+			activatingSOL:  50000,
+			runningVersion: "2.3.5",
+			sfdpMinVersion: "2.3.6",
+			wantAllowed:    true,
+		},
+		{
+			name:           "running version at SFDP minimum is deferred",
+			enabled:        true,
+			activatingSOL:  50000,
+			runningVersion: "2.3.6",
+			sfdpMinVersion: "2.3.6",
+			wantAllowed:    false,
+		},
+		{
+			name:          "strict SFDP participant stage is never deferred",
+			enabled:       true,
+			activatingSOL: 50000,
+			sfdpStage:     "Approved",
+			wantAllowed:   true,
+		},
+		{
+			name:          "other SFDP participant stage is deferred",
+			enabled:       true,
+			activatingSOL: 50000,
+			sfdpStage:     "Pending",
+			wantAllowed:   false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newStakeServer(t, tt.activatingSOL)
+			v := &Validator{
+				ActiveIdentityPublicKey: "active-key",
+				syncConfig: config.Sync{
+					StakeActivation: config.StakeActivation{
+						Enabled:            tt.enabled,
+						MaxPendingStakeSOL: 10000,
+					},
+					SFDPParticipant: config.SFDPParticipant{Enabled: true, StrictStages: []string{"Approved"}},
+				},
+				rpcClient: rpc.NewClient(server.URL),
+				logger:    log.WithPrefix("validator"),
+			}
+			if tt.sfdpStage != "" {
+				v.sfdpParticipant = &sfdp.Participant{State: tt.sfdpStage}
+			}
+			if tt.runningVersion != "" {
+				v.State.Version = goversion.Must(goversion.NewVersion(tt.runningVersion))
+				v.sfdpRequirements = &sfdp.Requirements{
+					HasMinVersion: true,
+					MinVersion:    goversion.Must(goversion.NewVersion(tt.sfdpMinVersion)),
+				}
+			}
+
+			allowed, err := v.stakeActivationAllowsSync(log.WithPrefix("sync"))
+			if err != nil {
+				t.Fatalf("stakeActivationAllowsSync() error = %v", err)
+			}
+			if allowed != tt.wantAllowed {
+				t.Errorf("stakeActivationAllowsSync() = %v, want %v", allowed, tt.wantAllowed)
+			}
+			if !tt.wantAllowed && v.LastDecision().Outcome != report.OutcomeSkipped {
+				t.Errorf("LastDecision().Outcome = %q, want %q", v.LastDecision().Outcome, report.OutcomeSkipped)
+			}
+			if !tt.wantAllowed && v.LastDecision().ReasonCode != report.ReasonCodeStakeActivationPending {
+				t.Errorf("LastDecision().ReasonCode = %q, want %q", v.LastDecision().ReasonCode, report.ReasonCodeStakeActivationPending)
+			}
+		})
+	}
+}
+
+func TestValidator_voteAccountPublicKey(t *testing.T) {
+	tests := []struct {
+		name            string
+		configured      string
+		activeIdentity  string
+		wantVoteAccount string
+		wantErr         bool
+	}{
+		{
+			name:            "configured vote account",
+			configured:      "configured-vote-key",
+			activeIdentity:  "active-key",
+			wantVoteAccount: "configured-vote-key",
+		},
+		{
+			name:            "looked up from active identity",
+			activeIdentity:  "active-key",
+			wantVoteAccount: "vote-key",
+		},
+		{
+			name:           "no vote account for active identity",
+			activeIdentity: "other-key",
+			wantErr:        true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newStakeServer(t, 0)
+			v := &Validator{
+				ActiveIdentityPublicKey: tt.activeIdentity,
+				syncConfig:              config.Sync{StakeActivation: config.StakeActivation{VoteAccount: tt.configured}},
+				rpcClient:               rpc.NewClient(server.URL),
+			}
+
+			voteAccount, err := v.voteAccountPublicKey()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("voteAccountPublicKey() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if voteAccount != tt.wantVoteAccount {
+				t.Errorf("voteAccountPublicKey() = %q, want %q", voteAccount, tt.wantVoteAccount)
+			}
+		})
+	}
+}
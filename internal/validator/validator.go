@@ -1,17 +1,27 @@
 package validator
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/log"
 	"github.com/hashicorp/go-version"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/adoption"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/cadence"
 	"github.com/sol-strategies/solana-validator-version-sync/internal/config"
 	"github.com/sol-strategies/solana-validator-version-sync/internal/constants"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/failinject"
 	"github.com/sol-strategies/solana-validator-version-sync/internal/github"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/logging"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/notify"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/report"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/restart"
 	"github.com/sol-strategies/solana-validator-version-sync/internal/rpc"
 	"github.com/sol-strategies/solana-validator-version-sync/internal/sfdp"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/state"
 	"github.com/sol-strategies/solana-validator-version-sync/internal/sync_commands"
 	"github.com/sol-strategies/solana-validator-version-sync/internal/versiondiff"
 )
@@ -30,21 +40,60 @@ type Options struct {
 	Cluster         string
 	SyncConfig      config.Sync
 	ValidatorConfig config.Validator
+	// HardForks are the cluster's configured hard fork slots, exposed to commands
+	HardForks []uint64
+	// StateStore persists sync state across runs, an in-memory store is used when nil
+	StateStore *state.Store
+	// FailureInjector deliberately fails syncs at configured stages, nil disables injection
+	FailureInjector *failinject.Injector
+	// ReadOnly disables executing commands regardless of config
+	ReadOnly bool
+	// Notifier sends notifications such as sync heads-ups, nil disables notifications
+	Notifier *notify.Notifier
+	// NotifyConfig configures the pending state notifications sent through Notifier
+	NotifyConfig config.Notify
 }
 
 // Validator represents the validator - its state can be refreshed with the RefreshState method
 type Validator struct {
 	ActiveIdentityPublicKey  string
 	PassiveIdentityPublicKey string
-	State                    State
+	// OtherIdentityPublicKeys are the public keys of validator.identities.others, keyed by name
+	OtherIdentityPublicKeys map[string]string
+	State                   State
 
 	versionConstraint version.Constraints
+	hardForks         []uint64
 	syncConfig        config.Sync
 	cfg               config.Validator
 	logger            *log.Logger
 	rpcClient         *rpc.Client
 	sfdpClient        *sfdp.Client
 	githubClient      *github.Client
+	stateStore        *state.Store
+	failureInjector   *failinject.Injector
+	notifier          *notify.Notifier
+	notifyConfig      config.Notify
+	readOnly          bool
+	lastDecision      report.Decision
+	// downgradeFloor is the lowest version downgrades may target, nil when validator.downgrade_floor is not set
+	downgradeFloor *version.Version
+	// lastCommandRuns are the command runs of the current sync, in execution order
+	lastCommandRuns []state.CommandRun
+	// identityRolesChecked is set once the identities have been checked against vote accounts
+	identityRolesChecked bool
+	// voteAccount is the vote account looked up from the active identity
+	voteAccount string
+	// sfdpRequirements are the SFDP requirements looked up during the current sync, nil when not looked up
+	sfdpRequirements *sfdp.Requirements
+	// sfdpParticipant is the SFDP participation looked up during the current sync, nil when unknown
+	sfdpParticipant *sfdp.Participant
+	// restartManifest is the cluster restart manifest published for the cluster during the current sync, nil when none
+	restartManifest *restart.Manifest
+	// configRevision is the configuration revision last applied and the target one, looked up during the current sync
+	configRevision configRevision
+	// adoptionSource is the stake-weighted version distribution source of the adoption gate, created on first use
+	adoptionSource adoption.Source
 }
 
 // New creates a new Validator
@@ -55,22 +104,54 @@ func New(opts Options) (v *Validator, err error) {
 		},
 		ActiveIdentityPublicKey:  opts.ValidatorConfig.Identities.ActiveKeyPair.PublicKey().String(),
 		PassiveIdentityPublicKey: opts.ValidatorConfig.Identities.PassiveKeyPair.PublicKey().String(),
+		OtherIdentityPublicKeys:  make(map[string]string, len(opts.ValidatorConfig.Identities.OtherKeyPairs)),
+		hardForks:                opts.HardForks,
 		syncConfig:               opts.SyncConfig,
 		cfg:                      opts.ValidatorConfig,
-		logger:                   log.WithPrefix("validator"),
+		stateStore:               opts.StateStore,
+		failureInjector:          opts.FailureInjector,
+		notifier:                 opts.Notifier,
+		notifyConfig:             opts.NotifyConfig,
+		readOnly:                 opts.ReadOnly,
+		logger:                   logging.WithPrefix("validator"),
 	}
 
-	// set supplied version constraint
+	for name, keyPair := range opts.ValidatorConfig.Identities.OtherKeyPairs {
+		v.OtherIdentityPublicKeys[name] = keyPair.PublicKey().String()
+	}
+
+	// fall back to keeping state in memory only
+	if v.stateStore == nil {
+		v.stateStore, err = state.NewStore("")
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// set supplied version constraint and downgrade floor
 	err = v.setVersionConstraint()
 	if err != nil {
 		return nil, err
 	}
+	err = v.setDowngradeFloor()
+	if err != nil {
+		return nil, err
+	}
 
 	// Create clients
 	v.rpcClient = rpc.NewClient(v.cfg.RPCURL)
-	v.githubClient, err = github.NewClient(github.Options{
-		Cluster: opts.Cluster,
-		Client:  v.cfg.Client,
+	if v.cfg.Client == constants.ClientNameAuto {
+		v.cfg.Client, err = v.detectClient()
+		if err != nil {
+			return nil, fmt.Errorf("failed to detect validator client: %w", err)
+		}
+	}
+	v.githubClient, err = github.SharedClient(github.Options{
+		Cluster:                  opts.Cluster,
+		Client:                   v.cfg.Client,
+		DisableMainnetPreference: !v.syncConfig.PreferMainnetVersion,
+		ReleaseFeeds:             v.syncConfig.ReleaseFeed.Enabled,
+		ReleaseFeedMaxAge:        v.syncConfig.ReleaseFeed.MaxAge,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to create github client: %w", err)
@@ -82,6 +163,7 @@ func New(opts Options) (v *Validator, err error) {
 
 	// Parse commands after copying the config
 	for i := range v.syncConfig.Commands {
+		v.syncConfig.Commands[i].SetDefaultEnvironmentPolicy(v.syncConfig.EnvironmentPolicy)
 		err = v.syncConfig.Commands[i].Parse()
 		if err != nil {
 			return nil, fmt.Errorf("failed to parse command %d (%s): %w", i, v.syncConfig.Commands[i].Name, err)
@@ -104,8 +186,32 @@ func (v *Validator) setVersionConstraint() (err error) {
 	return nil
 }
 
-// SyncVersion syncs the validator's version
-func (v *Validator) SyncVersion() (err error) {
+// SyncVersion syncs the validator's version - the decision it reached is available from LastDecision afterwards.
+// Cancelling ctx abandons any wait for the trigger slot.
+func (v *Validator) SyncVersion(ctx context.Context) (err error) {
+	startedAt := time.Now().UTC()
+	v.sfdpRequirements = nil
+	v.sfdpParticipant = nil
+	v.configRevision = configRevision{}
+	v.restartManifest = nil
+	v.lastCommandRuns = nil
+	v.lastDecision = report.Decision{
+		Time:    startedAt,
+		Cluster: v.State.Cluster,
+		Client:  v.cfg.Client,
+	}
+	defer func() {
+		v.lastDecision.Duration = time.Since(startedAt)
+		if err != nil {
+			reasonCode := report.ReasonCodeOf(err)
+			if errors.Is(err, failinject.ErrInjected) {
+				reasonCode = report.ReasonCodeInjectedFailure
+			}
+			v.recordOutcome(report.OutcomeFailed, reasonCode, err.Error())
+		}
+		v.notifyPendingState()
+	}()
+
 	// warn if active and passive identites are the same
 	if v.ActiveIdentityPublicKey == v.PassiveIdentityPublicKey {
 		v.logger.Warn("configured active and passive identites are the same",
@@ -124,57 +230,68 @@ func (v *Validator) SyncVersion() (err error) {
 		v.logger.Warn("sync.enabled_when_no_active_leader_in_gossip=true - syncing will be enabled when no active leader is found in gossip")
 	}
 
-	// refresh the validator's state
+	// refresh the validator's state, recording the observed health and role for flap detection - an unhealthy
+	// validator is still observed so health oscillating between syncs is detected
 	err = v.refreshState()
+	if err == nil || errors.Is(err, errUnhealthy) {
+		if observeErr := v.recordObservation(); observeErr != nil {
+			v.logger.Warn("failed to record health and role observation", "error", observeErr)
+		}
+	}
 	if err != nil {
 		return err
 	}
 
-	syncLogger := log.WithPrefix("sync").With(
+	// warn if the passive identity is the one staked on-chain
+	v.checkIdentityRoles()
+
+	v.lastDecision.IdentityPublicKey = v.State.IdentityPublicKey
+	v.lastDecision.Role = v.Role()
+	v.lastDecision.VersionFrom = v.State.VersionString
+
+	syncLogger := logging.WithPrefix("sync").With(
 		"client", v.cfg.Client,
 		"role", v.Role(),
 		"pubKey", v.State.IdentityPublicKey,
 	)
 
-	// decide if we should sync based on the validator's role and the enabled when active config
-	switch v.Role() {
-	case RoleActive:
-		if !v.syncConfig.EnabledWhenActive {
-			syncLogger.Warnf("validator is %s and we don't run with scissors ❌🏃✂️  - skipping sync (allow with sync.enabled_when_active=true)", v.Role())
-			return nil
-		}
-		syncLogger.Warnf("validator is %s and sync.enabled_when_active=%t running with scissors ⚠️🏃‍♂️✂️  - syncing", v.Role(), v.syncConfig.EnabledWhenActive)
-	case RolePassive:
-		// we need to safeguard against a situation where a sync could run during an in-flight failover or similar situation where
-		hasActiveLeaderInGossip, activeLeaderNode, err := v.rpcClient.GetNodeWithIdentityPublicKey(v.ActiveIdentityPublicKey)
-		if err != nil {
+	// never sync a validator whose health or role keeps changing - alert instead
+	if reason, flapping := v.flapping(); flapping {
+		syncLogger.Error("validator is flapping - suppressing sync until it settles", "reason", reason)
+		v.recordOutcome(report.OutcomeFlapping, report.ReasonCodeFlapping, reason)
+		return nil
+	}
+
+	// never act on a validator running an identity other than active or passive unless sync.on_other_role or
+	// sync.on_unknown_role allows it
+	if v.IsRoleUnknown() || v.IsRoleOther() {
+		allowed, err := v.otherRoleAllowsSync(syncLogger)
+		if err != nil || !allowed {
 			return err
 		}
+	}
 
-		// when active leader in gossip - no problem
-		if hasActiveLeaderInGossip {
-			syncLogger.Infof("active leader found in gossip - %s (%s)", activeLeaderNode.Pubkey, strings.Split(activeLeaderNode.Gossip, ":")[0])
-		} else {
-			// when active leader in gossip - check if we should sync
-			if !v.syncConfig.EnabledWhenNoActiveLeaderInGossip {
-				return fmt.Errorf("no active leader found in gossip with identity public key %s and sync.enabled_when_no_active_leader=false - skipping sync", v.ActiveIdentityPublicKey)
-			}
-			syncLogger.Warnf("no active leader found in gossip with identity public key %s and sync.enabled_when_no_active_leader=true - syncing", v.ActiveIdentityPublicKey)
-		}
+	// when configured, look up the cluster restart manifest - its version overrides the release lookup
+	err = v.lookupRestartManifest(ctx, syncLogger)
+	if err != nil {
+		return err
+	}
 
-		syncLogger.Infof("validator is %s - syncing", v.Role())
-	default:
-		return fmt.Errorf("validator identity public key %s is not %s or %s - skipping sync", v.State.IdentityPublicKey, RoleActive, RolePassive)
+	err = v.failureInjector.Check(failinject.StageReleaseLookup)
+	if err != nil {
+		return err
 	}
 
-	// by default target the latest client version for the cluster
+	// by default target the latest client version for the cluster, or the exact version the constraint pins
 	// (must be called before NormalizeToTagVersion to populate the tag version cache)
-	latestClientVersion, err := v.githubClient.GetLatestClientVersion()
+	targetVersion, err := v.lookupTargetVersion()
 	if err != nil {
 		if errors.Is(err, github.ErrNoMatchingTaggedVersion) {
 			syncLogger.Info("no matching tagged target version available yet - skipping sync", "reason", err.Error())
+			v.recordOutcome(report.OutcomeSkipped, report.ReasonCodeNoMatchingRelease, "no matching tagged target version available yet")
 			return nil
 		}
+		v.markSourceUnavailable(report.SourceGitHub)
 		return err
 	}
 
@@ -188,13 +305,29 @@ func (v *Validator) SyncVersion() (err error) {
 	)
 	versionDiff := versiondiff.VersionDiff{
 		From: normalizedFrom,
-		To:   latestClientVersion,
+		To:   targetVersion,
 	}
 
-	syncLogger.Debug("latest release from repo", "version", versionDiff.To.String())
+	syncLogger.Debug("target release from repo", "version", versionDiff.To.String())
+
+	// when configured, look up the SFDP participant stage - exposed to commands and notifications
+	v.lookupSFDPParticipant(syncLogger)
 
-	// If enabled, ensure target version is within SFDP constraints or update to max/min allowed SFDP version
-	if v.syncConfig.EnableSFDPCompliance {
+	// If enabled, ensure target version is within SFDP constraints or update to max/min allowed SFDP version -
+	// the version of a cluster restart manifest is followed as published, and syncs proceed without the SFDP
+	// clamp when SFDP is unavailable and not required
+	sfdpClamp := v.syncConfig.EnableSFDPCompliance
+	if sfdpClamp && v.restartManifest != nil {
+		syncLogger.Info("cluster restart manifest sets the target version - not applying SFDP constraints")
+		sfdpClamp = false
+	}
+	if sfdpClamp {
+		sfdpClamp, err = v.lookupSFDPRequirements(syncLogger)
+		if err != nil {
+			return err
+		}
+	}
+	if sfdpClamp {
 		syncLogger.Info("ensuring target version is within SFDP constraints")
 
 		sfdpCompliantVersion, err := v.getSFDPCompliantVersion(versionDiff.To)
@@ -205,10 +338,13 @@ func (v *Validator) SyncVersion() (err error) {
 		syncLogger.Info("confirming SFDP compliant version exists in repo", "sfdp_compliant_version", sfdpCompliantVersion.Original())
 		repoHasSFDPCompliantVersion, err := v.githubClient.HasTaggedVersion(sfdpCompliantVersion)
 		if err != nil {
+			v.markSourceUnavailable(report.SourceGitHub)
 			return err
 		}
 		if !repoHasSFDPCompliantVersion {
-			return fmt.Errorf("SFDP wants v%s and it does not exist as a tagged version in the client repo %s", sfdpCompliantVersion.Original(), v.githubClient.GetRepoURL())
+			return report.WithReasonCode(report.ReasonCodeSFDPVersionUnavailable,
+				fmt.Errorf("SFDP wants v%s and it does not exist as a tagged version in the client repo %s", sfdpCompliantVersion.Original(), v.githubClient.GetRepoURL()),
+			)
 		}
 
 		normalizedSFDPCompliantVersion := v.githubClient.NormalizeToTagVersion(sfdpCompliantVersion)
@@ -221,68 +357,461 @@ func (v *Validator) SyncVersion() (err error) {
 
 	syncLogger.Debugf("final target sync version: %s", versionDiff.To.Original())
 	syncLogger = syncLogger.With("targetVersion", versionDiff.To.Original())
+	v.lastDecision.VersionTo = versionDiff.To.Core().String()
+	v.lastDecision.VersionToTag = v.githubClient.TagNameForVersion(versionDiff.To)
+
+	// when configured, look up the target configuration revision - applied by syncs to a new version too
+	err = v.lookupConfigRevision(ctx, syncLogger)
+	if err != nil {
+		return err
+	}
 
-	// if already on the target version, do nothing
+	// if already on the target version, sync a changed configuration revision or a pending cluster restart only,
+	// or do nothing
+	if versionDiff.IsSameVersion() && (v.configRevision.changed() || v.restartPending()) {
+		return v.syncToTarget(ctx, syncLogger, versionDiff)
+	}
 	if versionDiff.IsSameVersion() {
 		syncLogger.Info("validator already running target version - nothing to do")
+		v.recordOutcome(report.OutcomeUpToDate, report.ReasonCodeUpToDate, "validator already running target version")
 		return nil
 	}
 
 	// if target version outside of declared constraint, error out
 	if !v.versionConstraint.Check(versionDiff.To.Core()) {
-		return fmt.Errorf("target version %s is outside of validator.version_constraint %s", versionDiff.To.Core().String(), v.versionConstraint.String())
+		return report.WithReasonCode(report.ReasonCodeOutsideVersionConstraint,
+			fmt.Errorf("target version %s is outside of validator.version_constraint %s", versionDiff.To.Core().String(), v.versionConstraint.String()),
+		)
+	}
+
+	// never downgrade below the known-safe floor, whatever the constraint or SFDP allow
+	err = v.checkDowngradeFloor(versionDiff)
+	if err != nil {
+		return err
 	}
 
-	// by now we know we need to sync and are allowed to sync to the target version
+	return v.syncToTarget(ctx, syncLogger, versionDiff)
+}
+
+// syncToTarget prepares the required sync target and, when the activation gates allow it, activates it - a
+// target on the running version only syncs the configuration revision or a pending cluster restart, so the
+// version-specific reference validator and adoption gates, heads-up and Nomad activation are skipped for it.
+// While a cluster restart manifest is published the reference validator, adoption, stake activation and slot
+// trigger gates are skipped too, as they can't be satisfied while the cluster is halted.
+func (v *Validator) syncToTarget(ctx context.Context, syncLogger *log.Logger, versionDiff versiondiff.VersionDiff) (err error) {
+	// by now we know a sync to the target version, configuration revision or a cluster restart is required
+	sameVersion := versionDiff.IsSameVersion()
+	clusterRestart := v.restartManifest != nil
 	syncLogger = syncLogger.With("syncDirection", versionDiff.Direction())
-	syncLogger.Info(
-		fmt.Sprintf("%v  %s required v%s -> v%s",
-			versionDiff.DirectionEmoji(), versionDiff.Direction(),
-			versionDiff.From.Original(), versionDiff.To.Original(),
-		),
-		"versionConstraint", v.versionConstraint.String(),
-	)
+	if v.syncConfig.ConfigRevision.Enabled {
+		syncLogger = syncLogger.With("configRevisionFrom", v.configRevision.From, "configRevisionTo", v.configRevision.To)
+	}
+	switch {
+	case clusterRestart:
+		syncLogger = syncLogger.With("restartSnapshotSlot", v.restartManifest.SnapshotSlot, "restartExpectedShredVersion", v.restartManifest.ExpectedShredVersion)
+		syncLogger.Info(fmt.Sprintf("%v  cluster restart required v%s -> v%s",
+			versionDiff.DirectionEmoji(), versionDiff.From.Original(), versionDiff.To.Original(),
+		))
+	case sameVersion:
+		syncLogger.Info(fmt.Sprintf("%v  configuration revision sync required %s -> %s on v%s",
+			versionDiff.DirectionEmoji(), v.configRevision.From, v.configRevision.To, versionDiff.To.Original(),
+		))
+	default:
+		syncLogger.Info(
+			fmt.Sprintf("%v  %s required v%s -> v%s",
+				versionDiff.DirectionEmoji(), versionDiff.Direction(),
+				versionDiff.From.Original(), versionDiff.To.Original(),
+			),
+			"versionConstraint", v.versionConstraint.String(),
+		)
+	}
 
 	commandsCount := len(v.syncConfig.Commands)
-	if commandsCount == 0 {
+	if commandsCount == 0 && (!v.syncConfig.Nomad.Enabled || sameVersion) {
 		syncLogger.Warn("no configured commands to execute - skipping")
+		v.recordOutcome(report.OutcomeSkipped, report.ReasonCodeNoCommands, "no configured commands to execute")
 		return nil
 	}
 
-	// create the commands
-	syncLogger.Infof("executing commands")
-	for cmd_i, cmd := range v.syncConfig.Commands {
-		err := cmd.ExecuteWithData(sync_commands.CommandTemplateData{
-			CommandIndex:                cmd_i,
-			CommandsCount:               commandsCount,
-			ValidatorClient:             v.cfg.Client,
-			ValidatorRPCURL:             v.cfg.RPCURL,
-			ValidatorRole:               v.Role(),
-			ValidatorRoleIsPassive:      v.IsPassive(),
-			ValidatorRoleIsActive:       v.IsActive(),
-			ValidatorIdentityPublicKey:  v.State.IdentityPublicKey,
-			ClusterName:                 v.State.Cluster,
-			VersionFrom:                 versionDiff.From.Core().String(),
-			VersionTo:                   versionDiff.To.Core().String(),
-			VersionToTag:                v.githubClient.TagNameForVersion(versionDiff.To),
-			SyncIsSFDPComplianceEnabled: v.syncConfig.EnableSFDPCompliance,
-		})
+	templateData := sync_commands.CommandTemplateData{
+		CommandsCount:               commandsCount,
+		ValidatorClient:             v.cfg.Client,
+		ValidatorRPCURL:             v.cfg.RPCURL,
+		ValidatorRole:               v.Role(),
+		ValidatorRoleIsPassive:      v.IsPassive(),
+		ValidatorRoleIsActive:       v.IsActive(),
+		ValidatorIdentityPublicKey:  v.State.IdentityPublicKey,
+		ClusterName:                 v.State.Cluster,
+		VersionFrom:                 versionDiff.From.Core().String(),
+		VersionTo:                   versionDiff.To.Core().String(),
+		VersionToTag:                v.githubClient.TagNameForVersion(versionDiff.To),
+		SyncIsSFDPComplianceEnabled: v.syncConfig.EnableSFDPCompliance,
+		SFDPParticipantStage:        v.sfdpParticipantStage(),
+		ConfigRevisionFrom:          v.configRevision.From,
+		ConfigRevisionTo:            v.configRevision.To,
+		SyncIsConfigOnly:            sameVersion && v.configRevision.changed(),
+		HardForks:                   v.templateHardForks(),
+	}
+	templateData.ShredVersion = v.shredVersion(syncLogger)
+	templateData.ExpectedShredVersion = templateData.ShredVersion
+	if clusterRestart {
+		templateData.ClusterRestart = true
+		templateData.RestartExpectedShredVersion = v.restartManifest.ExpectedShredVersion
+		templateData.ExpectedShredVersion = v.restartManifest.ExpectedShredVersion
+		templateData.RestartSnapshotSlot = v.restartManifest.SnapshotSlot
+		templateData.RestartExpectedBankHash = v.restartManifest.ExpectedBankHash
+	}
+
+	// let humans know about the target version before anything happens to it
+	if !sameVersion {
+		v.sendHeadsUp(syncLogger, versionDiff, templateData)
+	}
+
+	// decide if we should sync at all based on the validator's role and the enabled when active config - a
+	// validator we're told not to touch gets no commands, prepare commands included
+	allowed, err := v.roleAllowsSync(syncLogger)
+	if err != nil || !allowed {
+		return err
+	}
+
+	// stage the target as soon as the role allows it, ahead of the activation gates below, so the
+	// disruptive part of the sync is as short as possible whenever activation is allowed
+	if v.readOnly {
+		syncLogger.Warn("read-only mode - not executing prepare commands")
+	} else {
+		err = v.prepareTarget(syncLogger, templateData)
+		if err != nil {
+			return err
+		}
+	}
+
+	// when configured, follow the reference validator - only activate once it runs the target version or newer
+	referenceValidatorRequired := v.syncConfig.ReferenceValidator.Identity != "" && !sameVersion && !clusterRestart
+	if referenceValidatorRequired && v.sfdpStageIsStrict() {
+		syncLogger.Info("SFDP participant stage tracks releases strictly - not waiting for the reference validator", "sfdpStage", v.sfdpParticipantStage())
+		referenceValidatorRequired = false
+	}
+	if referenceValidatorRequired {
+		referenceReachedVersion, referenceVersion, err := v.referenceValidatorReachedVersion(versionDiff.To)
+		if err != nil {
+			return err
+		}
+		if !referenceReachedVersion {
+			syncLogger.Info("reference validator is behind the target version - skipping sync",
+				"referenceIdentity", v.syncConfig.ReferenceValidator.Identity,
+				"referenceVersion", referenceVersion,
+			)
+			v.recordOutcome(report.OutcomeSkipped, report.ReasonCodeReferenceValidatorBehind, "reference validator is behind the target version")
+			return nil
+		}
+		syncLogger.Info("reference validator is running the target version or newer",
+			"referenceIdentity", v.syncConfig.ReferenceValidator.Identity,
+			"referenceVersion", referenceVersion,
+		)
+	}
+
+	// when configured, hold activation until enough of the cluster's stake runs the target version
+	if !sameVersion && !clusterRestart {
+		allowed, err = v.adoptionGateAllowsSync(ctx, syncLogger, versionDiff.To)
+		if err != nil || !allowed {
+			return err
+		}
+	}
+
+	// when configured, defer while large stake changes are pending for the validator this epoch
+	if !clusterRestart {
+		allowed, err = v.stakeActivationAllowsSync(syncLogger)
+		if err != nil || !allowed {
+			return err
+		}
+	}
+
+	// in read-only mode stop short of executing anything
+	if v.readOnly {
+		syncLogger.Warn("read-only mode - not executing commands", "commands", v.commandNames())
+		v.recordOutcome(report.OutcomeSkipped, report.ReasonCodeReadOnly, "read-only mode")
+		return nil
+	}
+
+	// when configured, hold execution until the trigger slot is reached
+	if v.syncConfig.SlotTrigger.Enabled && clusterRestart {
+		syncLogger.Info("cluster restart in progress - not waiting for the trigger slot")
+	} else if v.syncConfig.SlotTrigger.Enabled {
+		err = v.waitForTriggerSlot(ctx, syncLogger)
+		if err != nil {
+			return err
+		}
+
+		// the wait can be long (up to an epoch) - a failover in the meantime must not be followed by a restart
+		err = v.recheckRoleAfterWait(syncLogger)
+		if err != nil {
+			return err
+		}
+	}
+
+	syncLogger.Infof("executing %s commands", sync_commands.PhaseActivate)
+	err = v.executeCommands(syncLogger, sync_commands.PhaseActivate, templateData)
+	if errors.Is(err, errBinaryCheckFailed) {
+		return v.rollbackAfterBinaryCheck(syncLogger, templateData, err)
+	}
+	if err != nil {
+		return err
+	}
+
+	// when configured, activate the target through Nomad once the activate commands have succeeded
+	if !sameVersion {
+		err = v.activateNomadJob(ctx, syncLogger, templateData.VersionTo)
 		if err != nil {
 			return err
 		}
 	}
 
+	// the prepared target (if any), the target configuration revision and the cluster restart have now been activated
+	err = v.stateStore.Update(func(data *state.Data) {
+		data.Prepared = nil
+		if v.syncConfig.ConfigRevision.Enabled {
+			data.ConfigRevision = v.configRevision.To
+		}
+		if clusterRestart {
+			data.ClusterRestart = v.restartManifest.Key()
+		}
+	})
+	if err != nil {
+		return fmt.Errorf("failed to clear prepared target from state: %w", err)
+	}
+
 	syncLogger.Infof("commands executed successfully")
+	switch {
+	case clusterRestart:
+		v.recordOutcome(report.OutcomeSynced, report.ReasonCodeSynced, fmt.Sprintf("cluster restart at slot %d (shred version %d) v%s -> v%s",
+			v.restartManifest.SnapshotSlot, v.restartManifest.ExpectedShredVersion, versionDiff.From.Original(), versionDiff.To.Original(),
+		))
+	case sameVersion:
+		v.recordOutcome(report.OutcomeSynced, report.ReasonCodeSynced, fmt.Sprintf("configuration revision %s -> %s on v%s", v.configRevision.From, v.configRevision.To, versionDiff.To.Original()))
+	default:
+		v.recordOutcome(report.OutcomeSynced, report.ReasonCodeSynced, fmt.Sprintf("%s v%s -> v%s", versionDiff.Direction(), versionDiff.From.Original(), versionDiff.To.Original()))
+	}
 	return nil
 }
 
-func (v *Validator) getSFDPCompliantVersion(targetVersion *version.Version) (sfdpCompliantVersion *version.Version, err error) {
-	sfdpRequirements, err := v.sfdpClient.GetLatestRequirements()
+// ReleaseCadence computes the client's release cadence from its recent releases as of now
+func (v *Validator) ReleaseCadence() (cadence.Cadence, error) {
+	publishedAt, err := v.githubClient.GetReleasePublishTimes()
 	if err != nil {
-		return nil, err
+		return cadence.Cadence{}, err
+	}
+	return cadence.New(publishedAt, time.Now())
+}
+
+// LastDecision returns the decision reached by the most recent SyncVersion call
+func (v *Validator) LastDecision() report.Decision {
+	return v.lastDecision
+}
+
+// recordOutcome records the outcome of the current sync decision with its stable reason code and human-readable reason
+func (v *Validator) recordOutcome(outcome string, reasonCode string, reason string) {
+	v.lastDecision.Outcome = outcome
+	v.lastDecision.ReasonCode = reasonCode
+	v.lastDecision.Reason = reason
+}
+
+// roleAllowsSync decides whether the validator's current role allows syncing, recording a skipped outcome when it doesn't
+func (v *Validator) roleAllowsSync(syncLogger *log.Logger) (allowed bool, err error) {
+	switch v.syncRole() {
+	case RoleActive:
+		if !v.syncConfig.EnabledWhenActive {
+			syncLogger.Warnf("validator is %s and we don't run with scissors ❌🏃✂️  - skipping sync (allow with sync.enabled_when_active=true)", v.Role())
+			v.recordOutcome(report.OutcomeSkipped, report.ReasonCodeRoleActive, "validator is active and sync.enabled_when_active=false")
+			return false, nil
+		}
+		syncLogger.Warnf("validator is %s and sync.enabled_when_active=%t running with scissors ⚠️🏃‍♂️✂️  - syncing", v.Role(), v.syncConfig.EnabledWhenActive)
+	case RolePassive:
+		// we need to safeguard against a situation where a sync could run during an in-flight failover or similar situation where
+		hasActiveLeaderInGossip, activeLeaderNode, err := v.rpcClient.GetNodeWithIdentityPublicKey(v.ActiveIdentityPublicKey)
+		if err != nil {
+			return false, err
+		}
+
+		// when active leader in gossip - no problem
+		if hasActiveLeaderInGossip {
+			syncLogger.Infof("active leader found in gossip - %s (%s)", activeLeaderNode.Pubkey, strings.Split(activeLeaderNode.Gossip, ":")[0])
+		} else {
+			// when active leader in gossip - check if we should sync
+			if !v.syncConfig.EnabledWhenNoActiveLeaderInGossip {
+				return false, report.WithReasonCode(report.ReasonCodeNoActiveLeaderInGossip,
+					fmt.Errorf("no active leader found in gossip with identity public key %s and sync.enabled_when_no_active_leader=false - skipping sync", v.ActiveIdentityPublicKey),
+				)
+			}
+			syncLogger.Warnf("no active leader found in gossip with identity public key %s and sync.enabled_when_no_active_leader=true - syncing", v.ActiveIdentityPublicKey)
+		}
+
+		syncLogger.Infof("validator is %s - syncing", v.Role())
+	default:
+		return false, v.otherRoleError()
+	}
+
+	return true, nil
+}
+
+// recheckRoleAfterWait refreshes the validator's state and re-applies the role and gossip checks,
+// aborting the sync when they no longer allow it
+func (v *Validator) recheckRoleAfterWait(syncLogger *log.Logger) (err error) {
+	roleBeforeWait := v.Role()
+
+	err = v.refreshState()
+	if err != nil {
+		return fmt.Errorf("failed to refresh validator state after waiting for trigger slot: %w", err)
+	}
+
+	if v.Role() != roleBeforeWait {
+		syncLogger.Warn("validator role changed while waiting for trigger slot", "roleBefore", roleBeforeWait, "roleNow", v.Role())
+	}
+
+	allowed, err := v.roleAllowsSync(syncLogger)
+	if err != nil {
+		return report.WithReasonCode(report.ReasonCodeRoleChangedDuringWait,
+			fmt.Errorf("sync no longer allowed after waiting for trigger slot: %w", err),
+		)
+	}
+	if !allowed {
+		return report.WithReasonCode(report.ReasonCodeRoleChangedDuringWait,
+			fmt.Errorf("sync no longer allowed after waiting for trigger slot - validator is now %s", v.Role()),
+		)
+	}
+
+	return nil
+}
+
+// prepareTarget runs the prepare phase commands for the sync target unless they already completed
+// for it in a previous run, recording the prepared target in state on success
+func (v *Validator) prepareTarget(syncLogger *log.Logger, templateData sync_commands.CommandTemplateData) (err error) {
+	if !v.hasCommandsInPhase(sync_commands.PhasePrepare) {
+		return nil
+	}
+
+	prepared := v.stateStore.Get().Prepared
+	if prepared != nil && prepared.Tag == templateData.VersionToTag && prepared.ConfigRevision == templateData.ConfigRevisionTo {
+		syncLogger.Info("target already prepared - skipping prepare commands",
+			"preparedTag", prepared.Tag,
+			"preparedAt", prepared.PreparedAt.Format(time.RFC3339),
+		)
+		return nil
+	}
+
+	err = v.failureInjector.Check(failinject.StagePrepare)
+	if err != nil {
+		return err
+	}
+
+	syncLogger.Infof("executing %s commands", sync_commands.PhasePrepare)
+	err = v.executeCommands(syncLogger, sync_commands.PhasePrepare, templateData)
+	if err != nil {
+		return err
+	}
+
+	// hash the downloaded outputs before the target counts as prepared - a mismatching artifact is never installed
+	err = v.recordAssetDownloads(syncLogger, templateData)
+	if err != nil {
+		return err
+	}
+
+	err = v.failureInjector.Check(failinject.StageVerify)
+	if err != nil {
+		return err
+	}
+
+	err = v.stateStore.Update(func(data *state.Data) {
+		data.Prepared = &state.PreparedTarget{
+			Version:        templateData.VersionTo,
+			Tag:            templateData.VersionToTag,
+			PreparedAt:     time.Now().UTC(),
+			ConfigRevision: templateData.ConfigRevisionTo,
+		}
+	})
+	if err != nil {
+		return fmt.Errorf("failed to record prepared target in state: %w", err)
 	}
 
-	v.logger.Debug("got latest requirements from SFDP", "sfdpRequirements", sfdpRequirements.Constraints.String())
+	syncLogger.Info("target prepared", "preparedTag", templateData.VersionToTag)
+	return nil
+}
+
+// executeCommands executes the configured commands belonging to the given phase in declaration order
+func (v *Validator) executeCommands(syncLogger *log.Logger, phase string, templateData sync_commands.CommandTemplateData) (err error) {
+	templateData.SyncPhase = phase
+	for cmd_i := range v.syncConfig.Commands {
+		cmd := &v.syncConfig.Commands[cmd_i]
+
+		// the installed target binary must report the target version before the validator is taken down - also
+		// when before_command is disabled, so disabling it never lets the commands after it run unchecked
+		if phase == sync_commands.PhaseActivate && cmd.Phase == phase && v.syncConfig.BinaryCheck.Enabled && cmd.Name == v.syncConfig.BinaryCheck.BeforeCommand {
+			err = v.checkTargetBinary(syncLogger, templateData)
+			if err != nil {
+				return err
+			}
+		}
+
+		if cmd.Phase != phase || cmd.Disabled {
+			continue
+		}
+
+		var runOnceLine string
+		var due bool
+		runOnceLine, due, err = v.runOnceDue(cmd, templateData)
+		if err != nil {
+			return fmt.Errorf("failed command %s: %w", cmd.Name, err)
+		}
+		if !due {
+			v.logger.Info("command already ran for this version line - skipping", "command", cmd.Name, "runOncePer", cmd.RunOncePer, "line", runOnceLine)
+			continue
+		}
+
+		err = v.failureInjector.CheckCommand(cmd_i)
+		if err != nil && cmd.AllowFailure {
+			v.logger.Warn("injected command failure with allow failure enabled - continuing", "command", cmd.Name, "error", err)
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed command %s: %w", cmd.Name, err)
+		}
+
+		templateData.CommandIndex = cmd_i
+		err = cmd.ExecuteWithData(templateData)
+		v.recordCommandRun(cmd, templateData)
+		if err != nil {
+			return err
+		}
+
+		// failures allowed by allow_failure are retried on the next sync to the line
+		if usage := cmd.LastUsage(); runOnceLine != "" && usage != nil && usage.ExitCode == 0 {
+			v.recordRunOnce(cmd, runOnceLine)
+		}
+	}
+	return nil
+}
+
+// commandNames returns the names of the configured commands in declaration order
+func (v *Validator) commandNames() (names []string) {
+	for _, cmd := range v.syncConfig.Commands {
+		names = append(names, cmd.Name)
+	}
+	return names
+}
+
+// hasCommandsInPhase returns true when at least one enabled command belongs to the given phase
+func (v *Validator) hasCommandsInPhase(phase string) bool {
+	for _, cmd := range v.syncConfig.Commands {
+		if cmd.Phase == phase && !cmd.Disabled {
+			return true
+		}
+	}
+	return false
+}
+
+// getSFDPCompliantVersion returns the target version clamped to the SFDP requirements looked up by
+// lookupSFDPRequirements
+func (v *Validator) getSFDPCompliantVersion(targetVersion *version.Version) (sfdpCompliantVersion *version.Version, err error) {
+	sfdpRequirements := v.sfdpRequirements
 
 	if constants.NormalizeClientName(v.cfg.Client) == constants.ClientNameFiredancer {
 		sfdpCompliantVersion, err = v.githubClient.ResolveFiredancerSFDPCompliantVersion(
@@ -293,6 +822,7 @@ func (v *Validator) getSFDPCompliantVersion(targetVersion *version.Version) (sfd
 			sfdpRequirements.HasMaxVersion,
 		)
 		if err != nil {
+			v.markSourceUnavailable(report.SourceGitHub)
 			return nil, err
 		}
 
@@ -362,6 +892,11 @@ func selectSFDPCompliantVersion(targetVersion *version.Version, minVersion *vers
 func (v *Validator) refreshState() error {
 	v.logger.Debug("refreshing validator state")
 
+	err := v.failureInjector.Check(failinject.StageRefresh)
+	if err != nil {
+		return err
+	}
+
 	// get the validator's version string
 	versionString, err := v.rpcClient.GetVersion()
 	if err != nil {
@@ -385,13 +920,14 @@ func (v *Validator) refreshState() error {
 	// get the validator's health
 	health, err := v.rpcClient.GetHealth()
 	if err != nil {
-		return err
+		v.State.HealthStatus = HealthStatusUnhealthy
+		return report.WithReasonCode(report.ReasonCodeUnhealthy, fmt.Errorf("%w: %w", errUnhealthy, err))
 	}
 	v.State.HealthStatus = health
 
-	// warn if the validator is running with an identity that does not match active or passive identities
+	// warn if the validator is running with an identity that does not match any configured identity
 	if v.IsRoleUnknown() {
-		v.logger.Warn("validator is running with an identity that does not match active or passive identities",
+		v.logger.Warn("validator is running with an identity that does not match active, passive or other identities",
 			"identityPubkey", v.State.IdentityPublicKey,
 			"activePubkey", v.ActiveIdentityPublicKey,
 			"passivePubkey", v.PassiveIdentityPublicKey,
@@ -411,6 +947,9 @@ func (v *Validator) Role() string {
 	if v.IsPassive() {
 		return RolePassive
 	}
+	if name, ok := v.otherRole(); ok {
+		return name
+	}
 	return RoleUnknown
 }
 
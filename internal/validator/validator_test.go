@@ -1,14 +1,26 @@
 package validator
 
 import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
 	"strings"
 	"testing"
 
+	"github.com/charmbracelet/log"
 	"github.com/gagliardetto/solana-go"
 	goversion "github.com/hashicorp/go-version"
 	"github.com/sol-strategies/solana-validator-version-sync/internal/config"
 	"github.com/sol-strategies/solana-validator-version-sync/internal/constants"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/failinject"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/github"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/report"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/rpc"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/state"
 	"github.com/sol-strategies/solana-validator-version-sync/internal/sync_commands"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/versiondiff"
 )
 
 func TestRoleConstants(t *testing.T) {
@@ -409,3 +421,399 @@ func TestNew_InvalidCommand(t *testing.T) {
 		t.Error("New() should return nil validator on error")
 	}
 }
+
+func TestValidator_prepareTarget(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Skipping on Windows")
+	}
+
+	markerFile := filepath.Join(t.TempDir(), "prepared")
+	stateStore, err := state.NewStore(filepath.Join(t.TempDir(), "state.json"))
+	if err != nil {
+		t.Fatalf("state.NewStore() error = %v", err)
+	}
+
+	commands := []sync_commands.Command{
+		{
+			Name:  "build",
+			Cmd:   "sh",
+			Args:  []string{"-c", "echo {{ .SyncPhase }} {{ .VersionToTag }} >> " + markerFile},
+			Phase: sync_commands.PhasePrepare,
+		},
+		{
+			Name: "restart",
+			Cmd:  "true",
+		},
+	}
+	for i := range commands {
+		if err := commands[i].Parse(); err != nil {
+			t.Fatalf("Parse() error = %v", err)
+		}
+	}
+
+	v := &Validator{
+		syncConfig: config.Sync{Commands: commands},
+		stateStore: stateStore,
+		logger:     log.WithPrefix("validator"),
+	}
+
+	templateData := sync_commands.CommandTemplateData{
+		CommandsCount: len(commands),
+		VersionTo:     "2.3.6",
+		VersionToTag:  "v2.3.6",
+	}
+
+	// first run prepares, second run for the same target is skipped
+	for i := 0; i < 2; i++ {
+		if err := v.prepareTarget(v.logger, templateData); err != nil {
+			t.Fatalf("prepareTarget() run %d error = %v", i, err)
+		}
+	}
+
+	content, err := os.ReadFile(markerFile)
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	if got := string(content); got != "prepare v2.3.6\n" {
+		t.Errorf("prepare commands output = %q, want a single prepare run", got)
+	}
+
+	prepared := stateStore.Get().Prepared
+	if prepared == nil || prepared.Tag != "v2.3.6" || prepared.Version != "2.3.6" {
+		t.Errorf("state Prepared = %+v, want v2.3.6", prepared)
+	}
+
+	// a new target is prepared again
+	templateData.VersionTo = "2.3.7"
+	templateData.VersionToTag = "v2.3.7"
+	if err := v.prepareTarget(v.logger, templateData); err != nil {
+		t.Fatalf("prepareTarget() new target error = %v", err)
+	}
+	if prepared := stateStore.Get().Prepared; prepared == nil || prepared.Tag != "v2.3.7" {
+		t.Errorf("state Prepared = %+v, want v2.3.7", prepared)
+	}
+
+	// a new configuration revision for the same version is prepared again
+	templateData.ConfigRevisionTo = "r42"
+	if err := v.prepareTarget(v.logger, templateData); err != nil {
+		t.Fatalf("prepareTarget() new configuration revision error = %v", err)
+	}
+	if prepared := stateStore.Get().Prepared; prepared == nil || prepared.Tag != "v2.3.7" || prepared.ConfigRevision != "r42" {
+		t.Errorf("state Prepared = %+v, want v2.3.7 at r42", prepared)
+	}
+}
+
+func TestValidator_prepareTarget_NoPrepareCommands(t *testing.T) {
+	stateStore, _ := state.NewStore("")
+	commands := []sync_commands.Command{{Name: "restart", Cmd: "true"}}
+	if err := commands[0].Parse(); err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	v := &Validator{
+		syncConfig: config.Sync{Commands: commands},
+		stateStore: stateStore,
+		logger:     log.WithPrefix("validator"),
+	}
+
+	if err := v.prepareTarget(v.logger, sync_commands.CommandTemplateData{VersionToTag: "v2.3.6"}); err != nil {
+		t.Fatalf("prepareTarget() error = %v", err)
+	}
+	if prepared := stateStore.Get().Prepared; prepared != nil {
+		t.Errorf("state Prepared = %+v, want nil without prepare commands", prepared)
+	}
+}
+
+func TestValidator_executeCommands_InjectedFailure(t *testing.T) {
+	commands := []sync_commands.Command{
+		{Name: "build", Cmd: "true"},
+		{Name: "restart", Cmd: "true"},
+	}
+	for i := range commands {
+		if err := commands[i].Parse(); err != nil {
+			t.Fatalf("Parse() error = %v", err)
+		}
+	}
+
+	failureInjector, err := failinject.New([]string{"command:2"})
+	if err != nil {
+		t.Fatalf("failinject.New() error = %v", err)
+	}
+
+	stateStore, err := state.NewStore("")
+	if err != nil {
+		t.Fatalf("state.NewStore() error = %v", err)
+	}
+
+	v := &Validator{
+		syncConfig:      config.Sync{Commands: commands},
+		failureInjector: failureInjector,
+		stateStore:      stateStore,
+		logger:          log.WithPrefix("validator"),
+	}
+
+	err = v.executeCommands(v.logger, sync_commands.PhaseActivate, sync_commands.CommandTemplateData{CommandsCount: len(commands)})
+	if !errors.Is(err, failinject.ErrInjected) {
+		t.Errorf("executeCommands() error = %v, want injected failure", err)
+	}
+
+	// allow_failure commands continue past injected failures
+	v.syncConfig.Commands[1].AllowFailure = true
+	err = v.executeCommands(v.logger, sync_commands.PhaseActivate, sync_commands.CommandTemplateData{CommandsCount: len(commands)})
+	if err != nil {
+		t.Errorf("executeCommands() with allow_failure error = %v, want nil", err)
+	}
+
+	// disabled commands are skipped before failures are injected
+	v.syncConfig.Commands[1].AllowFailure = false
+	v.syncConfig.Commands[1].Disabled = true
+	err = v.executeCommands(v.logger, sync_commands.PhaseActivate, sync_commands.CommandTemplateData{CommandsCount: len(commands)})
+	if err != nil {
+		t.Errorf("executeCommands() with disabled command error = %v, want nil", err)
+	}
+}
+
+func TestValidator_prepareTarget_InjectedFailure(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Skipping on Windows")
+	}
+
+	tests := []struct {
+		name           string
+		stage          string
+		wantCommandRan bool
+	}{
+		{
+			name:           "prepare fails before prepare commands run",
+			stage:          failinject.StagePrepare,
+			wantCommandRan: false,
+		},
+		{
+			name:           "verify fails after prepare commands ran",
+			stage:          failinject.StageVerify,
+			wantCommandRan: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			markerFile := filepath.Join(t.TempDir(), "prepared")
+			stateStore, _ := state.NewStore("")
+			commands := []sync_commands.Command{
+				{
+					Name:  "build",
+					Cmd:   "touch",
+					Args:  []string{markerFile},
+					Phase: sync_commands.PhasePrepare,
+				},
+			}
+			if err := commands[0].Parse(); err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+
+			failureInjector, err := failinject.New([]string{tt.stage})
+			if err != nil {
+				t.Fatalf("failinject.New() error = %v", err)
+			}
+
+			v := &Validator{
+				syncConfig:      config.Sync{Commands: commands},
+				stateStore:      stateStore,
+				failureInjector: failureInjector,
+				logger:          log.WithPrefix("validator"),
+			}
+
+			err = v.prepareTarget(v.logger, sync_commands.CommandTemplateData{VersionTo: "2.3.6", VersionToTag: "v2.3.6"})
+			if !errors.Is(err, failinject.ErrInjected) {
+				t.Errorf("prepareTarget() error = %v, want injected failure", err)
+			}
+
+			_, statErr := os.Stat(markerFile)
+			if commandRan := statErr == nil; commandRan != tt.wantCommandRan {
+				t.Errorf("prepare command ran = %v, want %v", commandRan, tt.wantCommandRan)
+			}
+			if prepared := stateStore.Get().Prepared; prepared != nil {
+				t.Errorf("state Prepared = %+v, want nil after injected failure", prepared)
+			}
+		})
+	}
+}
+
+func TestValidator_commandNames(t *testing.T) {
+	v := &Validator{
+		syncConfig: config.Sync{Commands: []sync_commands.Command{{Name: "build"}, {Name: "restart"}}},
+	}
+
+	names := v.commandNames()
+	if strings.Join(names, ",") != "build,restart" {
+		t.Errorf("commandNames() = %v, want [build restart]", names)
+	}
+}
+
+func TestValidator_SyncVersion_RecordsFailedDecision(t *testing.T) {
+	failureInjector, err := failinject.New([]string{failinject.StageRefresh})
+	if err != nil {
+		t.Fatalf("failinject.New() error = %v", err)
+	}
+
+	v := &Validator{
+		State:           State{Cluster: constants.ClusterNameTestnet},
+		cfg:             config.Validator{Client: constants.ClientNameAgave},
+		failureInjector: failureInjector,
+		logger:          log.WithPrefix("validator"),
+	}
+
+	err = v.SyncVersion(context.Background())
+	if !errors.Is(err, failinject.ErrInjected) {
+		t.Fatalf("SyncVersion() error = %v, want injected failure", err)
+	}
+
+	decision := v.LastDecision()
+	if decision.Outcome != report.OutcomeFailed {
+		t.Errorf("LastDecision().Outcome = %q, want %q", decision.Outcome, report.OutcomeFailed)
+	}
+	if decision.ReasonCode != report.ReasonCodeInjectedFailure {
+		t.Errorf("LastDecision().ReasonCode = %q, want %q", decision.ReasonCode, report.ReasonCodeInjectedFailure)
+	}
+	if decision.Reason != err.Error() {
+		t.Errorf("LastDecision().Reason = %q, want %q", decision.Reason, err.Error())
+	}
+	if decision.Cluster != constants.ClusterNameTestnet || decision.Client != constants.ClientNameAgave {
+		t.Errorf("LastDecision() cluster/client = %s/%s, want %s/%s", decision.Cluster, decision.Client, constants.ClusterNameTestnet, constants.ClientNameAgave)
+	}
+	if decision.Time.IsZero() {
+		t.Error("LastDecision().Time is zero")
+	}
+}
+
+// newSyncToTargetValidator returns an active validator whose prepare and activate commands append their phase to markerFile
+func newSyncToTargetValidator(t *testing.T, markerFile string, enabledWhenActive bool) *Validator {
+	t.Helper()
+
+	commands := []sync_commands.Command{
+		{
+			Name:  "build",
+			Cmd:   "sh",
+			Args:  []string{"-c", "echo {{ .SyncPhase }} >> " + markerFile},
+			Phase: sync_commands.PhasePrepare,
+		},
+		{
+			Name: "restart",
+			Cmd:  "sh",
+			Args: []string{"-c", "echo {{ .SyncPhase }} >> " + markerFile},
+		},
+	}
+	for i := range commands {
+		if err := commands[i].Parse(); err != nil {
+			t.Fatalf("Parse() error = %v", err)
+		}
+	}
+
+	stateStore, err := state.NewStore(filepath.Join(t.TempDir(), "state.json"))
+	if err != nil {
+		t.Fatalf("state.NewStore() error = %v", err)
+	}
+
+	githubClient, err := github.NewClient(github.Options{
+		Cluster: constants.ClusterNameTestnet,
+		Client:  constants.ClientNameAgave,
+	})
+	if err != nil {
+		t.Fatalf("github.NewClient() error = %v", err)
+	}
+
+	identity := "9WzDXwBbmkg8ZTbNMqUxvQRAyrZzDsGYdLVL9zYtAWWM"
+	server := newSnapshotServer(t, identity, true, []interface{}{
+		map[string]interface{}{"pubkey": identity, "gossip": "10.0.0.1:8001", "shredVersion": 2405},
+	})
+
+	return &Validator{
+		ActiveIdentityPublicKey:  "9WzDXwBbmkg8ZTbNMqUxvQRAyrZzDsGYdLVL9zYtAWWM",
+		PassiveIdentityPublicKey: "7xKXtg2CW87d97TXJSDpbD5jBkheTqA83TZRuJosgAsU",
+		State:                    State{IdentityPublicKey: "9WzDXwBbmkg8ZTbNMqUxvQRAyrZzDsGYdLVL9zYtAWWM"},
+		syncConfig:               config.Sync{EnabledWhenActive: enabledWhenActive, Commands: commands},
+		rpcClient:                rpc.NewClient(server.URL),
+		githubClient:             githubClient,
+		stateStore:               stateStore,
+		logger:                   log.WithPrefix("validator"),
+	}
+}
+
+// testVersionDiff returns an upgrade version diff from 2.3.5 to 2.3.6
+func testVersionDiff() versiondiff.VersionDiff {
+	return versiondiff.VersionDiff{
+		From: goversion.Must(goversion.NewVersion("2.3.5")),
+		To:   goversion.Must(goversion.NewVersion("2.3.6")),
+	}
+}
+
+func TestValidator_syncToTarget_PreparesAfterRoleGate(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Skipping on Windows")
+	}
+
+	tests := []struct {
+		name              string
+		enabledWhenActive bool
+		wantPhases        string
+		wantOutcome       string
+	}{
+		{
+			name:              "active validator with enabled when active false runs no commands",
+			enabledWhenActive: false,
+			wantPhases:        "",
+			wantOutcome:       report.OutcomeSkipped,
+		},
+		{
+			name:              "activation allowed prepares then activates",
+			enabledWhenActive: true,
+			wantPhases:        "prepare\nactivate\n",
+			wantOutcome:       report.OutcomeSynced,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			markerFile := filepath.Join(t.TempDir(), "phases")
+			v := newSyncToTargetValidator(t, markerFile, tt.enabledWhenActive)
+
+			if err := v.syncToTarget(context.Background(), v.logger, testVersionDiff()); err != nil {
+				t.Fatalf("syncToTarget() error = %v", err)
+			}
+
+			content, _ := os.ReadFile(markerFile)
+			if string(content) != tt.wantPhases {
+				t.Errorf("executed phases = %q, want %q", string(content), tt.wantPhases)
+			}
+			if outcome := v.LastDecision().Outcome; outcome != tt.wantOutcome {
+				t.Errorf("LastDecision().Outcome = %q, want %q", outcome, tt.wantOutcome)
+			}
+		})
+	}
+}
+
+func TestValidator_syncToTarget_ReadOnly(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Skipping on Windows")
+	}
+
+	markerFile := filepath.Join(t.TempDir(), "phases")
+	v := newSyncToTargetValidator(t, markerFile, true)
+	v.readOnly = true
+
+	if err := v.syncToTarget(context.Background(), v.logger, testVersionDiff()); err != nil {
+		t.Fatalf("syncToTarget() error = %v", err)
+	}
+
+	if _, err := os.Stat(markerFile); !os.IsNotExist(err) {
+		t.Errorf("commands executed in read-only mode, marker file stat error = %v", err)
+	}
+	if prepared := v.stateStore.Get().Prepared; prepared != nil {
+		t.Errorf("state Prepared = %+v, want nil in read-only mode", prepared)
+	}
+
+	decision := v.LastDecision()
+	if decision.Outcome != report.OutcomeSkipped || decision.ReasonCode != report.ReasonCodeReadOnly || decision.Reason != "read-only mode" {
+		t.Errorf("LastDecision() = %s/%s (%s), want %s/%s (read-only mode)", decision.Outcome, decision.ReasonCode, decision.Reason, report.OutcomeSkipped, report.ReasonCodeReadOnly)
+	}
+}
@@ -0,0 +1,54 @@
+package validator
+
+import (
+	"strings"
+
+	"github.com/hashicorp/go-version"
+)
+
+// pinnedVersion returns the single exact version a constraint allows, e.g. "= 2.3.6" or "2.3.6" -
+// constraints allowing a range of versions are not pins
+func pinnedVersion(constraints version.Constraints) (pinned *version.Version, isPinned bool) {
+	if len(constraints) != 1 {
+		return nil, false
+	}
+
+	constraintString := strings.TrimSpace(constraints[0].String())
+	if strings.ContainsAny(constraintString[:1], "!<>~") {
+		return nil, false
+	}
+
+	pinned, err := version.NewVersion(strings.TrimSpace(strings.TrimPrefix(constraintString, "=")))
+	if err != nil {
+		return nil, false
+	}
+
+	return pinned, true
+}
+
+// lookupTargetVersion returns the version to sync to - the cluster restart manifest's version while one is
+// published, or the pinned version when the version constraint pins one, verified to be tagged without listing
+// releases, otherwise the latest client version for the cluster
+func (v *Validator) lookupTargetVersion() (targetVersion *version.Version, err error) {
+	if v.restartManifest != nil {
+		restartVersion, err := v.restartManifest.VersionFor(v.cfg.Client)
+		if err != nil {
+			return nil, err
+		}
+		v.logger.Info("cluster restart manifest sets the version - skipping release lookup", "restartVersion", restartVersion.Original())
+		return v.githubClient.GetPinnedClientVersion(restartVersion)
+	}
+
+	pinned, isPinned := pinnedVersion(v.versionConstraint)
+	if !isPinned {
+		floor := v.releaseFloor()
+		if floor != nil {
+			v.logger.Debug("skipping releases below floor", "floor", floor.String(), "runningVersion", v.State.Version.String())
+		}
+		v.githubClient.SetVersionFloor(floor)
+		return v.githubClient.GetLatestClientVersion()
+	}
+
+	v.logger.Info("version constraint pins an exact version - skipping release lookup", "pinnedVersion", pinned.Original())
+	return v.githubClient.GetPinnedClientVersion(pinned)
+}
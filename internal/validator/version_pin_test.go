@@ -0,0 +1,40 @@
+package validator
+
+import (
+	"testing"
+
+	"github.com/hashicorp/go-version"
+)
+
+func TestPinnedVersion(t *testing.T) {
+	tests := []struct {
+		name       string
+		constraint string
+		wantPinned string
+	}{
+		{name: "exact with operator", constraint: "= 2.3.6", wantPinned: "2.3.6"},
+		{name: "exact without operator", constraint: "2.3.6", wantPinned: "2.3.6"},
+		{name: "exact prerelease", constraint: "=0.808.30014-beta.1", wantPinned: "0.808.30014-beta.1"},
+		{name: "lower bound", constraint: ">= 2.3.6", wantPinned: ""},
+		{name: "pessimistic", constraint: "~> 2.3.6", wantPinned: ""},
+		{name: "not equal", constraint: "!= 2.3.6", wantPinned: ""},
+		{name: "range", constraint: ">= 2.3.6, < 3.0.0", wantPinned: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			constraints, err := version.NewConstraint(tt.constraint)
+			if err != nil {
+				t.Fatalf("NewConstraint() error = %v", err)
+			}
+
+			pinned, isPinned := pinnedVersion(constraints)
+			if isPinned != (tt.wantPinned != "") {
+				t.Fatalf("pinnedVersion() isPinned = %v, want %v", isPinned, tt.wantPinned != "")
+			}
+			if isPinned && pinned.Original() != tt.wantPinned {
+				t.Errorf("pinnedVersion() = %s, want %s", pinned.Original(), tt.wantPinned)
+			}
+		})
+	}
+}
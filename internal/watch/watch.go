@@ -0,0 +1,144 @@
+package watch
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/sol-strategies/solana-validator-version-sync/internal/validator"
+)
+
+// clearScreen moves the cursor home and clears the terminal
+const clearScreen = "\033[H\033[2J"
+
+// Snapshotter takes point-in-time snapshots of a validator, e.g. *validator.Validator
+type Snapshotter interface {
+	Snapshot() validator.Snapshot
+}
+
+// Options represents the options for a Watcher
+type Options struct {
+	// Snapshotter is the validator to watch
+	Snapshotter Snapshotter
+	// RefreshRate is how often a new snapshot is taken and displayed
+	RefreshRate time.Duration
+	// Out is where snapshots are displayed
+	Out io.Writer
+	// ClearScreen clears the terminal before displaying each snapshot, otherwise snapshots are appended
+	ClearScreen bool
+}
+
+// Watcher repeatedly displays validator snapshots - it never syncs
+type Watcher struct {
+	opts Options
+}
+
+// New creates a new Watcher
+func New(opts Options) (w *Watcher, err error) {
+	if opts.Snapshotter == nil {
+		return nil, fmt.Errorf("snapshotter is required")
+	}
+	if opts.RefreshRate <= 0 {
+		return nil, fmt.Errorf("refresh rate must be greater than 0 - got: %s", opts.RefreshRate)
+	}
+	if opts.Out == nil {
+		return nil, fmt.Errorf("output is required")
+	}
+
+	return &Watcher{opts: opts}, nil
+}
+
+// Run displays a snapshot immediately and then every refresh rate until ctx is cancelled, returning the context error
+func (w *Watcher) Run(ctx context.Context) error {
+	ticker := time.NewTicker(w.opts.RefreshRate)
+	defer ticker.Stop()
+
+	for {
+		w.display(w.opts.Snapshotter.Snapshot())
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// display writes a snapshot to the output, clearing the screen first when configured
+func (w *Watcher) display(snapshot validator.Snapshot) {
+	if w.opts.ClearScreen {
+		fmt.Fprint(w.opts.Out, clearScreen)
+	}
+	fmt.Fprint(w.opts.Out, Render(snapshot, w.opts.RefreshRate))
+}
+
+// Render renders a snapshot as human-readable text
+func Render(snapshot validator.Snapshot, refreshRate time.Duration) string {
+	out := strings.Builder{}
+	fmt.Fprintf(&out, "%s - refreshing every %s, ctrl+c to exit\n", snapshot.Time.Format(time.RFC3339), refreshRate)
+
+	section(&out, "validator")
+	row(&out, "cluster", snapshot.Cluster)
+	row(&out, "client", snapshot.Client)
+	row(&out, "version", valueOrUnknown(snapshot.State.VersionString))
+	row(&out, "identity", fmt.Sprintf("%s (%s)", valueOrUnknown(snapshot.State.IdentityPublicKey), snapshot.State.Role))
+	row(&out, "health", valueOrUnknown(snapshot.State.HealthStatus))
+	errorRow(&out, snapshot.State.Error)
+
+	section(&out, "gossip")
+	switch {
+	case snapshot.ActiveLeader.Error != "":
+		errorRow(&out, snapshot.ActiveLeader.Error)
+	case snapshot.ActiveLeader.InGossip:
+		row(&out, "active leader", fmt.Sprintf("%s at %s running %s",
+			snapshot.ActiveIdentityPublicKey,
+			valueOrUnknown(snapshot.ActiveLeader.GossipAddress),
+			valueOrUnknown(snapshot.ActiveLeader.Version),
+		))
+	default:
+		row(&out, "active leader", fmt.Sprintf("%s NOT in gossip", snapshot.ActiveIdentityPublicKey))
+	}
+
+	section(&out, "latest release")
+	if snapshot.LatestRelease.Error != "" {
+		errorRow(&out, snapshot.LatestRelease.Error)
+	} else {
+		row(&out, "version", fmt.Sprintf("%s (%s)", snapshot.LatestRelease.Version, snapshot.LatestRelease.Tag))
+	}
+
+	section(&out, "sfdp")
+	if snapshot.SFDP.Error != "" {
+		errorRow(&out, snapshot.SFDP.Error)
+	} else {
+		row(&out, "requirement", fmt.Sprintf("%s (epoch %d)", valueOrUnknown(snapshot.SFDP.Requirement), snapshot.SFDP.Epoch))
+	}
+
+	return out.String()
+}
+
+// section writes a section heading
+func section(out *strings.Builder, name string) {
+	fmt.Fprintf(out, "\n%s\n", name)
+}
+
+// row writes an aligned label and value
+func row(out *strings.Builder, label string, value string) {
+	fmt.Fprintf(out, "  %-15s %s\n", label+":", value)
+}
+
+// errorRow writes an error row when err is not empty
+func errorRow(out *strings.Builder, err string) {
+	if err != "" {
+		row(out, "error", err)
+	}
+}
+
+// valueOrUnknown returns value, or "unknown" when it is empty
+func valueOrUnknown(value string) string {
+	if value == "" {
+		return "unknown"
+	}
+	return value
+}
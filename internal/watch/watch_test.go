@@ -0,0 +1,162 @@
+package watch
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sol-strategies/solana-validator-version-sync/internal/validator"
+)
+
+// fakeSnapshotter returns the same snapshot every time, cancelling after count snapshots
+type fakeSnapshotter struct {
+	snapshot    validator.Snapshot
+	count       int
+	cancelAfter int
+	cancel      context.CancelFunc
+}
+
+func (f *fakeSnapshotter) Snapshot() validator.Snapshot {
+	f.count++
+	if f.count >= f.cancelAfter {
+		f.cancel()
+	}
+	return f.snapshot
+}
+
+func testSnapshot() validator.Snapshot {
+	return validator.Snapshot{
+		Time:                    time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC),
+		Cluster:                 "testnet",
+		Client:                  "agave",
+		ActiveIdentityPublicKey: "active-key",
+		State: validator.StateSnapshot{
+			VersionString:     "2.3.6",
+			IdentityPublicKey: "passive-key",
+			Role:              validator.RolePassive,
+			HealthStatus:      "ok",
+		},
+		ActiveLeader:  validator.ActiveLeaderSnapshot{InGossip: true, GossipAddress: "10.0.0.1", Version: "2.3.6"},
+		LatestRelease: validator.LatestReleaseSnapshot{Version: "2.3.7", Tag: "v2.3.7"},
+		SFDP:          validator.SFDPSnapshot{Epoch: 800, Requirement: ">= 2.3.6"},
+	}
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    Options
+		wantErr bool
+	}{
+		{
+			name:    "valid",
+			opts:    Options{Snapshotter: &fakeSnapshotter{}, RefreshRate: time.Second, Out: &bytes.Buffer{}},
+			wantErr: false,
+		},
+		{
+			name:    "missing snapshotter",
+			opts:    Options{RefreshRate: time.Second, Out: &bytes.Buffer{}},
+			wantErr: true,
+		},
+		{
+			name:    "zero refresh rate",
+			opts:    Options{Snapshotter: &fakeSnapshotter{}, Out: &bytes.Buffer{}},
+			wantErr: true,
+		},
+		{
+			name:    "missing output",
+			opts:    Options{Snapshotter: &fakeSnapshotter{}, RefreshRate: time.Second},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New(tt.opts)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("New() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestWatcher_Run(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	snapshotter := &fakeSnapshotter{snapshot: testSnapshot(), cancelAfter: 3, cancel: cancel}
+	out := &bytes.Buffer{}
+	w, err := New(Options{Snapshotter: snapshotter, RefreshRate: time.Millisecond, Out: out, ClearScreen: true})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	if err := w.Run(ctx); err != context.Canceled {
+		t.Errorf("Run() error = %v, want %v", err, context.Canceled)
+	}
+	if snapshotter.count != 3 {
+		t.Errorf("snapshots taken = %d, want 3", snapshotter.count)
+	}
+	if got := strings.Count(out.String(), clearScreen); got != 3 {
+		t.Errorf("screen cleared %d times, want 3", got)
+	}
+}
+
+func TestRender(t *testing.T) {
+	tests := []struct {
+		name     string
+		mutate   func(s *validator.Snapshot)
+		contains []string
+	}{
+		{
+			name:   "all sources available",
+			mutate: func(s *validator.Snapshot) {},
+			contains: []string{
+				"2025-01-02T03:04:05Z - refreshing every 5s",
+				"identity:       passive-key (passive)",
+				"health:         ok",
+				"active leader:  active-key at 10.0.0.1 running 2.3.6",
+				"version:        2.3.7 (v2.3.7)",
+				"requirement:    >= 2.3.6 (epoch 800)",
+			},
+		},
+		{
+			name: "active leader not in gossip",
+			mutate: func(s *validator.Snapshot) {
+				s.ActiveLeader = validator.ActiveLeaderSnapshot{}
+			},
+			contains: []string{"active leader:  active-key NOT in gossip"},
+		},
+		{
+			name: "failing sources",
+			mutate: func(s *validator.Snapshot) {
+				s.State.HealthStatus = validator.HealthStatusUnhealthy
+				s.State.Error = "validator unhealthy: node is behind"
+				s.LatestRelease = validator.LatestReleaseSnapshot{Error: "rate limited"}
+				s.SFDP = validator.SFDPSnapshot{Error: "api unavailable"}
+			},
+			contains: []string{
+				"health:         unhealthy",
+				"error:          validator unhealthy: node is behind",
+				"error:          rate limited",
+				"error:          api unavailable",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			snapshot := testSnapshot()
+			tt.mutate(&snapshot)
+
+			rendered := Render(snapshot, 5*time.Second)
+			for _, want := range tt.contains {
+				if !strings.Contains(rendered, want) {
+					t.Errorf("Render() missing %q in:\n%s", want, rendered)
+				}
+			}
+		})
+	}
+}
@@ -25,6 +25,9 @@ type Config struct {
 		StatusCode   int    `yaml:"status_code"`
 		ResponseBody string `yaml:"response_body"`
 	} `yaml:"health"`
+	Gossip struct {
+		ActiveIdentityKeypair string `yaml:"active_identity_keypair"`
+	} `yaml:"gossip"`
 }
 
 var config Config
@@ -90,7 +93,11 @@ func loadKeypair(keypairPath string) (solana.PublicKey, error) {
 }
 
 func getIdentityPubkey() string {
-	publicKey, err := loadKeypair(config.Validator.IdentityKeypair)
+	return getPubkey(config.Validator.IdentityKeypair)
+}
+
+func getPubkey(keypairPath string) string {
+	publicKey, err := loadKeypair(keypairPath)
 	if err != nil {
 		log.Fatalf("Failed to load keypair: %v", err)
 	}
@@ -147,6 +154,28 @@ func rpcHandler(w http.ResponseWriter, r *http.Request) {
 			},
 		}
 
+	case "getClusterNodes":
+		// the validator itself and, when configured, the active leader it fails over from
+		nodes := []map[string]interface{}{
+			{
+				"pubkey":  getIdentityPubkey(),
+				"gossip":  "127.0.0.1:8001",
+				"version": config.Validator.RunningVersion,
+			},
+		}
+		if config.Gossip.ActiveIdentityKeypair != "" {
+			nodes = append(nodes, map[string]interface{}{
+				"pubkey":  getPubkey(config.Gossip.ActiveIdentityKeypair),
+				"gossip":  "127.0.0.1:8002",
+				"version": config.Validator.RunningVersion,
+			})
+		}
+		response = map[string]interface{}{
+			"jsonrpc": "2.0",
+			"id":      request.ID,
+			"result":  nodes,
+		}
+
 	default:
 		response = map[string]interface{}{
 			"jsonrpc": "2.0",
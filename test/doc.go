@@ -0,0 +1,6 @@
+// Package test holds the end-to-end integration harness, which builds the solana-validator-version-sync binary
+// and the mock validator server and runs the binary against them and fake GitHub and SFDP servers. It only
+// builds with the integration tag:
+//
+//	go test -tags=integration ./test/...
+package test
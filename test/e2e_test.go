@@ -0,0 +1,144 @@
+//go:build integration
+
+package test
+
+import (
+	"context"
+	"os"
+	"slices"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/sol-strategies/solana-validator-version-sync/internal/testsupport/githubfake"
+)
+
+func TestRunOnce(t *testing.T) {
+	tests := []struct {
+		name             string
+		runningVersion   string
+		releases         []githubfake.Release
+		failExitCode     int
+		args             []string
+		wantExitCode     int
+		wantCommandsLog  []string
+		wantCommandRuns  map[string]int
+		wantStateMissing bool
+	}{
+		{
+			name:            "passive validator synced to latest release",
+			runningVersion:  "3.0.10",
+			releases:        []githubfake.Release{testnetRelease("v3.0.14"), testnetRelease("v3.0.12")},
+			wantExitCode:    0,
+			wantCommandsLog: []string{"3.0.10 3.0.14"},
+			wantCommandRuns: map[string]int{"record": 0},
+		},
+		{
+			name:            "up to date validator runs no commands",
+			runningVersion:  "3.0.14",
+			releases:        []githubfake.Release{testnetRelease("v3.0.14")},
+			wantExitCode:    0,
+			wantCommandsLog: nil,
+			wantCommandRuns: map[string]int{},
+		},
+		{
+			name:            "failing command exits non-zero",
+			runningVersion:  "3.0.10",
+			releases:        []githubfake.Release{testnetRelease("v3.0.14")},
+			failExitCode:    3,
+			wantExitCode:    1,
+			wantCommandsLog: []string{"3.0.10 3.0.14"},
+			wantCommandRuns: map[string]int{"record": 0, "fail": 3},
+		},
+		{
+			name:             "read-only runs no commands and writes no state",
+			runningVersion:   "3.0.10",
+			releases:         []githubfake.Release{testnetRelease("v3.0.14")},
+			args:             []string{"--read-only"},
+			wantExitCode:     0,
+			wantCommandsLog:  nil,
+			wantStateMissing: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			validator := startMockValidator(t, tt.runningVersion, identityFile("passive-identity.json"))
+			env := newSyncEnv(t, validator, startFakeGitHub(t, tt.releases...), startFakeSFDP(t, "3.0.0", ""), tt.failExitCode)
+
+			exitCode, output := env.run(t, append([]string{"run"}, tt.args...)...)
+			if exitCode != tt.wantExitCode {
+				t.Fatalf("exit code = %d, want %d\n%s", exitCode, tt.wantExitCode, output)
+			}
+
+			if got := env.commandsLogLines(t); !slices.Equal(got, tt.wantCommandsLog) {
+				t.Errorf("commands log = %q, want %q\n%s", got, tt.wantCommandsLog, output)
+			}
+
+			if tt.wantStateMissing {
+				if _, err := os.Stat(env.stateFile); !os.IsNotExist(err) {
+					t.Errorf("state file exists, want none (stat error = %v)", err)
+				}
+				return
+			}
+
+			data := env.state(t)
+			if len(data.Observations) != 1 {
+				t.Errorf("state has %d observations, want 1", len(data.Observations))
+			}
+			gotCommandRuns := make(map[string]int, len(data.CommandRuns))
+			for _, run := range data.CommandRuns {
+				gotCommandRuns[run.Command] = run.ExitCode
+			}
+			if len(gotCommandRuns) != len(tt.wantCommandRuns) {
+				t.Errorf("state command runs = %v, want %v", gotCommandRuns, tt.wantCommandRuns)
+			}
+			for command, wantExitCode := range tt.wantCommandRuns {
+				if exitCode, ok := gotCommandRuns[command]; !ok || exitCode != wantExitCode {
+					t.Errorf("state command run %s exit code = %d (recorded %v), want %d", command, exitCode, ok, wantExitCode)
+				}
+			}
+		})
+	}
+}
+
+func TestRunOnInterval(t *testing.T) {
+	// the mock validator never upgrades, so every sync syncs it again
+	validator := startMockValidator(t, "3.0.10", identityFile("passive-identity.json"))
+	env := newSyncEnv(t, validator, startFakeGitHub(t, testnetRelease("v3.0.14")), startFakeSFDP(t, "3.0.0", ""), 0)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	cmd := env.command(ctx, "run", "--on-interval", "1s")
+	if err := cmd.Start(); err != nil {
+		t.Fatalf("failed to start binary: %v", err)
+	}
+
+	deadline := time.Now().Add(30 * time.Second)
+	for len(env.commandsLogLines(t)) < 2 {
+		if time.Now().After(deadline) {
+			_ = cmd.Process.Kill()
+			_ = cmd.Wait()
+			t.Fatalf("commands ran %d times within 30s, want at least 2", len(env.commandsLogLines(t)))
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	// interval mode stops cleanly on SIGTERM, e.g. a service stop
+	if err := cmd.Process.Signal(syscall.SIGTERM); err != nil {
+		t.Fatalf("failed to signal binary: %v", err)
+	}
+	if err := cmd.Wait(); err != nil {
+		t.Errorf("binary exited with %v after SIGTERM, want exit code 0", err)
+	}
+
+	for _, line := range env.commandsLogLines(t) {
+		if line != "3.0.10 3.0.14" {
+			t.Errorf("commands log line = %q, want %q", line, "3.0.10 3.0.14")
+		}
+	}
+	if data := env.state(t); len(data.CommandRuns) < 2 || len(data.Observations) < 2 {
+		t.Errorf("state has %d command runs and %d observations, want at least 2 of each", len(data.CommandRuns), len(data.Observations))
+	}
+}
@@ -0,0 +1,335 @@
+//go:build integration
+
+package test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"text/template"
+	"time"
+
+	"github.com/sol-strategies/solana-validator-version-sync/internal/state"
+	"github.com/sol-strategies/solana-validator-version-sync/internal/testsupport/githubfake"
+)
+
+var (
+	// binaryPath and mockValidatorPath are the binaries built by TestMain
+	binaryPath        string
+	mockValidatorPath string
+	// repoRoot is the repository root the harness runs from
+	repoRoot string
+)
+
+// TestMain builds the binary and the mock validator server once for every test
+func TestMain(m *testing.M) {
+	os.Exit(func() int {
+		var err error
+		repoRoot, err = filepath.Abs("..")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "failed to resolve repo root: %v\n", err)
+			return 1
+		}
+
+		buildDir, err := os.MkdirTemp("", "e2e-bin")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "failed to create build dir: %v\n", err)
+			return 1
+		}
+		defer os.RemoveAll(buildDir)
+
+		binaryPath = filepath.Join(buildDir, "solana-validator-version-sync")
+		if err := goBuild(repoRoot, binaryPath, "./cmd/solana-validator-version-sync"); err != nil {
+			fmt.Fprintf(os.Stderr, "failed to build binary: %v\n", err)
+			return 1
+		}
+
+		// the mock validator server is its own module
+		mockValidatorPath = filepath.Join(buildDir, "mock-server")
+		if err := goBuild(filepath.Join(repoRoot, "mock-server"), mockValidatorPath, "."); err != nil {
+			fmt.Fprintf(os.Stderr, "failed to build mock validator server: %v\n", err)
+			return 1
+		}
+
+		return m.Run()
+	}())
+}
+
+// goBuild builds the package in dir to output
+func goBuild(dir string, output string, pkg string) error {
+	cmd := exec.Command("go", "build", "-o", output, pkg)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "CGO_ENABLED=0")
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("%w: %s", err, out)
+	}
+	return nil
+}
+
+// freePort returns a loopback port nothing listens on
+func freePort(t *testing.T) int {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to find a free port: %v", err)
+	}
+	defer listener.Close()
+	return listener.Addr().(*net.TCPAddr).Port
+}
+
+// identityFile returns the absolute path of a local-test identity keypair, e.g. passive-identity.json
+func identityFile(name string) string {
+	return filepath.Join(repoRoot, "local-test", name)
+}
+
+// mockValidator is a running mock validator server
+type mockValidator struct {
+	URL string
+}
+
+// startMockValidator starts the mock validator server running version with the given identity keypair file,
+// stopping it when the test ends
+func startMockValidator(t *testing.T, runningVersion string, identityKeypair string) *mockValidator {
+	t.Helper()
+
+	port := freePort(t)
+	configFile := filepath.Join(t.TempDir(), "mock-validator.yaml")
+	config := fmt.Sprintf(`server:
+  port: "%d"
+validator:
+  identity_keypair: %q
+  running_version: %q
+health:
+  status_code: 200
+  response_body: "ok"
+gossip:
+  active_identity_keypair: %q
+`, port, identityKeypair, runningVersion, identityFile("active-identity.json"))
+	if err := os.WriteFile(configFile, []byte(config), 0o600); err != nil {
+		t.Fatalf("failed to write mock validator config: %v", err)
+	}
+
+	var output bytes.Buffer
+	cmd := exec.Command(mockValidatorPath, configFile)
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+	if err := cmd.Start(); err != nil {
+		t.Fatalf("failed to start mock validator: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = cmd.Process.Kill()
+		_ = cmd.Wait()
+		if t.Failed() {
+			t.Logf("mock validator output:\n%s", output.String())
+		}
+	})
+
+	url := fmt.Sprintf("http://127.0.0.1:%d", port)
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		resp, err := http.Get(url + "/health")
+		if err == nil {
+			resp.Body.Close()
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("mock validator did not start listening on %s: %v", url, err)
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+
+	return &mockValidator{URL: url}
+}
+
+// testnetRelease returns an agave testnet release of tag
+func testnetRelease(tag string) githubfake.Release {
+	publishedAt := time.Now().Add(-48 * time.Hour).UTC()
+	return githubfake.Release{
+		TagName:     tag,
+		Name:        tag,
+		Body:        "This is a testnet release",
+		PublishedAt: &publishedAt,
+	}
+}
+
+// startFakeGitHub starts a fake GitHub API serving the given agave releases and their tags
+func startFakeGitHub(t *testing.T, releases ...githubfake.Release) *githubfake.Server {
+	t.Helper()
+
+	fake := githubfake.New()
+	t.Cleanup(fake.Close)
+
+	tags := make([]string, 0, len(releases))
+	for _, release := range releases {
+		tags = append(tags, release.TagName)
+	}
+	fake.SetReleases("anza-xyz", "agave", releases...)
+	fake.SetTags("anza-xyz", "agave", tags...)
+	return fake
+}
+
+// startFakeSFDP starts a fake SFDP API requiring agave versions between minVersion and maxVersion - empty for no
+// limit - with no validator being an SFDP participant
+func startFakeSFDP(t *testing.T, minVersion string, maxVersion string) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/epoch/required_versions":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"data": []map[string]interface{}{{
+					"epoch":             800,
+					"cluster":           r.URL.Query().Get("cluster"),
+					"agave_min_version": minVersion,
+					"agave_max_version": maxVersion,
+				}},
+			})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// syncEnv is the environment of a binary run - its config, state file and the file its commands append to
+type syncEnv struct {
+	dir         string
+	configFile  string
+	stateFile   string
+	commandsLog string
+	githubURL   string
+	sfdpURL     string
+}
+
+// configTemplate is the config the binary runs with - the record command appends the versions it synced
+// between to the commands log, the fail command exits with its configured exit code
+var configTemplate = template.Must(template.New("config").Parse(`log:
+  level: debug
+validator:
+  client: agave
+  version_constraint: ">= 3.0.0, < 4.0.0"
+  rpc_url: {{ .RPCURL }}
+  identities:
+    active: {{ .ActiveIdentity }}
+    passive: {{ .PassiveIdentity }}
+cluster:
+  name: testnet
+state:
+  file: {{ .StateFile }}
+sync:
+  enable_sfdp_compliance: true
+  commands:
+    - name: record
+      cmd: sh
+      args: ["-c", "echo '{{ "{{ .VersionFrom }}" }} {{ "{{ .VersionTo }}" }}' >> {{ .CommandsLog }}"]
+{{- if .FailExitCode }}
+    - name: fail
+      cmd: sh
+      args: ["-c", "exit {{ .FailExitCode }}"]
+{{- end }}
+`))
+
+// newSyncEnv writes the config of a binary run against the validator and fake servers - a non-zero
+// failExitCode adds a command failing with it
+func newSyncEnv(t *testing.T, validator *mockValidator, github *githubfake.Server, sfdp *httptest.Server, failExitCode int) *syncEnv {
+	t.Helper()
+
+	dir := t.TempDir()
+	env := &syncEnv{
+		dir:         dir,
+		configFile:  filepath.Join(dir, "config.yaml"),
+		stateFile:   filepath.Join(dir, "state.json"),
+		commandsLog: filepath.Join(dir, "commands.log"),
+		githubURL:   github.URL(),
+		sfdpURL:     sfdp.URL,
+	}
+
+	var config bytes.Buffer
+	err := configTemplate.Execute(&config, map[string]interface{}{
+		"RPCURL":          validator.URL,
+		"ActiveIdentity":  identityFile("active-identity.json"),
+		"PassiveIdentity": identityFile("passive-identity.json"),
+		"StateFile":       env.stateFile,
+		"CommandsLog":     env.commandsLog,
+		"FailExitCode":    failExitCode,
+	})
+	if err != nil {
+		t.Fatalf("failed to render config: %v", err)
+	}
+	if err := os.WriteFile(env.configFile, config.Bytes(), 0o600); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	return env
+}
+
+// command returns the binary command running args against the environment's config and fake servers
+func (e *syncEnv) command(ctx context.Context, args ...string) *exec.Cmd {
+	args = append(args,
+		"--config", e.configFile,
+		"--github-api-url", e.githubURL,
+		"--sfdp-api-url", e.sfdpURL,
+	)
+	cmd := exec.CommandContext(ctx, binaryPath, args...)
+	cmd.Dir = e.dir
+	return cmd
+}
+
+// run runs the binary with args to completion, returning its exit code and combined output
+func (e *syncEnv) run(t *testing.T, args ...string) (exitCode int, output string) {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	out, err := e.command(ctx, args...).CombinedOutput()
+	var exitErr *exec.ExitError
+	switch {
+	case errors.As(err, &exitErr):
+		return exitErr.ExitCode(), string(out)
+	case err != nil:
+		t.Fatalf("failed to run binary: %v\n%s", err, out)
+	}
+	return 0, string(out)
+}
+
+// commandsLogLines returns the lines the record command appended, nil when it never ran
+func (e *syncEnv) commandsLogLines(t *testing.T) []string {
+	t.Helper()
+
+	content, err := os.ReadFile(e.commandsLog)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		t.Fatalf("failed to read commands log: %v", err)
+	}
+	return strings.Split(strings.TrimSpace(string(content)), "\n")
+}
+
+// state returns the persisted state
+func (e *syncEnv) state(t *testing.T) state.Data {
+	t.Helper()
+
+	content, err := os.ReadFile(e.stateFile)
+	if err != nil {
+		t.Fatalf("failed to read state file: %v", err)
+	}
+	var data state.Data
+	if err := json.Unmarshal(content, &data); err != nil {
+		t.Fatalf("failed to parse state file: %v", err)
+	}
+	return data
+}